@@ -52,7 +52,15 @@ var _ = Describe("api version", func() {
 						"api_domain": "test.fbxos.fr",
 						"uid": "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx",
 						"api_version": "0",
-						"device_type": "FreeboxServer0,0"
+						"device_type": "FreeboxServer0,0",
+						"model_info": {
+							"name": "unit/test",
+							"pretty_name": "Freebox v0",
+							"net_operator": "Free",
+							"has_vm": true,
+							"has_home_automation": false,
+							"has_dsl": true
+						}
 					}`)),
 				),
 			)
@@ -70,6 +78,13 @@ var _ = Describe("api version", func() {
 				UID:            "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx",
 				APIVersion:     "0",
 				DeviceType:     "FreeboxServer0,0",
+				ModelInfo: &types.BoxModelInfo{
+					Name:        "unit/test",
+					PrettyName:  "Freebox v0",
+					NetOperator: "Free",
+					HasVM:       true,
+					HasDSL:      true,
+				},
 			}))
 		})
 	})
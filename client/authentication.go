@@ -117,31 +117,39 @@ type sessionResponse struct {
 	PasswordSalt string            `json:"password_salt"`
 }
 
+// Login opens a new session, shared by the requests of the client. Concurrent calls share the same login.
 func (c *client) Login(ctx context.Context) (permissions types.Permissions, err error) {
-	if c.appID == nil {
-		return permissions, ErrAppIDIsNotSet
-	}
+	_, permissions, err = c.openSession(ctx)
 
-	if c.privateToken == nil {
-		return permissions, ErrPrivateTokenIsNotSet
-	}
+	return permissions, err
+}
 
-	challenge, err := c.getLoginChallenge(ctx)
-	if err != nil {
-		return permissions, fmt.Errorf("failed to get login challenge: %w", err)
-	}
+// openSession logs in, or waits for the login already in progress, and returns the new session.
+func (c *client) openSession(ctx context.Context) (*session, types.Permissions, error) {
+	return c.session.open(ctx, func() (*session, types.Permissions, error) {
+		if c.appID == nil {
+			return nil, types.Permissions{}, ErrAppIDIsNotSet
+		}
 
-	sessionResponse, err := c.getSession(ctx, challenge.Challenge)
-	if err != nil {
-		return permissions, fmt.Errorf("failed to get a session: %w", err)
-	}
+		if c.privateToken == nil {
+			return nil, types.Permissions{}, ErrPrivateTokenIsNotSet
+		}
 
-	c.session = &session{
-		token:   sessionResponse.SessionToken,
-		expires: time.Now().Add(LoginSessionTTL),
-	}
+		challenge, err := c.getLoginChallenge(ctx)
+		if err != nil {
+			return nil, types.Permissions{}, fmt.Errorf("failed to get login challenge: %w", err)
+		}
 
-	return sessionResponse.Permissions, nil
+		sessionResponse, err := c.getSession(ctx, challenge.Challenge)
+		if err != nil {
+			return nil, types.Permissions{}, fmt.Errorf("failed to get a session: %w", err)
+		}
+
+		return &session{
+			token:   sessionResponse.SessionToken,
+			expires: time.Now().Add(LoginSessionTTL),
+		}, sessionResponse.Permissions, nil
+	})
 }
 
 func (c *client) getLoginChallenge(ctx context.Context) (*loginChallenge, error) {
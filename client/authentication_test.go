@@ -2,6 +2,7 @@ package client_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -567,7 +568,12 @@ var _ = Describe("authentication", func() {
 				})
 				It("should return an error", func() {
 					Expect(*returnedErr).ToNot(BeNil())
-					Expect((*returnedErr).Error()).To(MatchRegexp(`failed with error code "invalid_token": Erreur d'authentification de l'application`))
+					Expect((*returnedErr).Error()).To(MatchRegexp(`failed with error code "invalid_token": Erreur d'authentification de l'application \(uid 9bb8f32441fcb41e4c9f2d9b60af3b13\)`))
+
+					apiErr := new(client.APIError)
+					Expect(errors.As(*returnedErr, &apiErr)).To(BeTrue())
+					Expect(apiErr.UID).To(Equal("9bb8f32441fcb41e4c9f2d9b60af3b13"))
+					Expect(apiErr.Endpoint).To(Equal(fmt.Sprintf("POST /api/%s/login/session", version)))
 				})
 			})
 			Context("because the returned body is an invalid JSON object", func() {
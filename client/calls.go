@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// ListCalls returns the call log, most recent calls first.
+func (c *client) ListCalls(ctx context.Context) (result []types.CallEntry, err error) {
+	if _, err = getList(ctx, c, "call/log/", &result, c.withSession(ctx)); err != nil {
+		return nil, fmt.Errorf("failed to GET call/log/ endpoint: %w", err)
+	}
+
+	return result, nil
+}
+
+// ListCallsWithOptions returns a page of the call log within a time window, most recent calls first. The call log
+// endpoint takes no query parameters and does not guarantee the order of the calls, so the whole call log is fetched
+// before the options are applied.
+func (c *client) ListCallsWithOptions(ctx context.Context, options types.CallLogOptions) ([]types.CallEntry, error) {
+	calls, err := c.ListCalls(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []types.CallEntry{}
+
+	for _, call := range calls {
+		if options.Match(call) {
+			result = append(result, call)
+		}
+	}
+
+	slices.SortStableFunc(result, func(a, b types.CallEntry) int {
+		return b.Datetime.Compare(a.Datetime.Time)
+	})
+
+	if options.Offset >= int64(len(result)) {
+		return []types.CallEntry{}, nil
+	}
+
+	result = result[max(options.Offset, 0):]
+
+	if options.Limit > 0 && options.Limit < int64(len(result)) {
+		result = result[:options.Limit]
+	}
+
+	return result, nil
+}
+
+// GetCall returns a call entry of the call log.
+func (c *client) GetCall(ctx context.Context, identifier int64) (result types.CallEntry, err error) {
+	response, err := c.get(ctx, fmt.Sprintf("call/log/%d", identifier), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrCallNotFound
+		}
+
+		return result, fmt.Errorf("failed to GET call/log/%d endpoint: %w", identifier, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a call entry from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdateCall updates a call entry of the call log, typically to mark it as read.
+func (c *client) UpdateCall(ctx context.Context, identifier int64, payload types.CallEntryUpdate) (result types.CallEntry, err error) {
+	response, err := c.put(ctx, fmt.Sprintf("call/log/%d", identifier), payload, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrCallNotFound
+		}
+
+		return result, fmt.Errorf("failed to PUT call/log/%d endpoint: %w", identifier, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a call entry from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// DeleteCall deletes a call entry from the call log.
+func (c *client) DeleteCall(ctx context.Context, identifier int64) error {
+	_, err := c.delete(ctx, fmt.Sprintf("call/log/%d", identifier), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrCallNotFound
+		}
+
+		return fmt.Errorf("failed to DELETE call/log/%d endpoint: %w", identifier, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,489 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("call log", func() {
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	Context("listing calls", func() {
+		var (
+			ctx context.Context
+
+			returnedCalls = new([]types.CallEntry)
+		)
+		BeforeEach(func() {
+			ctx = context.Background()
+		})
+		JustBeforeEach(func() {
+			*returnedCalls, *returnedErr = freeboxClient.ListCalls(ctx)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/log/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								{
+									"number": "0102030405",
+									"type": "missed",
+									"id": 42,
+									"duration": 0,
+									"datetime": 1711656593,
+									"contact_id": 0,
+									"line_id": 0,
+									"name": "0102030405",
+									"new": true
+								},
+								{
+									"number": "0607080910",
+									"type": "outgoing",
+									"id": 41,
+									"duration": 63,
+									"datetime": 1711650000,
+									"contact_id": "3",
+									"line_id": 0,
+									"name": "Jane",
+									"new": false
+								}
+							]
+						}`),
+					),
+				)
+			})
+			It("should return the correct calls", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedCalls).To(Equal([]types.CallEntry{
+					{
+						ID:       42,
+						Type:     types.CallTypeMissed,
+						Datetime: types.Timestamp{Time: time.Unix(1711656593, 0).UTC()},
+						Number:   "0102030405",
+						Name:     "0102030405",
+						New:      true,
+					},
+					{
+						ID:        41,
+						Type:      types.CallTypeOutgoing,
+						Datetime:  types.Timestamp{Time: time.Unix(1711650000, 0).UTC()},
+						Number:    "0607080910",
+						Name:      "Jane",
+						Duration:  63,
+						ContactID: 3,
+					},
+				}))
+			})
+		})
+		Context("when there are no calls", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/log/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should return an empty list", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedCalls).To(BeEmpty())
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the server returns an unexpected payload", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/log/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								"foo"
+							]
+						}`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the result is not a list", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/log/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"result": { "foo": ["bar"] },
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).To(MatchError(ContainSubstring("expected a list")))
+			})
+		})
+		Context("when the request fails after the result", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/log/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"result": { "foo": "bar" },
+							"success": false,
+							"error_code": "internal_error"
+						}`),
+					),
+				)
+			})
+			It("should return the API error", func() {
+				Expect(*returnedErr).To(MatchError(&client.APIError{Code: "internal_error"}))
+			})
+		})
+		Context("when the response body is truncated", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/log/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								{ "id": 42, "type": "missed"`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the raw result is requested", func() {
+			rawResult := new(client.RawResult)
+			BeforeEach(func() {
+				ctx = client.WithRawResult(ctx, rawResult)
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/log/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [{ "id": 42, "type": "missed", "datetime": 1711656593 }]
+						}`),
+					),
+				)
+			})
+			It("should return both the calls and the raw result", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedCalls).To(HaveLen(1))
+				Expect(string(rawResult.Get())).To(MatchJSON(`[{ "id": 42, "type": "missed", "datetime": 1711656593 }]`))
+			})
+		})
+	})
+	Context("listing a page of calls", func() {
+		var (
+			options    types.CallLogOptions
+			statusCode = http.StatusOK
+			body       string
+
+			returnedCalls = new([]types.CallEntry)
+		)
+		BeforeEach(func() {
+			options = types.CallLogOptions{}
+			body = `{
+				"success": true,
+				"result": [
+					{ "id": 43, "type": "outgoing", "datetime": 1711640000, "number": "0607080910" },
+					{ "id": 45, "type": "missed", "datetime": 1711660000, "number": "0102030405" },
+					{ "id": 42, "type": "missed", "datetime": 1711630000, "number": "0607080910" },
+					{ "id": 44, "type": "accepted", "datetime": 1711650000, "number": "0102030405" }
+				]
+			}`
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/log/", version)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWithPtr(&statusCode, &body),
+				),
+			)
+		})
+		JustBeforeEach(func() {
+			*returnedCalls, *returnedErr = freeboxClient.ListCallsWithOptions(context.Background(), options)
+		})
+		identifiers := func(calls []types.CallEntry) []int64 {
+			result := make([]int64, 0, len(calls))
+			for _, call := range calls {
+				result = append(result, call.ID)
+			}
+
+			return result
+		}
+		Context("when the page is limited", func() {
+			BeforeEach(func() {
+				options.Limit = 2
+				options.Offset = 1
+			})
+			It("should return the page of the most recent calls", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(identifiers(*returnedCalls)).To(Equal([]int64{44, 43}))
+			})
+		})
+		Context("when a time window is given", func() {
+			BeforeEach(func() {
+				options.Since = time.Unix(1711640000, 0)
+				options.Until = time.Unix(1711660000, 0)
+			})
+			It("should only return the calls of the window", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(identifiers(*returnedCalls)).To(Equal([]int64{44, 43}))
+			})
+		})
+		Context("when no call matches", func() {
+			BeforeEach(func() {
+				options.Since = time.Unix(1711670000, 0)
+			})
+			It("should return an empty list", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedCalls).To(BeEmpty())
+			})
+		})
+		Context("when the offset is past the last call", func() {
+			BeforeEach(func() {
+				options.Offset = 4
+			})
+			It("should return an empty list", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedCalls).To(BeEmpty())
+			})
+		})
+		Context("when a call is malformed", func() {
+			BeforeEach(func() {
+				body = `{ "success": true, "result": [ { "id": 45, "type": "missed", "datetime": 1711660000 }, "not a call" ] }`
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("getting a call", func() {
+		const identifier int64 = 42
+		returnedCall := new(types.CallEntry)
+		JustBeforeEach(func() {
+			*returnedCall, *returnedErr = freeboxClient.GetCall(context.Background(), identifier)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/log/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"number": "0102030405",
+								"type": "accepted",
+								"id": 42,
+								"duration": 12,
+								"datetime": 1711656593,
+								"contact_id": 0,
+								"line_id": 0,
+								"name": "0102030405",
+								"new": true
+							}
+						}`),
+					),
+				)
+			})
+			It("should return the correct call", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedCall).To(Equal(types.CallEntry{
+					ID:       identifier,
+					Type:     types.CallTypeAccepted,
+					Datetime: types.Timestamp{Time: time.Unix(1711656593, 0).UTC()},
+					Number:   "0102030405",
+					Name:     "0102030405",
+					Duration: 12,
+					New:      true,
+				}))
+			})
+		})
+		Context("when the call is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/log/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "noent"
+						}`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrCallNotFound))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("updating a call", func() {
+		const identifier int64 = 42
+		returnedCall := new(types.CallEntry)
+		JustBeforeEach(func() {
+			*returnedCall, *returnedErr = freeboxClient.UpdateCall(context.Background(), identifier, types.CallEntryUpdate{New: false})
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/call/log/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{ "new": false }`),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"number": "0102030405",
+								"type": "missed",
+								"id": 42,
+								"duration": 0,
+								"datetime": 1711656593,
+								"name": "0102030405",
+								"new": false
+							}
+						}`),
+					),
+				)
+			})
+			It("should mark the call as read", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedCall.ID).To(Equal(identifier))
+				Expect(returnedCall.New).To(BeFalse())
+			})
+		})
+		Context("when the call is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/call/log/%d", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "noent"
+						}`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrCallNotFound))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("deleting a call", func() {
+		const identifier int64 = 42
+		JustBeforeEach(func() {
+			*returnedErr = freeboxClient.DeleteCall(context.Background(), identifier)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/call/log/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the call is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/call/log/%d", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "noent"
+						}`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrCallNotFound))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+})
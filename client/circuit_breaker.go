@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CircuitBreaker is an HTTPClient which stops forwarding requests to the freebox once failureThreshold requests in a
+// row have failed, so that callers polling a rebooting freebox do not hammer it. Requests are then rejected with
+// ErrCircuitBreakerOpen until the cooldown has elapsed, after which a single request is let through to probe the
+// freebox: the circuit closes again if it succeeds and stays open for another cooldown otherwise.
+//
+// A request fails when it can not be performed or when the freebox answers with a server error status. It is meant to
+// be set with Client.WithHTTPClient, after which the TLS options of the Client apply to the wrapped HTTP client.
+type CircuitBreaker struct {
+	httpClient       HTTPClient
+	failureThreshold int
+	cooldown         time.Duration
+
+	mutex    sync.Mutex
+	failures int       // number of failures in a row
+	openedAt time.Time // time of the last failure which kept the circuit open
+	probing  bool      // whether a probe request is in flight
+}
+
+// NewCircuitBreaker wraps httpClient into a CircuitBreaker. A failureThreshold lower than 1 is treated as 1.
+func NewCircuitBreaker(httpClient HTTPClient, failureThreshold int, cooldown time.Duration) *CircuitBreaker {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+
+	return &CircuitBreaker{
+		httpClient:       httpClient,
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+	}
+}
+
+func (b *CircuitBreaker) Do(request *http.Request) (*http.Response, error) {
+	probe, err := b.acquire()
+	if err != nil {
+		return nil, err
+	}
+
+	b.mutex.Lock()
+	httpClient := b.httpClient
+	b.mutex.Unlock()
+
+	response, err := httpClient.Do(request)
+
+	switch {
+	case err != nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)):
+		// the caller gave up, which says nothing about the freebox
+		b.release(probe)
+	case err != nil || response.StatusCode >= http.StatusInternalServerError:
+		b.fail(probe)
+	default:
+		b.succeed(probe)
+	}
+
+	return response, err //nolint:wrapcheck
+}
+
+// IsOpen reports whether requests are currently rejected.
+func (b *CircuitBreaker) IsOpen() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.failures >= b.failureThreshold && (b.probing || time.Since(b.openedAt) < b.cooldown)
+}
+
+// updateHTTPClient replaces the wrapped HTTP client with the result of update, keeping the state of the circuit.
+func (b *CircuitBreaker) updateHTTPClient(update func(HTTPClient) HTTPClient) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.httpClient = update(b.httpClient)
+}
+
+// acquire checks whether a request can be performed, and whether it is the probe of a half-open circuit.
+func (b *CircuitBreaker) acquire() (probe bool, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.failures < b.failureThreshold {
+		return false, nil
+	}
+
+	if b.probing || time.Since(b.openedAt) < b.cooldown {
+		return false, ErrCircuitBreakerOpen
+	}
+
+	b.probing = true
+
+	return true, nil
+}
+
+func (b *CircuitBreaker) release(probe bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if probe {
+		b.probing = false
+	}
+}
+
+func (b *CircuitBreaker) fail(probe bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if probe {
+		b.probing = false
+	}
+
+	b.failures++
+	if b.failures >= b.failureThreshold {
+		b.openedAt = time.Now()
+	}
+}
+
+func (b *CircuitBreaker) succeed(probe bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if probe {
+		b.probing = false
+	}
+
+	b.failures = 0
+}
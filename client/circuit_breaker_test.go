@@ -0,0 +1,136 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/nikolalohinski/free-go/client"
+)
+
+var _ = Describe("circuit breaker", func() {
+	const (
+		failureThreshold = 3
+		cooldown         = 50 * time.Millisecond
+	)
+
+	var (
+		breaker *client.CircuitBreaker
+
+		calls    = new(int)
+		response = new(func() (*http.Response, error))
+
+		returnedErr = new(error)
+	)
+	failing := func() (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}
+	succeeding := func() (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}
+	do := func(ctx context.Context) error {
+		_, err := breaker.Do(Must(http.NewRequestWithContext(ctx, http.MethodGet, "http://mafreebox.freebox.fr/api/v10/", nil)))
+
+		return err
+	}
+	BeforeEach(func() {
+		*calls = 0
+		*response = succeeding
+
+		breaker = client.NewCircuitBreaker(&httpClientMock{
+			response: func() (*http.Response, error) {
+				*calls++
+
+				return (*response)()
+			},
+		}, failureThreshold, cooldown)
+	})
+	Context("default", func() {
+		JustBeforeEach(func() {
+			*returnedErr = do(context.Background())
+		})
+		It("should forward the request", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*calls).To(Equal(1))
+			Expect(breaker.IsOpen()).To(BeFalse())
+		})
+	})
+	Context("when the freebox fails less times in a row than the threshold", func() {
+		BeforeEach(func() {
+			*response = failing
+			for i := 0; i < failureThreshold-1; i++ {
+				Expect(do(context.Background())).ToNot(Succeed())
+			}
+			*response = succeeding
+			Expect(do(context.Background())).To(Succeed())
+			*response = failing
+			Expect(do(context.Background())).ToNot(Succeed())
+		})
+		It("should keep forwarding requests", func() {
+			Expect(breaker.IsOpen()).To(BeFalse())
+			Expect(*calls).To(Equal(failureThreshold + 1))
+		})
+	})
+	for name, failure := range map[string]func() (*http.Response, error){
+		"when requests can not be performed": failing,
+		"when the freebox answers with a server error": func() (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusBadGateway, Body: http.NoBody}, nil
+		},
+	} {
+		failure := failure
+		Context(name, func() {
+			BeforeEach(func() {
+				*response = failure
+				for i := 0; i < failureThreshold; i++ {
+					_ = do(context.Background())
+				}
+			})
+			It("should reject the next requests without forwarding them", func() {
+				Expect(breaker.IsOpen()).To(BeTrue())
+				Expect(do(context.Background())).To(MatchError(client.ErrCircuitBreakerOpen))
+				Expect(*calls).To(Equal(failureThreshold))
+			})
+			Context("once the cooldown has elapsed", func() {
+				BeforeEach(func() {
+					time.Sleep(cooldown)
+				})
+				Context("when the probe succeeds", func() {
+					BeforeEach(func() {
+						*response = succeeding
+					})
+					It("should close the circuit", func() {
+						Expect(do(context.Background())).To(Succeed())
+						Expect(breaker.IsOpen()).To(BeFalse())
+						Expect(do(context.Background())).To(Succeed())
+						Expect(*calls).To(Equal(failureThreshold + 2))
+					})
+				})
+				Context("when the probe fails", func() {
+					It("should open the circuit for another cooldown", func() {
+						Expect(errors.Is(do(context.Background()), client.ErrCircuitBreakerOpen)).To(BeFalse())
+						Expect(breaker.IsOpen()).To(BeTrue())
+						Expect(do(context.Background())).To(MatchError(client.ErrCircuitBreakerOpen))
+						Expect(*calls).To(Equal(failureThreshold + 1))
+					})
+				})
+			})
+		})
+	}
+	Context("when the caller cancels its requests", func() {
+		BeforeEach(func() {
+			*response = func() (*http.Response, error) {
+				return nil, context.Canceled
+			}
+			for i := 0; i < failureThreshold; i++ {
+				Expect(do(context.Background())).To(MatchError(context.Canceled))
+			}
+		})
+		It("should not count them as failures", func() {
+			Expect(breaker.IsOpen()).To(BeFalse())
+		})
+	})
+})
@@ -2,6 +2,8 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"net/http"
@@ -18,6 +20,11 @@ type Client interface {
 	WithAppID(string) Client
 	WithPrivateToken(types.PrivateToken) Client
 	WithHTTPClient(HTTPClient) Client
+	WithTransferRateLimit(types.BitRate) Client
+	WithAutomaticRelogin(bool) Client
+	WithTLSConfig(*tls.Config) Client
+	WithInsecureSkipVerify(bool) Client
+	WithAdditionalCAs(...*x509.Certificate) Client
 	// unauthenticated
 	APIVersion(context.Context) (types.APIVersion, error)
 	// authentication
@@ -30,20 +37,29 @@ type Client interface {
 	CreatePortForwardingRule(ctx context.Context, payload types.PortForwardingRulePayload) (types.PortForwardingRule, error)
 	UpdatePortForwardingRule(ctx context.Context, identifier int64, payload types.PortForwardingRulePayload) (types.PortForwardingRule, error)
 	DeletePortForwardingRule(ctx context.Context, identifier int64) error
+	ListUPnPRedirections(ctx context.Context) ([]types.UPnPRedirection, error)
+	ListAllForwardings(ctx context.Context) ([]types.Forwarding, error)
 	// dhcp
 	ListDHCPStaticLease(context.Context) ([]types.DHCPStaticLeaseInfo, error)
 	GetDHCPStaticLease(ctx context.Context, identifier string) (types.DHCPStaticLeaseInfo, error)
 	UpdateDHCPStaticLease(ctx context.Context, identifier string, payload types.DHCPStaticLeasePayload) (types.LanInterfaceHost, error)
 	CreateDHCPStaticLease(ctx context.Context, payload types.DHCPStaticLeasePayload) (types.LanInterfaceHost, error)
 	DeleteDHCPStaticLease(ctx context.Context, identifier string) error
+	ExportDHCPStaticLeases(context.Context) ([]types.DHCPStaticLeasePayload, error)
+	ReconcileDHCPStaticLeases(ctx context.Context, desired []types.DHCPStaticLeasePayload, deleteExtra bool) (types.DHCPStaticLeaseChanges, error)
 	// lan browser
 	ListLanInterfaceInfo(context.Context) ([]types.LanInfo, error)
 	GetLanInterface(ctx context.Context, name string) (result []types.LanInterfaceHost, err error)
 	GetLanInterfaceHost(ctx context.Context, interfaceName, identifier string) (result types.LanInterfaceHost, err error)
+	GetLanInterfaceHosts(ctx context.Context, interfaceName string, identifiers []string, concurrency int) ([]types.LanInterfaceHost, error)
 	// virtual machines
 	GetVirtualMachineInfo(context.Context) (result types.VirtualMachinesInfo, err error)
 	GetVirtualMachineDistributions(context.Context) (result []types.VirtualMachineDistribution, err error)
+	GetCachedVirtualMachineDistributions(context.Context) ([]types.VirtualMachineDistribution, error)
+	RefreshVirtualMachineDistributions(context.Context) ([]types.VirtualMachineDistribution, error)
 	ListVirtualMachines(context.Context) (result []types.VirtualMachine, err error)
+	ValidateVirtualMachinePayload(ctx context.Context, payload types.VirtualMachinePayload) error
+	ValidateVirtualMachineUpdate(ctx context.Context, identifier int64, payload types.VirtualMachinePayload) error
 	CreateVirtualMachine(ctx context.Context, payload types.VirtualMachinePayload) (result types.VirtualMachine, err error)
 	GetVirtualMachine(ctx context.Context, identifier int64) (result types.VirtualMachine, err error)
 	UpdateVirtualMachine(ctx context.Context, identifier int64, payload types.VirtualMachinePayload) (result types.VirtualMachine, err error)
@@ -51,42 +67,207 @@ type Client interface {
 	StartVirtualMachine(ctx context.Context, identifier int64) error
 	KillVirtualMachine(ctx context.Context, identifier int64) error
 	StopVirtualMachine(ctx context.Context, identifier int64) error
+	RestartVirtualMachine(ctx context.Context, identifier int64, timeout time.Duration) error
+	OpenVirtualMachineConsole(ctx context.Context, identifier int64) (io.ReadWriteCloser, error)
+	OpenVirtualMachineScreen(ctx context.Context, identifier int64) (io.ReadWriteCloser, error)
+	InstallVirtualMachine(ctx context.Context, distribution types.VirtualMachineDistribution, spec types.VirtualMachineInstallSpec) (types.VirtualMachine, error)
+	CloneVirtualMachine(ctx context.Context, identifier int64, spec types.VirtualMachinePayload) (types.VirtualMachine, error)
 	// virtual machines disks
 	GetVirtualDiskInfo(ctx context.Context, path string) (result types.VirtualDiskInfo, err error)
 	GetVirtualDiskTask(ctx context.Context, identifier int64) (result types.VirtualMachineDiskTask, err error)
 	CreateVirtualDisk(ctx context.Context, payload types.VirtualDisksCreatePayload) (result int64, err error)
 	ResizeVirtualDisk(ctx context.Context, payload types.VirtualDisksResizePayload) (result int64, err error)
 	DeleteVirtualDiskTask(ctx context.Context, identifier int64) error
+	WaitForVirtualDiskTask(ctx context.Context, identifier int64) (types.VirtualMachineDiskTask, error)
+	CreateVirtualDiskFromURL(ctx context.Context, payload types.VirtualDiskImportPayload) (types.VirtualDiskImport, error)
 	// websocket
-	ListenEvents(ctx context.Context, events []types.EventDescription) (chan types.Event, error)
+	ListenEvents(ctx context.Context, events []types.EventDescription, options ...EventsOption) (chan types.Event, error)
+	WatchVirtualMachine(ctx context.Context, identifier int64) (<-chan types.VirtualMachineStateChanged, error)
 	// filesystem
 	GetFileInfo(ctx context.Context, path string) (types.FileInfo, error)
+	StatFile(ctx context.Context, path string) (info types.FileInfo, exists bool, err error)
+	PathExists(ctx context.Context, path string) (bool, error)
+	ListFiles(ctx context.Context, path string) ([]types.FileInfo, error)
 	RemoveFiles(ctx context.Context, paths []string) (types.FileSystemTask, error)
 	UpdateFileSystemTask(ctx context.Context, identifier int64, payload types.FileSytemTaskUpdate) (types.FileSystemTask, error)
 	ListFileSystemTasks(ctx context.Context) (task []types.FileSystemTask, err error)
 	GetFileSystemTask(ctx context.Context, identifier int64) (types.FileSystemTask, error)
 	DeleteFileSystemTask(ctx context.Context, identifier int64) error
+	WatchFileSystemTask(ctx context.Context, identifier int64) (<-chan types.FileSystemTaskProgress, error)
+	WatchDirectory(ctx context.Context, path string) (<-chan types.DirectoryChange, error)
 	CreateDirectory(ctx context.Context, parent, name string) (path string, err error)
+	CreateDirectoryAll(ctx context.Context, path string) (string, error)
+	RenameFile(ctx context.Context, path, newName string) (types.FileInfo, error)
+	GetDiskUsage(ctx context.Context, path string) (task types.FileSystemTask, err error)
 	AddHashFileTask(ctx context.Context, payload types.HashPayload) (task types.FileSystemTask, err error)
 	GetHashResult(ctx context.Context, identifier int64) (result string, err error)
-	GetFile(ctx context.Context, path string) (result types.File, err error)
+	ComputeFileHash(ctx context.Context, path string, hashType types.HashType) (hash string, err error)
+	GetFile(ctx context.Context, path string, options ...HTTPOption) (result types.File, err error)
+	GetFilesArchive(ctx context.Context, paths []string, format types.ArchiveFormat) (result types.File, err error)
+	DownloadFile(ctx context.Context, path string, writer io.Writer, options ...DownloadOption) (written int64, err error)
+	DownloadDirectory(ctx context.Context, remotePath, localPath string, options types.DownloadDirectoryOptions) error
 	MoveFiles(ctx context.Context, sources []string, destination string, mode types.FileMoveMode) (result types.FileSystemTask, err error)
 	CopyFiles(ctx context.Context, sources []string, destination string, mode types.FileCopyMode) (result types.FileSystemTask, err error)
 	ExtractFile(ctx context.Context, payload types.ExtractFilePayload) (task types.FileSystemTask, err error)
+	// share links
+	ListShareLinks(ctx context.Context) ([]types.ShareLink, error)
+	GetShareLink(ctx context.Context, token string) (types.ShareLink, error)
+	CreateShareLink(ctx context.Context, request types.ShareLinkRequest) (types.ShareLink, error)
+	DeleteShareLink(ctx context.Context, token string) error
+	// call log
+	ListCalls(ctx context.Context) ([]types.CallEntry, error)
+	ListCallsWithOptions(ctx context.Context, options types.CallLogOptions) ([]types.CallEntry, error)
+	GetCall(ctx context.Context, identifier int64) (types.CallEntry, error)
+	UpdateCall(ctx context.Context, identifier int64, payload types.CallEntryUpdate) (types.CallEntry, error)
+	DeleteCall(ctx context.Context, identifier int64) error
+	// voicemail
+	ListVoicemails(ctx context.Context) ([]types.VoicemailEntry, error)
+	GetVoicemail(ctx context.Context, identifier string) (types.VoicemailEntry, error)
+	UpdateVoicemail(ctx context.Context, identifier string, payload types.VoicemailEntryUpdate) (types.VoicemailEntry, error)
+	DeleteVoicemail(ctx context.Context, identifier string) error
+	GetVoicemailAudio(ctx context.Context, identifier string) (types.File, error)
+	// phone
+	ListPhones(ctx context.Context) ([]types.PhoneStatus, error)
+	GetPhone(ctx context.Context, identifier int64) (types.PhoneStatus, error)
+	StartFXSRing(ctx context.Context) error
+	StopFXSRing(ctx context.Context) error
+	GetPhoneConfig(ctx context.Context) (types.PhoneConfig, error)
+	UpdatePhoneConfig(ctx context.Context, payload types.PhoneConfigPayload) (types.PhoneConfig, error)
+	ListDECTHandsets(ctx context.Context) ([]types.PhoneStatus, error)
+	StartDECTPairing(ctx context.Context) error
+	StopDECTPairing(ctx context.Context) error
+	StartDECTPaging(ctx context.Context) error
+	StopDECTPaging(ctx context.Context) error
+	// contacts
+	ListContacts(ctx context.Context, options types.ContactListOptions) ([]types.Contact, error)
+	GetContactNumber(ctx context.Context, identifier int64) (types.ContactNumber, error)
+	CreateContactNumber(ctx context.Context, payload types.ContactNumberPayload) (types.ContactNumber, error)
+	UpdateContactNumber(ctx context.Context, identifier int64, payload types.ContactNumberPayload) (types.ContactNumber, error)
+	DeleteContactNumber(ctx context.Context, identifier int64) error
+	GetContactEmail(ctx context.Context, identifier int64) (types.ContactEmail, error)
+	CreateContactEmail(ctx context.Context, payload types.ContactEmailPayload) (types.ContactEmail, error)
+	UpdateContactEmail(ctx context.Context, identifier int64, payload types.ContactEmailPayload) (types.ContactEmail, error)
+	DeleteContactEmail(ctx context.Context, identifier int64) error
+	GetContactAddress(ctx context.Context, identifier int64) (types.ContactAddress, error)
+	CreateContactAddress(ctx context.Context, payload types.ContactAddressPayload) (types.ContactAddress, error)
+	UpdateContactAddress(ctx context.Context, identifier int64, payload types.ContactAddressPayload) (types.ContactAddress, error)
+	DeleteContactAddress(ctx context.Context, identifier int64) error
+	GetContactURL(ctx context.Context, identifier int64) (types.ContactURL, error)
+	CreateContactURL(ctx context.Context, payload types.ContactURLPayload) (types.ContactURL, error)
+	UpdateContactURL(ctx context.Context, identifier int64, payload types.ContactURLPayload) (types.ContactURL, error)
+	DeleteContactURL(ctx context.Context, identifier int64) error
+	// pvr
+	ListPVRFinishedRecords(ctx context.Context) ([]types.PVRFinishedRecord, error)
+	GetPVRFinishedRecord(ctx context.Context, identifier int64) (types.PVRFinishedRecord, error)
+	UpdatePVRFinishedRecord(ctx context.Context, identifier int64, payload types.PVRFinishedRecordPayload) (types.PVRFinishedRecord, error)
+	DeletePVRFinishedRecord(ctx context.Context, identifier int64) error
+	CopyPVRFinishedRecord(ctx context.Context, identifier int64, destination string) (types.FileSystemTask, error)
+	MovePVRFinishedRecord(ctx context.Context, identifier int64, destination string) (types.FileSystemTask, error)
+	GetPVRFinishedRecordFile(ctx context.Context, identifier int64, options ...HTTPOption) (types.File, error)
+	// player
+	ListPlayers(ctx context.Context) ([]types.Player, error)
+	GetPlayerStatus(ctx context.Context, identifier int64) (types.PlayerStatus, error)
+	SendPlayerKey(ctx context.Context, identifier int64, key types.PlayerKey) error
+	GetPlayerVolume(ctx context.Context, identifier int64) (types.PlayerVolume, error)
+	SetPlayerVolume(ctx context.Context, identifier int64, payload types.PlayerVolumePayload) (types.PlayerVolume, error)
+	OpenPlayerURL(ctx context.Context, identifier int64, url string) error
+	// home automation
+	ListHomeNodes(ctx context.Context) ([]types.HomeNode, error)
+	GetHomeNode(ctx context.Context, identifier int64) (types.HomeNode, error)
+	GetHomeEndpointValue(ctx context.Context, nodeID int64, endpointID int64) (types.HomeEndpointValue, error)
+	SetHomeEndpointValue(ctx context.Context, nodeID int64, endpointID int64, value interface{}) error
+	GetAlarmState(ctx context.Context, nodeID int64) (types.AlarmState, error)
+	ArmAlarm(ctx context.Context, nodeID int64, mode types.AlarmMode) error
+	DisarmAlarm(ctx context.Context, nodeID int64, pin string) error
+	GetAlarmSettings(ctx context.Context, nodeID int64) (types.AlarmSettings, error)
+	UpdateAlarmSettings(ctx context.Context, nodeID int64, payload types.AlarmSettingsPayload) (types.AlarmSettings, error)
+	WatchAlarm(ctx context.Context) (<-chan types.HomeAlarmStateChanged, error)
+	// parental control
+	GetParentalConfig(ctx context.Context) (types.ParentalConfig, error)
+	UpdateParentalConfig(ctx context.Context, payload types.ParentalConfig) (types.ParentalConfig, error)
+	ListParentalFilters(ctx context.Context) ([]types.ParentalFilter, error)
+	GetParentalFilter(ctx context.Context, identifier int64) (types.ParentalFilter, error)
+	CreateParentalFilter(ctx context.Context, payload types.ParentalFilterPayload) (types.ParentalFilter, error)
+	UpdateParentalFilter(ctx context.Context, identifier int64, payload types.ParentalFilterPayload) (types.ParentalFilter, error)
+	DeleteParentalFilter(ctx context.Context, identifier int64) error
+	// profiles
+	ListProfiles(ctx context.Context) ([]types.Profile, error)
+	GetProfile(ctx context.Context, identifier int64) (types.Profile, error)
+	CreateProfile(ctx context.Context, payload types.ProfilePayload) (types.Profile, error)
+	UpdateProfile(ctx context.Context, identifier int64, payload types.ProfilePayload) (types.Profile, error)
+	DeleteProfile(ctx context.Context, identifier int64) error
+	SetProfileHosts(ctx context.Context, identifier int64, macs []types.MACAddress) (types.NetworkControl, error)
+	AddProfileHost(ctx context.Context, identifier int64, mac types.MACAddress) (types.NetworkControl, error)
+	RemoveProfileHost(ctx context.Context, identifier int64, mac types.MACAddress) (types.NetworkControl, error)
+	ListNetworkControls(ctx context.Context) ([]types.NetworkControl, error)
+	GetNetworkControl(ctx context.Context, profileID int64) (types.NetworkControl, error)
+	UpdateNetworkControl(ctx context.Context, profileID int64, payload types.NetworkControlPayload) (types.NetworkControl, error)
+	OverrideNetworkControl(ctx context.Context, profileID int64, mode types.NetworkControlMode, duration time.Duration) (types.NetworkControl, error)
+	ClearNetworkControlOverride(ctx context.Context, profileID int64) (types.NetworkControl, error)
+	GetNetworkControlPlanning(ctx context.Context, profileID int64) (types.NetworkControlPlanning, error)
+	UpdateNetworkControlPlanning(ctx context.Context, profileID int64, planning types.NetworkControlPlanning) (types.NetworkControlPlanning, error)
+	StartHomePairing(ctx context.Context, adapterID int64) (types.HomePairingStep, error)
+	GetHomePairingStep(ctx context.Context, adapterID int64) (types.HomePairingStep, error)
+	AnswerHomePairingStep(ctx context.Context, adapterID int64, answer types.HomePairingAnswer) (types.HomePairingStep, error)
+	StopHomePairing(ctx context.Context, adapterID int64) error
+	// language
+	GetLanguageSettings(ctx context.Context) (types.LanguageSettings, error)
+	UpdateLanguageSettings(ctx context.Context, payload types.LanguageSettingsPayload) error
+	// storage
+	ListStoragePartitions(ctx context.Context) ([]types.StoragePartition, error)
+	// vpn server
+	ListWireGuardPeerStats(ctx context.Context) ([]types.WireGuardPeerStats, error)
 	// downloads
 	ListDownloadTasks(ctx context.Context) ([]types.DownloadTask, error)
+	IterateDownloadTasks(ctx context.Context, filter types.DownloadTaskFilter, handle func(types.DownloadTask) error) error
 	GetDownloadTask(ctx context.Context, identifier int64) (types.DownloadTask, error)
 	AddDownloadTask(ctx context.Context, request types.DownloadRequest) (identifier int64, err error)
+	AddDownloadTaskFromFile(ctx context.Context, request types.DownloadFileRequest) (identifier int64, err error)
 	DeleteDownloadTask(ctx context.Context, identifier int64) error
 	EraseDownloadTask(ctx context.Context, identifier int64) error
 	UpdateDownloadTask(ctx context.Context, identifier int64, payload types.DownloadTaskUpdate) error
+	UpdateDownloadTasks(ctx context.Context, identifiers []int64, payload types.DownloadTaskUpdate) ([]types.DownloadTaskUpdateResult, error)
+	UpdateAllDownloadTasks(ctx context.Context, operation types.DownloadTasksOperation) error
+	StopSeedingDownloadTasks(ctx context.Context, ratio float64) error
+	CheckDownloadSpace(ctx context.Context, directory string, size types.ByteSize) error
+	WaitForDownloadTask(ctx context.Context, identifier int64, options types.WaitForDownloadTaskOptions) (types.DownloadTask, error)
+	GetDownloadStats(ctx context.Context) (types.DownloadStats, error)
+	ListDownloadTaskFiles(ctx context.Context, identifier int64) ([]types.DownloadFile, error)
+	UpdateDownloadTaskFile(ctx context.Context, identifier int64, fileID string, priority types.DownloadFilePriority) error
+	ListDownloadTaskTrackers(ctx context.Context, identifier int64) ([]types.DownloadTracker, error)
+	AddDownloadTaskTracker(ctx context.Context, identifier int64, announce string) error
+	RemoveDownloadTaskTracker(ctx context.Context, identifier int64, announce string) error
+	ReannounceStalledDownloadTasks(ctx context.Context, threshold time.Duration) error
+	ListDownloadTaskPeers(ctx context.Context, identifier int64) ([]types.DownloadPeer, error)
+	GetDownloadTaskPieces(ctx context.Context, identifier int64) (types.DownloadPieces, error)
+	// download feeds
+	ListDownloadFeeds(ctx context.Context) ([]types.DownloadFeed, error)
+	GetDownloadFeed(ctx context.Context, identifier int64) (types.DownloadFeed, error)
+	AddDownloadFeed(ctx context.Context, feedURL string) (types.DownloadFeed, error)
+	UpdateDownloadFeed(ctx context.Context, identifier int64, payload types.DownloadFeedUpdate) (types.DownloadFeed, error)
+	DeleteDownloadFeed(ctx context.Context, identifier int64) error
+	RefreshDownloadFeed(ctx context.Context, identifier int64) error
+	RefreshDownloadFeeds(ctx context.Context) error
+	ListDownloadFeedItems(ctx context.Context, feedID int64) ([]types.DownloadFeedItem, error)
+	ListFilteredDownloadFeedItems(ctx context.Context, feedID int64, filter types.DownloadFeedItemFilter) ([]types.DownloadFeedItem, error)
+	UpdateDownloadFeedItem(ctx context.Context, feedID, itemID int64, payload types.DownloadFeedItemUpdate) (types.DownloadFeedItem, error)
+	MarkDownloadFeedItemAsRead(ctx context.Context, feedID, itemID int64) (types.DownloadFeedItem, error)
+	MarkDownloadFeedItemAsUnread(ctx context.Context, feedID, itemID int64) (types.DownloadFeedItem, error)
+	MarkAllDownloadFeedItemsAsRead(ctx context.Context, feedID int64) error
+	DownloadFeedItem(ctx context.Context, feedID, itemID int64) (identifier int64, err error)
 	// uploads
 	FileUploadStart(ctx context.Context, input types.FileUploadStartActionInput) (io.WriteCloser, types.UploadRequestID, error)
+	ResumeFileUpload(ctx context.Context, input types.FileUploadStartActionInput, reader io.ReadSeeker) error
 	GetUploadTask(ctx context.Context, identifier int64) (types.UploadTask, error)
+	WatchUploadTask(ctx context.Context, identifier int64) (<-chan types.UploadTaskProgress, error)
 	ListUploadTasks(ctx context.Context) ([]types.UploadTask, error)
 	CancelUploadTask(ctx context.Context, identifier int64) error
 	DeleteUploadTask(ctx context.Context, identifier int64) error
 	CleanUploadTasks(ctx context.Context) error
+	UploadDirectory(ctx context.Context, localPath, remotePath string, options types.UploadDirectoryOptions) error
+	UploadFile(ctx context.Context, reader io.Reader, size int64, destDir, name string) (string, error)
+	UploadFileFromPath(ctx context.Context, localPath, destDir string) (string, error)
+	UploadFiles(ctx context.Context, specs []types.UploadSpec, concurrency int) ([]types.UploadResult, error)
 }
 
 type HTTPClient interface {
@@ -108,6 +289,7 @@ func New(endpoint, version string) (Client, error) {
 	return &client{
 		httpClient: http.DefaultClient,
 		base:       base,
+		relogin:    true,
 	}, nil
 }
 
@@ -116,8 +298,13 @@ type client struct {
 	privateToken *string
 	appID        *string
 
-	session *session
+	session sessionHolder
 	base    *url.URL
+	relogin bool
+
+	transferLimiter *rateLimiter
+	distributions   distributionsCache
+	downloadStalls  downloadStalls
 }
 
 type session struct {
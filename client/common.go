@@ -2,8 +2,11 @@ package client
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,11 +18,12 @@ const (
 )
 
 type genericResponse struct {
-	UID       string          `json:"uid,omitempty"`
-	Message   string          `json:"msg,omitempty"`
-	ErrorCode string          `json:"error_code,omitempty"`
-	Success   bool            `json:"success"`
-	Result    json.RawMessage `json:"result"`
+	UID          string          `json:"uid,omitempty"`
+	Message      string          `json:"msg,omitempty"`
+	ErrorCode    string          `json:"error_code,omitempty"`
+	MissingRight string          `json:"missing_right,omitempty"`
+	Success      bool            `json:"success"`
+	Result       json.RawMessage `json:"result"`
 }
 
 type HTTPOption = func(*http.Request) error
@@ -78,16 +82,92 @@ func (c *client) post(ctx context.Context, path string, body interface{}, option
 	return c.do(request, options...)
 }
 
-func (c *client) do(request *http.Request, options ...HTTPOption) (response *genericResponse, err error) {
-	for _, option := range options {
-		if err := option(request); err != nil {
-			return nil, fmt.Errorf("failed to apply option to request: %w", err)
+func (c *client) do(request *http.Request, options ...HTTPOption) (*genericResponse, error) {
+	replay := c.replayable(request)
+
+	response, err := c.doOnce(request, options...)
+	if replay != nil && c.sessionRejected(request, response) {
+		return c.doOnce(replay, options...)
+	}
+
+	return response, err
+}
+
+func (c *client) doOnce(request *http.Request, options ...HTTPOption) (response *genericResponse, err error) {
+	httpResponse, err := c.send(request, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		closeError := httpResponse.Body.Close()
+		if err == nil {
+			err = closeError
+		} else if closeError != nil {
+			err = fmt.Errorf("%s: %w", closeError.Error(), err)
+		}
+	}()
+
+	response, err = c.fromHTTPResponse(httpResponse)
+	storeRawResult(request.Context(), response)
+
+	return response, withEndpoint(request, err)
+}
+
+// getList performs a GET request like get, but decodes the items of the result list one at a time straight from the
+// response body into result. It spares buffering the whole body and result in memory, which matters for large lists
+// such as the LAN hosts or the call log on constrained hosts. The returned response holds every field but the result.
+func getList[T interface{}](ctx context.Context, c *client, path string, result *[]T, options ...HTTPOption) (response *genericResponse, err error) {
+	return getEach(ctx, c, path, func(item T) error {
+		*result = append(*result, item)
+
+		return nil
+	}, options...)
+}
+
+// getEach performs a GET request like getList, but hands the items of the result list over to handle one at a time
+// instead of collecting them. The first error returned by handle stops the iteration and is returned as is.
+func getEach[T interface{}](ctx context.Context, c *client, path string, handle func(T) error, options ...HTTPOption) (response *genericResponse, err error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s", c.base, path), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to forge new request: %w", err)
+	}
+
+	if _, ok := ctx.Value(rawResultKey{}).(*RawResult); ok {
+		// the raw result is requested so the body has to be buffered anyway
+		response, err = c.do(request, options...)
+		if err != nil || response.Result == nil {
+			return response, err
 		}
+
+		items := []T{}
+		if err := c.fromGenericResponse(response, &items); err != nil {
+			return response, err
+		}
+
+		for _, item := range items {
+			if err := handle(item); err != nil {
+				return response, err
+			}
+		}
+
+		return response, nil
 	}
 
-	httpResponse, err := c.httpClient.Do(request)
+	replay := c.replayable(request)
+
+	response, err = getEachOnce(c, request, handle, options...)
+	if replay != nil && c.sessionRejected(request, response) {
+		return getEachOnce(c, replay, handle, options...)
+	}
+
+	return response, err
+}
+
+func getEachOnce[T interface{}](c *client, request *http.Request, handle func(T) error, options ...HTTPOption) (response *genericResponse, err error) {
+	httpResponse, err := c.send(request, options...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to perform request: %w", err)
+		return nil, err
 	}
 
 	defer func() {
@@ -99,7 +179,45 @@ func (c *client) do(request *http.Request, options ...HTTPOption) (response *gen
 		}
 	}()
 
-	return c.fromHTTPResponse(httpResponse)
+	var handleErr error
+
+	response, err = c.fromHTTPResponseStream(httpResponse, decodeItems(func(item T) error {
+		handleErr = handle(item)
+
+		return handleErr
+	}))
+	if handleErr != nil {
+		return response, handleErr
+	}
+
+	return response, withEndpoint(request, err)
+}
+
+// send applies the options to the request and performs it. The caller is responsible for closing the response body.
+func (c *client) send(request *http.Request, options ...HTTPOption) (*http.Response, error) {
+	request.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	for _, option := range options {
+		if err := option(request); err != nil {
+			return nil, fmt.Errorf("failed to apply option to request: %w", err)
+		}
+	}
+
+	httpResponse, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to perform request: %w", err)
+	}
+
+	return httpResponse, nil
+}
+
+// withEndpoint records the endpoint of the request into err when it is an *APIError.
+func withEndpoint(request *http.Request, err error) error {
+	if apiErr := new(APIError); errors.As(err, &apiErr) {
+		apiErr.Endpoint = fmt.Sprintf("%s %s", request.Method, request.URL.Path)
+	}
+
+	return err
 }
 
 func (c *client) fromGenericResponse(generic *genericResponse, target interface{}) error {
@@ -111,7 +229,7 @@ func (c *client) fromGenericResponse(generic *genericResponse, target interface{
 }
 
 func (c *client) fromHTTPResponse(httpResponse *http.Response) (*genericResponse, error) {
-	body, err := io.ReadAll(httpResponse.Body)
+	body, err := readBody(httpResponse)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
@@ -127,14 +245,208 @@ func (c *client) fromHTTPResponse(httpResponse *http.Response) (*genericResponse
 
 	if !response.Success {
 		return response, &APIError{
-			Code:    response.ErrorCode,
-			Message: response.Message,
+			Code:         response.ErrorCode,
+			Message:      response.Message,
+			UID:          response.UID,
+			MissingRight: response.MissingRight,
 		}
 	}
 
 	return response, nil
 }
 
+// fromHTTPResponseStream is the streaming counterpart of fromHTTPResponse: the result is handed over to decodeResult
+// while the body is read instead of being stored in the returned response.
+func (c *client) fromHTTPResponseStream(httpResponse *http.Response, decodeResult func(*json.Decoder) error) (*genericResponse, error) {
+	if httpResponse.StatusCode >= http.StatusInternalServerError {
+		body, err := readBody(httpResponse)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+
+		return nil, fmt.Errorf("failed with status '%d': server returned '%s'", httpResponse.StatusCode, string(body))
+	}
+
+	reader, err := bodyReader(httpResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	defer reader.Close()
+
+	response := new(genericResponse)
+
+	resultErr, err := decodeEnvelope(json.NewDecoder(reader), response, decodeResult)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response body: %w", err)
+	}
+
+	if !response.Success {
+		return response, &APIError{
+			Code:         response.ErrorCode,
+			Message:      response.Message,
+			UID:          response.UID,
+			MissingRight: response.MissingRight,
+		}
+	}
+
+	if resultErr != nil {
+		return response, fmt.Errorf("failed to decode response result to given target: %w", resultErr)
+	}
+
+	return response, nil
+}
+
+// decodeEnvelope decodes the fields of a generic response from decoder, handing the result over to decodeResult.
+// An error decoding the result is returned apart, as it only matters when the request succeeded.
+func decodeEnvelope(decoder *json.Decoder, response *genericResponse, decodeResult func(*json.Decoder) error) (resultErr, err error) {
+	if token, err := decoder.Token(); err != nil {
+		return nil, err //nolint:wrapcheck
+	} else if token != json.Delim('{') {
+		return nil, fmt.Errorf("expected an object, got %v", token)
+	}
+
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		switch key {
+		case "result":
+			resultErr = decodeResult(decoder)
+		case "uid":
+			err = decoder.Decode(&response.UID)
+		case "msg":
+			err = decoder.Decode(&response.Message)
+		case "error_code":
+			err = decoder.Decode(&response.ErrorCode)
+		case "missing_right":
+			err = decoder.Decode(&response.MissingRight)
+		case "success":
+			err = decoder.Decode(&response.Success)
+		default:
+			err = decoder.Decode(new(json.RawMessage))
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode field %v: %w", key, err)
+		}
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return resultErr, nil
+}
+
+// decodeItems returns a decoder of a list handing its items over to handle one at a time. A null value is an empty
+// list. Whatever happens, the whole value is consumed so that the decoder can carry on with the next one.
+func decodeItems[T interface{}](handle func(T) error) func(*json.Decoder) error {
+	return func(decoder *json.Decoder) error {
+		token, err := decoder.Token()
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		if token == nil {
+			return nil
+		}
+
+		if token != json.Delim('[') {
+			if err := skipValue(decoder, token); err != nil {
+				return err
+			}
+
+			return fmt.Errorf("expected a list, got %v", token)
+		}
+
+		var itemsErr error
+
+		for decoder.More() {
+			if itemsErr != nil {
+				if err := decoder.Decode(new(json.RawMessage)); err != nil {
+					return err //nolint:wrapcheck
+				}
+
+				continue
+			}
+
+			var item T
+			if itemsErr = decoder.Decode(&item); itemsErr == nil {
+				itemsErr = handle(item)
+			}
+		}
+
+		if _, err := decoder.Token(); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return itemsErr
+	}
+}
+
+// skipValue consumes the remainder of the value started by token.
+func skipValue(decoder *json.Decoder, token json.Token) error {
+	depth := 0
+
+	for {
+		switch token {
+		case json.Delim('['), json.Delim('{'):
+			depth++
+		case json.Delim(']'), json.Delim('}'):
+			depth--
+		}
+
+		if depth == 0 {
+			return nil
+		}
+
+		var err error
+		if token, err = decoder.Token(); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+// readBody reads the whole response body, decompressing it according to its Content-Encoding.
+func readBody(httpResponse *http.Response) ([]byte, error) {
+	reader, err := bodyReader(httpResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	return io.ReadAll(reader) //nolint:wrapcheck
+}
+
+// bodyReader returns a reader of the response body decompressing it according to its Content-Encoding.
+// Closing the reader does not close the response body.
+func bodyReader(httpResponse *http.Response) (io.ReadCloser, error) {
+	switch encoding := httpResponse.Header.Get("Content-Encoding"); encoding {
+	case "", "identity":
+		return io.NopCloser(httpResponse.Body), nil
+	case "gzip":
+		reader, err := gzip.NewReader(httpResponse.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress gzip content: %w", err)
+		}
+
+		return reader, nil
+	case "deflate":
+		reader, err := zlib.NewReader(httpResponse.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress deflate content: %w", err)
+		}
+
+		return reader, nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+}
+
 func (c *client) withJSONContentType(req *http.Request) error {
 	req.Header.Add("Content-Type", "application/json")
 
@@ -149,36 +461,66 @@ func (c *client) withWWWFormURLEncodedContentType(req *http.Request) error {
 
 func (c *client) withSession(ctx context.Context) func(req *http.Request) error {
 	return func(req *http.Request) error {
-		if c.session == nil {
-			if _, err := c.Login(ctx); err != nil {
-				return fmt.Errorf("failed to login before attempting request: %w", err)
-			}
-		}
-
-		if time.Now().After(c.session.expires) {
-			if _, err := c.Login(ctx); err != nil {
-				return fmt.Errorf("failed to login again after session expired: %w", err)
-			}
+		current, err := c.ensureSession(ctx)
+		if err != nil {
+			return err
 		}
 
-		req.Header.Add(AuthHeader, c.session.token)
+		req.Header.Add(AuthHeader, current.token)
 
 		return nil
 	}
 }
 
+// ensureSession returns the current session, logging in when there is none yet or when it expired.
+func (c *client) ensureSession(ctx context.Context) (*session, error) {
+	current := c.session.get()
+
+	var err error
+	if current == nil {
+		if current, _, err = c.openSession(ctx); err != nil {
+			return nil, fmt.Errorf("failed to login before attempting request: %w", err)
+		}
+	}
+
+	if time.Now().After(current.expires) {
+		if current, _, err = c.openSession(ctx); err != nil {
+			return nil, fmt.Errorf("failed to login again after session expired: %w", err)
+		}
+	}
+
+	return current, nil
+}
+
 // APIError represents a structured Freebox API error.
 type APIError struct {
-	Code    string
-	Message string
+	Code         string
+	Message      string
+	UID          string // Identifier of the response, to correlate the error with the logs of the freebox
+	Endpoint     string // Method and path of the failed request, such as "GET /api/v10/vm/1"
+	MissingRight string // Permission the application misses, set along the insufficient_rights error code
 }
 
 func (e *APIError) Error() string {
+	message := fmt.Sprintf("failed with error code %q", e.Code)
 	if e.Message != "" {
-		return fmt.Sprintf("failed with error code %q: %s", e.Code, e.Message)
+		message = fmt.Sprintf("%s: %s", message, e.Message)
+	}
+
+	if e.UID != "" {
+		message = fmt.Sprintf("%s (uid %s)", message, e.UID)
+	}
+
+	return message
+}
+
+// Unwrap returns the error the code maps to with ErrorForCode, if any.
+func (e *APIError) Unwrap() error {
+	if e.Code == codeInsufficientRights && e.MissingRight != "" {
+		return &InsufficientRightsError{MissingRight: e.MissingRight}
 	}
 
-	return fmt.Sprintf("failed with error code %q", e.Code)
+	return errorCodes[e.Code]
 }
 
 func (e *APIError) Is(target error) bool {
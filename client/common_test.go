@@ -1,12 +1,21 @@
 package client_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
 
 	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
 )
 
 var _ = Describe("APIError", func() {
@@ -54,6 +63,16 @@ var _ = Describe("APIError", func() {
 				Expect(err.Error()).To(Equal(`failed with error code "code": message`))
 			})
 
+			Context("when UID is not empty", func() {
+				BeforeEach(func() {
+					err.UID = "9bb8f32441fcb41e4c9f2d9b60af3b13"
+				})
+
+				It("should return a string with the uid", func() {
+					Expect(err.Error()).To(Equal(`failed with error code "code": message (uid 9bb8f32441fcb41e4c9f2d9b60af3b13)`))
+				})
+			})
+
 			Describe("errors.IS", func() {
 				var target *client.APIError
 
@@ -97,3 +116,101 @@ var _ = Describe("APIError", func() {
 		})
 	})
 })
+
+var _ = Describe("compressed responses", func() {
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedProfiles = new([]types.Profile)
+		returnedErr      = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	JustBeforeEach(func() {
+		*returnedProfiles, *returnedErr = freeboxClient.ListProfiles(context.Background())
+	})
+	body := `{
+		"success": true,
+		"result": [
+			{ "id": 4, "name": "Léa", "icon": "/resources/images/profile/profile_02.png" }
+		]
+	}`
+	for encoding, compress := range map[string]func(io.Writer) io.WriteCloser{
+		"gzip":    func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+		"deflate": func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) },
+	} {
+		encoding, compress := encoding, compress
+		Context("when the response is compressed with "+encoding, func() {
+			BeforeEach(func() {
+				compressed := new(bytes.Buffer)
+				writer := compress(compressed)
+				Must(writer.Write([]byte(body)))
+				Expect(writer.Close()).To(Succeed())
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/profile/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyHeaderKV("Accept-Encoding", "gzip, deflate"),
+						ghttp.RespondWith(http.StatusOK, compressed.Bytes(), http.Header{
+							"Content-Encoding": []string{encoding},
+						}),
+					),
+				)
+			})
+			It("should return the decompressed result", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedProfiles).To(Equal([]types.Profile{
+					{ID: 4, ProfilePayload: types.ProfilePayload{Name: "Léa", Icon: "/resources/images/profile/profile_02.png"}},
+				}))
+			})
+		})
+	}
+	Context("when the response is compressed with an unknown encoding", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/profile/", version)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, body, http.Header{
+						"Content-Encoding": []string{"br"},
+					}),
+				),
+			)
+		})
+		It("should return an error", func() {
+			Expect(*returnedErr).To(MatchError(ContainSubstring(`unsupported content encoding "br"`)))
+		})
+	})
+	Context("when the compressed response is corrupted", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/profile/", version)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, body, http.Header{
+						"Content-Encoding": []string{"gzip"},
+					}),
+				),
+			)
+		})
+		It("should return an error", func() {
+			Expect(*returnedErr).ToNot(BeNil())
+		})
+	})
+})
@@ -12,16 +12,75 @@ func (e Error) Error() string {
 
 const (
 	// Errors.
-	ErrAppIDIsNotSet              = Error("app id is not set")
-	ErrPrivateTokenIsNotSet       = Error("private token is not set")
-	ErrInterfaceNotFound          = Error("interface not found")
-	ErrInterfaceHostNotFound      = Error("interface host not found")
-	ErrPortForwardingRuleNotFound = Error("port forwarding rule not found")
-	ErrVirtualMachineNotFound     = Error("virtual machine not found")
-	ErrVirtualMachineNameTooLong  = Error("virtual machine name must be less than 30 characters")
-	ErrPathNotFound               = Error("path not found")
-	ErrTaskNotFound               = Error("task not found")
-	ErrDestinationConflict        = Error("file or folder already exists")
+	ErrAppIDIsNotSet                 = Error("app id is not set")
+	ErrPrivateTokenIsNotSet          = Error("private token is not set")
+	ErrInterfaceNotFound             = Error("interface not found")
+	ErrInterfaceHostNotFound         = Error("interface host not found")
+	ErrPortForwardingRuleNotFound    = Error("port forwarding rule not found")
+	ErrVirtualMachineNotFound        = Error("virtual machine not found")
+	ErrVirtualMachineNameTooLong     = Error("virtual machine name must be less than 30 characters")
+	ErrVirtualMachineNameRequired    = Error("virtual machine name is required")
+	ErrVirtualMachineNotStopped      = Error("virtual machine is not stopped")
+	ErrPathNotFound                  = Error("path not found")
+	ErrTaskNotFound                  = Error("task not found")
+	ErrDestinationConflict           = Error("file or folder already exists")
+	ErrNotADirectory                 = Error("path is not a directory")
+	ErrInvalidConflictMode           = Error("invalid conflict resolution mode")
+	ErrFileSystemTaskFailed          = Error("filesystem task failed")
+	ErrShareLinkNotFound             = Error("share link not found")
+	ErrCallNotFound                  = Error("call not found")
+	ErrVoicemailNotFound             = Error("voicemail not found")
+	ErrPhoneNotFound                 = Error("phone not found")
+	ErrContactFieldNotFound          = Error("contact number, email, address or url not found")
+	ErrPVRRecordNotFound             = Error("pvr record not found")
+	ErrPlayerNotFound                = Error("player not found")
+	ErrPlayerUnavailable             = Error("player api is not available")
+	ErrHomeNodeNotFound              = Error("home node not found")
+	ErrHomeEndpointNotFound          = Error("home endpoint not found")
+	ErrHomeAdapterNotFound           = Error("home adapter not found")
+	ErrHomePairingInProgress         = Error("a pairing is already running on this home adapter")
+	ErrParentalFilterNotFound        = Error("parental filter not found")
+	ErrProfileNotFound               = Error("profile not found")
+	ErrCircuitBreakerOpen            = Error("circuit breaker is open: the freebox failed too many times in a row")
+	ErrAuthenticationRequired        = Error("authentication required")
+	ErrInvalidSession                = Error("session is invalid")
+	ErrInsufficientRights            = Error("insufficient rights")
+	ErrNotFound                      = Error("not found")
+	ErrDeviceNotFound                = Error("device not found")
+	ErrBusy                          = Error("resource is busy")
+	ErrDownloadStopped               = Error("download task stopped before completing")
+	ErrDownloadIncomplete            = Error("download task is not complete")
+	ErrDownloadFailed                = Error("download failed")
+	ErrDownloadDiskFull              = Error("disk is full")
+	ErrDownloadUnknownHost           = Error("unknown host")
+	ErrDownloadTimeout               = Error("timed out")
+	ErrDownloadConnectionRefused     = Error("connection refused")
+	ErrDownloadBadAuthentication     = Error("invalid credentials")
+	ErrDownloadNotFound              = Error("remote content not found")
+	ErrDownloadHashFailed            = Error("failed to verify the downloaded content hash")
+	ErrDownloadTrackerFailed         = Error("unable to announce on tracker")
+	ErrDownloadMissingFiles          = Error("missing or unreadable downloaded files")
+	ErrDownloadInvalidContent        = Error("invalid downloaded content")
+	ErrDownloadInternal              = Error("internal downloader error")
+	ErrDownloadServerError           = Error("remote server returned an error")
+	ErrTrackerNotFound               = Error("tracker not found")
+	ErrUnknownDownloadTasksOperation = Error("unknown download tasks operation")
+	ErrUploadNotDone                 = Error("upload is not done")
+	ErrUploadEventsStopped           = Error("upload events stopped before the task was over")
+	ErrVMDiskSizeInvalid             = Error("vm disk size is invalid")
+	ErrVirtualDiskTaskFailed         = Error("virtual disk task failed")
+	ErrEventsAuthenticationFailed    = Error("events authentication failed")
+	ErrEventsProtocol                = Error("unexpected events protocol message")
+	ErrInvalidEventsBufferSize       = Error("invalid events buffer size")
+	ErrInvalidEventsHistorySize      = Error("invalid events history size")
+	ErrInvalidPlayerVolume           = Error("player volume must be between 0 and 100")
+	ErrHomeNodeNotAnAlarm            = Error("home node is not an alarm")
+	ErrInvalidNetworkControlPlanning = Error("network control planning must hold a mode for every slot of the week")
+	ErrUnknownBoxVendor              = Error("unknown box vendor")
+	ErrRemoteAccessUnavailable       = Error("remote access is not available on the box")
+	ErrInvalidDHCPStaticLeases       = Error("invalid dhcp static leases")
+	ErrInsufficientSpace             = Error("insufficient space")
+	ErrPartitionNotFound             = Error("partition not found")
 )
 
 var (
@@ -31,4 +90,31 @@ var (
 	// Authorize.
 	AuthorizeGrantingTimeout = time.Minute * 5
 	AuthorizeRetryDelay      = time.Second * 5
+
+	// Remote access.
+	RemoteAccessProbeTimeout = time.Second * 2 // Maximum time waited for the box to answer on the local network
+
+	// Events.
+	EventsCloseTimeout = time.Second * 5 // Maximum time waited for the freebox to acknowledge the closing of the events websocket
+
+	// Filesystem tasks.
+	FileSystemTaskPollInterval = time.Second
+
+	// Filesystem.
+	DirectoryWatchPollInterval = time.Second * 5 // Time between two listings of a directory watched by WatchDirectory
+
+	// Uploads.
+	FileUploadChunkSize = 1024 * 1024 // Maximum size of a binary frame sent over the upload websocket
+
+	// Virtual machines.
+	VirtualMachinePollInterval          = time.Second
+	VirtualMachineShutdownTimeout       = time.Minute * 2 // Time RestartVirtualMachine waits for a graceful shutdown when given no timeout
+	VirtualMachineDistributionsCacheTTL = time.Hour       // Time during which GetCachedVirtualMachineDistributions does not ask the freebox again
+
+	// Virtual disk tasks.
+	VirtualDiskTaskPollInterval = time.Second
+
+	// Download tasks.
+	DownloadTasksConcurrency = 4 // Maximum number of download tasks updated at once by bulk operations
+	DownloadTaskPollInterval = time.Second * 5
 )
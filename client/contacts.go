@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// The numbers, emails, addresses and URLs of a contact are each managed through their own endpoint.
+const (
+	contactNumbersPath   = "number/"
+	contactEmailsPath    = "email/"
+	contactAddressesPath = "address/"
+	contactURLsPath      = "url/"
+)
+
+// ListContacts returns the contacts selected by the options, the freebox applying the limit, offset and group.
+func (c *client) ListContacts(ctx context.Context, options types.ContactListOptions) (result []types.Contact, err error) {
+	path := "contact/"
+	if query := options.Query(); len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	if _, err = getList(ctx, c, path, &result, c.withSession(ctx)); err != nil {
+		return nil, fmt.Errorf("failed to GET contact/ endpoint: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetContactNumber returns a phone number of a contact.
+func (c *client) GetContactNumber(ctx context.Context, identifier int64) (types.ContactNumber, error) {
+	return getContactField[types.ContactNumber](ctx, c, contactNumbersPath, identifier)
+}
+
+// CreateContactNumber adds a phone number to a contact.
+func (c *client) CreateContactNumber(ctx context.Context, payload types.ContactNumberPayload) (types.ContactNumber, error) {
+	return createContactField[types.ContactNumber](ctx, c, contactNumbersPath, payload)
+}
+
+// UpdateContactNumber updates a phone number of a contact.
+func (c *client) UpdateContactNumber(ctx context.Context, identifier int64, payload types.ContactNumberPayload) (types.ContactNumber, error) {
+	return updateContactField[types.ContactNumber](ctx, c, contactNumbersPath, identifier, payload)
+}
+
+// DeleteContactNumber removes a phone number from a contact.
+func (c *client) DeleteContactNumber(ctx context.Context, identifier int64) error {
+	return c.deleteContactField(ctx, contactNumbersPath, identifier)
+}
+
+// GetContactEmail returns an email address of a contact.
+func (c *client) GetContactEmail(ctx context.Context, identifier int64) (types.ContactEmail, error) {
+	return getContactField[types.ContactEmail](ctx, c, contactEmailsPath, identifier)
+}
+
+// CreateContactEmail adds an email address to a contact.
+func (c *client) CreateContactEmail(ctx context.Context, payload types.ContactEmailPayload) (types.ContactEmail, error) {
+	return createContactField[types.ContactEmail](ctx, c, contactEmailsPath, payload)
+}
+
+// UpdateContactEmail updates an email address of a contact.
+func (c *client) UpdateContactEmail(ctx context.Context, identifier int64, payload types.ContactEmailPayload) (types.ContactEmail, error) {
+	return updateContactField[types.ContactEmail](ctx, c, contactEmailsPath, identifier, payload)
+}
+
+// DeleteContactEmail removes an email address from a contact.
+func (c *client) DeleteContactEmail(ctx context.Context, identifier int64) error {
+	return c.deleteContactField(ctx, contactEmailsPath, identifier)
+}
+
+// GetContactAddress returns a postal address of a contact.
+func (c *client) GetContactAddress(ctx context.Context, identifier int64) (types.ContactAddress, error) {
+	return getContactField[types.ContactAddress](ctx, c, contactAddressesPath, identifier)
+}
+
+// CreateContactAddress adds a postal address to a contact.
+func (c *client) CreateContactAddress(ctx context.Context, payload types.ContactAddressPayload) (types.ContactAddress, error) {
+	return createContactField[types.ContactAddress](ctx, c, contactAddressesPath, payload)
+}
+
+// UpdateContactAddress updates a postal address of a contact.
+func (c *client) UpdateContactAddress(ctx context.Context, identifier int64, payload types.ContactAddressPayload) (types.ContactAddress, error) {
+	return updateContactField[types.ContactAddress](ctx, c, contactAddressesPath, identifier, payload)
+}
+
+// DeleteContactAddress removes a postal address from a contact.
+func (c *client) DeleteContactAddress(ctx context.Context, identifier int64) error {
+	return c.deleteContactField(ctx, contactAddressesPath, identifier)
+}
+
+// GetContactURL returns a URL of a contact.
+func (c *client) GetContactURL(ctx context.Context, identifier int64) (types.ContactURL, error) {
+	return getContactField[types.ContactURL](ctx, c, contactURLsPath, identifier)
+}
+
+// CreateContactURL adds a URL to a contact.
+func (c *client) CreateContactURL(ctx context.Context, payload types.ContactURLPayload) (types.ContactURL, error) {
+	return createContactField[types.ContactURL](ctx, c, contactURLsPath, payload)
+}
+
+// UpdateContactURL updates a URL of a contact.
+func (c *client) UpdateContactURL(ctx context.Context, identifier int64, payload types.ContactURLPayload) (types.ContactURL, error) {
+	return updateContactField[types.ContactURL](ctx, c, contactURLsPath, identifier, payload)
+}
+
+// DeleteContactURL removes a URL from a contact.
+func (c *client) DeleteContactURL(ctx context.Context, identifier int64) error {
+	return c.deleteContactField(ctx, contactURLsPath, identifier)
+}
+
+func getContactField[T interface{}](ctx context.Context, c *client, path string, identifier int64) (result T, err error) {
+	response, err := c.get(ctx, fmt.Sprintf("%s%d", path, identifier), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrContactFieldNotFound
+		}
+
+		return result, fmt.Errorf("failed to GET %s%d endpoint: %w", path, identifier, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a contact %s from generic response: %w", path, err)
+	}
+
+	return result, nil
+}
+
+func createContactField[T interface{}](ctx context.Context, c *client, path string, payload interface{}) (result T, err error) {
+	response, err := c.post(ctx, path, payload, c.withSession(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to POST to %s endpoint: %w", path, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a contact %s from generic response: %w", path, err)
+	}
+
+	return result, nil
+}
+
+func updateContactField[T interface{}](ctx context.Context, c *client, path string, identifier int64, payload interface{}) (result T, err error) {
+	response, err := c.put(ctx, fmt.Sprintf("%s%d", path, identifier), payload, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrContactFieldNotFound
+		}
+
+		return result, fmt.Errorf("failed to PUT %s%d endpoint: %w", path, identifier, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a contact %s from generic response: %w", path, err)
+	}
+
+	return result, nil
+}
+
+func (c *client) deleteContactField(ctx context.Context, path string, identifier int64) error {
+	_, err := c.delete(ctx, fmt.Sprintf("%s%d", path, identifier), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrContactFieldNotFound
+		}
+
+		return fmt.Errorf("failed to DELETE %s%d endpoint: %w", path, identifier, err)
+	}
+
+	return nil
+}
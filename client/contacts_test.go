@@ -0,0 +1,429 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("contacts", func() {
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	Context("listing contacts", func() {
+		var (
+			options types.ContactListOptions
+
+			returnedContacts = new([]types.Contact)
+		)
+		BeforeEach(func() {
+			options = types.ContactListOptions{}
+		})
+		JustBeforeEach(func() {
+			*returnedContacts, *returnedErr = freeboxClient.ListContacts(context.Background(), options)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/contact/", version), ""),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								{
+									"id": 3,
+									"display_name": "Jane Doe",
+									"first_name": "Jane",
+									"last_name": "Doe",
+									"last_update": 1711650000,
+									"numbers": [
+										{
+											"id": 7,
+											"contact_id": 3,
+											"type": "mobile",
+											"number": "0607080910",
+											"is_default": true
+										}
+									]
+								}
+							]
+						}`),
+					),
+				)
+			})
+			It("should return every contact", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedContacts).To(HaveLen(1))
+				Expect((*returnedContacts)[0].DisplayName).To(Equal("Jane Doe"))
+				Expect((*returnedContacts)[0].LastUpdate.Unix()).To(Equal(int64(1711650000)))
+				Expect((*returnedContacts)[0].Numbers).To(Equal([]types.ContactNumber{
+					{
+						ContactNumberPayload: types.ContactNumberPayload{
+							ContactID: 3,
+							Type:      types.ContactNumberTypeMobile,
+							Number:    "0607080910",
+							IsDefault: true,
+						},
+						ID: 7,
+					},
+				}))
+			})
+		})
+		Context("when a page of a group is requested", func() {
+			BeforeEach(func() {
+				options = types.ContactListOptions{
+					Limit:   10,
+					Offset:  20,
+					GroupID: 2,
+				}
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/contact/", version), "group_id=2&limit=10&offset=20"),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should send the options as query parameters", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedContacts).To(BeEmpty())
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("getting a contact number", func() {
+		const identifier int64 = 7
+		returnedNumber := new(types.ContactNumber)
+		JustBeforeEach(func() {
+			*returnedNumber, *returnedErr = freeboxClient.GetContactNumber(context.Background(), identifier)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/number/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"id": 7,
+								"contact_id": 3,
+								"type": "mobile",
+								"number": "0607080910",
+								"is_default": true,
+								"is_own": false
+							}
+						}`),
+					),
+				)
+			})
+			It("should return the correct number", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedNumber).To(Equal(types.ContactNumber{
+					ContactNumberPayload: types.ContactNumberPayload{
+						ContactID: 3,
+						Type:      types.ContactNumberTypeMobile,
+						Number:    "0607080910",
+						IsDefault: true,
+					},
+					ID: identifier,
+				}))
+			})
+		})
+		Context("when the number is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/number/%d", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "noent" }`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrContactFieldNotFound))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the server returns an unexpected payload", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/number/%d", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": [] }`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("creating a contact number", func() {
+		returnedNumber := new(types.ContactNumber)
+		JustBeforeEach(func() {
+			*returnedNumber, *returnedErr = freeboxClient.CreateContactNumber(context.Background(), types.ContactNumberPayload{
+				ContactID: 3,
+				Type:      types.ContactNumberTypeFixed,
+				Number:    "0102030405",
+			})
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/number/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{ "contact_id": 3, "type": "fixed", "number": "0102030405" }`),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": { "id": 8, "contact_id": 3, "type": "fixed", "number": "0102030405" }
+						}`),
+					),
+				)
+			})
+			It("should return the created number", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedNumber.ID).To(BeEquivalentTo(8))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("updating a contact number", func() {
+		const identifier int64 = 7
+		returnedNumber := new(types.ContactNumber)
+		JustBeforeEach(func() {
+			*returnedNumber, *returnedErr = freeboxClient.UpdateContactNumber(context.Background(), identifier, types.ContactNumberPayload{
+				ContactID: 3,
+				IsDefault: true,
+			})
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/number/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{ "contact_id": 3, "is_default": true }`),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": { "id": 7, "contact_id": 3, "type": "mobile", "number": "0607080910", "is_default": true }
+						}`),
+					),
+				)
+			})
+			It("should return the updated number", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedNumber.IsDefault).To(BeTrue())
+			})
+		})
+		Context("when the number is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/number/%d", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "noent" }`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrContactFieldNotFound))
+			})
+		})
+	})
+	Context("deleting a contact number", func() {
+		const identifier int64 = 7
+		JustBeforeEach(func() {
+			*returnedErr = freeboxClient.DeleteContactNumber(context.Background(), identifier)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/number/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the number is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/number/%d", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "noent" }`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrContactFieldNotFound))
+			})
+		})
+	})
+	Context("managing the other fields of a contact", func() {
+		const identifier int64 = 9
+		for _, field := range []struct {
+			path         string
+			requestBody  string
+			responseBody string
+			call         func(freeboxClient client.Client) (int64, error)
+		}{
+			{
+				path:         "email",
+				requestBody:  `{ "contact_id": 3, "type": "work", "email": "jane@example.com" }`,
+				responseBody: `{ "id": 9, "contact_id": 3, "type": "work", "email": "jane@example.com" }`,
+				call: func(freeboxClient client.Client) (int64, error) {
+					ctx := context.Background()
+					payload := types.ContactEmailPayload{ContactID: 3, Type: types.ContactEmailTypeWork, Email: "jane@example.com"}
+					if _, err := freeboxClient.CreateContactEmail(ctx, payload); err != nil {
+						return 0, err
+					}
+					email, err := freeboxClient.GetContactEmail(ctx, identifier)
+					if err != nil {
+						return 0, err
+					}
+					if _, err := freeboxClient.UpdateContactEmail(ctx, identifier, payload); err != nil {
+						return 0, err
+					}
+					return email.ID, freeboxClient.DeleteContactEmail(ctx, identifier)
+				},
+			},
+			{
+				path:         "address",
+				requestBody:  `{ "contact_id": 3, "type": "home", "number": "8", "street": "rue de la Ville l'Evêque", "city": "Paris", "zipcode": "75008", "country": "France" }`,
+				responseBody: `{ "id": 9, "contact_id": 3, "type": "home", "number": "8", "street": "rue de la Ville l'Evêque", "city": "Paris", "zipcode": "75008", "country": "France" }`,
+				call: func(freeboxClient client.Client) (int64, error) {
+					ctx := context.Background()
+					payload := types.ContactAddressPayload{
+						ContactID: 3,
+						Type:      types.ContactAddressTypeHome,
+						Number:    "8",
+						Street:    "rue de la Ville l'Evêque",
+						City:      "Paris",
+						Zipcode:   "75008",
+						Country:   "France",
+					}
+					if _, err := freeboxClient.CreateContactAddress(ctx, payload); err != nil {
+						return 0, err
+					}
+					address, err := freeboxClient.GetContactAddress(ctx, identifier)
+					if err != nil {
+						return 0, err
+					}
+					if _, err := freeboxClient.UpdateContactAddress(ctx, identifier, payload); err != nil {
+						return 0, err
+					}
+					return address.ID, freeboxClient.DeleteContactAddress(ctx, identifier)
+				},
+			},
+			{
+				path:         "url",
+				requestBody:  `{ "contact_id": 3, "type": "site", "url": "https://example.com" }`,
+				responseBody: `{ "id": 9, "contact_id": 3, "type": "site", "url": "https://example.com" }`,
+				call: func(freeboxClient client.Client) (int64, error) {
+					ctx := context.Background()
+					payload := types.ContactURLPayload{ContactID: 3, Type: types.ContactURLTypeSite, URL: "https://example.com"}
+					if _, err := freeboxClient.CreateContactURL(ctx, payload); err != nil {
+						return 0, err
+					}
+					url, err := freeboxClient.GetContactURL(ctx, identifier)
+					if err != nil {
+						return 0, err
+					}
+					if _, err := freeboxClient.UpdateContactURL(ctx, identifier, payload); err != nil {
+						return 0, err
+					}
+					return url.ID, freeboxClient.DeleteContactURL(ctx, identifier)
+				},
+			},
+		} {
+			field := field
+			Context(fmt.Sprintf("when managing a contact %s", field.path), func() {
+				BeforeEach(func() {
+					server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/%s/", version, field.path)),
+							verifyAuth(*sessionToken),
+							ghttp.VerifyJSON(field.requestBody),
+							ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %s }`, field.responseBody)),
+						),
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/%s/%d", version, field.path, identifier)),
+							verifyAuth(*sessionToken),
+							ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %s }`, field.responseBody)),
+						),
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/%s/%d", version, field.path, identifier)),
+							verifyAuth(*sessionToken),
+							ghttp.VerifyJSON(field.requestBody),
+							ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %s }`, field.responseBody)),
+						),
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/%s/%d", version, field.path, identifier)),
+							verifyAuth(*sessionToken),
+							ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+						),
+					)
+				})
+				It("should use the endpoint of the field", func() {
+					Expect(field.call(freeboxClient)).To(Equal(identifier))
+					Expect(server.ReceivedRequests()).To(HaveLen(6))
+				})
+			})
+		}
+	})
+})
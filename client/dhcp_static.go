@@ -25,6 +25,8 @@ func (c *client) ListDHCPStaticLease(ctx context.Context) (result []types.DHCPSt
 }
 
 func (c *client) GetDHCPStaticLease(ctx context.Context, identifier string) (result types.DHCPStaticLeaseInfo, err error) {
+	identifier = normalizeMACIdentifier(identifier)
+
 	response, err := c.get(ctx, "dhcp/static_lease/"+identifier, c.withSession(ctx))
 	if err != nil {
 		return result, fmt.Errorf("failed to GET dhcp/static_lease/%s endpoint: %w", identifier, err)
@@ -38,7 +40,9 @@ func (c *client) GetDHCPStaticLease(ctx context.Context, identifier string) (res
 }
 
 func (c *client) UpdateDHCPStaticLease(ctx context.Context, identifier string, payload types.DHCPStaticLeasePayload) (result types.LanInterfaceHost, err error) {
-	response, err := c.put(ctx, "dhcp/static_lease/"+identifier, c.withSession(ctx))
+	identifier = normalizeMACIdentifier(identifier)
+
+	response, err := c.put(ctx, "dhcp/static_lease/"+identifier, payload, c.withSession(ctx))
 	if err != nil {
 		return result, fmt.Errorf("failed to PUT dhcp/static_lease/%s endpoint: %w", identifier, err)
 	}
@@ -64,6 +68,8 @@ func (c *client) CreateDHCPStaticLease(ctx context.Context, payload types.DHCPSt
 }
 
 func (c *client) DeleteDHCPStaticLease(ctx context.Context, identifier string) error {
+	identifier = normalizeMACIdentifier(identifier)
+
 	_, err := c.delete(ctx, "dhcp/static_lease/"+identifier, c.withSession(ctx))
 	if err != nil {
 		return fmt.Errorf("failed to DELETE dhcp/static_lease/%s endpoint: %w", identifier, err)
@@ -71,3 +77,14 @@ func (c *client) DeleteDHCPStaticLease(ctx context.Context, identifier string) e
 
 	return nil
 }
+
+// normalizeMACIdentifier formats an identifier which is a mac address the way the freebox expects it, in lower case
+// and separated by colons, as it does not recognize the other formats. Other identifiers are returned as is.
+func normalizeMACIdentifier(identifier string) string {
+	address, err := types.ParseMACAddress(identifier)
+	if err != nil {
+		return identifier
+	}
+
+	return address.String()
+}
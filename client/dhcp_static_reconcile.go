@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// ExportDHCPStaticLeases returns every static lease as the payload creating it, which can be stored as JSON and given
+// back to ReconcileDHCPStaticLeases.
+func (c *client) ExportDHCPStaticLeases(ctx context.Context) ([]types.DHCPStaticLeasePayload, error) {
+	leases, err := c.ListDHCPStaticLease(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.DHCPStaticLeasePayload, 0, len(leases))
+
+	for _, lease := range leases {
+		ip := lease.IP
+		result = append(result, types.DHCPStaticLeasePayload{
+			Mac:     lease.Mac,
+			Comment: lease.Comment,
+			IP:      &ip,
+		})
+	}
+
+	return result, nil
+}
+
+// ReconcileDHCPStaticLeases makes the static leases match the desired ones, identified by their mac address: missing
+// leases are created and leases with another IP or comment are updated. An empty comment leaves the comment of the
+// lease as is. Leases which are not desired are deleted when deleteExtra is set.
+// The extra leases are deleted first and the drifted ones updated before the missing ones are created, so that IPs
+// can move from a host to another. Errors on individual leases do not stop the others: they are all returned along
+// with the changes which succeeded.
+func (c *client) ReconcileDHCPStaticLeases(ctx context.Context, desired []types.DHCPStaticLeasePayload, deleteExtra bool) (changes types.DHCPStaticLeaseChanges, err error) {
+	wanted := make(map[string]types.DHCPStaticLeasePayload, len(desired))
+
+	for _, lease := range desired {
+		if len(lease.Mac) == 0 || lease.IP == nil {
+			return changes, fmt.Errorf("%w: the mac address and IP of every lease must be set", ErrInvalidDHCPStaticLeases)
+		}
+
+		if _, ok := wanted[lease.Mac.String()]; ok {
+			return changes, fmt.Errorf("%w: several leases for %s", ErrInvalidDHCPStaticLeases, lease.Mac)
+		}
+
+		wanted[lease.Mac.String()] = lease
+	}
+
+	leases, err := c.ListDHCPStaticLease(ctx)
+	if err != nil {
+		return changes, err
+	}
+
+	existing := make(map[string]types.DHCPStaticLeaseInfo, len(leases))
+	for _, lease := range leases {
+		existing[lease.Mac.String()] = lease
+	}
+
+	var errs []error
+
+	if deleteExtra {
+		for _, lease := range leases {
+			if _, ok := wanted[lease.Mac.String()]; ok {
+				continue
+			}
+
+			if err := c.DeleteDHCPStaticLease(ctx, lease.ID); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete lease of %s: %w", lease.Mac, err))
+
+				continue
+			}
+
+			changes.Deleted = append(changes.Deleted, lease.Mac)
+		}
+	}
+
+	for _, lease := range desired {
+		current, ok := existing[lease.Mac.String()]
+		if !ok || (current.IP == *lease.IP && (lease.Comment == "" || current.Comment == lease.Comment)) {
+			continue
+		}
+
+		if _, err := c.UpdateDHCPStaticLease(ctx, current.ID, types.DHCPStaticLeasePayload{IP: lease.IP, Comment: lease.Comment}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update lease of %s: %w", lease.Mac, err))
+
+			continue
+		}
+
+		changes.Updated = append(changes.Updated, lease.Mac)
+	}
+
+	for _, lease := range desired {
+		if _, ok := existing[lease.Mac.String()]; ok {
+			continue
+		}
+
+		if _, err := c.CreateDHCPStaticLease(ctx, lease); err != nil {
+			errs = append(errs, fmt.Errorf("failed to create lease of %s: %w", lease.Mac, err))
+
+			continue
+		}
+
+		changes.Created = append(changes.Created, lease.Mac)
+	}
+
+	return changes, errors.Join(errs...)
+}
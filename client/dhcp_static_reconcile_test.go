@@ -0,0 +1,170 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("DHCP static lease reconciliation", func() {
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		setupLoginFlow(server)
+	})
+	lease := func(mac, ip, comment string) types.DHCPStaticLeasePayload {
+		address := netip.MustParseAddr(ip)
+
+		return types.DHCPStaticLeasePayload{
+			Mac:     Must(types.ParseMACAddress(mac)),
+			IP:      &address,
+			Comment: comment,
+		}
+	}
+	listHandler := ghttp.CombineHandlers(
+		ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/dhcp/static_lease/", version)),
+		ghttp.RespondWith(http.StatusOK, `{
+			"success": true,
+			"result": [
+				{ "id": "00:11:22:33:44:55", "mac": "00:11:22:33:44:55", "ip": "192.168.1.10", "comment": "nas" },
+				{ "id": "00:11:22:33:44:56", "mac": "00:11:22:33:44:56", "ip": "192.168.1.11", "comment": "printer" },
+				{ "id": "00:11:22:33:44:57", "mac": "00:11:22:33:44:57", "ip": "192.168.1.12", "comment": "old laptop" }
+			]
+		}`),
+	)
+	Context("exporting the leases", func() {
+		returnedLeases := new([]types.DHCPStaticLeasePayload)
+		BeforeEach(func() {
+			server.AppendHandlers(listHandler)
+		})
+		JustBeforeEach(func() {
+			*returnedLeases, *returnedErr = freeboxClient.ExportDHCPStaticLeases(context.Background())
+		})
+		It("should return the payloads of the leases", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*returnedLeases).To(Equal([]types.DHCPStaticLeasePayload{
+				lease("00:11:22:33:44:55", "192.168.1.10", "nas"),
+				lease("00:11:22:33:44:56", "192.168.1.11", "printer"),
+				lease("00:11:22:33:44:57", "192.168.1.12", "old laptop"),
+			}))
+		})
+	})
+	Context("reconciling the leases", func() {
+		var (
+			desired     []types.DHCPStaticLeasePayload
+			deleteExtra bool
+
+			returnedChanges = new(types.DHCPStaticLeaseChanges)
+		)
+		BeforeEach(func() {
+			desired = []types.DHCPStaticLeasePayload{
+				lease("00:11:22:33:44:55", "192.168.1.10", "nas"),
+				lease("00:11:22:33:44:56", "192.168.1.20", ""),
+				lease("AA-BB-CC-DD-EE-FF", "192.168.1.12", "new laptop"),
+			}
+			deleteExtra = false
+		})
+		JustBeforeEach(func() {
+			*returnedChanges, *returnedErr = freeboxClient.ReconcileDHCPStaticLeases(context.Background(), desired, deleteExtra)
+		})
+		updateHandler := ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/dhcp/static_lease/00:11:22:33:44:56", version)),
+			ghttp.VerifyJSON(`{ "ip": "192.168.1.20" }`),
+			ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": {} }`),
+		)
+		createHandler := ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/dhcp/static_lease/", version)),
+			ghttp.VerifyJSON(`{ "mac": "aa:bb:cc:dd:ee:ff", "ip": "192.168.1.12", "comment": "new laptop" }`),
+			ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": {} }`),
+		)
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(listHandler, updateHandler, createHandler)
+			})
+			It("should update the drifted leases and create the missing ones", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedChanges.Updated).To(Equal([]types.MACAddress{desired[1].Mac}))
+				Expect(returnedChanges.Created).To(Equal([]types.MACAddress{desired[2].Mac}))
+				Expect(returnedChanges.Deleted).To(BeEmpty())
+			})
+		})
+		Context("when extra leases are deleted", func() {
+			BeforeEach(func() {
+				deleteExtra = true
+
+				server.AppendHandlers(
+					listHandler,
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/dhcp/static_lease/00:11:22:33:44:57", version)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+					updateHandler,
+					createHandler,
+				)
+			})
+			It("should delete them before creating the missing ones", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedChanges.Deleted).To(Equal([]types.MACAddress{Must(types.ParseMACAddress("00:11:22:33:44:57"))}))
+				Expect(returnedChanges.Updated).To(HaveLen(1))
+				Expect(returnedChanges.Created).To(HaveLen(1))
+			})
+		})
+		Context("when a change fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					listHandler,
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/dhcp/static_lease/00:11:22:33:44:56", version)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "inval", "msg": "IP already in use" }`),
+					),
+					createHandler,
+				)
+			})
+			It("should carry on with the others and return the error", func() {
+				Expect(*returnedErr).To(MatchError(ContainSubstring("failed to update lease of 00:11:22:33:44:56")))
+				Expect(returnedChanges.Updated).To(BeEmpty())
+				Expect(returnedChanges.Created).To(HaveLen(1))
+			})
+		})
+		Context("when a desired lease has no IP", func() {
+			BeforeEach(func() {
+				desired[0].IP = nil
+			})
+			It("should return an error without changing anything", func() {
+				Expect(*returnedErr).To(MatchError(client.ErrInvalidDHCPStaticLeases))
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+		Context("when a mac address is desired twice", func() {
+			BeforeEach(func() {
+				desired = append(desired, lease("00-11-22-33-44-55", "192.168.1.30", ""))
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).To(MatchError(client.ErrInvalidDHCPStaticLeases))
+			})
+		})
+	})
+})
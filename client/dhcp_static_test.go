@@ -3,6 +3,7 @@ package client_test
 import (
 	"fmt"
 	"net/http"
+	"net/netip"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -63,8 +64,8 @@ var _ = Describe("DHCPStatic", func() {
 							Result: []types.DHCPStaticLeaseInfo{
 								{
 									ID:       "identifier",
-									IP:       IPAddress,
-									Mac:      MACAddress,
+									IP:       netip.MustParseAddr(IPAddress),
+									Mac:      Must(types.ParseMACAddress(MACAddress)),
 									Comment:  CurrentSpecReport().FullText(),
 									Hostname: "test-hostname",
 									Host: types.LanInterfaceHost{
@@ -88,7 +89,7 @@ var _ = Describe("DHCPStatic", func() {
 										PrimaryNameManual: true,
 										L3Connectivities: []types.L3Connectivity{
 											{
-												Address:           IPAddress,
+												Address:           netip.MustParseAddr(IPAddress),
 												Active:            true,
 												Reachable:         true,
 												LastActivity:      types.Timestamp{Time: time.Unix(1682578724, 0)},
@@ -114,8 +115,8 @@ var _ = Describe("DHCPStatic", func() {
 				Expect(*returnedDHCPStatjcLeases).To(ConsistOf(
 					gstruct.MatchAllFields(gstruct.Fields{
 						"ID":       Equal("identifier"),
-						"IP":       Equal(IPAddress),
-						"Mac":      Equal(MACAddress),
+						"IP":       Equal(netip.MustParseAddr(IPAddress)),
+						"Mac":      Equal(Must(types.ParseMACAddress(MACAddress))),
 						"Comment":  Equal(CurrentSpecReport().FullText()),
 						"Hostname": Equal("test-hostname"),
 						"Host": gstruct.MatchAllFields(gstruct.Fields{
@@ -138,7 +139,7 @@ var _ = Describe("DHCPStatic", func() {
 							}),
 							"PrimaryNameManual": BeTrue(),
 							"L3Connectivities": ConsistOf(gstruct.MatchAllFields(gstruct.Fields{
-								"Address":   Equal(IPAddress),
+								"Address":   Equal(netip.MustParseAddr(IPAddress)),
 								"Active":    BeTrue(),
 								"Reachable": BeTrue(),
 								"LastActivity": gstruct.MatchAllFields(gstruct.Fields{
@@ -150,6 +151,7 @@ var _ = Describe("DHCPStatic", func() {
 								}),
 							})),
 							"DefaultName": Equal("testing"),
+							"AccessPoint": BeNil(),
 							"FirstActivity": gstruct.MatchAllFields(gstruct.Fields{
 								"Time": BeTemporally("==", time.Unix(1682578724, 0)),
 							}),
@@ -212,10 +214,16 @@ var _ = Describe("DHCPStatic", func() {
 			MACAddress = "00:11:22:33:44:56"
 		)
 
+		var identifier string
+
 		returnedDHCPStaticLease := new(types.DHCPStaticLeaseInfo)
 
+		BeforeEach(func() {
+			identifier = MACAddress
+		})
+
 		JustBeforeEach(func(ctx SpecContext) {
-			*returnedDHCPStaticLease, *returnedErr = freeboxClient.GetDHCPStaticLease(ctx, MACAddress)
+			*returnedDHCPStaticLease, *returnedErr = freeboxClient.GetDHCPStaticLease(ctx, identifier)
 		})
 
 		Context("default", func() {
@@ -231,8 +239,8 @@ var _ = Describe("DHCPStatic", func() {
 							Success: true,
 							Result: types.DHCPStaticLeaseInfo{
 								ID:       "identifier",
-								IP:       IPAddress,
-								Mac:      MACAddress,
+								IP:       netip.MustParseAddr(IPAddress),
+								Mac:      Must(types.ParseMACAddress(MACAddress)),
 								Comment:  CurrentSpecReport().FullText(),
 								Hostname: "test-hostname",
 								Host: types.LanInterfaceHost{
@@ -261,7 +269,7 @@ var _ = Describe("DHCPStatic", func() {
 									DefaultName:       "testing",
 									L3Connectivities: []types.L3Connectivity{
 										{
-											Address:           IPAddress,
+											Address:           netip.MustParseAddr(IPAddress),
 											Active:            true,
 											Reachable:         true,
 											LastActivity:      types.Timestamp{Time: time.Unix(1682578724, 0)},
@@ -280,8 +288,8 @@ var _ = Describe("DHCPStatic", func() {
 				Expect(*returnedErr).ToNot(HaveOccurred())
 				Expect(*returnedDHCPStaticLease).To(gstruct.MatchAllFields(gstruct.Fields{
 					"ID":       Equal("identifier"),
-					"IP":       Equal(IPAddress),
-					"Mac":      Equal(MACAddress),
+					"IP":       Equal(netip.MustParseAddr(IPAddress)),
+					"Mac":      Equal(Must(types.ParseMACAddress(MACAddress))),
 					"Comment":  Equal(CurrentSpecReport().FullText()),
 					"Hostname": Equal("test-hostname"),
 					"Host": gstruct.MatchAllFields(gstruct.Fields{
@@ -313,7 +321,7 @@ var _ = Describe("DHCPStatic", func() {
 						"PrimaryName": Equal("testing"),
 						"DefaultName": Equal("testing"),
 						"L3Connectivities": ConsistOf(gstruct.MatchAllFields(gstruct.Fields{
-							"Address":   Equal(IPAddress),
+							"Address":   Equal(netip.MustParseAddr(IPAddress)),
 							"Active":    BeTrue(),
 							"Reachable": BeTrue(),
 							"LastActivity": gstruct.MatchAllFields(gstruct.Fields{
@@ -324,6 +332,7 @@ var _ = Describe("DHCPStatic", func() {
 								"Time": BeTemporally("==", time.Unix(1682578724, 0)),
 							}),
 						})),
+						"AccessPoint": BeNil(),
 					}),
 				}))
 			})
@@ -348,6 +357,32 @@ var _ = Describe("DHCPStatic", func() {
 				Expect(*returnedErr).To(HaveOccurred())
 			})
 		})
+
+		Context("when the mac address is not in its normalized form", func() {
+			BeforeEach(func() {
+				identifier = "00-11-22-33-44-56"
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", fmt.Sprintf("/api/%s/dhcp/static_lease/%s", version, MACAddress)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"id": "00:11:22:33:44:56",
+								"mac": "00:11:22:33:44:56",
+								"ip": "192.168.1.11"
+							}
+						}`),
+					),
+				)
+			})
+
+			It("should request the normalized mac address", func() {
+				Expect(*returnedErr).ToNot(HaveOccurred())
+				Expect(returnedDHCPStaticLease.ID).To(Equal(MACAddress))
+			})
+		})
 	})
 
 	Context("UpdateDHCPStaticLease", func() {
@@ -404,7 +439,7 @@ var _ = Describe("DHCPStatic", func() {
 								},
 								L3Connectivities: []types.L3Connectivity{
 									{
-										Address:           IPAddress,
+										Address:           netip.MustParseAddr(IPAddress),
 										Active:            true,
 										Reachable:         true,
 										LastActivity:      types.Timestamp{Time: time.Unix(1682578724, 0)},
@@ -417,6 +452,11 @@ var _ = Describe("DHCPStatic", func() {
 					),
 				)
 			})
+
+			It("should return the updated host", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedDHCPStaticLease.L2Ident.ID).To(Equal(MACAddress))
+			})
 		})
 
 		Context("when the server returns an error", func() {
@@ -449,11 +489,12 @@ var _ = Describe("DHCPStatic", func() {
 		returnedDHCPStaticLease := new(types.LanInterfaceHost)
 
 		JustBeforeEach(func(ctx SpecContext) {
+			ip := netip.MustParseAddr(IPAddress)
 			*returnedDHCPStaticLease, *returnedErr = freeboxClient.CreateDHCPStaticLease(ctx, types.DHCPStaticLeasePayload{
 				Comment:  CurrentSpecReport().FullText(),
-				Mac:      MACAddress,
+				Mac:      Must(types.ParseMACAddress(MACAddress)),
 				Hostname: "test-hostname",
-				IP:       IPAddress,
+				IP:       &ip,
 			})
 		})
 
@@ -500,7 +541,7 @@ var _ = Describe("DHCPStatic", func() {
 								},
 								L3Connectivities: []types.L3Connectivity{
 									{
-										Address:           IPAddress,
+										Address:           netip.MustParseAddr(IPAddress),
 										Active:            true,
 										Reachable:         true,
 										LastActivity:      types.Timestamp{Time: time.Unix(1682578724, 0)},
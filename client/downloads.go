@@ -1,10 +1,13 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"sort"
@@ -13,10 +16,6 @@ import (
 	"github.com/nikolalohinski/free-go/types"
 )
 
-const (
-	codeTaskNotFound = "task_not_found"
-)
-
 func (c *client) ListDownloadTasks(ctx context.Context) (result []types.DownloadTask, err error) {
 	response, err := c.get(ctx, "downloads/", c.withSession(ctx))
 	if err != nil {
@@ -37,7 +36,7 @@ func (c *client) ListDownloadTasks(ctx context.Context) (result []types.Download
 func (c *client) GetDownloadTask(ctx context.Context, identifier int64) (result types.DownloadTask, err error) {
 	response, err := c.get(ctx, fmt.Sprintf("downloads/%d", identifier), c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == codeTaskNotFound {
+		if errors.Is(err, ErrTaskNotFound) {
 			return result, ErrTaskNotFound
 		}
 
@@ -51,6 +50,20 @@ func (c *client) GetDownloadTask(ctx context.Context, identifier int64) (result
 	return result, nil
 }
 
+// GetDownloadStats returns the global statistics of the downloader.
+func (c *client) GetDownloadStats(ctx context.Context) (result types.DownloadStats, err error) {
+	response, err := c.get(ctx, "downloads/stats", c.withSession(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to GET downloads/stats endpoint: %w", err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get download stats from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
 // “application/x-www-form-urlencoded” instead of “application/json”.
 func (c *client) AddDownloadTask(ctx context.Context, downloadRequest types.DownloadRequest) (int64, error) {
 	form := url.Values{}
@@ -120,7 +133,63 @@ func (c *client) AddDownloadTask(ctx context.Context, downloadRequest types.Down
 		return 0, fmt.Errorf("failed to forge new request: %w", err)
 	}
 
-	response, err := c.do(request, c.withSession(ctx), c.withWWWFormURLEncodedContentType)
+	return c.addDownloadTask(request, c.withSession(ctx), c.withWWWFormURLEncodedContentType)
+}
+
+// AddDownloadTaskFromFile adds a download task from the content of a .torrent or .nzb file,
+// sent as “multipart/form-data”.
+func (c *client) AddDownloadTaskFromFile(ctx context.Context, downloadRequest types.DownloadFileRequest) (int64, error) {
+	if downloadRequest.File == nil {
+		return 0, errors.New("a file is required to add a download task from a file")
+	}
+
+	filename := downloadRequest.Filename
+	if filename == "" {
+		filename = "download.torrent"
+	}
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	if downloadRequest.DownloadDirectory != "" {
+		if err := writer.WriteField("download_dir", base64.StdEncoding.EncodeToString([]byte(downloadRequest.DownloadDirectory))); err != nil {
+			return 0, fmt.Errorf("failed to write download_dir field: %w", err)
+		}
+	}
+
+	if downloadRequest.ArchivePassword != "" {
+		if err := writer.WriteField("archive_password", downloadRequest.ArchivePassword); err != nil {
+			return 0, fmt.Errorf("failed to write archive_password field: %w", err)
+		}
+	}
+
+	part, err := writer.CreateFormFile("download_file", filename)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create download_file field: %w", err)
+	}
+
+	if _, err = io.Copy(part, downloadRequest.File); err != nil {
+		return 0, fmt.Errorf("failed to write download_file field: %w", err)
+	}
+
+	if err = writer.Close(); err != nil {
+		return 0, fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/downloads/add", c.base), body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to forge new request: %w", err)
+	}
+
+	return c.addDownloadTask(request, c.withSession(ctx), func(req *http.Request) error {
+		req.Header.Add("Content-Type", writer.FormDataContentType())
+
+		return nil
+	})
+}
+
+func (c *client) addDownloadTask(request *http.Request, options ...HTTPOption) (int64, error) {
+	response, err := c.do(request, options...)
 	if err != nil {
 		return 0, fmt.Errorf("failed to POST downloads/add endpoint: %w", err)
 	}
@@ -138,9 +207,9 @@ func (c *client) AddDownloadTask(ctx context.Context, downloadRequest types.Down
 
 // DeleteDownloadTask deletes a download task by its identifier.
 func (c *client) DeleteDownloadTask(ctx context.Context, identifier int64) error {
-	response, err := c.delete(ctx, fmt.Sprintf("downloads/%d", identifier), c.withSession(ctx))
+	_, err := c.delete(ctx, fmt.Sprintf("downloads/%d", identifier), c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == codeTaskNotFound {
+		if errors.Is(err, ErrTaskNotFound) {
 			return ErrTaskNotFound
 		}
 
@@ -152,9 +221,9 @@ func (c *client) DeleteDownloadTask(ctx context.Context, identifier int64) error
 
 // EraseDownloadTask erases a download task and the downloaded files.
 func (c *client) EraseDownloadTask(ctx context.Context, identifier int64) error {
-	response, err := c.delete(ctx, fmt.Sprintf("downloads/%d/erase", identifier), c.withSession(ctx))
+	_, err := c.delete(ctx, fmt.Sprintf("downloads/%d/erase", identifier), c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == codeTaskNotFound {
+		if errors.Is(err, ErrTaskNotFound) {
 			return ErrTaskNotFound
 		}
 
@@ -166,9 +235,9 @@ func (c *client) EraseDownloadTask(ctx context.Context, identifier int64) error
 
 // UpdateDownloadTask updates a download task by its identifier.
 func (c *client) UpdateDownloadTask(ctx context.Context, identifier int64, downloadRequest types.DownloadTaskUpdate) error {
-	resp, err := c.put(ctx, fmt.Sprintf("downloads/%d", identifier), downloadRequest, c.withSession(ctx))
+	_, err := c.put(ctx, fmt.Sprintf("downloads/%d", identifier), downloadRequest, c.withSession(ctx))
 	if err != nil {
-		if resp != nil && resp.ErrorCode == codeTaskNotFound {
+		if errors.Is(err, ErrTaskNotFound) {
 			return ErrTaskNotFound
 		}
 
@@ -177,3 +246,41 @@ func (c *client) UpdateDownloadTask(ctx context.Context, identifier int64, downl
 
 	return nil
 }
+
+// ListDownloadTaskFiles lists the files of a download task.
+func (c *client) ListDownloadTaskFiles(ctx context.Context, identifier int64) (result []types.DownloadFile, err error) {
+	response, err := c.get(ctx, fmt.Sprintf("downloads/%d/files", identifier), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrTaskNotFound) {
+			return nil, ErrTaskNotFound
+		}
+
+		return nil, fmt.Errorf("failed to GET downloads/%d/files endpoint: %w", identifier, err)
+	}
+
+	if response.Result == nil {
+		return
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to get download files from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdateDownloadTaskFile updates the priority of a file of a download task.
+func (c *client) UpdateDownloadTaskFile(ctx context.Context, identifier int64, fileID string, priority types.DownloadFilePriority) error {
+	_, err := c.put(ctx, fmt.Sprintf("downloads/%d/files/%s", identifier, url.PathEscape(fileID)), map[string]interface{}{
+		"priority": priority,
+	}, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrTaskNotFound) {
+			return ErrTaskNotFound
+		}
+
+		return fmt.Errorf("failed to PUT downloads/%d/files/%s endpoint: %w", identifier, fileID, err)
+	}
+
+	return nil
+}
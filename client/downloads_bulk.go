@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// UpdateAllDownloadTasks applies an operation to every download task it is relevant to, with at most
+// DownloadTasksConcurrency concurrent requests. Errors on individual tasks do not stop the others
+// and are all returned once every task has been processed.
+func (c *client) UpdateAllDownloadTasks(ctx context.Context, operation types.DownloadTasksOperation) error {
+	selected, apply, err := c.downloadTasksOperation(operation)
+	if err != nil {
+		return err
+	}
+
+	return c.updateDownloadTasks(ctx, string(operation), selected, apply)
+}
+
+// StopSeedingDownloadTasks stops every seeding task which transmitted at least ratio times its size. The tasks whose
+// size is not known yet are left seeding.
+func (c *client) StopSeedingDownloadTasks(ctx context.Context, ratio float64) error {
+	return c.updateDownloadTasks(ctx, "stop seeding", func(task types.DownloadTask) bool {
+		return task.Status == types.DownloadTaskStatusSeeding && task.SizeBytes > 0 &&
+			float64(task.TransmittedBytes) >= ratio*float64(task.SizeBytes)
+	}, c.stopDownloadTask)
+}
+
+// UpdateDownloadTasks applies the same update to several download tasks, with at most DownloadTasksConcurrency
+// concurrent requests. The results are in the same order as the identifiers. Errors on individual tasks do not stop
+// the others and are all returned once every task has been processed.
+func (c *client) UpdateDownloadTasks(ctx context.Context, identifiers []int64, payload types.DownloadTaskUpdate) ([]types.DownloadTaskUpdateResult, error) {
+	concurrency := DownloadTasksConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		wg        sync.WaitGroup
+		results   = make([]types.DownloadTaskUpdateResult, len(identifiers))
+		semaphore = make(chan struct{}, concurrency)
+	)
+
+	for index, identifier := range identifiers {
+		results[index].ID = identifier
+
+		if err := ctx.Err(); err != nil {
+			results[index].Error = err
+
+			continue
+		}
+
+		semaphore <- struct{}{}
+
+		wg.Add(1)
+
+		go func(index int, identifier int64) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			results[index].Error = c.UpdateDownloadTask(ctx, identifier, payload)
+		}(index, identifier)
+	}
+
+	wg.Wait()
+
+	errs := make([]error, 0)
+
+	for _, result := range results {
+		if result.Error != nil {
+			errs = append(errs, fmt.Errorf("failed to update download task %d: %w", result.ID, result.Error))
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+func (c *client) updateDownloadTasks(ctx context.Context, operation string, selected func(types.DownloadTask) bool, apply func(context.Context, int64) error) error {
+	tasks, err := c.ListDownloadTasks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list download tasks: %w", err)
+	}
+
+	concurrency := DownloadTasksConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		wg        sync.WaitGroup
+		lock      sync.Mutex
+		errs      []error
+		semaphore = make(chan struct{}, concurrency)
+	)
+
+	for _, task := range tasks {
+		if !selected(task) {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+
+			break
+		}
+
+		semaphore <- struct{}{}
+
+		wg.Add(1)
+
+		go func(identifier int64) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			if err := apply(ctx, identifier); err != nil {
+				lock.Lock()
+				defer lock.Unlock()
+
+				errs = append(errs, fmt.Errorf("failed to %s download task %d: %w", operation, identifier, err))
+			}
+		}(task.ID)
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func (c *client) downloadTasksOperation(operation types.DownloadTasksOperation) (func(types.DownloadTask) bool, func(context.Context, int64) error, error) {
+	switch operation {
+	case types.DownloadTasksOperationPause:
+		return isDownloadTaskActive, c.stopDownloadTask, nil
+	case types.DownloadTasksOperationResume:
+		return isDownloadTaskStopped, c.resumeDownloadTask, nil
+	case types.DownloadTasksOperationDeleteDone:
+		return isDownloadTaskDone, c.DeleteDownloadTask, nil
+	case types.DownloadTasksOperationEraseDone:
+		return isDownloadTaskDone, c.EraseDownloadTask, nil
+	default:
+		return nil, nil, fmt.Errorf("%q: %w", operation, ErrUnknownDownloadTasksOperation)
+	}
+}
+
+func (c *client) stopDownloadTask(ctx context.Context, identifier int64) error {
+	return c.UpdateDownloadTask(ctx, identifier, types.DownloadTaskUpdate{Status: types.DownloadTaskStatusStopped})
+}
+
+func (c *client) resumeDownloadTask(ctx context.Context, identifier int64) error {
+	return c.UpdateDownloadTask(ctx, identifier, types.DownloadTaskUpdate{Status: types.DownloadTaskStatusDownloading})
+}
+
+func isDownloadTaskActive(task types.DownloadTask) bool {
+	switch task.Status {
+	case types.DownloadTaskStatusQueued, types.DownloadTaskStatusStarting, types.DownloadTaskStatusDownloading, types.DownloadTaskStatusSeeding:
+		return true
+	default:
+		return false
+	}
+}
+
+func isDownloadTaskStopped(task types.DownloadTask) bool {
+	return task.Status == types.DownloadTaskStatusStopped
+}
+
+func isDownloadTaskDone(task types.DownloadTask) bool {
+	return task.Status == types.DownloadTaskStatusDone
+}
@@ -0,0 +1,291 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("bulk download tasks operations", func() {
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken string
+
+		operation types.DownloadTasksOperation
+
+		lock  *sync.Mutex
+		calls map[string]string
+
+		returnedErr error
+
+		recordCall = func(w http.ResponseWriter, r *http.Request) {
+			body := map[string]interface{}{}
+			if r.Method == http.MethodPut {
+				Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+
+			calls[r.Method+" "+path.Base(r.URL.Path)] = fmt.Sprint(body["status"])
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"success": true}`))
+		}
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		sessionToken = setupLoginFlow(server)
+
+		client.DownloadTasksConcurrency = 2
+
+		lock = new(sync.Mutex)
+		calls = map[string]string{}
+
+		server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/downloads/", version), ghttp.CombineHandlers(
+			verifyAuth(sessionToken),
+			ghttp.RespondWith(http.StatusOK, `{
+				"success": true,
+				"result": [
+					{"id": 1, "status": "downloading"},
+					{"id": 2, "status": "stopped"},
+					{"id": 3, "status": "done"},
+					{"id": 4, "status": "seeding"},
+					{"id": 5, "status": "error"}
+				]
+			}`),
+		))
+		server.RouteToHandler(http.MethodPut, regexp.MustCompile(fmt.Sprintf(`^/api/%s/downloads/\d+$`, version)), ghttp.CombineHandlers(
+			verifyAuth(sessionToken),
+			recordCall,
+		))
+		server.RouteToHandler(http.MethodDelete, regexp.MustCompile(fmt.Sprintf(`^/api/%s/downloads/\d+(/erase)?$`, version)), ghttp.CombineHandlers(
+			verifyAuth(sessionToken),
+			recordCall,
+		))
+	})
+	Context("updating all download tasks", func() {
+		JustBeforeEach(func(ctx SpecContext) {
+			returnedErr = freeboxClient.UpdateAllDownloadTasks(ctx, operation)
+		})
+		Context("when pausing every task", func() {
+			BeforeEach(func() {
+				operation = types.DownloadTasksOperationPause
+			})
+			It("should stop the active tasks", func() {
+				Expect(returnedErr).To(BeNil())
+				Expect(calls).To(Equal(map[string]string{
+					"PUT 1": "stopped",
+					"PUT 4": "stopped",
+				}))
+			})
+		})
+		Context("when resuming every task", func() {
+			BeforeEach(func() {
+				operation = types.DownloadTasksOperationResume
+			})
+			It("should resume the stopped tasks", func() {
+				Expect(returnedErr).To(BeNil())
+				Expect(calls).To(Equal(map[string]string{
+					"PUT 2": "downloading",
+				}))
+			})
+		})
+		Context("when deleting finished tasks", func() {
+			BeforeEach(func() {
+				operation = types.DownloadTasksOperationDeleteDone
+			})
+			It("should delete the finished tasks", func() {
+				Expect(returnedErr).To(BeNil())
+				Expect(calls).To(Equal(map[string]string{
+					"DELETE 3": "<nil>",
+				}))
+			})
+		})
+		Context("when erasing finished tasks", func() {
+			BeforeEach(func() {
+				operation = types.DownloadTasksOperationEraseDone
+			})
+			It("should erase the finished tasks", func() {
+				Expect(returnedErr).To(BeNil())
+				Expect(calls).To(Equal(map[string]string{
+					"DELETE erase": "<nil>",
+				}))
+			})
+		})
+		Context("when some tasks fail to be updated", func() {
+			BeforeEach(func() {
+				operation = types.DownloadTasksOperationPause
+				server.RouteToHandler(http.MethodPut, regexp.MustCompile(fmt.Sprintf(`^/api/%s/downloads/\d+$`, version)), ghttp.CombineHandlers(
+					verifyAuth(sessionToken),
+					func(w http.ResponseWriter, r *http.Request) {
+						if path.Base(r.URL.Path) != "4" {
+							recordCall(w, r)
+
+							return
+						}
+
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"success": false, "error_code": "task_not_found"}`))
+					},
+				))
+			})
+			It("should update the other tasks and return the errors", func() {
+				Expect(errors.Is(returnedErr, client.ErrTaskNotFound)).To(BeTrue())
+				Expect(calls).To(Equal(map[string]string{
+					"PUT 1": "stopped",
+				}))
+			})
+		})
+		Context("when the operation is unknown", func() {
+			BeforeEach(func() {
+				operation = "restart"
+			})
+			It("should return the correct error", func() {
+				Expect(errors.Is(returnedErr, client.ErrUnknownDownloadTasksOperation)).To(BeTrue())
+				Expect(calls).To(BeEmpty())
+			})
+		})
+	})
+	Context("updating several download tasks", func() {
+		var (
+			identifiers []int64
+			payload     types.DownloadTaskUpdate
+
+			updateCtx context.Context
+
+			returnedResults []types.DownloadTaskUpdateResult
+		)
+		BeforeEach(func(ctx SpecContext) {
+			// log in beforehand so that the concurrent updates share the same session
+			Expect(freeboxClient.Login(ctx)).ToNot(BeNil())
+
+			updateCtx = context.Background()
+			identifiers = []int64{4, 1, 2}
+			payload = types.DownloadTaskUpdate{Status: types.DownloadTaskStatusStopped}
+		})
+		JustBeforeEach(func() {
+			returnedResults, returnedErr = freeboxClient.UpdateDownloadTasks(updateCtx, identifiers, payload)
+		})
+		It("should update every task and return the results in order", func() {
+			Expect(returnedErr).To(BeNil())
+			Expect(returnedResults).To(Equal([]types.DownloadTaskUpdateResult{
+				{ID: 4},
+				{ID: 1},
+				{ID: 2},
+			}))
+			Expect(calls).To(Equal(map[string]string{
+				"PUT 4": "stopped",
+				"PUT 1": "stopped",
+				"PUT 2": "stopped",
+			}))
+		})
+		Context("when moving the tasks in the queue", func() {
+			BeforeEach(func() {
+				identifiers = []int64{2}
+				payload = types.DownloadTaskUpdate{QueuePosition: new(int64)}
+				server.RouteToHandler(http.MethodPut, regexp.MustCompile(fmt.Sprintf(`^/api/%s/downloads/\d+$`, version)), ghttp.CombineHandlers(
+					verifyAuth(sessionToken),
+					ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/downloads/2", version)),
+					ghttp.VerifyJSON(`{"queue_pos": 0}`),
+					ghttp.RespondWith(http.StatusOK, `{"success": true}`),
+				))
+			})
+			It("should send the queue position", func() {
+				Expect(returnedErr).To(BeNil())
+				Expect(returnedResults).To(Equal([]types.DownloadTaskUpdateResult{{ID: 2}}))
+			})
+		})
+		Context("when some tasks fail to be updated", func() {
+			BeforeEach(func() {
+				server.RouteToHandler(http.MethodPut, regexp.MustCompile(fmt.Sprintf(`^/api/%s/downloads/\d+$`, version)), ghttp.CombineHandlers(
+					verifyAuth(sessionToken),
+					func(w http.ResponseWriter, r *http.Request) {
+						if path.Base(r.URL.Path) != "1" {
+							recordCall(w, r)
+
+							return
+						}
+
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"success": false, "error_code": "task_not_found"}`))
+					},
+				))
+			})
+			It("should update the other tasks and report the failure of each task", func() {
+				Expect(errors.Is(returnedErr, client.ErrTaskNotFound)).To(BeTrue())
+				Expect(returnedResults).To(HaveLen(3))
+				Expect(returnedResults[0]).To(Equal(types.DownloadTaskUpdateResult{ID: 4}))
+				Expect(returnedResults[1].ID).To(Equal(int64(1)))
+				Expect(errors.Is(returnedResults[1].Error, client.ErrTaskNotFound)).To(BeTrue())
+				Expect(returnedResults[2]).To(Equal(types.DownloadTaskUpdateResult{ID: 2}))
+				Expect(calls).To(Equal(map[string]string{
+					"PUT 4": "stopped",
+					"PUT 2": "stopped",
+				}))
+			})
+		})
+		Context("when the context is done", func() {
+			BeforeEach(func() {
+				var cancel context.CancelFunc
+				updateCtx, cancel = context.WithCancel(updateCtx)
+				cancel()
+			})
+			It("should not update any task", func() {
+				Expect(errors.Is(returnedErr, context.Canceled)).To(BeTrue())
+				Expect(returnedResults).To(HaveLen(3))
+				for _, result := range returnedResults {
+					Expect(errors.Is(result.Error, context.Canceled)).To(BeTrue())
+				}
+				Expect(calls).To(BeEmpty())
+			})
+		})
+	})
+	Context("stopping seeding download tasks", func() {
+		BeforeEach(func() {
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/downloads/", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, `{
+					"success": true,
+					"result": [
+						{"id": 1, "status": "seeding", "tx_bytes": 300, "rx_bytes": 110, "size": 100},
+						{"id": 2, "status": "seeding", "tx_bytes": 100, "rx_bytes": 110, "size": 100},
+						{"id": 3, "status": "done", "tx_bytes": 500, "rx_bytes": 110, "size": 100},
+						{"id": 4, "status": "seeding", "tx_bytes": 300, "rx_bytes": 0, "size": 100},
+						{"id": 5, "status": "seeding", "tx_bytes": 300, "rx_bytes": 0, "size": 0}
+					]
+				}`),
+			))
+		})
+		JustBeforeEach(func(ctx SpecContext) {
+			returnedErr = freeboxClient.StopSeedingDownloadTasks(ctx, 1.5)
+		})
+		It("should stop the seeding tasks past the ratio of their size", func() {
+			Expect(returnedErr).To(BeNil())
+			Expect(calls).To(Equal(map[string]string{
+				"PUT 1": "stopped",
+				"PUT 4": "stopped",
+			}))
+		})
+	})
+})
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var downloadTaskErrors = map[types.DownloadTaskErrorCode]error{
+	types.DownloadTaskErrorDiskFull:           ErrDownloadDiskFull,
+	types.DownloadTaskErrorUnknownHost:        ErrDownloadUnknownHost,
+	types.DownloadTaskErrorTimeout:            ErrDownloadTimeout,
+	types.DownloadTaskErrorConnectionRefused:  ErrDownloadConnectionRefused,
+	types.DownloadTaskErrorBadAuthentication:  ErrDownloadBadAuthentication,
+	types.DownloadTaskErrorNZBAuthFailed:      ErrDownloadBadAuthentication,
+	types.DownloadTaskError4XX:                ErrDownloadNotFound,
+	types.DownloadTaskErrorNZBNotFound:        ErrDownloadNotFound,
+	types.DownloadTaskErrorNZBNoGroup:         ErrDownloadNotFound,
+	types.DownloadTaskErrorHashDownloadDisk:   ErrDownloadHashFailed,
+	types.DownloadTaskErrorBTTrackerError:     ErrDownloadTrackerFailed,
+	types.DownloadTaskErrorBTMissingFiles:     ErrDownloadMissingFiles,
+	types.DownloadTaskErrorBTFileError:        ErrDownloadMissingFiles,
+	types.DownloadTaskErrorParseError:         ErrDownloadInvalidContent,
+	types.DownloadTaskErrorNZBInvalidCRC:      ErrDownloadInvalidContent,
+	types.DownloadTaskErrorNZBInvalidSize:     ErrDownloadInvalidContent,
+	types.DownloadTaskErrorNZBInvalidFilename: ErrDownloadInvalidContent,
+	types.DownloadTaskErrorNZBMissingSegments: ErrDownloadInvalidContent,
+	types.DownloadTaskErrorNZBDecodeError:     ErrDownloadInvalidContent,
+	types.DownloadTaskErrorInternal:           ErrDownloadInternal,
+	types.DownloadTaskErrorMissingCtxFile:     ErrDownloadInternal,
+	types.DownloadTaskErrorNZBOpenFailed:      ErrDownloadInternal,
+	types.DownloadTaskErrorNZBServerError:     ErrDownloadServerError,
+}
+
+// DownloadTaskError returns the error a download task failed with, or nil if it did not fail.
+// The returned error always wraps ErrDownloadFailed and, when the error code is known, a more
+// specific sentinel error such as ErrDownloadDiskFull.
+func DownloadTaskError(task types.DownloadTask) error {
+	if task.Error == "" || task.Error == types.DownloadTaskErrorNone {
+		return nil
+	}
+
+	if err, ok := downloadTaskErrors[task.Error]; ok {
+		return fmt.Errorf("task %d: %w: %w", task.ID, ErrDownloadFailed, err)
+	}
+
+	return fmt.Errorf("task %d: %w with error %q", task.ID, ErrDownloadFailed, task.Error)
+}
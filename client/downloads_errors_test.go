@@ -0,0 +1,76 @@
+package client_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("download task errors", func() {
+	var (
+		task        = new(types.DownloadTask)
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		*task = types.DownloadTask{
+			ID:     42,
+			Status: types.DownloadTaskStatusError,
+		}
+	})
+	JustBeforeEach(func() {
+		*returnedErr = client.DownloadTaskError(*task)
+	})
+	Context("when the task did not fail", func() {
+		BeforeEach(func() {
+			task.Status = types.DownloadTaskStatusDone
+			task.Error = types.DownloadTaskErrorNone
+		})
+		It("should not return an error", func() {
+			Expect(*returnedErr).To(BeNil())
+		})
+	})
+	Context("when the error field is empty", func() {
+		It("should not return an error", func() {
+			Expect(*returnedErr).To(BeNil())
+		})
+	})
+	Context("when the disk is full", func() {
+		BeforeEach(func() {
+			task.Error = types.DownloadTaskErrorDiskFull
+		})
+		It("should return the correct error", func() {
+			Expect(errors.Is(*returnedErr, client.ErrDownloadFailed)).To(BeTrue())
+			Expect(errors.Is(*returnedErr, client.ErrDownloadDiskFull)).To(BeTrue())
+		})
+	})
+	Context("when the tracker can not be reached", func() {
+		BeforeEach(func() {
+			task.Error = types.DownloadTaskErrorBTTrackerError
+		})
+		It("should return the correct error", func() {
+			Expect(errors.Is(*returnedErr, client.ErrDownloadFailed)).To(BeTrue())
+			Expect(errors.Is(*returnedErr, client.ErrDownloadTrackerFailed)).To(BeTrue())
+		})
+	})
+	Context("when the remote content is not found", func() {
+		BeforeEach(func() {
+			task.Error = types.DownloadTaskError4XX
+		})
+		It("should return the correct error", func() {
+			Expect(errors.Is(*returnedErr, client.ErrDownloadNotFound)).To(BeTrue())
+		})
+	})
+	Context("when the error code is unknown", func() {
+		BeforeEach(func() {
+			task.Error = "something_new"
+		})
+		It("should return a generic error", func() {
+			Expect(errors.Is(*returnedErr, client.ErrDownloadFailed)).To(BeTrue())
+			Expect((*returnedErr).Error()).To(ContainSubstring("something_new"))
+		})
+	})
+})
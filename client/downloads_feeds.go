@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// ListDownloadFeeds lists the RSS feeds followed by the downloader.
+func (c *client) ListDownloadFeeds(ctx context.Context) (result []types.DownloadFeed, err error) {
+	response, err := c.get(ctx, "downloads/feeds/", c.withSession(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET downloads/feeds/ endpoint: %w", err)
+	}
+
+	if response.Result == nil {
+		return
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to get download feeds from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetDownloadFeed gets an RSS feed by its identifier.
+func (c *client) GetDownloadFeed(ctx context.Context, identifier int64) (result types.DownloadFeed, err error) {
+	response, err := c.get(ctx, fmt.Sprintf("downloads/feeds/%d", identifier), c.withSession(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to GET downloads/feeds/%d endpoint: %w", identifier, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a download feed from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// AddDownloadFeed follows a new RSS feed.
+func (c *client) AddDownloadFeed(ctx context.Context, feedURL string) (result types.DownloadFeed, err error) {
+	response, err := c.post(ctx, "downloads/feeds/", map[string]interface{}{
+		"url": feedURL,
+	}, c.withSession(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to POST downloads/feeds/ endpoint: %w", err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a download feed from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdateDownloadFeed updates an RSS feed by its identifier.
+func (c *client) UpdateDownloadFeed(ctx context.Context, identifier int64, payload types.DownloadFeedUpdate) (result types.DownloadFeed, err error) {
+	response, err := c.put(ctx, fmt.Sprintf("downloads/feeds/%d", identifier), payload, c.withSession(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to PUT downloads/feeds/%d endpoint: %w", identifier, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a download feed from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// DeleteDownloadFeed stops following an RSS feed.
+func (c *client) DeleteDownloadFeed(ctx context.Context, identifier int64) error {
+	if _, err := c.delete(ctx, fmt.Sprintf("downloads/feeds/%d", identifier), c.withSession(ctx)); err != nil {
+		return fmt.Errorf("failed to DELETE downloads/feeds/%d endpoint: %w", identifier, err)
+	}
+
+	return nil
+}
+
+// RefreshDownloadFeed fetches the latest items of an RSS feed.
+func (c *client) RefreshDownloadFeed(ctx context.Context, identifier int64) error {
+	if _, err := c.post(ctx, fmt.Sprintf("downloads/feeds/%d/fetch", identifier), nil, c.withSession(ctx)); err != nil {
+		return fmt.Errorf("failed to POST downloads/feeds/%d/fetch endpoint: %w", identifier, err)
+	}
+
+	return nil
+}
+
+// RefreshDownloadFeeds fetches the latest items of every RSS feed.
+func (c *client) RefreshDownloadFeeds(ctx context.Context) error {
+	if _, err := c.post(ctx, "downloads/feeds/fetch", nil, c.withSession(ctx)); err != nil {
+		return fmt.Errorf("failed to POST downloads/feeds/fetch endpoint: %w", err)
+	}
+
+	return nil
+}
+
+// ListDownloadFeedItems lists the items of an RSS feed.
+func (c *client) ListDownloadFeedItems(ctx context.Context, feedID int64) (result []types.DownloadFeedItem, err error) {
+	response, err := c.get(ctx, fmt.Sprintf("downloads/feeds/%d/items", feedID), c.withSession(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET downloads/feeds/%d/items endpoint: %w", feedID, err)
+	}
+
+	if response.Result == nil {
+		return
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to get download feed items from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdateDownloadFeedItem updates an item of an RSS feed, for instance to mark it as read.
+func (c *client) UpdateDownloadFeedItem(ctx context.Context, feedID, itemID int64, payload types.DownloadFeedItemUpdate) (result types.DownloadFeedItem, err error) {
+	response, err := c.put(ctx, fmt.Sprintf("downloads/feeds/%d/items/%d", feedID, itemID), payload, c.withSession(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to PUT downloads/feeds/%d/items/%d endpoint: %w", feedID, itemID, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a download feed item from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// MarkAllDownloadFeedItemsAsRead marks every item of an RSS feed as read.
+func (c *client) MarkAllDownloadFeedItemsAsRead(ctx context.Context, feedID int64) error {
+	if _, err := c.post(ctx, fmt.Sprintf("downloads/feeds/%d/items/mark_all_as_read", feedID), nil, c.withSession(ctx)); err != nil {
+		return fmt.Errorf("failed to POST downloads/feeds/%d/items/mark_all_as_read endpoint: %w", feedID, err)
+	}
+
+	return nil
+}
+
+// ListFilteredDownloadFeedItems lists the items of an RSS feed selected by the filter, such as its unread items.
+func (c *client) ListFilteredDownloadFeedItems(ctx context.Context, feedID int64, filter types.DownloadFeedItemFilter) ([]types.DownloadFeedItem, error) {
+	items, err := c.ListDownloadFeedItems(ctx, feedID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.DownloadFeedItem, 0, len(items))
+
+	for _, item := range items {
+		if filter.Match(item) {
+			result = append(result, item)
+		}
+	}
+
+	return result, nil
+}
+
+// MarkDownloadFeedItemAsRead marks an item of an RSS feed as read.
+func (c *client) MarkDownloadFeedItemAsRead(ctx context.Context, feedID, itemID int64) (types.DownloadFeedItem, error) {
+	return c.UpdateDownloadFeedItem(ctx, feedID, itemID, types.DownloadFeedItemUpdate{IsRead: true})
+}
+
+// MarkDownloadFeedItemAsUnread marks an item of an RSS feed as unread.
+func (c *client) MarkDownloadFeedItemAsUnread(ctx context.Context, feedID, itemID int64) (types.DownloadFeedItem, error) {
+	return c.UpdateDownloadFeedItem(ctx, feedID, itemID, types.DownloadFeedItemUpdate{IsRead: false})
+}
+
+// DownloadFeedItem adds a download task for the content of an RSS feed item, and returns the identifier of the task.
+// The identifier is 0 when the freebox does not report it.
+func (c *client) DownloadFeedItem(ctx context.Context, feedID, itemID int64) (int64, error) {
+	response, err := c.post(ctx, fmt.Sprintf("downloads/feeds/%d/items/%d/download", feedID, itemID), nil, c.withSession(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("failed to POST downloads/feeds/%d/items/%d/download endpoint: %w", feedID, itemID, err)
+	}
+
+	if response.Result == nil {
+		return 0, nil
+	}
+
+	var result struct {
+		ID int64 `json:"id"`
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return 0, fmt.Errorf("failed to get an ID from generic response: %w", err)
+	}
+
+	return result.ID, nil
+}
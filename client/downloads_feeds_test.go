@@ -0,0 +1,638 @@
+package client_test
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("download feeds", func() {
+	const (
+		feedID = int64(3)
+		itemID = int64(27)
+
+		feedPayload = `{
+			"id": 3,
+			"status": "ready",
+			"url": "https://example.org/feed.rss",
+			"title": "Example",
+			"desc": "An example feed",
+			"image_url": "https://example.org/logo.png",
+			"image_link": "https://example.org",
+			"fetch_ts": 1711656593,
+			"pub_date": 1711650000,
+			"nb_items": 10,
+			"nb_unread": 2,
+			"auto_download": true
+		}`
+		itemPayload = `{
+			"id": 27,
+			"feed_id": 3,
+			"title": "Episode 1",
+			"desc": "The first episode",
+			"author": "someone",
+			"link": "https://example.org/episode-1",
+			"pub_date": 1711650000,
+			"enclosure_url": "https://example.org/episode-1.torrent",
+			"enclosure_type": "application/x-bittorrent",
+			"enclosure_length": 2048,
+			"is_read": false,
+			"is_downloaded": true
+		}`
+	)
+
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+
+		expectedFeed = types.DownloadFeed{
+			ID:             feedID,
+			Status:         types.DownloadFeedStatusReady,
+			URL:            "https://example.org/feed.rss",
+			Title:          "Example",
+			Description:    "An example feed",
+			ImageURL:       "https://example.org/logo.png",
+			ImageLink:      "https://example.org",
+			FetchTimestamp: types.Timestamp{Time: time.Unix(1711656593, 0).UTC()},
+			PublishDate:    types.Timestamp{Time: time.Unix(1711650000, 0).UTC()},
+			ItemsCount:     10,
+			UnreadCount:    2,
+			AutoDownload:   true,
+		}
+		expectedItem = types.DownloadFeedItem{
+			ID:              itemID,
+			FeedID:          feedID,
+			Title:           "Episode 1",
+			Description:     "The first episode",
+			Author:          "someone",
+			Link:            "https://example.org/episode-1",
+			PublishDate:     types.Timestamp{Time: time.Unix(1711650000, 0).UTC()},
+			EnclosureURL:    "https://example.org/episode-1.torrent",
+			EnclosureType:   "application/x-bittorrent",
+			EnclosureLength: 2048,
+			IsDownloaded:    true,
+		}
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	Context("listing feeds", func() {
+		returnedFeeds := new([]types.DownloadFeed)
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedFeeds, *returnedErr = freeboxClient.ListDownloadFeeds(ctx)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/feeds/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+							"success": true,
+							"result": [%s]
+						}`, feedPayload)),
+					),
+				)
+			})
+			It("should return the correct feeds", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedFeeds).To(Equal([]types.DownloadFeed{expectedFeed}))
+			})
+		})
+		Context("when there are no feeds", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/feeds/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should return an empty list", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedFeeds).To(BeEmpty())
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the server returns an unexpected payload", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/feeds/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								"foo"
+							]
+						}`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("getting a feed", func() {
+		returnedFeed := new(types.DownloadFeed)
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedFeed, *returnedErr = freeboxClient.GetDownloadFeed(ctx, feedID)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/feeds/%d", version, feedID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+							"success": true,
+							"result": %s
+						}`, feedPayload)),
+					),
+				)
+			})
+			It("should return the correct feed", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedFeed).To(Equal(expectedFeed))
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("adding a feed", func() {
+		returnedFeed := new(types.DownloadFeed)
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedFeed, *returnedErr = freeboxClient.AddDownloadFeed(ctx, "https://example.org/feed.rss")
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/downloads/feeds/", version)),
+						ghttp.VerifyJSON(`{"url": "https://example.org/feed.rss"}`),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+							"success": true,
+							"result": %s
+						}`, feedPayload)),
+					),
+				)
+			})
+			It("should return the created feed", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedFeed).To(Equal(expectedFeed))
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("updating a feed", func() {
+		returnedFeed := new(types.DownloadFeed)
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedFeed, *returnedErr = freeboxClient.UpdateDownloadFeed(ctx, feedID, types.DownloadFeedUpdate{
+				AutoDownload: true,
+			})
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/downloads/feeds/%d", version, feedID)),
+						ghttp.VerifyJSON(`{"auto_download": true}`),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+							"success": true,
+							"result": %s
+						}`, feedPayload)),
+					),
+				)
+			})
+			It("should return the updated feed", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedFeed).To(Equal(expectedFeed))
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("deleting a feed", func() {
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedErr = freeboxClient.DeleteDownloadFeed(ctx, feedID)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/downloads/feeds/%d", version, feedID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("refreshing a feed", func() {
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedErr = freeboxClient.RefreshDownloadFeed(ctx, feedID)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/downloads/feeds/%d/fetch", version, feedID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("refreshing all feeds", func() {
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedErr = freeboxClient.RefreshDownloadFeeds(ctx)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/downloads/feeds/fetch", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("listing feed items", func() {
+		returnedItems := new([]types.DownloadFeedItem)
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedItems, *returnedErr = freeboxClient.ListDownloadFeedItems(ctx, feedID)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/feeds/%d/items", version, feedID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+							"success": true,
+							"result": [%s]
+						}`, itemPayload)),
+					),
+				)
+			})
+			It("should return the correct items", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedItems).To(Equal([]types.DownloadFeedItem{expectedItem}))
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the server returns an unexpected payload", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/feeds/%d/items", version, feedID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								"foo"
+							]
+						}`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("marking a feed item as read", func() {
+		returnedItem := new(types.DownloadFeedItem)
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedItem, *returnedErr = freeboxClient.UpdateDownloadFeedItem(ctx, feedID, itemID, types.DownloadFeedItemUpdate{
+				IsRead: true,
+			})
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/downloads/feeds/%d/items/%d", version, feedID, itemID)),
+						ghttp.VerifyJSON(`{"is_read": true}`),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+							"success": true,
+							"result": %s
+						}`, itemPayload)),
+					),
+				)
+			})
+			It("should return the updated item", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedItem).To(Equal(expectedItem))
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("listing filtered feed items", func() {
+		var (
+			filter        types.DownloadFeedItemFilter
+			returnedItems []types.DownloadFeedItem
+		)
+		BeforeEach(func() {
+			filter = types.DownloadFeedItemFilter{}
+		})
+		JustBeforeEach(func(ctx SpecContext) {
+			returnedItems, *returnedErr = freeboxClient.ListFilteredDownloadFeedItems(ctx, feedID, filter)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/feeds/%d/items", version, feedID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+							"success": true,
+							"result": [
+								%s,
+								{"id": 28, "feed_id": 3, "is_read": true, "is_downloaded": false},
+								{"id": 29, "feed_id": 3, "is_read": false, "is_downloaded": false}
+							]
+						}`, itemPayload)),
+					),
+				)
+			})
+			It("should return every item", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedItems).To(HaveLen(3))
+			})
+			Context("when only the unread items are selected", func() {
+				BeforeEach(func() {
+					filter.Unread = true
+				})
+				It("should return the unread items", func() {
+					Expect(*returnedErr).To(BeNil())
+					Expect(returnedItems).To(Equal([]types.DownloadFeedItem{
+						expectedItem,
+						{ID: 29, FeedID: feedID},
+					}))
+				})
+			})
+			Context("when only the unread items not downloaded yet are selected", func() {
+				BeforeEach(func() {
+					filter.Unread = true
+					filter.NotDownloaded = true
+				})
+				It("should return the matching items", func() {
+					Expect(*returnedErr).To(BeNil())
+					Expect(returnedItems).To(Equal([]types.DownloadFeedItem{
+						{ID: 29, FeedID: feedID},
+					}))
+				})
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("marking a feed item as unread", func() {
+		returnedItem := new(types.DownloadFeedItem)
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedItem, *returnedErr = freeboxClient.MarkDownloadFeedItemAsUnread(ctx, feedID, itemID)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/downloads/feeds/%d/items/%d", version, feedID, itemID)),
+						ghttp.VerifyJSON(`{"is_read": false}`),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+							"success": true,
+							"result": %s
+						}`, itemPayload)),
+					),
+				)
+			})
+			It("should return the updated item", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedItem).To(Equal(expectedItem))
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("marking all feed items as read", func() {
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedErr = freeboxClient.MarkAllDownloadFeedItemsAsRead(ctx, feedID)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/downloads/feeds/%d/items/mark_all_as_read", version, feedID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("downloading a feed item", func() {
+		returnedID := new(int64)
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedID, *returnedErr = freeboxClient.DownloadFeedItem(ctx, feedID, itemID)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/downloads/feeds/%d/items/%d/download", version, feedID, itemID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"id": 42
+							}
+						}`),
+					),
+				)
+			})
+			It("should return the identifier of the download task", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedID).To(Equal(int64(42)))
+			})
+		})
+		Context("when the identifier of the download task is not returned", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/downloads/feeds/%d/items/%d/download", version, feedID, itemID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedID).To(BeZero())
+			})
+		})
+		Context("when the server returns an unexpected payload", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/downloads/feeds/%d/items/%d/download", version, feedID, itemID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": "foo"
+						}`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+})
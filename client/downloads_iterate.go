@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// IterateDownloadTasks hands the download tasks matching the filter over to handle one at a time, as they are decoded
+// from the response, instead of building the whole list like ListDownloadTasks. The first error returned by handle
+// stops the iteration and is returned as is.
+func (c *client) IterateDownloadTasks(ctx context.Context, filter types.DownloadTaskFilter, handle func(types.DownloadTask) error) error {
+	if err := filter.Validate(); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	var handleErr error
+
+	_, err := getEach(ctx, c, "downloads/", func(task types.DownloadTask) error {
+		if !filter.Match(task) {
+			return nil
+		}
+
+		handleErr = handle(task)
+
+		return handleErr
+	}, c.withSession(ctx))
+	if handleErr != nil {
+		return handleErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to GET downloads/ endpoint: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("iterating download tasks", func() {
+	const tasks = `{
+		"success": true,
+		"result": [
+			{ "id": 1, "name": "debian-12.iso", "status": "done", "type": "http" },
+			{ "id": 2, "name": "ubuntu-24.04.iso", "status": "seeding", "type": "bt" },
+			{ "id": 3, "name": "holidays.mkv", "status": "downloading", "type": "bt" },
+			{ "id": 4, "name": "fedora-40.iso", "status": "downloading", "type": "bt" }
+		]
+	}`
+
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken = new(string)
+
+		filter types.DownloadTaskFilter
+		handle func(types.DownloadTask) error
+
+		visited     = new([]int64)
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+
+		filter = types.DownloadTaskFilter{}
+		*visited = []int64{}
+		handle = func(task types.DownloadTask) error {
+			*visited = append(*visited, task.ID)
+
+			return nil
+		}
+	})
+	JustBeforeEach(func() {
+		*returnedErr = freeboxClient.IterateDownloadTasks(context.Background(), filter, handle)
+	})
+	Context("when the server returns tasks", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/", version)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, tasks),
+				),
+			)
+		})
+		Context("default", func() {
+			It("should visit every task in order", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*visited).To(Equal([]int64{1, 2, 3, 4}))
+			})
+		})
+		Context("when filtering by status", func() {
+			BeforeEach(func() {
+				filter.Statuses = []types.DownloadTaskStatus{types.DownloadTaskStatusDone, types.DownloadTaskStatusSeeding}
+			})
+			It("should only visit the matching tasks", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*visited).To(Equal([]int64{1, 2}))
+			})
+		})
+		Context("when filtering by type and name", func() {
+			BeforeEach(func() {
+				filter.Types = []types.DownloadTaskType{types.DownloadTaskTypeBitTorrent}
+				filter.NamePattern = "*.iso"
+			})
+			It("should only visit the matching tasks", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*visited).To(Equal([]int64{2, 4}))
+			})
+		})
+		Context("when the handler fails", func() {
+			errStop := errors.New("stop")
+			BeforeEach(func() {
+				handle = func(task types.DownloadTask) error {
+					*visited = append(*visited, task.ID)
+
+					if task.ID == 2 {
+						return errStop
+					}
+
+					return nil
+				}
+			})
+			It("should stop and return the error as is", func() {
+				Expect(*returnedErr).To(Equal(errStop))
+				Expect(*visited).To(Equal([]int64{1, 2}))
+			})
+		})
+	})
+	Context("when the server returns no task", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/", version)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+				),
+			)
+		})
+		It("should not visit anything", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*visited).To(BeEmpty())
+		})
+	})
+	Context("when the server returns an error", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/", version)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "internal_error" }`),
+				),
+			)
+		})
+		It("should return the error", func() {
+			Expect(*returnedErr).To(MatchError(&client.APIError{Code: "internal_error"}))
+		})
+	})
+	Context("when the name pattern is malformed", func() {
+		BeforeEach(func() {
+			filter.NamePattern = "[*.iso"
+		})
+		It("should return an error without calling the server", func() {
+			Expect(*returnedErr).To(MatchError(path.ErrBadPattern))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+})
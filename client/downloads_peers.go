@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// ListDownloadTaskPeers lists the peers of a bittorrent download task.
+func (c *client) ListDownloadTaskPeers(ctx context.Context, identifier int64) (result []types.DownloadPeer, err error) {
+	response, err := c.get(ctx, fmt.Sprintf("downloads/%d/peers", identifier), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrTaskNotFound) {
+			return nil, ErrTaskNotFound
+		}
+
+		return nil, fmt.Errorf("failed to GET downloads/%d/peers endpoint: %w", identifier, err)
+	}
+
+	if response.Result == nil {
+		return
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to get download peers from generic response: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,153 @@
+package client_test
+
+import (
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("download peers", func() {
+	const taskID = int64(42)
+
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr   = new(error)
+		returnedPeers = new([]types.DownloadPeer)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	JustBeforeEach(func(ctx SpecContext) {
+		*returnedPeers, *returnedErr = freeboxClient.ListDownloadTaskPeers(ctx, taskID)
+	})
+	Context("default", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d/peers", version, taskID)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": true,
+						"result": [
+							{
+								"host": "203.0.113.12",
+								"port": 51413,
+								"state": "ready",
+								"origin": "dht",
+								"protocol": "utp",
+								"client": "Transmission 4.0.5",
+								"country": "FR",
+								"tx": 1024,
+								"rx": 4096,
+								"tx_rate": 10,
+								"rx_rate": 2048,
+								"progress": 0.5
+							}
+						]
+					}`),
+				),
+			)
+		})
+		It("should return the correct peers", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*returnedPeers).To(Equal([]types.DownloadPeer{
+				{
+					Host:             "203.0.113.12",
+					Port:             51413,
+					State:            "ready",
+					Origin:           types.DownloadPeerOriginDHT,
+					Protocol:         types.DownloadPeerProtocolUTP,
+					Client:           "Transmission 4.0.5",
+					Country:          "FR",
+					TransmittedBytes: 1024,
+					ReceivedBytes:    4096,
+					TransmitRate:     10 * types.BytePerSecond,
+					ReceiveRate:      2048 * types.BytePerSecond,
+					Progress:         0.5,
+				},
+			}))
+		})
+	})
+	Context("when there are no peers", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d/peers", version, taskID)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": true
+					}`),
+				),
+			)
+		})
+		It("should return an empty list", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*returnedPeers).To(BeEmpty())
+		})
+	})
+	Context("when the task is not found", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d/peers", version, taskID)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": false,
+						"error_code": "task_not_found"
+					}`),
+				),
+			)
+		})
+		It("should return the correct error", func() {
+			Expect(*returnedErr).To(Equal(client.ErrTaskNotFound))
+		})
+	})
+	Context("when the server fails to respond", func() {
+		BeforeEach(func() {
+			server.Close()
+		})
+		It("should return an error", func() {
+			Expect(*returnedErr).ToNot(BeNil())
+		})
+	})
+	Context("when the server returns an unexpected payload", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d/peers", version, taskID)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": true,
+						"result": [
+							"foo"
+						]
+					}`),
+				),
+			)
+		})
+		It("should return an error", func() {
+			Expect(*returnedErr).ToNot(BeNil())
+		})
+	})
+})
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// GetDownloadTaskPieces returns the state of each piece of a bittorrent download task.
+func (c *client) GetDownloadTaskPieces(ctx context.Context, identifier int64) (result types.DownloadPieces, err error) {
+	response, err := c.get(ctx, fmt.Sprintf("downloads/%d/pieces", identifier), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrTaskNotFound) {
+			return nil, ErrTaskNotFound
+		}
+
+		return nil, fmt.Errorf("failed to GET downloads/%d/pieces endpoint: %w", identifier, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to get download pieces from generic response: %w", err)
+	}
+
+	return result, nil
+}
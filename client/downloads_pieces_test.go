@@ -0,0 +1,116 @@
+package client_test
+
+import (
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("download pieces", func() {
+	const taskID = int64(42)
+
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr    = new(error)
+		returnedPieces = new(types.DownloadPieces)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	JustBeforeEach(func(ctx SpecContext) {
+		*returnedPieces, *returnedErr = freeboxClient.GetDownloadTaskPieces(ctx, taskID)
+	})
+	Context("default", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d/pieces", version, taskID)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": true,
+						"result": "XXX/..X"
+					}`),
+				),
+			)
+		})
+		It("should return the state of each piece", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*returnedPieces).To(Equal(types.DownloadPieces{
+				types.DownloadPieceStateComplete,
+				types.DownloadPieceStateComplete,
+				types.DownloadPieceStateComplete,
+				types.DownloadPieceStateDownloading,
+				types.DownloadPieceStateMissing,
+				types.DownloadPieceStateMissing,
+				types.DownloadPieceStateComplete,
+			}))
+			Expect(returnedPieces.Count(types.DownloadPieceStateComplete)).To(Equal(4))
+			Expect(returnedPieces.Count(types.DownloadPieceStateMissing)).To(Equal(2))
+		})
+	})
+	Context("when the task is not found", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d/pieces", version, taskID)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": false,
+						"error_code": "task_not_found"
+					}`),
+				),
+			)
+		})
+		It("should return the correct error", func() {
+			Expect(*returnedErr).To(Equal(client.ErrTaskNotFound))
+		})
+	})
+	Context("when the server fails to respond", func() {
+		BeforeEach(func() {
+			server.Close()
+		})
+		It("should return an error", func() {
+			Expect(*returnedErr).ToNot(BeNil())
+		})
+	})
+	Context("when the server returns an unexpected payload", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d/pieces", version, taskID)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": true,
+						"result": [
+							"foo"
+						]
+					}`),
+				),
+			)
+		})
+		It("should return an error", func() {
+			Expect(*returnedErr).ToNot(BeNil())
+		})
+	})
+})
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// downloadStalls remembers the transfer counters of the active bittorrent tasks across calls to
+// ReannounceStalledDownloadTasks, to tell since when each of them has not transferred anything.
+type downloadStalls struct {
+	mutex sync.Mutex
+	tasks map[int64]downloadStall
+}
+
+type downloadStall struct {
+	receivedBytes    types.ByteSize
+	transmittedBytes types.ByteSize
+	since            time.Time // time the counters were last seen moving
+}
+
+// ReannounceStalledDownloadTasks restarts the active bittorrent tasks which have not received nor transmitted a byte
+// for at least threshold and whose trackers all fail, so that they announce themselves again to their trackers, with
+// at most DownloadTasksConcurrency tasks processed at once. It is meant to be called periodically to keep unattended
+// seedboxes connected to their swarms.
+//
+// A task is only known to be stalled once its transfer counters did not move across calls spanning threshold, so the
+// first call never restarts anything. A restarted task is given another threshold before being restarted again, and
+// tasks with a working tracker are left alone as they already announce at the interval the tracker requests.
+//
+// The API has no endpoint to re-announce a task to its trackers, so the tasks are stopped then resumed, which makes
+// them announce to every tracker without touching their list of trackers. This restarts the whole task: its peers are
+// disconnected and it may check its files again before transferring. Errors on individual tasks do not stop the
+// others and are all returned once every task has been processed.
+func (c *client) ReannounceStalledDownloadTasks(ctx context.Context, threshold time.Duration) error {
+	now := time.Now()
+	active := make(map[int64]bool)
+
+	// the selection is called for each task in turn, before the restarts it triggers
+	err := c.updateDownloadTasks(ctx, "reannounce", func(task types.DownloadTask) bool {
+		if task.Type != types.DownloadTaskTypeBitTorrent ||
+			(task.Status != types.DownloadTaskStatusDownloading && task.Status != types.DownloadTaskStatusSeeding) {
+			return false
+		}
+
+		active[task.ID] = true
+
+		return c.downloadStalls.stalled(task, now, threshold)
+	}, c.restartUnreachableDownloadTask)
+
+	// a failed call may not have seen every active task, whose counters must not be lost
+	if err == nil {
+		c.downloadStalls.forget(active)
+	}
+
+	return err
+}
+
+// restartUnreachableDownloadTask stops then resumes a bittorrent task when none of its trackers is working.
+func (c *client) restartUnreachableDownloadTask(ctx context.Context, identifier int64) error {
+	trackers, err := c.ListDownloadTaskTrackers(ctx, identifier)
+	if err != nil {
+		return err
+	}
+
+	if len(trackers) == 0 {
+		return nil
+	}
+
+	for _, tracker := range trackers {
+		if tracker.Status == types.DownloadTrackerStatusWorking {
+			return nil
+		}
+	}
+
+	if err := c.stopDownloadTask(ctx, identifier); err != nil {
+		return err
+	}
+
+	if err := c.resumeDownloadTask(ctx, identifier); err != nil {
+		return fmt.Errorf("failed to resume the stopped task: %w", err)
+	}
+
+	return nil
+}
+
+// stalled records the transfer counters of the task and reports whether they did not move for at least threshold.
+// A stalled task is given another threshold before being reported again.
+func (s *downloadStalls) stalled(task types.DownloadTask, now time.Time, threshold time.Duration) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.tasks == nil {
+		s.tasks = make(map[int64]downloadStall)
+	}
+
+	stall, ok := s.tasks[task.ID]
+	if !ok || stall.receivedBytes != task.ReceivedBytes || stall.transmittedBytes != task.TransmittedBytes {
+		s.tasks[task.ID] = downloadStall{
+			receivedBytes:    task.ReceivedBytes,
+			transmittedBytes: task.TransmittedBytes,
+			since:            now,
+		}
+
+		return false
+	}
+
+	if now.Sub(stall.since) < threshold {
+		return false
+	}
+
+	stall.since = now
+	s.tasks[task.ID] = stall
+
+	return true
+}
+
+// forget drops the counters of the tasks which are no longer active.
+func (s *downloadStalls) forget(active map[int64]bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for identifier := range s.tasks {
+		if !active[identifier] {
+			delete(s.tasks, identifier)
+		}
+	}
+}
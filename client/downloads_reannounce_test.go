@@ -0,0 +1,153 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+)
+
+var _ = Describe("reannouncing stalled download tasks", func() {
+	const taskID = int64(42)
+
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken string
+
+		threshold time.Duration
+
+		respondWithTasks = func(receivedBytes int64) http.HandlerFunc {
+			return ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/", version)),
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+					"success": true,
+					"result": [
+						{"id": %d, "type": "bt", "status": "seeding", "rx_bytes": %d, "tx_bytes": 2048},
+						{"id": 43, "type": "http", "status": "downloading", "rx_bytes": 0, "tx_bytes": 0},
+						{"id": 44, "type": "bt", "status": "stopped", "rx_bytes": 0, "tx_bytes": 0}
+					]
+				}`, taskID, receivedBytes)),
+			)
+		}
+		respondWithTrackers = func(status string) http.HandlerFunc {
+			return ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d/trackers", version, taskID)),
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+					"success": true,
+					"result": [
+						{"announce": "udp://tracker.example.org:1337/announce", "status": %q, "reannounce": 1800},
+						{"announce": "udp://backup.example.org/announce", "status": "unknown", "is_backup": true}
+					]
+				}`, status)),
+			)
+		}
+		verifyStatusUpdate = func(status string) http.HandlerFunc {
+			return ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/downloads/%d", version, taskID)),
+				verifyAuth(sessionToken),
+				ghttp.VerifyJSON(fmt.Sprintf(`{"status": %q}`, status)),
+				ghttp.RespondWith(http.StatusOK, `{"success": true}`),
+			)
+		}
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		sessionToken = setupLoginFlow(server)
+
+		threshold = 0
+	})
+	reannounce := func(ctx context.Context) error {
+		return freeboxClient.ReannounceStalledDownloadTasks(ctx, threshold)
+	}
+	Context("when the task has not been seen before", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(respondWithTasks(1024))
+		})
+		It("should not restart it", func(ctx SpecContext) {
+			Expect(reannounce(ctx)).To(Succeed())
+		})
+	})
+	Context("when the task stopped transferring and its trackers fail", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				respondWithTasks(1024),
+				respondWithTasks(1024),
+				respondWithTrackers("failed"),
+				verifyStatusUpdate("stopped"),
+				verifyStatusUpdate("downloading"),
+			)
+		})
+		It("should restart it without touching its trackers", func(ctx SpecContext) {
+			Expect(reannounce(ctx)).To(Succeed())
+			Expect(reannounce(ctx)).To(Succeed())
+			Expect(server.ReceivedRequests()).To(HaveLen(7))
+		})
+		Context("and it was restarted less than the threshold ago", func() {
+			BeforeEach(func() {
+				threshold = time.Hour
+			})
+			It("should not restart it", func(ctx SpecContext) {
+				Expect(reannounce(ctx)).To(Succeed())
+				Expect(reannounce(ctx)).To(Succeed())
+				Expect(server.ReceivedRequests()).To(HaveLen(4))
+			})
+		})
+		Context("and it can not be resumed", func() {
+			BeforeEach(func() {
+				server.SetHandler(6, ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/downloads/%d", version, taskID)),
+					ghttp.RespondWith(http.StatusOK, `{"success": false, "error_code": "task_not_found"}`),
+				))
+			})
+			It("should return an error", func(ctx SpecContext) {
+				Expect(reannounce(ctx)).To(Succeed())
+
+				err := reannounce(ctx)
+				Expect(errors.Is(err, client.ErrTaskNotFound)).To(BeTrue())
+				Expect(err).To(MatchError(ContainSubstring("failed to resume the stopped task")))
+			})
+		})
+	})
+	Context("when the task is transferring", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				respondWithTasks(1024),
+				respondWithTasks(4096),
+			)
+		})
+		It("should not restart it", func(ctx SpecContext) {
+			Expect(reannounce(ctx)).To(Succeed())
+			Expect(reannounce(ctx)).To(Succeed())
+		})
+	})
+	Context("when the task is idle but a tracker works", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				respondWithTasks(1024),
+				respondWithTasks(1024),
+				respondWithTrackers("working"),
+			)
+		})
+		It("should not restart it", func(ctx SpecContext) {
+			Expect(reannounce(ctx)).To(Succeed())
+			Expect(reannounce(ctx)).To(Succeed())
+		})
+	})
+})
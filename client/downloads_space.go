@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// CheckDownloadSpace checks the partition holding the download directory has at least size bytes left, so that a
+// download task of that size is not added only to fail once the disk is full. It returns ErrInsufficientSpace when
+// the space left is too small, and ErrPartitionNotFound when no mounted partition holds the directory.
+func (c *client) CheckDownloadSpace(ctx context.Context, directory string, size types.ByteSize) error {
+	if directory == "" {
+		return errors.New("a download directory is required to check the space left")
+	}
+
+	partitions, err := c.ListStoragePartitions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list storage partitions: %w", err)
+	}
+
+	partition, ok := partitionOf(partitions, directory)
+	if !ok {
+		return fmt.Errorf("%w: no mounted partition holds %s", ErrPartitionNotFound, directory)
+	}
+
+	if partition.FreeBytes < size {
+		return fmt.Errorf("%w: %s left on %s, %s needed", ErrInsufficientSpace, partition.FreeBytes, partition.Path, size)
+	}
+
+	return nil
+}
+
+// partitionOf returns the mounted partition with the longest path holding the directory.
+func partitionOf(partitions []types.StoragePartition, directory string) (types.StoragePartition, bool) {
+	var (
+		found     types.StoragePartition
+		foundRoot string
+		ok        bool
+	)
+
+	directory = path.Clean("/" + directory)
+
+	for _, partition := range partitions {
+		if partition.State != types.StoragePartitionStateMounted {
+			continue
+		}
+
+		root := path.Clean("/" + string(partition.Path))
+		if directory != root && !strings.HasPrefix(directory, strings.TrimSuffix(root, "/")+"/") {
+			continue
+		}
+
+		if !ok || len(root) > len(foundRoot) {
+			found, foundRoot, ok = partition, root, true
+		}
+	}
+
+	return found, ok
+}
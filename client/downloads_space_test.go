@@ -0,0 +1,106 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("download space check", func() {
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken string
+
+		directory string
+		size      types.ByteSize
+
+		returnedErr error
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		sessionToken = setupLoginFlow(server)
+
+		directory = "/Disque dur/Téléchargements"
+		size = 500 * types.Megabyte
+
+		// "/Disque dur", "/Disque dur/Films" and "/Clé USB"
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/storage/partition/", version)),
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+					"success": true,
+					"result": [
+						{"id": 1, "state": "mounted", "path": "L0Rpc3F1ZSBkdXI=", "free_bytes": %d},
+						{"id": 2, "state": "mounted", "path": "L0Rpc3F1ZSBkdXIvRmlsbXM=", "free_bytes": %d},
+						{"id": 3, "state": "umounted", "path": "L0Nsw6kgVVNC", "free_bytes": 0}
+					]
+				}`, types.Gigabyte, 100*types.Megabyte)),
+			),
+		)
+	})
+	JustBeforeEach(func() {
+		returnedErr = freeboxClient.CheckDownloadSpace(context.Background(), directory, size)
+	})
+	Context("when the partition has enough space left", func() {
+		It("should not return an error", func() {
+			Expect(returnedErr).To(BeNil())
+		})
+	})
+	Context("when the partition does not have enough space left", func() {
+		BeforeEach(func() {
+			size = 2 * types.Gigabyte
+		})
+		It("should return the correct error", func() {
+			Expect(errors.Is(returnedErr, client.ErrInsufficientSpace)).To(BeTrue())
+		})
+	})
+	Context("when the directory is on a nested partition", func() {
+		BeforeEach(func() {
+			directory = "/Disque dur/Films/"
+		})
+		It("should check the space left on the nested partition", func() {
+			Expect(errors.Is(returnedErr, client.ErrInsufficientSpace)).To(BeTrue())
+		})
+	})
+	Context("when the directory only shares a prefix with a partition", func() {
+		BeforeEach(func() {
+			directory = "/Disque dur/Filmstrip"
+		})
+		It("should check the space left on the parent partition", func() {
+			Expect(returnedErr).To(BeNil())
+		})
+	})
+	Context("when no partition holds the directory", func() {
+		BeforeEach(func() {
+			directory = "/Clé USB/Téléchargements"
+		})
+		It("should return the correct error", func() {
+			Expect(errors.Is(returnedErr, client.ErrPartitionNotFound)).To(BeTrue())
+		})
+	})
+	Context("when the directory is not set", func() {
+		BeforeEach(func() {
+			directory = ""
+		})
+		It("should return an error", func() {
+			Expect(returnedErr).ToNot(BeNil())
+		})
+	})
+})
@@ -3,7 +3,9 @@ package client_test
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -139,7 +141,7 @@ var _ = Describe("downloads", func() {
 						TransmittedBytes:   0,
 						ReceivedBytes:      185610000,
 						TransmitRate:       0,
-						ReceiveRate:        473800,
+						ReceiveRate:        473800 * types.BytePerSecond,
 						TransmitPercentage: 10000,
 						ReceivedPercentage: 2987,
 						Error:              "none",
@@ -236,7 +238,7 @@ var _ = Describe("downloads", func() {
 					TransmittedBytes:   0,
 					ReceivedBytes:      184300000,
 					TransmitRate:       0,
-					ReceiveRate:        481950,
+					ReceiveRate:        481950 * types.BytePerSecond,
 					TransmitPercentage: 10000,
 					ReceivedPercentage: 2966,
 					Error:              "none",
@@ -499,6 +501,87 @@ var _ = Describe("downloads", func() {
 			})
 		})
 	})
+	Context("add a download task from a file", func() {
+		downloadRequest := new(types.DownloadFileRequest)
+		returnedID := new(int64)
+		BeforeEach(func() {
+			*downloadRequest = types.DownloadFileRequest{
+				File:              strings.NewReader("torrent-content"),
+				Filename:          "ubuntu.torrent",
+				DownloadDirectory: "/Freebox/ISOs",
+				ArchivePassword:   "secret",
+			}
+		})
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedID, *returnedErr = freeboxClient.AddDownloadTaskFromFile(ctx, *downloadRequest)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/downloads/add", version)),
+						ghttp.VerifyMimeType("multipart/form-data"),
+						verifyAuth(*sessionToken),
+						func(w http.ResponseWriter, r *http.Request) {
+							Expect(r.ParseMultipartForm(1024)).To(Succeed())
+							Expect(r.MultipartForm.Value).To(Equal(map[string][]string{
+								"download_dir":     {"L0ZyZWVib3gvSVNPcw=="},
+								"archive_password": {"secret"},
+							}))
+							Expect(r.MultipartForm.File).To(HaveKey("download_file"))
+							file := r.MultipartForm.File["download_file"][0]
+							Expect(file.Filename).To(Equal("ubuntu.torrent"))
+							content := Must(file.Open())
+							defer content.Close()
+							Expect(io.ReadAll(content)).To(BeEquivalentTo("torrent-content"))
+						},
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"id": 1234
+							}
+						}`),
+					),
+				)
+			})
+			It("should return the correct task ID", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedID).To(Equal(int64(1234)))
+			})
+		})
+		Context("when no file is given", func() {
+			BeforeEach(func() {
+				downloadRequest.File = nil
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the server returns an unexpected payload", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/downloads/add", version)),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": []
+						}`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
 	Context("delete a download task", func() {
 		var (
 			taskID = int64(1234)
@@ -610,5 +693,267 @@ var _ = Describe("downloads", func() {
 				Expect(freeboxClient.UpdateDownloadTask(ctx, taskID, *payload)).To(Succeed())
 			})
 		})
+		Context("when the stop ratio is set", func() {
+			BeforeEach(func() {
+				payload.StopRatio = types.NewDownloadStopRatio(1.5)
+				setupServer(ghttp.VerifyJSON(`{"stop_ratio":150}`))
+			})
+			It("should work", func(ctx SpecContext) {
+				Expect(freeboxClient.UpdateDownloadTask(ctx, taskID, *payload)).To(Succeed())
+			})
+		})
+		Context("when the stop ratio is zero", func() {
+			BeforeEach(func() {
+				payload.StopRatio = types.NewDownloadStopRatio(0)
+				setupServer(ghttp.VerifyJSON(`{"stop_ratio":0}`))
+			})
+			It("should not omit it", func(ctx SpecContext) {
+				Expect(freeboxClient.UpdateDownloadTask(ctx, taskID, *payload)).To(Succeed())
+			})
+		})
+	})
+	Context("getting download stats", func() {
+		returnedStats := new(types.DownloadStats)
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedStats, *returnedErr = freeboxClient.GetDownloadStats(ctx)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/stats", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"nb_tasks": 5,
+								"nb_tasks_active": 1,
+								"nb_tasks_stopped": 1,
+								"nb_tasks_queued": 0,
+								"nb_tasks_stopping": 0,
+								"nb_tasks_downloading": 1,
+								"nb_tasks_seeding": 0,
+								"nb_tasks_checking": 0,
+								"nb_tasks_repairing": 0,
+								"nb_tasks_extracting": 0,
+								"nb_tasks_done": 2,
+								"nb_tasks_error": 1,
+								"nb_rss": 2,
+								"nb_rss_items_unread": 7,
+								"nb_peers": 12,
+								"tx_rate": 1024,
+								"rx_rate": 204800,
+								"throttling_mode": "schedule",
+								"throttling_is_scheduled": true,
+								"throttling_rate": {
+									"tx_rate": 51200,
+									"rx_rate": 0
+								}
+							}
+						}`),
+					),
+				)
+			})
+			It("should return the correct stats", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedStats).To(Equal(types.DownloadStats{
+					TasksCount:            5,
+					TasksActiveCount:      1,
+					TasksStoppedCount:     1,
+					TasksDownloadingCount: 1,
+					TasksDoneCount:        2,
+					TasksErrorCount:       1,
+					RSSCount:              2,
+					RSSItemsUnreadCount:   7,
+					PeersCount:            12,
+					TransmitRate:          1024 * types.BytePerSecond,
+					ReceiveRate:           204800 * types.BytePerSecond,
+					ThrottlingMode:        types.DownloadThrottlingModeSchedule,
+					ThrottlingIsScheduled: true,
+					ThrottlingRate: types.DownloadRates{
+						TransmitRate: 51200 * types.BytePerSecond,
+					},
+				}))
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the server returns an unexpected payload", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/stats", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								"foo"
+							]
+						}`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("listing download task files", func() {
+		const taskID = int64(42)
+
+		returnedFiles := new([]types.DownloadFile)
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedFiles, *returnedErr = freeboxClient.ListDownloadTaskFiles(ctx, taskID)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d/files", version, taskID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								{
+									"id": "42-0",
+									"task_id": 42,
+									"path": "cGF0aC90by9maWxl",
+									"filepath": "to/file",
+									"name": "file",
+									"mimetype": "video/mp4",
+									"size": 1024,
+									"rx": 512,
+									"status": "downloading",
+									"priority": "high",
+									"error": "none",
+									"long_name": "to/file"
+								}
+							]
+						}`),
+					),
+				)
+			})
+			It("should return the correct files", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedFiles).To(Equal([]types.DownloadFile{
+					{
+						ID:            "42-0",
+						TaskID:        taskID,
+						Path:          "path/to/file",
+						FilePath:      "to/file",
+						Name:          "file",
+						MimeType:      "video/mp4",
+						SizeBytes:     1024,
+						ReceivedBytes: 512,
+						Status:        types.DownloadFileStatusDownloading,
+						Priority:      types.DownloadFilePriorityHigh,
+						Error:         types.DownloadTaskErrorNone,
+						LongName:      "to/file",
+					},
+				}))
+			})
+		})
+		Context("when the task is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d/files", version, taskID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "task_not_found"
+						}`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrTaskNotFound))
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the server returns an unexpected payload", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d/files", version, taskID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								"foo"
+							]
+						}`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("updating a download task file", func() {
+		const (
+			taskID = int64(42)
+			fileID = "42-0"
+		)
+
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedErr = freeboxClient.UpdateDownloadTaskFile(ctx, taskID, fileID, types.DownloadFilePriorityNoDownload)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/downloads/%d/files/%s", version, taskID, fileID)),
+						ghttp.VerifyJSON(`{"priority":"no_dl"}`),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the task is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/downloads/%d/files/%s", version, taskID, fileID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "task_not_found"
+						}`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrTaskNotFound))
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
 	})
 })
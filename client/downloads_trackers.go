@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// ListDownloadTaskTrackers lists the trackers of a bittorrent download task.
+//
+// The API has no action to make a task announce itself to its trackers: a task announces when it starts, then at the
+// interval requested by each tracker, as told by the reannounce field of the trackers.
+func (c *client) ListDownloadTaskTrackers(ctx context.Context, identifier int64) (result []types.DownloadTracker, err error) {
+	response, err := c.get(ctx, fmt.Sprintf("downloads/%d/trackers", identifier), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrTaskNotFound) {
+			return nil, ErrTaskNotFound
+		}
+
+		return nil, fmt.Errorf("failed to GET downloads/%d/trackers endpoint: %w", identifier, err)
+	}
+
+	if response.Result == nil {
+		return
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to get download trackers from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// AddDownloadTaskTracker adds a tracker to a bittorrent download task.
+func (c *client) AddDownloadTaskTracker(ctx context.Context, identifier int64, announce string) error {
+	_, err := c.post(ctx, fmt.Sprintf("downloads/%d/trackers", identifier), map[string]interface{}{
+		"announce": announce,
+	}, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrTaskNotFound) {
+			return ErrTaskNotFound
+		}
+
+		return fmt.Errorf("failed to POST downloads/%d/trackers endpoint: %w", identifier, err)
+	}
+
+	return nil
+}
+
+// RemoveDownloadTaskTracker removes a tracker from a bittorrent download task.
+func (c *client) RemoveDownloadTaskTracker(ctx context.Context, identifier int64, announce string) error {
+	_, err := c.delete(ctx, fmt.Sprintf("downloads/%d/trackers/%s", identifier, url.PathEscape(announce)), c.withSession(ctx))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrTaskNotFound):
+			return ErrTaskNotFound
+		case errors.Is(err, ErrTrackerNotFound):
+			return ErrTrackerNotFound
+		}
+
+		return fmt.Errorf("failed to DELETE downloads/%d/trackers endpoint: %w", identifier, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,228 @@
+package client_test
+
+import (
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("download trackers", func() {
+	const (
+		taskID   = int64(42)
+		announce = "udp://tracker.example.org:1337/announce"
+	)
+
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	Context("listing trackers", func() {
+		returnedTrackers := new([]types.DownloadTracker)
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedTrackers, *returnedErr = freeboxClient.ListDownloadTaskTrackers(ctx, taskID)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d/trackers", version, taskID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								{
+									"announce": "udp://tracker.example.org:1337/announce",
+									"is_backup": false,
+									"status": "working",
+									"interval": 1800,
+									"min_interval": 900,
+									"reannounce": 1200,
+									"nseeders": 10,
+									"nleechers": 3
+								}
+							]
+						}`),
+					),
+				)
+			})
+			It("should return the correct trackers", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedTrackers).To(Equal([]types.DownloadTracker{
+					{
+						Announce:           announce,
+						Status:             types.DownloadTrackerStatusWorking,
+						IntervalSeconds:    1800,
+						MinIntervalSeconds: 900,
+						ReannounceSeconds:  1200,
+						SeedersCount:       10,
+						LeechersCount:      3,
+					},
+				}))
+			})
+		})
+		Context("when the task is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d/trackers", version, taskID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "task_not_found"
+						}`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrTaskNotFound))
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the server returns an unexpected payload", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d/trackers", version, taskID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								"foo"
+							]
+						}`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("adding a tracker", func() {
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedErr = freeboxClient.AddDownloadTaskTracker(ctx, taskID, announce)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/downloads/%d/trackers", version, taskID)),
+						ghttp.VerifyJSON(`{"announce": "udp://tracker.example.org:1337/announce"}`),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the task is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/downloads/%d/trackers", version, taskID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "task_not_found"
+						}`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrTaskNotFound))
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("removing a tracker", func() {
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedErr = freeboxClient.RemoveDownloadTaskTracker(ctx, taskID, announce)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/downloads/%d/trackers/%s", version, taskID, announce)),
+						verifyAuth(*sessionToken),
+						func(w http.ResponseWriter, r *http.Request) {
+							Expect(r.URL.EscapedPath()).To(HaveSuffix("/trackers/udp:%2F%2Ftracker.example.org:1337%2Fannounce"))
+						},
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the tracker is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/downloads/%d/trackers/%s", version, taskID, announce)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "bt_tracker_not_found"
+						}`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrTrackerNotFound))
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// WaitForDownloadTask waits until a download task is complete, as reported by types.DownloadTask.IsComplete, and
+// returns it. An error wrapping ErrDownloadFailed is returned if the task ended in error, and ErrDownloadStopped if it
+// was stopped before its download was over.
+//
+// The task is checked again as soon as the freebox notifies a change of a download task over the events websocket,
+// and at least every poll interval, which is the only trigger when the events are not available.
+func (c *client) WaitForDownloadTask(ctx context.Context, identifier int64, options types.WaitForDownloadTaskOptions) (types.DownloadTask, error) {
+	interval := options.PollInterval
+	if interval <= 0 {
+		interval = DownloadTaskPollInterval
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	notifications := c.downloadTaskNotifications(ctx)
+
+	for {
+		task, err := c.GetDownloadTask(ctx, identifier)
+		if err != nil {
+			return task, err
+		}
+
+		if options.Progress != nil {
+			options.Progress(task)
+		}
+
+		if task.IsComplete() {
+			return task, nil
+		}
+
+		switch task.Status {
+		case types.DownloadTaskStatusStopped:
+			return task, fmt.Errorf("task %d: %w", identifier, ErrDownloadStopped)
+		case types.DownloadTaskStatusError:
+			if err := DownloadTaskError(task); err != nil {
+				return task, err
+			}
+
+			return task, fmt.Errorf("task %d: %w", identifier, ErrDownloadFailed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return task, fmt.Errorf("stopped waiting for download task %d: %w", identifier, ctx.Err())
+		case _, ok := <-notifications:
+			if !ok {
+				// the listener stopped, carry on polling
+				notifications = nil
+			}
+		case <-time.After(interval):
+		}
+	}
+}
+
+// downloadTaskNotifications returns a channel receiving a value each time the freebox notifies a change of a download
+// task, and closed once the notifications stop. It returns nil when the events are not available.
+func (c *client) downloadTaskNotifications(ctx context.Context) <-chan struct{} {
+	events, err := c.ListenEvents(ctx, []types.EventDescription{{
+		Source: types.EventSourceDownload,
+		Name:   types.EventDownloadTaskChanged,
+	}}, WithEventsBufferSize(1), WithEventsOverflowPolicy(EventsOverflowDropOldest))
+	if err != nil {
+		return nil
+	}
+
+	notifications := make(chan struct{}, 1)
+
+	go func() {
+		defer close(notifications)
+
+		for event := range events {
+			if event.Error != nil {
+				continue
+			}
+
+			select {
+			case notifications <- struct{}{}:
+			default:
+				// a notification is already pending
+			}
+		}
+	}()
+
+	return notifications
+}
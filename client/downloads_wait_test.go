@@ -0,0 +1,209 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("waiting for a download task", func() {
+	const taskID = int64(42)
+
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		ctx     context.Context
+		cancel  context.CancelFunc
+		options = new(types.WaitForDownloadTaskOptions)
+
+		progress = new([]types.DownloadTask)
+
+		returnedTask = new(types.DownloadTask)
+		returnedErr  = new(error)
+
+		respondWithProgress = func(status, errorCode string, receivedBytes, size int64) http.HandlerFunc {
+			return ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d", version, taskID)),
+				verifyAuth(*sessionToken),
+				ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+					"success": true,
+					"result": {"id": %d, "status": %q, "error": %q, "rx_bytes": %d, "size": %d}
+				}`, taskID, status, errorCode, receivedBytes, size)),
+			)
+		}
+		respondWithStatus = func(status, errorCode string) http.HandlerFunc {
+			return respondWithProgress(status, errorCode, 1100, 1024)
+		}
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+
+		// the events are not available unless a test says otherwise
+		server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/ws/event", version), ghttp.RespondWith(http.StatusNotFound, nil))
+
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		*progress = nil
+		*options = types.WaitForDownloadTaskOptions{
+			PollInterval: time.Millisecond * 10,
+			Progress: func(task types.DownloadTask) {
+				*progress = append(*progress, task)
+			},
+		}
+	})
+	JustBeforeEach(func() {
+		*returnedTask, *returnedErr = freeboxClient.WaitForDownloadTask(ctx, taskID, *options)
+	})
+	Context("default", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				respondWithStatus("queued", "none"),
+				respondWithStatus("downloading", "none"),
+				respondWithStatus("done", "none"),
+			)
+		})
+		It("should return the finished task", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(returnedTask.Status).To(BeEquivalentTo(types.DownloadTaskStatusDone))
+			Expect(*progress).To(HaveLen(3))
+		})
+	})
+	Context("when the task is seeding", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				respondWithProgress("downloading", "none", 512, 1024),
+				respondWithProgress("seeding", "none", 1100, 1024),
+			)
+		})
+		It("should return the seeding task", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(returnedTask.Status).To(BeEquivalentTo(types.DownloadTaskStatusSeeding))
+			Expect(*progress).To(HaveLen(2))
+		})
+	})
+	Context("when the task is seeding without having received every byte", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				respondWithProgress("seeding", "none", 512, 1024),
+			)
+		})
+		It("should return the seeding task", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(returnedTask.Status).To(BeEquivalentTo(types.DownloadTaskStatusSeeding))
+			Expect(returnedTask.ReceivedBytes).To(BeEquivalentTo(512))
+			Expect(*progress).To(HaveLen(1))
+		})
+	})
+	Context("when the task is stopped", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				respondWithStatus("downloading", "none"),
+				respondWithStatus("stopped", "none"),
+			)
+		})
+		It("should return the correct error", func() {
+			Expect(errors.Is(*returnedErr, client.ErrDownloadStopped)).To(BeTrue())
+			Expect(returnedTask.Status).To(BeEquivalentTo(types.DownloadTaskStatusStopped))
+		})
+	})
+	Context("when the events are available", func() {
+		BeforeEach(func() {
+			options.PollInterval = time.Hour
+
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/ws/event", version), wsHandler(func(ws *websocket.Conn) {
+				_, message, err := ws.ReadMessage()
+				Expect(err).To(BeNil())
+				Expect(message).To(MatchJSON(`{"action": "register", "events": ["download_task_changed"]}`))
+				Expect(ws.WriteMessage(websocket.TextMessage, []byte(`{
+					"action": "register",
+					"success": true
+				}`))).To(BeNil())
+
+				Expect(ws.WriteMessage(websocket.TextMessage, []byte(`{
+					"action": "notification",
+					"success": true,
+					"source": "download",
+					"event": "task_changed",
+					"result": {"id": 42}
+				}`))).To(BeNil())
+
+				_, _, err = ws.ReadMessage()
+				Expect(websocket.IsCloseError(err, websocket.CloseNormalClosure)).To(BeTrue(), "websocket should have been closed by client")
+			}))
+			server.AppendHandlers(
+				respondWithStatus("downloading", "none"),
+				respondWithStatus("done", "none"),
+			)
+		})
+		It("should check the task again when notified", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(returnedTask.Status).To(BeEquivalentTo(types.DownloadTaskStatusDone))
+			Expect(*progress).To(HaveLen(2))
+		})
+	})
+	Context("when the task fails", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				respondWithStatus("downloading", "none"),
+				respondWithStatus("error", "disk_full"),
+			)
+		})
+		It("should return the correct error", func() {
+			Expect(errors.Is(*returnedErr, client.ErrDownloadDiskFull)).To(BeTrue())
+			Expect(returnedTask.Status).To(BeEquivalentTo(types.DownloadTaskStatusError))
+		})
+	})
+	Context("when the task is not found", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d", version, taskID)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": false,
+						"error_code": "task_not_found"
+					}`),
+				),
+			)
+		})
+		It("should return the correct error", func() {
+			Expect(*returnedErr).To(Equal(client.ErrTaskNotFound))
+		})
+	})
+	Context("when the context is canceled", func() {
+		BeforeEach(func() {
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d", version, taskID), respondWithStatus("downloading", "none"))
+			options.Progress = func(types.DownloadTask) {
+				cancel()
+			}
+		})
+		It("should return an error", func() {
+			Expect(errors.Is(*returnedErr, context.Canceled)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+const (
+	DefaultEndpoint         = "mafreebox.freebox.fr" // Address of the box on the local network of a Freebox
+	DefaultIliadboxEndpoint = "myiliadbox.iliad.it"  // Address of the box on the local network of an Iliadbox
+	DefaultVersion          = "latest"
+)
+
+// defaultEndpoints are the addresses of the boxes on their local network, by vendor.
+var defaultEndpoints = map[types.BoxVendor]string{
+	types.BoxVendorFree:  DefaultEndpoint,
+	types.BoxVendorIliad: DefaultIliadboxEndpoint,
+}
+
+// environment holds the settings read by NewFromEnv, from the configuration file then the environment variables.
+type environment struct {
+	Vendor       string `yaml:"vendor"`
+	Endpoint     string `yaml:"endpoint"`
+	Version      string `yaml:"version"`
+	AppID        string `yaml:"app_id"`
+	PrivateToken string `yaml:"token"`
+}
+
+// NewFromEnv returns a client configured from the environment, so that programs embedding free-go share the same
+// settings:
+//
+//	FREEBOX_VENDOR    vendor of the box, freebox or iliadbox (defaults to freebox)
+//	FREEBOX_ENDPOINT  address of the box (defaults to the one of the vendor, such as mafreebox.freebox.fr)
+//	FREEBOX_VERSION   version of the API (defaults to latest)
+//	FREEBOX_APP_ID    identifier of the application
+//	FREEBOX_TOKEN     private token of the application
+//	FREEBOX_CONFIG    path of an optional JSON or YAML file holding the vendor, endpoint, version, app_id and token fields
+//
+// The environment variables take precedence over the configuration file. The app id and private token are left unset
+// when they are found nowhere, as they are not needed to call Authorize or APIVersion.
+func NewFromEnv() (Client, error) {
+	settings := environment{
+		Vendor:  string(types.BoxVendorFree),
+		Version: DefaultVersion,
+	}
+
+	if path, ok := os.LookupEnv("FREEBOX_CONFIG"); ok {
+		if err := settings.readFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	for variable, field := range map[string]*string{
+		"FREEBOX_VENDOR":   &settings.Vendor,
+		"FREEBOX_ENDPOINT": &settings.Endpoint,
+		"FREEBOX_VERSION":  &settings.Version,
+		"FREEBOX_APP_ID":   &settings.AppID,
+		"FREEBOX_TOKEN":    &settings.PrivateToken,
+	} {
+		if value, ok := os.LookupEnv(variable); ok {
+			*field = value
+		}
+	}
+
+	if settings.Endpoint == "" {
+		endpoint, ok := defaultEndpoints[types.BoxVendor(settings.Vendor)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownBoxVendor, settings.Vendor)
+		}
+
+		settings.Endpoint = endpoint
+	}
+
+	freebox, err := New(settings.Endpoint, settings.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.AppID != "" {
+		freebox = freebox.WithAppID(settings.AppID)
+	}
+
+	if settings.PrivateToken != "" {
+		freebox = freebox.WithPrivateToken(settings.PrivateToken)
+	}
+
+	return freebox, nil
+}
+
+// readFile overrides the settings with the fields set in the configuration file. JSON being a subset of YAML, both are
+// decoded the same way.
+func (e *environment) readFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open configuration file: %w", err)
+	}
+
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(e); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to decode configuration file %s: %w", path, err)
+	}
+
+	return nil
+}
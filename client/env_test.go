@@ -0,0 +1,148 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+)
+
+var _ = Describe("client from environment", func() {
+	var (
+		freeboxClient client.Client
+
+		server *ghttp.Server
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		for _, variable := range []string{"FREEBOX_VENDOR", "FREEBOX_ENDPOINT", "FREEBOX_VERSION", "FREEBOX_APP_ID", "FREEBOX_TOKEN", "FREEBOX_CONFIG"} {
+			if value, ok := os.LookupEnv(variable); ok {
+				DeferCleanup(os.Setenv, variable, value)
+				Expect(os.Unsetenv(variable)).To(Succeed())
+			}
+		}
+	})
+	JustBeforeEach(func() {
+		freeboxClient, *returnedErr = client.NewFromEnv()
+	})
+	writeConfig := func(name, content string) {
+		path := filepath.Join(GinkgoT().TempDir(), name)
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+
+		GinkgoT().Setenv("FREEBOX_CONFIG", path)
+	}
+	Context("with environment variables", func() {
+		BeforeEach(func() {
+			GinkgoT().Setenv("FREEBOX_ENDPOINT", server.Addr())
+			GinkgoT().Setenv("FREEBOX_VERSION", version)
+			GinkgoT().Setenv("FREEBOX_APP_ID", appID)
+			GinkgoT().Setenv("FREEBOX_TOKEN", privateToken)
+
+			setupLoginFlow(server)
+		})
+		It("should configure the client", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(freeboxClient.Login(context.Background())).Error().To(BeNil())
+		})
+	})
+	Context("with a YAML configuration file", func() {
+		BeforeEach(func() {
+			writeConfig("freebox.yaml", `
+endpoint: `+server.Addr()+`
+version: `+version+`
+app_id: `+appID+`
+token: `+privateToken+`
+`)
+			setupLoginFlow(server)
+		})
+		It("should configure the client", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(freeboxClient.Login(context.Background())).Error().To(BeNil())
+		})
+	})
+	Context("with a JSON configuration file overridden by environment variables", func() {
+		BeforeEach(func() {
+			writeConfig("freebox.json", `{
+				"endpoint": "unreachable.invalid",
+				"version": "`+version+`",
+				"app_id": "`+appID+`",
+				"token": "`+privateToken+`"
+			}`)
+			GinkgoT().Setenv("FREEBOX_ENDPOINT", server.Addr())
+
+			setupLoginFlow(server)
+		})
+		It("should configure the client", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(freeboxClient.Login(context.Background())).Error().To(BeNil())
+		})
+	})
+	Context("without any setting", func() {
+		It("should leave the credentials unset", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(freeboxClient.Login(context.Background())).Error().To(MatchError(client.ErrAppIDIsNotSet))
+		})
+	})
+	for vendor, expected := range map[string]string{
+		"":         client.DefaultEndpoint,
+		"freebox":  client.DefaultEndpoint,
+		"iliadbox": client.DefaultIliadboxEndpoint,
+	} {
+		vendor, expected := vendor, expected
+		Context(fmt.Sprintf("when the vendor is '%s' without endpoint", vendor), func() {
+			httpMock := new(httpClientMock)
+			BeforeEach(func() {
+				if vendor != "" {
+					GinkgoT().Setenv("FREEBOX_VENDOR", vendor)
+				}
+
+				httpMock = &httpClientMock{
+					response: func() (*http.Response, error) {
+						return nil, errors.New("just fail")
+					},
+				}
+			})
+			It("should target the default endpoint of the vendor", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(freeboxClient.WithHTTPClient(httpMock).APIVersion(context.Background())).Error().ToNot(BeNil())
+				Expect(httpMock.request.URL.Host).To(Equal(expected))
+			})
+		})
+	}
+	Context("when the vendor is unknown", func() {
+		BeforeEach(func() {
+			writeConfig("freebox.yaml", "vendor: sfrbox\n")
+		})
+		It("should return an error", func() {
+			Expect(*returnedErr).To(MatchError(client.ErrUnknownBoxVendor))
+		})
+	})
+	Context("when the configuration file does not exist", func() {
+		BeforeEach(func() {
+			GinkgoT().Setenv("FREEBOX_CONFIG", filepath.Join(GinkgoT().TempDir(), "missing.yaml"))
+		})
+		It("should return an error", func() {
+			Expect(*returnedErr).To(MatchError(os.ErrNotExist))
+		})
+	})
+	Context("when the configuration file holds an unknown field", func() {
+		BeforeEach(func() {
+			writeConfig("freebox.yaml", "private_token: "+privateToken+"\n")
+		})
+		It("should return an error", func() {
+			Expect(*returnedErr).To(MatchError(ContainSubstring("field private_token not found")))
+		})
+	})
+})
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"fmt"
+)
+
+const (
+	codeAuthRequired           = "auth_required"
+	codeInvalidSession         = "invalid_session"
+	codeInvalidToken           = "invalid_token"
+	codeInsufficientRights     = "insufficient_rights"
+	codePathNotFound           = "path_not_found"
+	codeDestinationConflict    = "destination_conflict"
+	codeTaskNotFound           = "task_not_found"
+	codeVirtualMachineNotFound = "no_such_vm"
+	codeNotFound               = "noent"
+	codeDeviceNotFound         = "nodev"
+	codeHostNotFound           = "nohost"
+	codeBusy                   = "busy"
+	codeTrackerNotFound        = "bt_tracker_not_found"
+	codeFileNotFound           = "file_not_found"
+	codeDiskTaskNotFound       = "task_notfound"
+)
+
+// errorCodes maps the error codes the freebox returns to the errors of the client. The *APIError of a failed call
+// wraps the error its code maps to, so that it can be matched with errors.Is on any endpoint. Codes naming what is
+// missing only through the endpoint, such as noent, map to a generic error like ErrNotFound, which the endpoints
+// return as their own error, such as ErrCallNotFound.
+var errorCodes = map[string]error{
+	codeAuthRequired:           ErrAuthenticationRequired,
+	codeInvalidSession:         ErrInvalidSession,
+	codeInvalidToken:           ErrInvalidSession,
+	codeInsufficientRights:     ErrInsufficientRights,
+	codePathNotFound:           ErrPathNotFound,
+	codeDestinationConflict:    ErrDestinationConflict,
+	codeTaskNotFound:           ErrTaskNotFound,
+	codeVirtualMachineNotFound: ErrVirtualMachineNotFound,
+	codeNotFound:               ErrNotFound,
+	codeDeviceNotFound:         ErrDeviceNotFound,
+	codeHostNotFound:           ErrInterfaceHostNotFound,
+	codeBusy:                   ErrBusy,
+	codeTrackerNotFound:        ErrTrackerNotFound,
+	codeFileNotFound:           ErrPathNotFound,
+	codeDiskTaskNotFound:       ErrTaskNotFound,
+}
+
+// ErrorForCode returns the error of the client which an error code returned by the freebox maps to whatever the
+// endpoint, or nil when the code is not mapped.
+func ErrorForCode(code string) error {
+	return errorCodes[code]
+}
+
+// InsufficientRightsError is returned, wrapped in an *APIError, when the freebox tells which permission the
+// application misses to perform a call. It matches ErrInsufficientRights with errors.Is.
+type InsufficientRightsError struct {
+	MissingRight string // Permission to grant to the application in the freebox settings, such as "explorer" or "vm"
+}
+
+func (e *InsufficientRightsError) Error() string {
+	return fmt.Sprintf("%s: missing the %q permission", ErrInsufficientRights, e.MissingRight)
+}
+
+func (e *InsufficientRightsError) Unwrap() error {
+	return ErrInsufficientRights
+}
@@ -0,0 +1,153 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("error codes", func() {
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken).
+			WithAutomaticRelogin(false)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	Context("creating a profile", func() {
+		JustBeforeEach(func() {
+			_, *returnedErr = freeboxClient.CreateProfile(context.Background(), types.ProfilePayload{Name: "Léa"})
+		})
+		for code, expectedErr := range map[string]error{
+			"auth_required":        client.ErrAuthenticationRequired,
+			"invalid_session":      client.ErrInvalidSession,
+			"invalid_token":        client.ErrInvalidSession,
+			"insufficient_rights":  client.ErrInsufficientRights,
+			"path_not_found":       client.ErrPathNotFound,
+			"destination_conflict": client.ErrDestinationConflict,
+			"task_not_found":       client.ErrTaskNotFound,
+			"no_such_vm":           client.ErrVirtualMachineNotFound,
+			"noent":                client.ErrNotFound,
+			"nodev":                client.ErrDeviceNotFound,
+			"nohost":               client.ErrInterfaceHostNotFound,
+			"busy":                 client.ErrBusy,
+			"bt_tracker_not_found": client.ErrTrackerNotFound,
+			"file_not_found":       client.ErrPathNotFound,
+			"task_notfound":        client.ErrTaskNotFound,
+		} {
+			code, expectedErr := code, expectedErr
+			Context(fmt.Sprintf("when the server returns %s", code), func() {
+				BeforeEach(func() {
+					server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/profile/", version)),
+							verifyAuth(*sessionToken),
+							ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "`+code+`" }`),
+						),
+					)
+				})
+				It("should return an error matching the mapped error", func() {
+					Expect(*returnedErr).To(MatchError(expectedErr))
+					Expect(*returnedErr).To(MatchError(&client.APIError{Code: code}))
+				})
+			})
+		}
+		Context("when the server tells which right is missing", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/profile/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusForbidden, `{
+							"success": false,
+							"msg": "Cette application n'est pas autorisée à accéder à cette fonction",
+							"error_code": "insufficient_rights",
+							"missing_right": "parental"
+						}`),
+					),
+				)
+			})
+			It("should return the missing right", func() {
+				Expect(*returnedErr).To(MatchError(client.ErrInsufficientRights))
+
+				rightsErr := new(client.InsufficientRightsError)
+				Expect(errors.As(*returnedErr, &rightsErr)).To(BeTrue())
+				Expect(rightsErr.MissingRight).To(Equal("parental"))
+				Expect(rightsErr.Error()).To(Equal(`insufficient rights: missing the "parental" permission`))
+			})
+		})
+		Context("when the server returns a code which is not mapped", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/profile/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "internal_error" }`),
+					),
+				)
+			})
+			It("should only return the API error", func() {
+				Expect(*returnedErr).To(MatchError(&client.APIError{Code: "internal_error"}))
+				Expect(errors.Unwrap(errors.Unwrap(*returnedErr))).To(BeNil())
+			})
+		})
+	})
+	Context("listing calls", func() {
+		JustBeforeEach(func() {
+			_, *returnedErr = freeboxClient.ListCalls(context.Background())
+		})
+		Context("when the server tells which right is missing", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/log/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusForbidden, `{
+							"success": false,
+							"error_code": "insufficient_rights",
+							"missing_right": "calls"
+						}`),
+					),
+				)
+			})
+			It("should return the missing right", func() {
+				rightsErr := new(client.InsufficientRightsError)
+				Expect(errors.As(*returnedErr, &rightsErr)).To(BeTrue())
+				Expect(rightsErr.MissingRight).To(Equal("calls"))
+				Expect(*returnedErr).To(MatchError(client.ErrInsufficientRights))
+			})
+		})
+	})
+	Context("looking up an error code", func() {
+		It("should return the error it maps to", func() {
+			Expect(client.ErrorForCode("insufficient_rights")).To(Equal(client.ErrInsufficientRights))
+		})
+		It("should not return an error for a code which is not mapped", func() {
+			Expect(client.ErrorForCode("internal_error")).To(BeNil())
+		})
+	})
+})
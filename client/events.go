@@ -3,9 +3,10 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"strings"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/nikolalohinski/free-go/types"
@@ -16,6 +17,41 @@ const (
 	actionRegister     = "register"
 )
 
+const defaultEventsBufferSize = 10
+
+// EventsOverflowPolicy tells what ListenEvents does with a notification received while the events channel is full.
+type EventsOverflowPolicy int
+
+const (
+	EventsOverflowBlock      EventsOverflowPolicy = iota // Wait for the consumer, pausing the websocket reads
+	EventsOverflowDropOldest                             // Drop the oldest buffered event to make room for the notification
+	EventsOverflowDropNewest                             // Drop the notification
+)
+
+// EventsOption configures a call to ListenEvents.
+type EventsOption func(*eventsOptions)
+
+type eventsOptions struct {
+	bufferSize     int
+	overflowPolicy EventsOverflowPolicy
+	history        *EventsHistory
+}
+
+// WithEventsBufferSize sets the capacity of the events channel. It must be positive when events are dropped on overflow.
+func WithEventsBufferSize(size int) EventsOption {
+	return func(options *eventsOptions) {
+		options.bufferSize = size
+	}
+}
+
+// WithEventsOverflowPolicy sets what happens to notifications received while the events channel is full.
+// The number of dropped notifications is reported by the Dropped field of the next event sent.
+func WithEventsOverflowPolicy(policy EventsOverflowPolicy) EventsOption {
+	return func(options *eventsOptions) {
+		options.overflowPolicy = policy
+	}
+}
+
 type registerAction struct {
 	RequestID string   `json:"request_id,omitempty"`
 	Action    string   `json:"action"`
@@ -31,27 +67,32 @@ type registerResponse struct {
 	Message   string          `json:"msg,omitempty"`
 }
 
-// ListenEvents implements Client.
-func (c *client) ListenEvents(ctx context.Context, events []types.EventDescription) (chan types.Event, error) {
-	header := http.Header{}
-	if err := c.withSession(ctx)(&http.Request{
-		Header: header,
-	}); err != nil {
-		return nil, fmt.Errorf("failed to get a session: %w", err)
+// ListenEvents registers to the given events and sends their notifications on the returned channel.
+// The channel is closed once the context is canceled or the freebox closes the connection. When the listener stops
+// because of a failure, the last event sent before closing the channel holds the error: it wraps
+// ErrEventsAuthenticationFailed when the session is rejected and ErrEventsProtocol when an unexpected message is
+// received. A channel closed without an error event means the listener stopped cleanly.
+// By default the channel buffers 10 events, and a full channel pauses the listener until the consumer catches up.
+func (c *client) ListenEvents(ctx context.Context, events []types.EventDescription, options ...EventsOption) (chan types.Event, error) {
+	opts := eventsOptions{
+		bufferSize:     defaultEventsBufferSize,
+		overflowPolicy: EventsOverflowBlock,
 	}
-
-	url := *c.base
-	url.Scheme = "ws"
-
-	if strings.ToLower(c.base.Scheme) == "https" {
-		url.Scheme = "wss"
+	for _, option := range options {
+		option(&opts)
 	}
 
-	url.Path = url.Path + "/ws/event"
+	if opts.bufferSize < 0 || (opts.bufferSize == 0 && opts.overflowPolicy != EventsOverflowBlock) {
+		return nil, fmt.Errorf("%d: %w", opts.bufferSize, ErrInvalidEventsBufferSize)
+	}
 
-	ws, dialResponse, err := websocket.DefaultDialer.Dial(url.String(), header)
+	ws, dialResponse, err := c.dialWebSocket(ctx, "ws/event")
 	if err != nil {
-		return nil, fmt.Errorf("dialing websocket returned a status %s: %w", dialResponse.Status, err)
+		if dialResponse != nil && (dialResponse.StatusCode == http.StatusUnauthorized || dialResponse.StatusCode == http.StatusForbidden) {
+			return nil, fmt.Errorf("dialing websocket returned a status %s: %w", dialResponse.Status, ErrEventsAuthenticationFailed)
+		}
+
+		return nil, err
 	}
 
 	registerActionPayload := registerAction{
@@ -63,68 +104,166 @@ func (c *client) ListenEvents(ctx context.Context, events []types.EventDescripti
 	}
 
 	if err := ws.WriteJSON(registerActionPayload); err != nil {
+		_ = ws.Close()
+
 		return nil, fmt.Errorf("failed to register action: %w", err)
 	}
 
 	var response registerResponse
 	if err := ws.ReadJSON(&response); err != nil {
-		return nil, fmt.Errorf("failed to read register response from websocket: %w", err)
+		_ = ws.Close()
+
+		return nil, fmt.Errorf("failed to read register response from websocket: %w", eventsError(err))
 	}
 
 	if !response.Success {
-		return nil, fmt.Errorf("registering to websocket notifications failed with error %s: %s", response.ErrorCode, response.Message)
+		_ = ws.Close()
+
+		cause := ErrEventsProtocol
+		if isAuthenticationErrorCode(response.ErrorCode) {
+			cause = ErrEventsAuthenticationFailed
+		}
+
+		return nil, fmt.Errorf("registering to websocket notifications failed with error %s: %s: %w", response.ErrorCode, response.Message, cause)
 	}
 
-	channel := make(chan types.Event, 10)
+	channel := make(chan types.Event, opts.bufferSize)
+	done := make(chan struct{})
 
+	// a pending read does not watch the context, so the connection is gracefully closed as soon as the context is done:
+	// the freebox answers with a close frame which ends the pending read
 	go func() {
-		var err error
-		defer func() {
-			if err != nil {
-				channel <- types.Event{
-					Error: fmt.Errorf("encountered error while handling the event notification: %w", err),
+		select {
+		case <-done:
+		case <-ctx.Done():
+			_ = ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(EventsCloseTimeout))
+			_ = ws.SetReadDeadline(time.Now().Add(EventsCloseTimeout))
+		}
+	}()
+
+	go func() {
+		var (
+			err     error
+			dropped int64
+		)
+
+		// deliver sends an event according to the overflow policy and returns false once the context is done
+		deliver := func(event types.Event, policy EventsOverflowPolicy) bool {
+			for {
+				event.Dropped = dropped
+
+				if policy == EventsOverflowBlock {
+					select {
+					case <-ctx.Done():
+						return false
+					case channel <- event:
+						dropped = 0
+
+						return true
+					}
+				}
+
+				select {
+				case channel <- event:
+					dropped = 0
+
+					return true
+				default:
 				}
-			}
 
-			if err = ws.Close(); err != nil {
-				channel <- types.Event{
-					Error: fmt.Errorf("closing websocket returned an error: %w", err),
+				if policy == EventsOverflowDropNewest {
+					dropped++
+
+					return true
+				}
+
+				select {
+				case oldest := <-channel:
+					dropped += 1 + oldest.Dropped
+				default:
 				}
 			}
+		}
+
+		defer func() {
+			close(done)
+
+			if closeErr := ws.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("closing websocket returned an error: %w", closeErr)
+			}
+
+			if err != nil {
+				deliver(types.Event{
+					Error: fmt.Errorf("encountered error while handling the event notification: %w", err),
+				}, EventsOverflowBlock)
+			}
 
 			close(channel)
 		}()
 
 		for {
-			select {
-			case <-ctx.Done():
-				err = ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
-				if err != nil && !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
-					err = fmt.Errorf("failed to gracefully close websocket connection: %w", err)
+			var eventPayload types.EventNotification
+			if err = ws.ReadJSON(&eventPayload); err != nil {
+				if ctx.Err() != nil {
+					// the connection was closed because the context is done
+					err = nil
+
+					return
+				}
+
+				if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+					err = nil
+				} else {
+					err = fmt.Errorf("failed to read message from websocket: %w", eventsError(err))
 				}
 
 				return
-			default:
-				var eventPayload types.EventNotification
-				if err = ws.ReadJSON(&eventPayload); err != nil {
-					if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
-						err = fmt.Errorf("failed to read message from websocket: %w", err)
-					}
+			}
 
-					return
-				}
+			if !eventPayload.Success || eventPayload.Action != actionNotification {
+				err = fmt.Errorf("received unexpected event payload with success=%t and action=%s: %w", eventPayload.Success, eventPayload.Action, ErrEventsProtocol)
 
-				if !eventPayload.Success || eventPayload.Action != actionNotification {
-					err = fmt.Errorf("received unexpected event payload with success=%t and action=%s", eventPayload.Success, eventPayload.Action)
+				return
+			}
 
-					return
-				}
-				channel <- types.Event{
-					Notification: eventPayload,
-				}
+			event := types.Event{
+				Notification: eventPayload,
+				ReceivedAt:   time.Now(),
+			}
+
+			if opts.history != nil {
+				opts.history.record(event)
+			}
+
+			if !deliver(event, opts.overflowPolicy) {
+				return
 			}
 		}
 	}()
 
 	return channel, nil
 }
+
+// eventsError tells apart the errors caused by the freebox rejecting the session, or sending unexpected messages.
+func eventsError(err error) error {
+	if websocket.IsCloseError(err, websocket.ClosePolicyViolation) {
+		return fmt.Errorf("%w: %w", ErrEventsAuthenticationFailed, err)
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+		return fmt.Errorf("%w: %w", ErrEventsProtocol, err)
+	}
+
+	return err
+}
+
+func isAuthenticationErrorCode(code string) bool {
+	switch code {
+	case codeAuthRequired, codeInvalidSession, codeInsufficientRights:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// EventsHistory retains the last notifications of each source received by the listeners it is given to with
+// WithEventsHistory, including the ones dropped because the events channel was full. A consumer which lost track of
+// the notifications for a while, such as while reconnecting to a downstream service, replays the ones it missed with
+// ReplaySince. It is safe for concurrent use and can be shared by successive listeners.
+type EventsHistory struct {
+	mutex   sync.Mutex
+	size    int
+	sources map[string]*eventsRing
+}
+
+// eventsRing holds the last events of a source, overwriting the oldest one once full.
+type eventsRing struct {
+	events []types.Event
+	next   int
+}
+
+// NewEventsHistory returns a history retaining the last size notifications of each source.
+func NewEventsHistory(size int) (*EventsHistory, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("%d: %w", size, ErrInvalidEventsHistorySize)
+	}
+
+	return &EventsHistory{
+		size:    size,
+		sources: map[string]*eventsRing{},
+	}, nil
+}
+
+// WithEventsHistory records the notifications received by ListenEvents in the history.
+func WithEventsHistory(history *EventsHistory) EventsOption {
+	return func(options *eventsOptions) {
+		options.history = history
+	}
+}
+
+// ReplaySince returns the retained notifications received after since, from the oldest to the newest.
+func (h *EventsHistory) ReplaySince(since time.Time) []types.Event {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	sources := make([]string, 0, len(h.sources))
+	for source := range h.sources {
+		sources = append(sources, source)
+	}
+
+	sort.Strings(sources)
+
+	result := make([]types.Event, 0)
+
+	for _, source := range sources {
+		ring := h.sources[source]
+
+		for i := range ring.events {
+			if event := ring.events[(ring.next+i)%len(ring.events)]; event.ReceivedAt.After(since) {
+				result = append(result, event)
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].ReceivedAt.Before(result[j].ReceivedAt)
+	})
+
+	return result
+}
+
+func (h *EventsHistory) record(event types.Event) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	source := string(event.Notification.Source)
+
+	ring, ok := h.sources[source]
+	if !ok {
+		ring = &eventsRing{events: make([]types.Event, 0, h.size)}
+		h.sources[source] = ring
+	}
+
+	if len(ring.events) < h.size {
+		ring.events = append(ring.events, event)
+
+		return
+	}
+
+	ring.events[ring.next] = event
+	ring.next = (ring.next + 1) % h.size
+}
@@ -0,0 +1,28 @@
+package client_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/nikolalohinski/free-go/client"
+)
+
+var _ = Describe("events history", func() {
+	Context("creating a history", func() {
+		It("should start empty", func() {
+			history, err := client.NewEventsHistory(1)
+			Expect(err).To(BeNil())
+			Expect(history.ReplaySince(time.Time{})).To(BeEmpty())
+		})
+		for _, size := range []int{0, -1} {
+			size := size
+			It("should not accept a size of "+fmt.Sprint(size), func() {
+				_, err := client.NewEventsHistory(size)
+				Expect(err).To(MatchError(client.ErrInvalidEventsHistorySize))
+			})
+		}
+	})
+})
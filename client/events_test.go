@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/websocket"
 	. "github.com/onsi/ginkgo/v2"
@@ -28,6 +29,7 @@ var _ = Describe("events", func() {
 		ctx           context.Context
 		cancelContext func()
 		events        = new([]types.EventDescription)
+		options       = new([]client.EventsOption)
 
 		returnedChannel = new(chan types.Event)
 		returnedErr     = new(error)
@@ -40,6 +42,7 @@ var _ = Describe("events", func() {
 		*endpoint = server.Addr()
 
 		*returnedChannel = make(chan types.Event)
+		*options = nil
 
 		freeboxClient = Must(client.New(*endpoint, version)).
 			WithAppID(appID).
@@ -48,7 +51,7 @@ var _ = Describe("events", func() {
 		*sessionToken = setupLoginFlow(server)
 	})
 	JustBeforeEach(func() {
-		*returnedChannel, *returnedErr = freeboxClient.ListenEvents(ctx, *events)
+		*returnedChannel, *returnedErr = freeboxClient.ListenEvents(ctx, *events, *options...)
 	})
 	Context("default", func() {
 		BeforeEach(func() {
@@ -100,6 +103,8 @@ var _ = Describe("events", func() {
 			Expect(*returnedErr).To(BeNil())
 			var event types.Event
 			Eventually(*returnedChannel).Should(Receive(&event))
+			Expect(event.ReceivedAt).To(BeTemporally("~", time.Now(), time.Minute))
+			event.ReceivedAt = time.Time{}
 			Expect(event).To(Equal(types.Event{
 				Notification: types.EventNotification{
 					Action:  "notification",
@@ -149,8 +154,225 @@ var _ = Describe("events", func() {
 				Expect(websocket.IsCloseError(err, websocket.CloseNormalClosure)).To(BeTrue(), "websocket should have been closed by client")
 			})
 		})
-		It("should not return an error", func() {
+		It("should close the channel without sending an error", func() {
 			Expect(*returnedErr).To(BeNil())
+			Eventually(*returnedChannel).Should(BeClosed())
+		})
+	})
+	Context("when the freebox closes the connection", func() {
+		var closeCode *int
+		BeforeEach(func() {
+			closeCode = new(int)
+			*closeCode = websocket.CloseNormalClosure
+			*events = []types.EventDescription{
+				{
+					Source: "foo",
+					Name:   "bar",
+				},
+			}
+			server.AppendHandlers(wsHandler(func(ws *websocket.Conn) {
+				_, _, err := ws.ReadMessage()
+				Expect(err).To(BeNil())
+				Expect(ws.WriteMessage(websocket.TextMessage, []byte(`{
+					"action": "register",
+					"success": true
+				}`))).To(BeNil())
+
+				Expect(ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(*closeCode, ""))).To(BeNil())
+			}))
+		})
+		It("should close the channel without sending an error", func() {
+			Expect(*returnedErr).To(BeNil())
+			Eventually(*returnedChannel).Should(BeClosed())
+		})
+		Context("because the session is rejected", func() {
+			BeforeEach(func() {
+				*closeCode = websocket.ClosePolicyViolation
+			})
+			It("should send an authentication error", func() {
+				Expect(*returnedErr).To(BeNil())
+				var event types.Event
+				Eventually(*returnedChannel).Should(Receive(&event))
+				Expect(event.Error).To(MatchError(client.ErrEventsAuthenticationFailed))
+				Eventually(*returnedChannel).Should(BeClosed())
+			})
+		})
+	})
+	Context("when the consumer is slower than the notifications", func() {
+		var (
+			flushed chan struct{}
+			proceed chan struct{}
+		)
+		notification := func(index int) string {
+			return fmt.Sprintf(`{ "action": "notification", "success": true, "source": "foo", "event": "bar", "result": { "index": %d } }`, index)
+		}
+		receive := func() types.Event {
+			var event types.Event
+			Eventually(*returnedChannel).Should(Receive(&event))
+
+			return event
+		}
+		BeforeEach(func() {
+			flushed = make(chan struct{})
+			proceed = make(chan struct{})
+			*events = []types.EventDescription{
+				{
+					Source: "foo",
+					Name:   "bar",
+				},
+			}
+			server.AppendHandlers(wsHandler(func(ws *websocket.Conn) {
+				_, _, err := ws.ReadMessage()
+				Expect(err).To(BeNil())
+				Expect(ws.WriteMessage(websocket.TextMessage, []byte(`{
+					"action": "register",
+					"success": true
+				}`))).To(BeNil())
+
+				for i := 1; i <= 3; i++ {
+					Expect(ws.WriteMessage(websocket.TextMessage, []byte(notification(i)))).To(BeNil())
+				}
+
+				// the pong is only sent back once the client has read all the previous notifications
+				ws.SetPongHandler(func(string) error {
+					close(flushed)
+					select {
+					case <-proceed:
+					case <-time.After(5 * time.Second):
+					}
+
+					return ws.WriteMessage(websocket.TextMessage, []byte(notification(4)))
+				})
+				Expect(ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second))).To(Succeed())
+
+				_, _, err = ws.ReadMessage()
+				Expect(websocket.IsCloseError(err, websocket.CloseNormalClosure)).To(BeTrue(), "websocket should have been closed by client")
+			}))
+		})
+		JustBeforeEach(func() {
+			DeferCleanup(cancelContext)
+		})
+		Context("by default", func() {
+			BeforeEach(func() {
+				*options = []client.EventsOption{client.WithEventsBufferSize(1)}
+			})
+			It("should wait for the consumer", func() {
+				Expect(*returnedErr).To(BeNil())
+				for i := 1; i <= 3; i++ {
+					event := receive()
+					Expect(event.Notification.Result).To(MatchJSON(fmt.Sprintf(`{ "index": %d }`, i)))
+					Expect(event.Dropped).To(BeZero())
+				}
+				Eventually(flushed).Should(BeClosed())
+
+				close(proceed)
+
+				event := receive()
+				Expect(event.Notification.Result).To(MatchJSON(`{ "index": 4 }`))
+			})
+		})
+		Context("when dropping the newest notifications", func() {
+			BeforeEach(func() {
+				*options = []client.EventsOption{
+					client.WithEventsBufferSize(1),
+					client.WithEventsOverflowPolicy(client.EventsOverflowDropNewest),
+				}
+			})
+			It("should keep the first notification and count the dropped ones", func() {
+				Expect(*returnedErr).To(BeNil())
+				Eventually(flushed).Should(BeClosed())
+
+				event := receive()
+				Expect(event.Notification.Result).To(MatchJSON(`{ "index": 1 }`))
+				Expect(event.Dropped).To(BeZero())
+
+				close(proceed)
+
+				event = receive()
+				Expect(event.Notification.Result).To(MatchJSON(`{ "index": 4 }`))
+				Expect(event.Dropped).To(BeEquivalentTo(2))
+			})
+		})
+		Context("when dropping the oldest notifications", func() {
+			BeforeEach(func() {
+				*options = []client.EventsOption{
+					client.WithEventsBufferSize(1),
+					client.WithEventsOverflowPolicy(client.EventsOverflowDropOldest),
+				}
+			})
+			It("should keep the last notification and count the dropped ones", func() {
+				Expect(*returnedErr).To(BeNil())
+				Eventually(flushed).Should(BeClosed())
+
+				event := receive()
+				Expect(event.Notification.Result).To(MatchJSON(`{ "index": 3 }`))
+				Expect(event.Dropped).To(BeEquivalentTo(2))
+
+				close(proceed)
+
+				event = receive()
+				Expect(event.Notification.Result).To(MatchJSON(`{ "index": 4 }`))
+				Expect(event.Dropped).To(BeZero())
+			})
+		})
+	})
+	Context("when recording the history of the notifications", func() {
+		var (
+			history *client.EventsHistory
+			since   time.Time
+		)
+		BeforeEach(func() {
+			history = Must(client.NewEventsHistory(2))
+			since = time.Now()
+			*options = []client.EventsOption{client.WithEventsHistory(history)}
+			*events = []types.EventDescription{
+				{Source: "foo", Name: "bar"},
+				{Source: "baz", Name: "qux"},
+			}
+			server.AppendHandlers(wsHandler(func(ws *websocket.Conn) {
+				_, _, err := ws.ReadMessage()
+				Expect(err).To(BeNil())
+				Expect(ws.WriteMessage(websocket.TextMessage, []byte(`{
+					"action": "register",
+					"success": true
+				}`))).To(BeNil())
+
+				Expect(ws.WriteMessage(websocket.TextMessage, []byte(`{ "action": "notification", "success": true, "source": "baz", "event": "qux", "result": { "index": 0 } }`))).To(BeNil())
+				for i := 1; i <= 3; i++ {
+					Expect(ws.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{ "action": "notification", "success": true, "source": "foo", "event": "bar", "result": { "index": %d } }`, i)))).To(BeNil())
+				}
+
+				Expect(ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))).To(BeNil())
+			}))
+		})
+		It("should replay the last notifications of each source", func() {
+			Expect(*returnedErr).To(BeNil())
+			received := []types.Event{}
+			for event := range *returnedChannel {
+				received = append(received, event)
+			}
+			Expect(received).To(HaveLen(4))
+
+			replayed := history.ReplaySince(since)
+			Expect(replayed).To(HaveLen(3))
+			for i, index := range []int{0, 2, 3} {
+				Expect(replayed[i].Notification.Result).To(MatchJSON(fmt.Sprintf(`{ "index": %d }`, index)))
+			}
+			Expect(replayed[1:]).To(Equal(received[2:]))
+
+			Expect(history.ReplaySince(received[3].ReceivedAt)).To(BeEmpty())
+		})
+	})
+	Context("when dropping notifications without a buffer", func() {
+		BeforeEach(func() {
+			server.Reset()
+			*options = []client.EventsOption{
+				client.WithEventsBufferSize(0),
+				client.WithEventsOverflowPolicy(client.EventsOverflowDropNewest),
+			}
+		})
+		It("should return the correct error", func() {
+			Expect(*returnedErr).To(MatchError(client.ErrInvalidEventsBufferSize))
 		})
 	})
 	Context("when the received notification is unexpected", func() {
@@ -196,6 +418,7 @@ var _ = Describe("events", func() {
 			Expect(event).To(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
 				"Error": Not(BeNil()),
 			}))
+			Expect(event.Error).To(MatchError(client.ErrEventsProtocol))
 		})
 	})
 
@@ -226,6 +449,14 @@ var _ = Describe("events", func() {
 			Expect(*returnedErr).ToNot(BeNil())
 		})
 	})
+	Context("when the websocket rejects the session", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, nil))
+		})
+		It("should return the correct error", func() {
+			Expect(*returnedErr).To(MatchError(client.ErrEventsAuthenticationFailed))
+		})
+	})
 	Context("when registering for notifications fails", func() {
 		BeforeEach(func() {
 			*events = []types.EventDescription{
@@ -289,8 +520,31 @@ var _ = Describe("events", func() {
 				}`))).To(BeNil())
 			})
 		})
-		It("should return an error", func() {
-			Expect(*returnedErr).ToNot(BeNil())
+		It("should return the correct error", func() {
+			Expect(*returnedErr).To(MatchError(client.ErrEventsProtocol))
+		})
+	})
+	Context("when registering for notifications requires authentication", func() {
+		BeforeEach(func() {
+			*events = []types.EventDescription{
+				{
+					Source: "foo",
+					Name:   "bar",
+				},
+			}
+			server.AppendHandlers(wsHandler(func(ws *websocket.Conn) {
+				_, _, err := ws.ReadMessage()
+				Expect(err).To(BeNil())
+				Expect(ws.WriteMessage(websocket.TextMessage, []byte(`{
+					"action": "register",
+					"success": false,
+					"error_code": "auth_required",
+					"msg": "Invalid session token, or no session token sent"
+				}`))).To(BeNil())
+			}))
+		})
+		It("should return the correct error", func() {
+			Expect(*returnedErr).To(MatchError(client.ErrEventsAuthenticationFailed))
 		})
 	})
 })
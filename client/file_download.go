@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+const downloadBufferSize = 32 * 1024
+
+// DownloadOption configures a call to DownloadFile.
+type DownloadOption func(*downloadOptions)
+
+type downloadOptions struct {
+	progress         func(types.TransferProgress)
+	progressInterval time.Duration
+	httpOptions      []HTTPOption
+}
+
+// WithDownloadProgress registers a callback called at most once per interval while the file is downloaded,
+// and a last time once the download is over.
+func WithDownloadProgress(interval time.Duration, callback func(types.TransferProgress)) DownloadOption {
+	return func(options *downloadOptions) {
+		options.progress = callback
+		options.progressInterval = interval
+	}
+}
+
+// WithDownloadHTTPOptions forwards options such as WithRange to the download request.
+func WithDownloadHTTPOptions(httpOptions ...HTTPOption) DownloadOption {
+	return func(options *downloadOptions) {
+		options.httpOptions = append(options.httpOptions, httpOptions...)
+	}
+}
+
+// DownloadFile streams a file into the given writer and returns the number of bytes written.
+func (c *client) DownloadFile(ctx context.Context, path string, writer io.Writer, options ...DownloadOption) (written int64, err error) {
+	settings := &downloadOptions{}
+	for _, option := range options {
+		option(settings)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/dl/%s", c.base, base64.StdEncoding.EncodeToString([]byte(path))), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to forge new request: %w", err)
+	}
+
+	httpResponse, err := c.openDownload(request, append([]HTTPOption{c.withSession(ctx)}, settings.httpOptions...)...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		closeError := httpResponse.Body.Close()
+		if err == nil {
+			err = closeError
+		} else if closeError != nil {
+			err = fmt.Errorf("%s: %w", closeError.Error(), err)
+		}
+	}()
+
+	report := func() {
+		if settings.progress != nil {
+			settings.progress(types.TransferProgress{
+				TransferredBytes: written,
+				TotalBytes:       httpResponse.ContentLength,
+			})
+		}
+	}
+
+	buffer := make([]byte, downloadBufferSize)
+	lastReport := time.Now()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return written, fmt.Errorf("download interrupted: %w", err)
+		}
+
+		read, readErr := httpResponse.Body.Read(buffer)
+		if read > 0 {
+			n, err := writer.Write(buffer[:read])
+			written += int64(n)
+
+			if err != nil {
+				return written, fmt.Errorf("failed to write downloaded content: %w", err)
+			}
+
+			if n != read {
+				return written, io.ErrShortWrite
+			}
+		}
+
+		if readErr != nil {
+			if errors.Is(readErr, io.EOF) {
+				break
+			}
+
+			return written, fmt.Errorf("failed to read response body: %w", readErr)
+		}
+
+		if time.Since(lastReport) >= settings.progressInterval {
+			report()
+
+			lastReport = time.Now()
+		}
+	}
+
+	report()
+
+	return written, nil
+}
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// DownloadDirectory mirrors a remote directory tree into a local directory, creating local directories as needed.
+// Errors on individual files or subdirectories do not stop the other downloads and are all returned once the tree is
+// walked. Only the cancellation of the context stops the walk early.
+func (c *client) DownloadDirectory(ctx context.Context, remotePath, localPath string, options types.DownloadDirectoryOptions) error {
+	concurrency := options.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	filters, err := newPathFilters(options.Include, options.Exclude)
+	if err != nil {
+		return err
+	}
+
+	walker := &directoryDownloader{
+		client:    c,
+		options:   options,
+		filters:   filters,
+		root:      remotePath,
+		semaphore: make(chan struct{}, concurrency),
+	}
+
+	walkErr := walker.walk(ctx, remotePath, localPath)
+
+	walker.wg.Wait()
+
+	if walkErr != nil {
+		walker.errs = append(walker.errs, walkErr)
+	}
+
+	return errors.Join(walker.errs...)
+}
+
+type directoryDownloader struct {
+	client  *client
+	options types.DownloadDirectoryOptions
+	filters pathFilters
+	root    string
+
+	wg        sync.WaitGroup
+	lock      sync.Mutex
+	errs      []error
+	semaphore chan struct{}
+}
+
+func (d *directoryDownloader) walk(ctx context.Context, remoteDirectory, localDirectory string) error {
+	if err := os.MkdirAll(localDirectory, 0o755); err != nil { //nolint:gomnd
+		return fmt.Errorf("failed to create local directory %s: %w", localDirectory, err)
+	}
+
+	files, err := d.client.ListFiles(ctx, remoteDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to list remote directory %s: %w", remoteDirectory, err)
+	}
+
+	for _, file := range files {
+		if file.Name == "." || file.Name == ".." {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("download interrupted: %w", err)
+		}
+
+		remoteFile := path.Join(remoteDirectory, file.Name)
+		localFile := filepath.Join(localDirectory, file.Name)
+
+		relative := strings.TrimPrefix(remoteFile, strings.TrimSuffix(path.Clean(d.root), "/")+"/")
+
+		if file.Type == types.FileTypeDirectory {
+			if d.filters.excluded(relative) {
+				continue
+			}
+
+			if err := d.walk(ctx, remoteFile, localFile); err != nil {
+				if ctx.Err() != nil {
+					return err
+				}
+
+				d.fail(err)
+			}
+
+			continue
+		}
+
+		if !d.filters.included(relative) {
+			continue
+		}
+
+		d.semaphore <- struct{}{}
+
+		d.wg.Add(1)
+
+		go func() {
+			defer d.wg.Done()
+			defer func() { <-d.semaphore }()
+
+			if err := d.download(ctx, remoteFile, localFile); err != nil {
+				d.fail(fmt.Errorf("failed to download %s to %s: %w", remoteFile, localFile, err))
+			}
+		}()
+	}
+
+	return nil
+}
+
+// fail records an error which does not stop the walk, to be returned once the tree is walked.
+func (d *directoryDownloader) fail(err error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.errs = append(d.errs, err)
+}
+
+func (d *directoryDownloader) download(ctx context.Context, remoteFile, localFile string) (err error) {
+	file, err := os.Create(localFile)
+	if err != nil {
+		return fmt.Errorf("failed to create local file: %w", err)
+	}
+
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to close local file: %w", closeErr))
+		}
+	}()
+
+	var options []DownloadOption
+	if d.options.Progress != nil {
+		options = append(options, WithDownloadProgress(time.Second, func(progress types.TransferProgress) {
+			d.options.Progress(remoteFile, progress)
+		}))
+	}
+
+	if _, err := d.client.DownloadFile(ctx, remoteFile, file, options...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// pathFilters selects relative paths using include and exclude path.Match patterns.
+type pathFilters struct {
+	include, exclude []string
+}
+
+func newPathFilters(include, exclude []string) (pathFilters, error) {
+	for _, pattern := range append(append([]string{}, include...), exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return pathFilters{}, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	return pathFilters{include: include, exclude: exclude}, nil
+}
+
+func (f pathFilters) excluded(relative string) bool {
+	for _, pattern := range f.exclude {
+		if matched, _ := path.Match(pattern, relative); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f pathFilters) included(relative string) bool {
+	if f.excluded(relative) {
+		return false
+	}
+
+	if len(f.include) == 0 {
+		return true
+	}
+
+	for _, pattern := range f.include {
+		if matched, _ := path.Match(pattern, relative); matched {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,195 @@
+package client_test
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("directory download", func() {
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken string
+
+		localPath string
+		options   types.DownloadDirectoryOptions
+
+		returnedErr error
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		sessionToken = setupLoginFlow(server)
+
+		localPath = filepath.Join(GinkgoT().TempDir(), "dst")
+		options = types.DownloadDirectoryOptions{
+			Concurrency: 2,
+		}
+	})
+	JustBeforeEach(func(ctx SpecContext) {
+		returnedErr = freeboxClient.DownloadDirectory(ctx, "/src", localPath, options)
+	})
+	Context("default", func() {
+		BeforeEach(func() {
+			options.Exclude = []string{"*.log"}
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/fs/ls/L3NyYw==", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, `{
+					"success": true,
+					"result": [
+						{"type": "dir", "name": "."},
+						{"type": "dir", "name": ".."},
+						{"type": "file", "name": "a.txt"},
+						{"type": "file", "name": "skip.log"},
+						{"type": "dir", "name": "sub"}
+					]
+				}`),
+			))
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/fs/ls/L3NyYy9zdWI=", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, `{
+					"success": true,
+					"result": [
+						{"type": "file", "name": "b.txt"}
+					]
+				}`),
+			))
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/dl/L3NyYy9hLnR4dA==", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, `content-a`),
+			))
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/dl/L3NyYy9zdWIvYi50eHQ=", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, `content-b`),
+			))
+		})
+		It("should mirror the remote tree", func() {
+			Expect(returnedErr).To(BeNil())
+			Expect(os.ReadFile(filepath.Join(localPath, "a.txt"))).To(BeEquivalentTo("content-a"))
+			Expect(os.ReadFile(filepath.Join(localPath, "sub", "b.txt"))).To(BeEquivalentTo("content-b"))
+			Expect(filepath.Join(localPath, "skip.log")).ToNot(BeAnExistingFile())
+		})
+	})
+	Context("when include patterns are given", func() {
+		BeforeEach(func() {
+			options.Include = []string{"sub/*"}
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/fs/ls/L3NyYw==", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, `{
+					"success": true,
+					"result": [
+						{"type": "file", "name": "a.txt"},
+						{"type": "dir", "name": "sub"}
+					]
+				}`),
+			))
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/fs/ls/L3NyYy9zdWI=", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, `{
+					"success": true,
+					"result": [
+						{"type": "file", "name": "b.txt"}
+					]
+				}`),
+			))
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/dl/L3NyYy9zdWIvYi50eHQ=", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, `content-b`),
+			))
+		})
+		It("should only download the matching files", func() {
+			Expect(returnedErr).To(BeNil())
+			Expect(filepath.Join(localPath, "a.txt")).ToNot(BeAnExistingFile())
+			Expect(os.ReadFile(filepath.Join(localPath, "sub", "b.txt"))).To(BeEquivalentTo("content-b"))
+		})
+	})
+	Context("when a pattern is invalid", func() {
+		BeforeEach(func() {
+			options.Exclude = []string{"["}
+		})
+		It("should return an error", func() {
+			Expect(returnedErr).ToNot(BeNil())
+		})
+	})
+	Context("when a file download fails", func() {
+		BeforeEach(func() {
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/fs/ls/L3NyYw==", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, `{
+					"success": true,
+					"result": [
+						{"type": "file", "name": "a.txt"}
+					]
+				}`),
+			))
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/dl/L3NyYy9hLnR4dA==", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusNotFound, `not found`),
+			))
+		})
+		It("should return an error", func() {
+			Expect(returnedErr).ToNot(BeNil())
+		})
+	})
+	Context("when a subdirectory fails to be listed", func() {
+		BeforeEach(func() {
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/fs/ls/L3NyYw==", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, `{
+					"success": true,
+					"result": [
+						{"type": "dir", "name": "broken"},
+						{"type": "dir", "name": "ok"}
+					]
+				}`),
+			))
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/fs/ls/L3NyYy9icm9rZW4=", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, `{
+					"success": false,
+					"error_code": "access_denied"
+				}`),
+			))
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/fs/ls/L3NyYy9vaw==", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, `{
+					"success": true,
+					"result": [
+						{"type": "file", "name": "b.txt"}
+					]
+				}`),
+			))
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/dl/L3NyYy9vay9iLnR4dA==", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, `content-b`),
+			))
+		})
+		It("should download its siblings and return an error", func() {
+			Expect(returnedErr).To(MatchError(ContainSubstring("/src/broken")))
+			Expect(os.ReadFile(filepath.Join(localPath, "ok", "b.txt"))).To(BeEquivalentTo("content-b"))
+		})
+	})
+	Context("when server fails to respond", func() {
+		BeforeEach(func() {
+			server.Close()
+		})
+		It("should return an error", func() {
+			Expect(returnedErr).ToNot(BeNil())
+		})
+	})
+})
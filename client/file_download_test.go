@@ -0,0 +1,180 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+type cancelingWriter struct {
+	bytes.Buffer
+	cancel context.CancelFunc
+}
+
+func (w *cancelingWriter) Write(p []byte) (int, error) {
+	defer w.cancel()
+
+	return w.Buffer.Write(p)
+}
+
+var _ = Describe("file download", func() {
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	Context("downloading a file into a writer", func() {
+		const path = "path/to/file"
+
+		var (
+			ctx             context.Context
+			writer          io.Writer
+			buffer          *bytes.Buffer
+			options         = new([]client.DownloadOption)
+			returnedWritten = new(int64)
+		)
+		BeforeEach(func() {
+			ctx = context.Background()
+			buffer = new(bytes.Buffer)
+			writer = buffer
+			*options = nil
+		})
+		JustBeforeEach(func() {
+			*returnedWritten, *returnedErr = freeboxClient.DownloadFile(ctx, path, writer, *options...)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/dl/cGF0aC90by9maWxl", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `the-content`),
+					),
+				)
+			})
+			It("should write the file content", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedWritten).To(BeEquivalentTo(len("the-content")))
+				Expect(buffer.String()).To(Equal("the-content"))
+			})
+		})
+		Context("when a progress callback is registered", func() {
+			progress := new([]types.TransferProgress)
+			BeforeEach(func() {
+				*progress = nil
+				*options = []client.DownloadOption{
+					client.WithDownloadProgress(0, func(p types.TransferProgress) {
+						*progress = append(*progress, p)
+					}),
+				}
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/dl/cGF0aC90by9maWxl", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `the-content`, http.Header{
+							"Content-Length": []string{"11"},
+						}),
+					),
+				)
+			})
+			It("should report the progress", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*progress).ToNot(BeEmpty())
+				Expect((*progress)[len(*progress)-1]).To(Equal(types.TransferProgress{
+					TransferredBytes: 11,
+					TotalBytes:       11,
+				}))
+			})
+		})
+		Context("when http options are given", func() {
+			BeforeEach(func() {
+				*options = []client.DownloadOption{
+					client.WithDownloadHTTPOptions(client.WithRange(4, 0)),
+				}
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/dl/cGF0aC90by9maWxl", version)),
+						ghttp.VerifyHeaderKV("Range", "bytes=4-"),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusPartialContent, `content`),
+					),
+				)
+			})
+			It("should forward them to the request", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(buffer.String()).To(Equal("content"))
+			})
+		})
+		Context("when the context is canceled while downloading", func() {
+			BeforeEach(func() {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(context.Background(), time.Minute)
+				DeferCleanup(cancel)
+
+				writer = &cancelingWriter{cancel: cancel}
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/dl/cGF0aC90by9maWxl", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, strings.Repeat("a", 1024*1024)),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+				Expect(*returnedWritten).To(BeNumerically("<", 1024*1024))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the server returns an unexpected status", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/dl/cGF0aC90by9maWxl", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusNotFound, `not found`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+})
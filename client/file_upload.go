@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"io"
 	"net"
-	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -16,28 +15,10 @@ import (
 	"github.com/nikolalohinski/free-go/types"
 )
 
-const codeUploadTaskNotFound = "noent"
-
 func (c *client) FileUploadStart(ctx context.Context, input types.FileUploadStartActionInput) (io.WriteCloser, types.UploadRequestID, error) {
-	header := http.Header{}
-	if err := c.withSession(ctx)(&http.Request{
-		Header: header,
-	}); err != nil {
-		return nil, 0, fmt.Errorf("get a session: %w", err)
-	}
-
-	url := *c.base
-	url.Scheme = "ws"
-
-	if strings.ToLower(c.base.Scheme) == "https" {
-		url.Scheme = "wss"
-	}
-
-	url.Path = url.Path + "/ws/upload"
-
-	ws, dialResponse, err := websocket.DefaultDialer.DialContext(ctx, url.String(), header)
+	ws, _, err := c.dialWebSocket(ctx, "ws/upload")
 	if err != nil {
-		return nil, 0, fmt.Errorf("dialing websocket returned a status %s: %w", dialResponse.Status, err)
+		return nil, 0, err
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -86,16 +67,56 @@ func (c *client) FileUploadStart(ctx context.Context, input types.FileUploadStar
 		break
 	}
 
+	// when resuming, the freebox reports how much of the file was already uploaded
+	written := 0
+	if input.Force == types.FileUploadStartActionForceResume {
+		written = response.FileSize
+	}
+
 	// caller should close the writer
 	return &ChunkWriter{
 		Conn:      ws,
 		RequestID: requestID,
-		written:   0,
+		written:   written,
 		expected:  input.Size,
+		ctx:       ctx,
 		cancel:    cancel,
+		limiter:   c.transferLimiter,
 	}, requestID, nil
 }
 
+// ResumeFileUpload resumes an interrupted upload: the content already present on the freebox is
+// skipped by seeking the reader, and only the remaining bytes are sent.
+func (c *client) ResumeFileUpload(ctx context.Context, input types.FileUploadStartActionInput, reader io.ReadSeeker) (err error) {
+	input.Force = types.FileUploadStartActionForceResume
+
+	writer, _, err := c.FileUploadStart(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to start upload: %w", err)
+	}
+
+	chunkWriter, ok := writer.(*ChunkWriter)
+	if !ok {
+		return errors.Join(errors.New("unexpected upload writer"), writer.Close())
+	}
+
+	if _, err := reader.Seek(int64(chunkWriter.Written()), io.SeekStart); err != nil {
+		return errors.Join(fmt.Errorf("failed to seek to offset %d: %w", chunkWriter.Written(), err), chunkWriter.Cancel())
+	}
+
+	defer func() {
+		if closeErr := chunkWriter.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to close upload: %w", closeErr))
+		}
+	}()
+
+	if _, err := io.Copy(chunkWriter, reader); err != nil {
+		return fmt.Errorf("failed to write upload content: %w", err)
+	}
+
+	return nil
+}
+
 // ListUploadTasks returns a list of upload tasks.
 func (c *client) ListUploadTasks(ctx context.Context) ([]types.UploadTask, error) {
 	response, err := c.get(ctx, "upload/", c.withSession(ctx))
@@ -119,7 +140,7 @@ func (c *client) ListUploadTasks(ctx context.Context) ([]types.UploadTask, error
 func (c *client) GetUploadTask(ctx context.Context, identifier int64) (result types.UploadTask, err error) {
 	response, err := c.get(ctx, fmt.Sprintf("upload/%d", identifier), c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == codeTaskNotFound {
+		if errors.Is(err, ErrTaskNotFound) {
 			return result, ErrTaskNotFound
 		}
 
@@ -135,9 +156,9 @@ func (c *client) GetUploadTask(ctx context.Context, identifier int64) (result ty
 
 // CancelUploadTask cancels a upload task by its identifier.
 func (c *client) CancelUploadTask(ctx context.Context, identifier int64) error {
-	response, err := c.delete(ctx, fmt.Sprintf("upload/%d/cancel", identifier), nil, c.withSession(ctx))
+	_, err := c.delete(ctx, fmt.Sprintf("upload/%d/cancel", identifier), nil, c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == codeTaskNotFound {
+		if errors.Is(err, ErrTaskNotFound) {
 			return ErrTaskNotFound
 		}
 
@@ -149,9 +170,9 @@ func (c *client) CancelUploadTask(ctx context.Context, identifier int64) error {
 
 // DeleteUploadTask deletes a upload task by its identifier.
 func (c *client) DeleteUploadTask(ctx context.Context, identifier int64) error {
-	response, err := c.delete(ctx, fmt.Sprintf("upload/%d", identifier), c.withSession(ctx))
+	_, err := c.delete(ctx, fmt.Sprintf("upload/%d", identifier), c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == codeUploadTaskNotFound {
+		if errors.Is(err, ErrNotFound) {
 			return ErrTaskNotFound
 		}
 
@@ -178,7 +199,9 @@ func newRequestID() types.UploadRequestID {
 type ChunkWriter struct {
 	*websocket.Conn
 
-	cancel context.CancelFunc
+	ctx     context.Context //nolint:containedctx
+	cancel  context.CancelFunc
+	limiter *rateLimiter // optional limit of the upload rate
 
 	RequestID         types.UploadRequestID
 	lock              sync.Mutex
@@ -187,10 +210,39 @@ type ChunkWriter struct {
 
 var _ io.WriteCloser = (*ChunkWriter)(nil)
 
+// Write sends the data in binary frames of at most FileUploadChunkSize bytes, waiting for each frame to be acknowledged.
 func (w *ChunkWriter) Write(data []byte) (n int, err error) {
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
+	chunkSize := FileUploadChunkSize
+	if chunkSize < 1 {
+		chunkSize = len(data)
+	}
+
+	for len(data) > 0 {
+		chunk := data[:min(chunkSize, len(data))]
+
+		if w.limiter != nil {
+			if err := w.limiter.wait(w.ctx, len(chunk)); err != nil {
+				return n, err
+			}
+		}
+
+		written, err := w.writeChunk(chunk)
+		n += written
+
+		if err != nil {
+			return n, err
+		}
+
+		data = data[len(chunk):]
+	}
+
+	return n, nil
+}
+
+func (w *ChunkWriter) writeChunk(data []byte) (int, error) {
 	if err := w.Conn.WriteMessage(websocket.BinaryMessage, data); err != nil {
 		return 0, fmt.Errorf("write chunk: %w", err)
 	}
@@ -214,10 +266,35 @@ func (w *ChunkWriter) Write(data []byte) (n int, err error) {
 	return written, nil
 }
 
-func (w *ChunkWriter) Close() (finalErr error) {
+// Written returns the current length of the target file, including the content present before resuming.
+func (w *ChunkWriter) Written() int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.written
+}
+
+// Close finalizes the upload if every expected byte was written, and cancels it otherwise.
+func (w *ChunkWriter) Close() error {
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
+	if w.written == w.expected {
+		return w.end(types.FileUploadStartActionNameUploadFinalize)
+	}
+
+	return w.end(types.FileUploadStartActionNameUploadCancel)
+}
+
+// Cancel aborts the upload regardless of how much was written, and closes the connection.
+func (w *ChunkWriter) Cancel() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.end(types.FileUploadStartActionNameUploadCancel)
+}
+
+func (w *ChunkWriter) end(action types.WebSocketAction) (finalErr error) {
 	ctx := context.Background()
 
 	defer func(ctx context.Context, conn *websocket.Conn) {
@@ -238,8 +315,8 @@ func (w *ChunkWriter) Close() (finalErr error) {
 		finalErr = errors.Join(errs...)
 	}(ctx, w.Conn)
 
-	switch w.written {
-	case w.expected:
+	switch action {
+	case types.FileUploadStartActionNameUploadFinalize:
 		if err := w.Conn.WriteJSON(&types.FileUploadFinalize{
 			Action:    types.FileUploadStartActionNameUploadFinalize,
 			RequestID: w.RequestID,
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// UploadDirectory walks a local directory tree and uploads it under the remote path,
+// creating the remote directories as needed. Errors on individual files do not stop
+// the other uploads and are all returned once the walk is over.
+func (c *client) UploadDirectory(ctx context.Context, localPath, remotePath string, options types.UploadDirectoryOptions) error {
+	concurrency := options.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	if _, err := c.CreateDirectoryAll(ctx, remotePath); err != nil {
+		return fmt.Errorf("failed to create remote directory %s: %w", remotePath, err)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		lock      sync.Mutex
+		errs      []error
+		semaphore = make(chan struct{}, concurrency)
+	)
+
+	walkErr := filepath.WalkDir(localPath, func(localFile string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		relative, err := filepath.Rel(localPath, localFile)
+		if err != nil {
+			return fmt.Errorf("failed to compute relative path of %s: %w", localFile, err)
+		}
+
+		if relative == "." {
+			return nil
+		}
+
+		remoteFile := path.Join(remotePath, filepath.ToSlash(relative))
+
+		if entry.IsDir() {
+			if _, err := c.CreateDirectory(ctx, path.Dir(remoteFile), path.Base(remoteFile)); err != nil && !errors.Is(err, ErrDestinationConflict) {
+				return fmt.Errorf("failed to create remote directory %s: %w", remoteFile, err)
+			}
+
+			return nil
+		}
+
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		semaphore <- struct{}{}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			if err := c.uploadLocalFile(ctx, localFile, remoteFile, options); err != nil {
+				lock.Lock()
+				defer lock.Unlock()
+
+				errs = append(errs, fmt.Errorf("failed to upload %s to %s: %w", localFile, remoteFile, err))
+			}
+		}()
+
+		return nil
+	})
+
+	wg.Wait()
+
+	if walkErr != nil {
+		errs = append(errs, fmt.Errorf("failed to walk %s: %w", localPath, walkErr))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *client) uploadLocalFile(ctx context.Context, localFile, remoteFile string, options types.UploadDirectoryOptions) (err error) {
+	file, err := os.Open(localFile)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	var progress func(types.TransferProgress)
+	if options.Progress != nil {
+		progress = func(p types.TransferProgress) {
+			options.Progress(remoteFile, p)
+		}
+	}
+
+	return c.upload(ctx, file, types.FileUploadStartActionInput{
+		Size:     int(stat.Size()),
+		Dirname:  types.Base64Path(path.Dir(remoteFile)),
+		Filename: path.Base(remoteFile),
+		Force:    options.Force,
+	}, progress)
+}
+
+// upload streams the reader into a new upload and finalizes it.
+func (c *client) upload(ctx context.Context, reader io.Reader, input types.FileUploadStartActionInput, progress func(types.TransferProgress)) (err error) {
+	writer, _, err := c.FileUploadStart(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to start upload: %w", err)
+	}
+
+	defer func() {
+		if closeErr := writer.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to close upload: %w", closeErr))
+		}
+	}()
+
+	var destination io.Writer = writer
+	if progress != nil {
+		destination = TrackFileUpload(c, writer, input, func(p types.UploadProgress) {
+			progress(types.TransferProgress{
+				TransferredBytes: p.SentBytes,
+				TotalBytes:       p.TotalBytes,
+			})
+		})
+	}
+
+	if _, err := io.Copy(destination, reader); err != nil {
+		return fmt.Errorf("failed to write upload content: %w", err)
+	}
+
+	return nil
+}
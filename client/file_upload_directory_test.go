@@ -0,0 +1,215 @@
+package client_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// fakeUploadHandler emulates a successful websocket upload and records the uploaded content by remote path.
+func fakeUploadHandler(lock *sync.Mutex, uploads map[string]string) http.HandlerFunc {
+	return wsHandler(func(ws *websocket.Conn) {
+		var start types.FileUploadStartAction
+		Expect(readJSON(ws, &start)).To(Succeed())
+		Expect(writeJSON(ws, &types.FileUploadStartResponse{
+			Success:   true,
+			Action:    types.FileUploadStartActionNameUploadStart,
+			RequestID: start.RequestID,
+		})).To(Succeed())
+
+		content := []byte{}
+
+		for {
+			messageType, data, err := ws.ReadMessage()
+			Expect(err).ToNot(HaveOccurred())
+
+			if messageType == websocket.BinaryMessage {
+				content = append(content, data...)
+				Expect(writeJSON(ws, &types.WebSocketResponse[types.FileUploadChunkResponse]{
+					Success:   true,
+					Action:    types.FileUploadStartActionNameUploadData,
+					RequestID: start.RequestID,
+					Result: types.FileUploadChunkResponse{
+						TotalLen: len(content),
+					},
+				})).To(Succeed())
+
+				continue
+			}
+
+			var finalize types.FileUploadFinalize
+			Expect(json.Unmarshal(data, &finalize)).To(Succeed())
+			Expect(finalize.Action).To(Equal(types.FileUploadStartActionNameUploadFinalize))
+
+			lock.Lock()
+			uploads[string(start.Dirname)+"/"+start.Filename] = string(content)
+			lock.Unlock()
+
+			Expect(writeJSON(ws, &types.WebSocketResponse[types.FileUploadFinalizeResponse]{
+				Success:   true,
+				Action:    types.FileUploadStartActionNameUploadFinalize,
+				RequestID: start.RequestID,
+				Result: types.FileUploadFinalizeResponse{
+					TotalLen: len(content),
+					Complete: true,
+				},
+			})).To(Succeed())
+
+			break
+		}
+
+		_, _, err := ws.ReadMessage()
+		Expect(websocket.IsCloseError(err, websocket.CloseNormalClosure)).To(BeTrue())
+	})
+}
+
+// fakeMkdirHandler accepts every directory creation and records the created paths.
+func fakeMkdirHandler(lock *sync.Mutex, directories *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Parent  types.Base64Path `json:"parent"`
+			Dirname string           `json:"dirname"`
+		}
+		Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+
+		lock.Lock()
+		*directories = append(*directories, filepath.ToSlash(filepath.Join(string(payload.Parent), payload.Dirname)))
+		lock.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success": true, "result": "Lw=="}`))
+	}
+}
+
+var _ = Describe("directory upload", func() {
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken string
+
+		localPath string
+		options   types.UploadDirectoryOptions
+
+		lock        *sync.Mutex
+		uploads     map[string]string
+		directories *[]string
+
+		returnedErr error
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		sessionToken = setupLoginFlow(server)
+
+		localPath = GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(localPath, "a.txt"), []byte("content-a"), 0o600)).To(Succeed())
+		Expect(os.Mkdir(filepath.Join(localPath, "sub"), 0o700)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(localPath, "sub", "b.txt"), []byte("content-b"), 0o600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(localPath, "sub", "empty.txt"), []byte{}, 0o600)).To(Succeed())
+
+		options = types.UploadDirectoryOptions{
+			Concurrency: 2,
+			Force:       types.FileUploadStartActionForceOverwrite,
+		}
+
+		lock = new(sync.Mutex)
+		uploads = map[string]string{}
+		directories = new([]string)
+	})
+	JustBeforeEach(func(ctx SpecContext) {
+		returnedErr = freeboxClient.UploadDirectory(ctx, localPath, "/dest", options)
+	})
+	Context("default", func() {
+		progress := map[string]types.TransferProgress{}
+		BeforeEach(func() {
+			server.RouteToHandler(http.MethodPost, fmt.Sprintf("/api/%s/fs/mkdir/", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				fakeMkdirHandler(lock, directories),
+			))
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/ws/upload", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				fakeUploadHandler(lock, uploads),
+			))
+			options.Progress = func(remotePath string, p types.TransferProgress) {
+				lock.Lock()
+				defer lock.Unlock()
+
+				progress[remotePath] = p
+			}
+		})
+		It("should create the remote directories and upload every file", func() {
+			Expect(returnedErr).To(BeNil())
+			Expect(*directories).To(ConsistOf("/dest", "/dest/sub"))
+			Expect(uploads).To(Equal(map[string]string{
+				"/dest/a.txt":         "content-a",
+				"/dest/sub/b.txt":     "content-b",
+				"/dest/sub/empty.txt": "",
+			}))
+			Expect(progress).To(HaveKeyWithValue("/dest/a.txt", types.TransferProgress{
+				TransferredBytes: 9,
+				TotalBytes:       9,
+			}))
+		})
+	})
+	Context("when the remote directory can not be created", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/mkdir/", version)),
+					verifyAuth(sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": false,
+						"error_code": "internal_error"
+					}`),
+				),
+			)
+		})
+		It("should return an error", func() {
+			Expect(returnedErr).ToNot(BeNil())
+		})
+	})
+	Context("when an upload fails", func() {
+		BeforeEach(func() {
+			server.RouteToHandler(http.MethodPost, fmt.Sprintf("/api/%s/fs/mkdir/", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				fakeMkdirHandler(lock, directories),
+			))
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/ws/upload", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusForbidden, ``),
+			))
+		})
+		It("should return an error", func() {
+			Expect(returnedErr).ToNot(BeNil())
+		})
+	})
+	Context("when the local directory does not exist", func() {
+		BeforeEach(func() {
+			localPath = filepath.Join(localPath, "missing")
+			server.RouteToHandler(http.MethodPost, fmt.Sprintf("/api/%s/fs/mkdir/", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				fakeMkdirHandler(lock, directories),
+			))
+		})
+		It("should return an error", func() {
+			Expect(returnedErr).ToNot(BeNil())
+		})
+	})
+})
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// UploadFiles uploads several files with at most concurrency uploads in parallel. The results are in the
+// same order as the specs, and the upload tasks of the failed uploads are deleted from the freebox.
+// Errors on individual files do not stop the other uploads and are all returned once every file is processed.
+func (c *client) UploadFiles(ctx context.Context, specs []types.UploadSpec, concurrency int) ([]types.UploadResult, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		wg        sync.WaitGroup
+		results   = make([]types.UploadResult, len(specs))
+		semaphore = make(chan struct{}, concurrency)
+	)
+
+	for index, spec := range specs {
+		results[index].Path = path.Join(spec.Dirname, spec.Filename)
+
+		if err := ctx.Err(); err != nil {
+			results[index].Error = err
+
+			continue
+		}
+
+		semaphore <- struct{}{}
+
+		wg.Add(1)
+
+		go func(index int, spec types.UploadSpec) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			results[index].Error = c.uploadSpec(ctx, spec)
+		}(index, spec)
+	}
+
+	wg.Wait()
+
+	errs := make([]error, 0)
+
+	for _, result := range results {
+		if result.Error != nil {
+			errs = append(errs, fmt.Errorf("failed to upload %s: %w", result.Path, result.Error))
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+func (c *client) uploadSpec(ctx context.Context, spec types.UploadSpec) error {
+	input := types.FileUploadStartActionInput{
+		Size:     int(spec.Size),
+		Dirname:  types.Base64Path(spec.Dirname),
+		Filename: spec.Filename,
+		Force:    spec.Force,
+	}
+
+	uploadErr := c.upload(ctx, spec.Reader, input, nil)
+	if uploadErr == nil {
+		return nil
+	}
+
+	// the cleanup must happen even if the upload failed because the context is done
+	cleanupCtx := context.WithoutCancel(ctx)
+
+	task, err := findUploadTask(cleanupCtx, c, input.Dirname, input.Filename)
+	if err != nil {
+		if errors.Is(err, ErrTaskNotFound) {
+			return uploadErr
+		}
+
+		return errors.Join(uploadErr, fmt.Errorf("failed to find upload task: %w", err))
+	}
+
+	if err := c.DeleteUploadTask(cleanupCtx, task.ID); err != nil && !errors.Is(err, ErrTaskNotFound) {
+		return errors.Join(uploadErr, fmt.Errorf("failed to delete upload task %d: %w", task.ID, err))
+	}
+
+	return uploadErr
+}
+
+// UploadFile uploads the content of the reader as name in the remote directory, then checks the
+// upload task reports the upload as done. It returns the remote path of the created file.
+func (c *client) UploadFile(ctx context.Context, reader io.Reader, size int64, destDir, name string) (string, error) {
+	input := types.FileUploadStartActionInput{
+		Size:     int(size),
+		Dirname:  types.Base64Path(destDir),
+		Filename: name,
+	}
+
+	if err := c.upload(ctx, reader, input, nil); err != nil {
+		return "", err
+	}
+
+	task, err := findUploadTask(ctx, c, input.Dirname, input.Filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to verify upload task: %w", err)
+	}
+
+	if task.Status != types.UploadTaskStatusDone {
+		return "", fmt.Errorf("%w: upload task %d is %s", ErrUploadNotDone, task.ID, task.Status)
+	}
+
+	return path.Join(destDir, name), nil
+}
+
+// UploadFileFromPath uploads a local file in the remote directory under the same name, and returns its remote path.
+func (c *client) UploadFileFromPath(ctx context.Context, localPath, destDir string) (string, error) {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return "", fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	return c.UploadFile(ctx, file, stat.Size(), destDir, filepath.Base(localPath))
+}
@@ -0,0 +1,282 @@
+package client_test
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("UploadFiles", func() {
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken string
+
+		specs       []types.UploadSpec
+		concurrency int
+
+		lock    *sync.Mutex
+		uploads map[string]string
+
+		returnedResults []types.UploadResult
+		returnedErr     error
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		sessionToken = setupLoginFlow(server)
+
+		specs = []types.UploadSpec{
+			{Reader: strings.NewReader("first"), Size: 5, Dirname: "/backup", Filename: "a.txt"},
+			{Reader: strings.NewReader("second"), Size: 6, Dirname: "/backup", Filename: "b.txt"},
+			{Reader: strings.NewReader("third"), Size: 5, Dirname: "/backup/nested", Filename: "c.txt"},
+		}
+		concurrency = 2
+
+		lock = new(sync.Mutex)
+		uploads = make(map[string]string)
+	})
+	JustBeforeEach(func(ctx SpecContext) {
+		returnedResults, returnedErr = freeboxClient.UploadFiles(ctx, specs, concurrency)
+	})
+	Context("default", func() {
+		BeforeEach(func(ctx SpecContext) {
+			// log in beforehand so that the concurrent uploads share the same session
+			Expect(freeboxClient.Login(ctx)).ToNot(BeNil())
+
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/ws/upload", version), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				fakeUploadHandler(lock, uploads),
+			))
+		})
+		It("should upload every file", func() {
+			Expect(returnedErr).To(BeNil())
+			Expect(returnedResults).To(Equal([]types.UploadResult{
+				{Path: "/backup/a.txt"},
+				{Path: "/backup/b.txt"},
+				{Path: "/backup/nested/c.txt"},
+			}))
+			Expect(uploads).To(Equal(map[string]string{
+				"/backup/a.txt":        "first",
+				"/backup/b.txt":        "second",
+				"/backup/nested/c.txt": "third",
+			}))
+		})
+	})
+	Context("when an upload fails", func() {
+		BeforeEach(func() {
+			concurrency = 1
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					verifyAuth(sessionToken),
+					fakeUploadHandler(lock, uploads),
+				),
+				ghttp.CombineHandlers(
+					verifyAuth(sessionToken),
+					wsHandler(func(ws *websocket.Conn) {
+						var start types.FileUploadStartAction
+						Expect(readJSON(ws, &start)).To(Succeed())
+						Expect(start.Filename).To(Equal("b.txt"))
+
+						Expect(writeJSON(ws, &types.FileUploadStartResponse{
+							Success:   false,
+							Action:    types.FileUploadStartActionNameUploadStart,
+							RequestID: start.RequestID,
+							ErrorCode: "conflict",
+							Message:   "Le fichier existe déjà",
+						})).To(Succeed())
+					}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/upload/", version)),
+					verifyAuth(sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": true,
+						"result": [
+							{ "id": 7, "upload_name": "b.txt", "dirname": "/backup", "status": "conflict", "start_date": 1700000000 }
+						]
+					}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/upload/7", version)),
+					verifyAuth(sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+				),
+				ghttp.CombineHandlers(
+					verifyAuth(sessionToken),
+					fakeUploadHandler(lock, uploads),
+				),
+			)
+		})
+		It("should report the failure, clean its upload task and upload the other files", func() {
+			Expect(returnedErr).To(MatchError(ContainSubstring("failed to upload /backup/b.txt")))
+			Expect(returnedResults).To(HaveLen(3))
+			Expect(returnedResults[0]).To(Equal(types.UploadResult{Path: "/backup/a.txt"}))
+			Expect(returnedResults[1].Path).To(Equal("/backup/b.txt"))
+			Expect(returnedResults[1].Error).To(MatchError(&types.WebSocketResponseError{
+				ErrorCode: "conflict",
+				Message:   "Le fichier existe déjà",
+			}))
+			Expect(returnedResults[2]).To(Equal(types.UploadResult{Path: "/backup/nested/c.txt"}))
+			Expect(uploads).To(Equal(map[string]string{
+				"/backup/a.txt":        "first",
+				"/backup/nested/c.txt": "third",
+			}))
+		})
+	})
+	Context("when server fails to respond", func() {
+		BeforeEach(func() {
+			server.Close()
+		})
+		It("should return an error for every file", func() {
+			Expect(returnedErr).To(HaveOccurred())
+			Expect(returnedResults).To(HaveLen(len(specs)))
+			for _, result := range returnedResults {
+				Expect(result.Error).To(HaveOccurred())
+			}
+		})
+	})
+})
+
+var _ = Describe("UploadFile", func() {
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken string
+
+		lock    *sync.Mutex
+		uploads map[string]string
+
+		localPath string
+
+		returnedPath string
+		returnedErr  error
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		sessionToken = setupLoginFlow(server)
+
+		lock = new(sync.Mutex)
+		uploads = make(map[string]string)
+
+		localPath = filepath.Join(GinkgoT().TempDir(), "report.txt")
+		Expect(os.WriteFile(localPath, []byte("content"), 0o600)).To(Succeed())
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/ws/upload", version)),
+			verifyAuth(sessionToken),
+			fakeUploadHandler(lock, uploads),
+		))
+	})
+	Context("from a reader", func() {
+		JustBeforeEach(func(ctx SpecContext) {
+			returnedPath, returnedErr = freeboxClient.UploadFile(ctx, strings.NewReader("content"), 7, "/backup", "report.txt")
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/upload/", version)),
+					verifyAuth(sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": true,
+						"result": [
+							{ "id": 3, "upload_name": "report.txt", "dirname": "/backup", "status": "done", "size": 7, "uploaded": 7, "start_date": 1700000000 }
+						]
+					}`),
+				))
+			})
+			It("should upload the file and return its remote path", func() {
+				Expect(returnedErr).To(BeNil())
+				Expect(returnedPath).To(Equal("/backup/report.txt"))
+				Expect(uploads).To(HaveKeyWithValue("/backup/report.txt", "content"))
+			})
+		})
+		Context("when the upload task is not done", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/upload/", version)),
+					verifyAuth(sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": true,
+						"result": [
+							{ "id": 3, "upload_name": "report.txt", "dirname": "/backup", "status": "failed", "start_date": 1700000000 }
+						]
+					}`),
+				))
+			})
+			It("should return the correct error", func() {
+				Expect(returnedErr).To(MatchError(client.ErrUploadNotDone))
+				Expect(returnedPath).To(BeEmpty())
+			})
+		})
+		Context("when the upload task is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/upload/", version)),
+					verifyAuth(sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": [] }`),
+				))
+			})
+			It("should return the correct error", func() {
+				Expect(returnedErr).To(MatchError(client.ErrTaskNotFound))
+			})
+		})
+	})
+	Context("from a local path", func() {
+		JustBeforeEach(func(ctx SpecContext) {
+			returnedPath, returnedErr = freeboxClient.UploadFileFromPath(ctx, localPath, "/backup")
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/upload/", version)),
+					verifyAuth(sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": true,
+						"result": [
+							{ "id": 3, "upload_name": "report.txt", "dirname": "/backup", "status": "done", "start_date": 1700000000 }
+						]
+					}`),
+				))
+			})
+			It("should upload the file under the same name", func() {
+				Expect(returnedErr).To(BeNil())
+				Expect(returnedPath).To(Equal("/backup/report.txt"))
+				Expect(uploads).To(HaveKeyWithValue("/backup/report.txt", "content"))
+			})
+		})
+		Context("when the local file does not exist", func() {
+			BeforeEach(func() {
+				localPath = filepath.Join(filepath.Dir(localPath), "missing.txt")
+			})
+			It("should return an error", func() {
+				Expect(returnedErr).To(MatchError(os.ErrNotExist))
+			})
+		})
+	})
+})
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// UploadProgressWriter wraps the writer returned by FileUploadStart to report the progress of the upload
+// and give access to the associated upload task.
+type UploadProgressWriter struct {
+	io.WriteCloser
+
+	client   Client
+	input    types.FileUploadStartActionInput
+	callback func(types.UploadProgress)
+
+	lock      sync.Mutex
+	sent      int64
+	lastWrite time.Time
+	progress  types.UploadProgress
+}
+
+var _ io.WriteCloser = (*UploadProgressWriter)(nil)
+
+// TrackFileUpload wraps a writer returned by the FileUploadStart method of freebox for the given input. The optional
+// callback is called after each write with the bytes sent, the instantaneous rate and the estimated time left.
+func TrackFileUpload(freebox Client, writer io.WriteCloser, input types.FileUploadStartActionInput, callback func(types.UploadProgress)) *UploadProgressWriter {
+	return &UploadProgressWriter{
+		WriteCloser: writer,
+		client:      freebox,
+		input:       input,
+		callback:    callback,
+		lastWrite:   time.Now(),
+		progress: types.UploadProgress{
+			TotalBytes: int64(input.Size),
+		},
+	}
+}
+
+func (w *UploadProgressWriter) Write(data []byte) (int, error) {
+	n, err := w.WriteCloser.Write(data)
+
+	w.lock.Lock()
+
+	now := time.Now()
+	elapsed := now.Sub(w.lastWrite)
+	w.lastWrite = now
+	w.sent += int64(n)
+
+	w.progress.SentBytes = w.sent
+	if elapsed > 0 {
+		w.progress.Rate = float64(n) / elapsed.Seconds()
+	}
+
+	w.progress.ETA = 0
+	if w.progress.Rate > 0 && w.progress.TotalBytes > w.sent {
+		w.progress.ETA = time.Duration(float64(w.progress.TotalBytes-w.sent) / w.progress.Rate * float64(time.Second))
+	}
+
+	progress := w.progress
+
+	w.lock.Unlock()
+
+	if w.callback != nil {
+		w.callback(progress)
+	}
+
+	return n, err //nolint:wrapcheck
+}
+
+// Progress returns the progress reported after the last write.
+func (w *UploadProgressWriter) Progress() types.UploadProgress {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.progress
+}
+
+// Task returns the upload task created by the freebox for this upload. The websocket protocol does not
+// expose its identifier, so the most recent task with the same destination is returned.
+func (w *UploadProgressWriter) Task(ctx context.Context) (types.UploadTask, error) {
+	return findUploadTask(ctx, w.client, w.input.Dirname, w.input.Filename)
+}
+
+// findUploadTask returns the most recent upload task targeting the given file.
+func findUploadTask(ctx context.Context, freebox Client, dirname types.Base64Path, filename string) (types.UploadTask, error) {
+	tasks, err := freebox.ListUploadTasks(ctx)
+	if err != nil {
+		return types.UploadTask{}, fmt.Errorf("failed to list upload tasks: %w", err)
+	}
+
+	encodedDirname := base64.StdEncoding.EncodeToString([]byte(dirname))
+
+	var (
+		result types.UploadTask
+		found  bool
+	)
+
+	for _, task := range tasks {
+		if task.UploadName != filename || (task.Dirname != string(dirname) && task.Dirname != encodedDirname) {
+			continue
+		}
+
+		if !found || task.StartDate.After(result.StartDate.Time) {
+			result = task
+			found = true
+		}
+	}
+
+	if !found {
+		return result, ErrTaskNotFound
+	}
+
+	return result, nil
+}
@@ -0,0 +1,149 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("upload progress", func() {
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken string
+
+		input   types.FileUploadStartActionInput
+		content string
+
+		lock    *sync.Mutex
+		uploads map[string]string
+
+		reported []types.UploadProgress
+		writer   *client.UploadProgressWriter
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		sessionToken = setupLoginFlow(server)
+
+		content = "some data"
+		input = types.FileUploadStartActionInput{
+			Dirname:  "/some/dir",
+			Filename: "file.txt",
+			Size:     len(content),
+		}
+
+		lock = new(sync.Mutex)
+		uploads = make(map[string]string)
+
+		reported = nil
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/ws/upload", version)),
+			verifyAuth(sessionToken),
+			fakeUploadHandler(lock, uploads),
+		))
+	})
+	JustBeforeEach(func() {
+		// the upload outlives this node, so it cannot be bound to the spec context
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		DeferCleanup(cancel)
+
+		uploadWriter, _, err := freeboxClient.FileUploadStart(ctx, input)
+		Expect(err).ToNot(HaveOccurred())
+
+		writer = client.TrackFileUpload(freeboxClient, uploadWriter, input, func(progress types.UploadProgress) {
+			reported = append(reported, progress)
+		})
+	})
+	Context("default", func() {
+		It("should report the progress after each write", func() {
+			Expect(writer.Write([]byte(content[:4]))).To(Equal(4))
+			Expect(writer.Write([]byte(content[4:]))).To(Equal(len(content) - 4))
+			Expect(writer.Close()).To(Succeed())
+
+			Expect(reported).To(HaveLen(2))
+			Expect(reported[0].SentBytes).To(BeEquivalentTo(4))
+			Expect(reported[0].TotalBytes).To(BeEquivalentTo(len(content)))
+			Expect(reported[0].Rate).To(BeNumerically(">", 0))
+			Expect(reported[0].ETA).To(BeNumerically(">", 0))
+			Expect(reported[1].SentBytes).To(BeEquivalentTo(len(content)))
+			Expect(reported[1].ETA).To(BeZero())
+			Expect(writer.Progress()).To(Equal(reported[1]))
+
+			Expect(uploads).To(HaveKeyWithValue("/some/dir/file.txt", content))
+		})
+	})
+	Context("when querying the upload task", func() {
+		var (
+			returnedTask types.UploadTask
+			returnedErr  error
+		)
+		JustBeforeEach(func(ctx SpecContext) {
+			Expect(writer.Write([]byte(content))).To(Equal(len(content)))
+			Expect(writer.Close()).To(Succeed())
+
+			returnedTask, returnedErr = writer.Task(ctx)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/upload/", version)),
+					verifyAuth(sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": true,
+						"result": [
+							{ "id": 1, "upload_name": "file.txt", "dirname": "/some/dir", "status": "done", "start_date": 1700000000 },
+							{ "id": 2, "upload_name": "other.txt", "dirname": "/some/dir", "status": "done", "start_date": 1700000200 },
+							{ "id": 3, "upload_name": "file.txt", "dirname": "/some/dir", "status": "done", "size": 9, "uploaded": 9, "start_date": 1700000100 }
+						]
+					}`),
+				))
+			})
+			It("should return the most recent task with the same destination", func() {
+				Expect(returnedErr).To(BeNil())
+				Expect(returnedTask.ID).To(BeEquivalentTo(3))
+				Expect(returnedTask.Uploaded).To(BeEquivalentTo(len(content)))
+			})
+		})
+		Context("when no task matches", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/upload/", version)),
+					verifyAuth(sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": [] }`),
+				))
+			})
+			It("should return the correct error", func() {
+				Expect(returnedErr).To(MatchError(client.ErrTaskNotFound))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/upload/", version)),
+					verifyAuth(sessionToken),
+					ghttp.RespondWith(http.StatusInternalServerError, nil),
+				))
+			})
+			It("should return an error", func() {
+				Expect(returnedErr).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -0,0 +1,120 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/onsi/gomega/gstruct"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("ResumeFileUpload", func() {
+	var (
+		freeboxClient client.Client
+		server        *ghttp.Server
+		sessionToken  string
+
+		content []byte
+
+		returnedErr error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		sessionToken = setupLoginFlow(server)
+
+		content = []byte("some data")
+	})
+
+	JustBeforeEach(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		DeferCleanup(cancel)
+
+		returnedErr = freeboxClient.ResumeFileUpload(ctx, types.FileUploadStartActionInput{
+			Dirname:  "dir",
+			Filename: "the-file",
+			Size:     len(content),
+		}, bytes.NewReader(content))
+	})
+
+	Context("default", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					verifyAuth(sessionToken),
+					wsHandler(func(ws *websocket.Conn) {
+						var result types.FileUploadStartAction
+						Expect(readJSON(ws, &result)).To(Succeed())
+						Expect(result).To(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+							"Action":   BeEquivalentTo(types.FileUploadStartActionNameUploadStart),
+							"Size":     BeEquivalentTo(len(content)),
+							"Dirname":  BeEquivalentTo("dir"),
+							"Filename": Equal("the-file"),
+							"Force":    BeEquivalentTo(types.FileUploadStartActionForceResume),
+						}))
+
+						Expect(writeJSON(ws, &types.FileUploadStartResponse{
+							Success:   true,
+							Action:    types.FileUploadStartActionNameUploadStart,
+							RequestID: result.RequestID,
+							FileSize:  5,
+						})).To(Succeed())
+
+						received, err := readChunk(ws)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(received)).To(Equal("data"))
+
+						Expect(writeJSON(ws, &types.WebSocketResponse[types.FileUploadChunkResponse]{
+							Success:   true,
+							Action:    types.FileUploadStartActionNameUploadData,
+							RequestID: result.RequestID,
+							Result: types.FileUploadChunkResponse{
+								TotalLen: len(content),
+							},
+						})).To(Succeed())
+
+						var finalize types.FileUploadFinalize
+						Expect(readJSON(ws, &finalize)).To(Succeed())
+						Expect(finalize.Action).To(BeEquivalentTo(types.FileUploadStartActionNameUploadFinalize))
+
+						Expect(writeJSON(ws, &types.WebSocketResponse[types.FileUploadFinalizeResponse]{
+							Success:   true,
+							Action:    types.FileUploadStartActionNameUploadFinalize,
+							RequestID: result.RequestID,
+							Result: types.FileUploadFinalizeResponse{
+								TotalLen: len(content),
+								Complete: true,
+							},
+						})).To(Succeed())
+					}),
+				),
+			)
+		})
+
+		It("should only send the content missing on the freebox", func() {
+			Expect(returnedErr).To(BeNil())
+		})
+	})
+	Context("when server fails to respond", func() {
+		BeforeEach(func() {
+			server.Close()
+		})
+
+		It("should return an error", func() {
+			Expect(returnedErr).To(HaveOccurred())
+		})
+	})
+})
@@ -490,6 +490,124 @@ var _ = Describe("FileUpload", func() {
 				Expect(returnedWriter.Close()).To(Succeed())
 			})
 		})
+		Context("with a chunk size smaller than the content", func() {
+			BeforeEach(func() {
+				chunkSize := client.FileUploadChunkSize
+				client.FileUploadChunkSize = 3
+				DeferCleanup(func() { client.FileUploadChunkSize = chunkSize })
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						verifyAuth(sessionToken),
+						wsHandler(func(ws *websocket.Conn) {
+							var result types.FileUploadStartAction
+							Expect(readJSON(ws, &result)).To(Succeed())
+
+							Expect(writeJSON(ws, &types.FileUploadStartResponse{
+								Success:   true,
+								Action:    types.FileUploadStartActionNameUploadStart,
+								RequestID: result.RequestID,
+							})).To(Succeed())
+
+							totalLen := 0
+							for _, expected := range []string{"dat", "a"} {
+								received, err := readChunk(ws)
+								Expect(err).ToNot(HaveOccurred())
+								Expect(string(received)).To(Equal(expected))
+
+								totalLen += len(received)
+								Expect(writeJSON(ws, &types.WebSocketResponse[types.FileUploadChunkResponse]{
+									Success:   true,
+									Action:    types.FileUploadStartActionNameUploadData,
+									RequestID: result.RequestID,
+									Result: types.FileUploadChunkResponse{
+										TotalLen: totalLen,
+									},
+								})).To(Succeed())
+							}
+
+							var finalize types.FileUploadFinalize
+							Expect(readJSON(ws, &finalize)).To(Succeed())
+							Expect(finalize.Action).To(BeEquivalentTo(types.FileUploadStartActionNameUploadFinalize))
+
+							Expect(writeJSON(ws, &types.WebSocketResponse[types.FileUploadFinalizeResponse]{
+								Success:   true,
+								Action:    types.FileUploadStartActionNameUploadFinalize,
+								RequestID: result.RequestID,
+								Result: types.FileUploadFinalizeResponse{
+									TotalLen: totalLen,
+									Complete: true,
+								},
+							})).To(Succeed())
+						}),
+					),
+				)
+			})
+
+			It("should send one acknowledged frame per chunk", func() {
+				Expect(returnedErr).To(BeNil())
+
+				Expect(returnedWriter.Write(content)).To(BeEquivalentTo(size))
+				Expect(returnedWriter.(*client.ChunkWriter).Written()).To(Equal(size))
+
+				Expect(returnedWriter.Close()).To(Succeed())
+			})
+		})
+		Context("when cancelled", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						verifyAuth(sessionToken),
+						wsHandler(func(ws *websocket.Conn) {
+							var result types.FileUploadStartAction
+							Expect(readJSON(ws, &result)).To(Succeed())
+
+							Expect(writeJSON(ws, &types.FileUploadStartResponse{
+								Success:   true,
+								Action:    types.FileUploadStartActionNameUploadStart,
+								RequestID: result.RequestID,
+							})).To(Succeed())
+
+							received, err := readChunk(ws)
+							Expect(err).ToNot(HaveOccurred())
+
+							Expect(writeJSON(ws, &types.WebSocketResponse[types.FileUploadChunkResponse]{
+								Success:   true,
+								Action:    types.FileUploadStartActionNameUploadData,
+								RequestID: result.RequestID,
+								Result: types.FileUploadChunkResponse{
+									TotalLen: len(received),
+								},
+							})).To(Succeed())
+
+							var cancel types.FileUploadCancelAction
+							Expect(readJSON(ws, &cancel)).To(Succeed())
+							Expect(cancel).To(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+								"Action":    BeEquivalentTo(types.FileUploadStartActionNameUploadCancel),
+								"RequestID": BeEquivalentTo(result.RequestID),
+							}))
+
+							Expect(writeJSON(ws, &types.WebSocketResponse[types.FileUploadCancelResponse]{
+								Success:   true,
+								Action:    types.FileUploadStartActionNameUploadCancel,
+								RequestID: result.RequestID,
+								Result: types.FileUploadCancelResponse{
+									Cancelled: true,
+								},
+							})).To(Succeed())
+						}),
+					),
+				)
+			})
+
+			It("should cancel the upload even if every byte was written", func() {
+				Expect(returnedErr).To(BeNil())
+
+				Expect(returnedWriter.Write(content)).To(BeEquivalentTo(size))
+
+				Expect(returnedWriter.(*client.ChunkWriter).Cancel()).To(Succeed())
+			})
+		})
 	})
 })
 
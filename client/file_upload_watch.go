@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// WatchUploadTask sends the progress of an upload task each time it changes, until the task is over. The task is only
+// fetched again when the freebox notifies a change of an upload task over the events websocket, so an error is
+// returned when the events are not available.
+//
+// The channel is closed once the task is over, the context is done, or right after a progress holding an error, such
+// as ErrUploadEventsStopped when the events stop before the task is over.
+func (c *client) WatchUploadTask(ctx context.Context, identifier int64) (<-chan types.UploadTaskProgress, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	// listening before getting the task, so that no change is missed in between
+	events, err := c.ListenEvents(ctx, []types.EventDescription{{
+		Source: types.EventSourceUpload,
+		Name:   types.EventUploadTaskChanged,
+	}}, WithEventsBufferSize(1), WithEventsOverflowPolicy(EventsOverflowDropOldest))
+	if err != nil {
+		cancel()
+
+		return nil, fmt.Errorf("failed to listen to upload events: %w", err)
+	}
+
+	task, err := c.GetUploadTask(ctx, identifier)
+	if err != nil {
+		cancel()
+
+		return nil, fmt.Errorf("failed to get upload task %d: %w", identifier, err)
+	}
+
+	channel := make(chan types.UploadTaskProgress, 1)
+
+	go func() {
+		defer close(channel)
+		defer cancel()
+
+		send := func(progress types.UploadTaskProgress) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case channel <- progress:
+				return true
+			}
+		}
+
+		last := uploadTaskProgress(task)
+		if !send(last) {
+			return
+		}
+
+		for !isUploadTaskOver(task) {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-events:
+				switch {
+				case !ok:
+					send(types.UploadTaskProgress{
+						ID:    identifier,
+						Error: fmt.Errorf("upload task %d: %w", identifier, ErrUploadEventsStopped),
+					})
+
+					return
+				case event.Error != nil:
+					send(types.UploadTaskProgress{
+						ID:    identifier,
+						Error: fmt.Errorf("upload task %d: %w: %s", identifier, ErrUploadEventsStopped, event.Error),
+					})
+
+					return
+				}
+			}
+
+			task, err = c.GetUploadTask(ctx, identifier)
+			if err != nil {
+				send(types.UploadTaskProgress{
+					ID:    identifier,
+					Error: fmt.Errorf("failed to get upload task %d: %w", identifier, err),
+				})
+
+				return
+			}
+
+			if progress := uploadTaskProgress(task); progress != last {
+				if !send(progress) {
+					return
+				}
+
+				last = progress
+			}
+		}
+	}()
+
+	return channel, nil
+}
+
+func uploadTaskProgress(task types.UploadTask) types.UploadTaskProgress {
+	return types.UploadTaskProgress{
+		ID:            task.ID,
+		Status:        task.Status,
+		UploadedBytes: task.Uploaded,
+		TotalBytes:    task.Size,
+	}
+}
+
+// isUploadTaskOver reports whether the task reached a status it never leaves.
+func isUploadTaskOver(task types.UploadTask) bool {
+	switch task.Status {
+	case types.UploadTaskStatusDone, types.UploadTaskStatusFailed, types.UploadTaskStatusConflict,
+		types.UploadTaskStatusTimeout, types.UploadTaskStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
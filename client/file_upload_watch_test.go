@@ -0,0 +1,216 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("watching an upload task", func() {
+	const identifier = int64(12)
+
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken = new(string)
+
+		ctx    context.Context
+		cancel context.CancelFunc
+
+		returnedChannel = new(<-chan types.UploadTaskProgress)
+		returnedErr     = new(error)
+
+		fetched chan struct{}
+	)
+	respondWithTask := func(status string, uploaded int64) http.HandlerFunc {
+		fetched := fetched
+
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/upload/%d", version, identifier)),
+			verifyAuth(*sessionToken),
+			ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+				"success": true,
+				"result": { "id": 12, "size": 100, "uploaded": %d, "status": %q, "upload_name": "file.iso", "dirname": "Freebox" }
+			}`, uploaded, status)),
+			func(http.ResponseWriter, *http.Request) {
+				fetched <- struct{}{}
+			},
+		)
+	}
+	registerUploadEvents := func(ws *websocket.Conn) {
+		_, message, err := ws.ReadMessage()
+		Expect(err).To(BeNil())
+		Expect(message).To(MatchJSON(`{"action": "register", "events": ["upload_task_changed"]}`))
+		Expect(ws.WriteMessage(websocket.TextMessage, []byte(`{
+			"action": "register",
+			"success": true
+		}`))).To(BeNil())
+	}
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+
+		notified := make(chan struct{}, 10)
+		fetched = notified
+
+		// the task changes once again each time it is fetched, until the client closes the events
+		server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/ws/event", version), wsHandler(func(ws *websocket.Conn) {
+			registerUploadEvents(ws)
+
+			closed := make(chan struct{})
+			go func() {
+				defer close(closed)
+
+				for {
+					if _, _, err := ws.ReadMessage(); err != nil {
+						return
+					}
+				}
+			}()
+
+			for {
+				select {
+				case <-closed:
+					return
+				case <-notified:
+					_ = ws.WriteMessage(websocket.TextMessage, []byte(`{
+						"action": "notification",
+						"success": true,
+						"source": "upload",
+						"event": "task_changed",
+						"result": {"id": 12}
+					}`))
+				}
+			}
+		}))
+
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+	})
+	JustBeforeEach(func() {
+		*returnedChannel, *returnedErr = freeboxClient.WatchUploadTask(ctx, identifier)
+	})
+	Context("default", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				respondWithTask("in_progress", 10),
+				respondWithTask("in_progress", 10),
+				respondWithTask("in_progress", 60),
+				respondWithTask("done", 100),
+			)
+		})
+		It("should send each distinct progress and close the channel", func() {
+			Expect(*returnedErr).To(BeNil())
+			Eventually(*returnedChannel).Should(Receive(Equal(types.UploadTaskProgress{
+				ID: identifier, Status: types.UploadTaskStatusInProgress, UploadedBytes: 10, TotalBytes: 100,
+			})))
+			Eventually(*returnedChannel).Should(Receive(Equal(types.UploadTaskProgress{
+				ID: identifier, Status: types.UploadTaskStatusInProgress, UploadedBytes: 60, TotalBytes: 100,
+			})))
+			Eventually(*returnedChannel).Should(Receive(Equal(types.UploadTaskProgress{
+				ID: identifier, Status: types.UploadTaskStatusDone, UploadedBytes: 100, TotalBytes: 100,
+			})))
+			Eventually(*returnedChannel).Should(BeClosed())
+		})
+	})
+	for _, status := range []types.UploadTaskStatus{
+		types.UploadTaskStatusFailed, types.UploadTaskStatusConflict, types.UploadTaskStatusTimeout, types.UploadTaskStatusCancelled,
+	} {
+		status := status
+		Context(fmt.Sprintf("when the task is already %s", status), func() {
+			BeforeEach(func() {
+				server.AppendHandlers(respondWithTask(string(status), 0))
+			})
+			It("should send the terminal status and close the channel", func() {
+				Expect(*returnedErr).To(BeNil())
+				Eventually(*returnedChannel).Should(Receive(HaveField("Status", status)))
+				Eventually(*returnedChannel).Should(BeClosed())
+			})
+		})
+	}
+	Context("when the task is not found", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/upload/%d", version, identifier)),
+					ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "task_not_found" }`),
+				),
+			)
+		})
+		It("should return the error", func() {
+			Expect(*returnedErr).To(MatchError(client.ErrTaskNotFound))
+			Expect(*returnedChannel).To(BeNil())
+		})
+	})
+	Context("when the task disappears while watching", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				respondWithTask("in_progress", 10),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/upload/%d", version, identifier)),
+					ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "task_not_found" }`),
+				),
+			)
+		})
+		It("should send the error and close the channel", func() {
+			Expect(*returnedErr).To(BeNil())
+			Eventually(*returnedChannel).Should(Receive(HaveField("Status", types.UploadTaskStatusInProgress)))
+			Eventually(*returnedChannel).Should(Receive(HaveField("Error", MatchError(client.ErrTaskNotFound))))
+			Eventually(*returnedChannel).Should(BeClosed())
+		})
+	})
+	Context("when the events are not available", func() {
+		BeforeEach(func() {
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/ws/event", version), ghttp.RespondWith(http.StatusNotFound, nil))
+		})
+		It("should return an error", func() {
+			Expect(*returnedErr).ToNot(BeNil())
+			Expect(*returnedChannel).To(BeNil())
+		})
+	})
+	Context("when the events stop before the task is over", func() {
+		BeforeEach(func() {
+			notified := fetched
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/ws/event", version), wsHandler(func(ws *websocket.Conn) {
+				registerUploadEvents(ws)
+
+				<-notified
+				Expect(ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))).To(Succeed())
+			}))
+			server.AppendHandlers(respondWithTask("in_progress", 10))
+		})
+		It("should send the error and close the channel", func() {
+			Expect(*returnedErr).To(BeNil())
+			Eventually(*returnedChannel).Should(Receive(HaveField("Status", types.UploadTaskStatusInProgress)))
+			Eventually(*returnedChannel).Should(Receive(HaveField("Error", MatchError(client.ErrUploadEventsStopped))))
+			Eventually(*returnedChannel).Should(BeClosed())
+		})
+	})
+	Context("when the context is canceled", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(respondWithTask("in_progress", 10))
+			server.SetAllowUnhandledRequests(true)
+		})
+		It("should close the channel", func() {
+			Expect(*returnedErr).To(BeNil())
+			Eventually(*returnedChannel).Should(Receive())
+			cancel()
+			Eventually(*returnedChannel).Should(BeClosed())
+		})
+	})
+})
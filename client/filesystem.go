@@ -9,22 +9,19 @@ import (
 	"io"
 	"mime"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/nikolalohinski/free-go/types"
 )
 
-const (
-	pathNotFoundCode        = "path_not_found"
-	destinationConflictCode = "destination_conflict"
-)
-
 func (c *client) GetFileInfo(ctx context.Context, path string) (types.FileInfo, error) {
 	base64Path := base64.StdEncoding.EncodeToString([]byte(path))
 
 	response, err := c.get(ctx, "fs/info/"+base64Path, c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == pathNotFoundCode {
+		if errors.Is(err, ErrPathNotFound) {
 			return types.FileInfo{}, ErrPathNotFound
 		}
 
@@ -41,6 +38,52 @@ func (c *client) GetFileInfo(ctx context.Context, path string) (types.FileInfo,
 	return result, nil
 }
 
+// StatFile returns the information of a file like GetFileInfo, along with whether it exists: a missing path is not an
+// error.
+func (c *client) StatFile(ctx context.Context, path string) (info types.FileInfo, exists bool, err error) {
+	info, err = c.GetFileInfo(ctx, path)
+	if errors.Is(err, ErrPathNotFound) {
+		return types.FileInfo{}, false, nil
+	}
+
+	if err != nil {
+		return types.FileInfo{}, false, err
+	}
+
+	return info, true, nil
+}
+
+// PathExists reports whether a file or a directory exists at path.
+func (c *client) PathExists(ctx context.Context, path string) (bool, error) {
+	_, exists, err := c.StatFile(ctx, path)
+
+	return exists, err
+}
+
+// ListFiles lists the content of a directory.
+func (c *client) ListFiles(ctx context.Context, path string) (result []types.FileInfo, err error) {
+	base64Path := base64.StdEncoding.EncodeToString([]byte(path))
+
+	response, err := c.get(ctx, "fs/ls/"+base64Path, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrPathNotFound) {
+			return nil, ErrPathNotFound
+		}
+
+		return nil, fmt.Errorf("failed to GET fs/ls/%s endpoint: %w", base64Path, err)
+	}
+
+	if response.Result == nil {
+		return
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to get a list of files from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
 func (c *client) RemoveFiles(ctx context.Context, paths []string) (task types.FileSystemTask, err error) {
 	files := make([]types.Base64Path, len(paths))
 	for i, p := range paths {
@@ -92,11 +135,9 @@ func (c *client) ListFileSystemTasks(ctx context.Context) (task []types.FileSyst
 func (c *client) GetFileSystemTask(ctx context.Context, identifier int64) (task types.FileSystemTask, err error) {
 	response, err := c.get(ctx, fmt.Sprintf("fs/tasks/%d", identifier), c.withSession(ctx))
 	if err != nil {
-		if response != nil {
-			// The invalid_id code is returned when the task ID is not found
-			if response.ErrorCode == codeTaskNotFound || response.ErrorCode == string(types.FileTaskErrorInvalidID) {
-				return task, ErrTaskNotFound
-			}
+		// The invalid_id code is returned when the task ID is not found
+		if errors.Is(err, ErrTaskNotFound) || errors.Is(err, &APIError{Code: string(types.FileTaskErrorInvalidID)}) {
+			return task, ErrTaskNotFound
 		}
 
 		return task, fmt.Errorf("failed to GET fs/tasks/%d endpoint: %w", identifier, err)
@@ -110,9 +151,9 @@ func (c *client) GetFileSystemTask(ctx context.Context, identifier int64) (task
 }
 
 func (c *client) DeleteFileSystemTask(ctx context.Context, identifier int64) error {
-	response, err := c.delete(ctx, fmt.Sprintf("fs/tasks/%d", identifier), c.withSession(ctx))
+	_, err := c.delete(ctx, fmt.Sprintf("fs/tasks/%d", identifier), c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == codeTaskNotFound {
+		if errors.Is(err, ErrTaskNotFound) {
 			return ErrTaskNotFound
 		}
 
@@ -122,8 +163,84 @@ func (c *client) DeleteFileSystemTask(ctx context.Context, identifier int64) err
 	return nil
 }
 
+// WatchFileSystemTask polls a filesystem task and sends its progress on the returned channel each time it changes.
+// The channel is closed once the task is done or failed, when the context is canceled, or after an error is sent.
+func (c *client) WatchFileSystemTask(ctx context.Context, identifier int64) (<-chan types.FileSystemTaskProgress, error) {
+	task, err := c.GetFileSystemTask(ctx, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get filesystem task %d: %w", identifier, err)
+	}
+
+	channel := make(chan types.FileSystemTaskProgress, 1)
+
+	go func() {
+		defer close(channel)
+
+		send := func(progress types.FileSystemTaskProgress) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case channel <- progress:
+				return true
+			}
+		}
+
+		last := fileSystemTaskProgress(task)
+		if !send(last) {
+			return
+		}
+
+		for !isFileSystemTaskOver(task) {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(FileSystemTaskPollInterval):
+			}
+
+			task, err = c.GetFileSystemTask(ctx, identifier)
+			if err != nil {
+				send(types.FileSystemTaskProgress{
+					ID:    identifier,
+					Error: fmt.Errorf("failed to get filesystem task %d: %w", identifier, err),
+				})
+
+				return
+			}
+
+			if progress := fileSystemTaskProgress(task); progress != last {
+				if !send(progress) {
+					return
+				}
+
+				last = progress
+			}
+		}
+	}()
+
+	return channel, nil
+}
+
+func fileSystemTaskProgress(task types.FileSystemTask) types.FileSystemTaskProgress {
+	return types.FileSystemTaskProgress{
+		ID:                     task.ID,
+		State:                  task.State,
+		TaskError:              task.Error,
+		ProgressPercent:        task.ProgressPercent,
+		ProcessingRate:         task.ProcessingRate,
+		EstimatedTimeRemaining: task.EstimatedTimeRemainingSeconds.Duration(),
+	}
+}
+
+func isFileSystemTaskOver(task types.FileSystemTask) bool {
+	return task.State == types.FileTaskStateDone || task.State == types.FileTaskStateFailed
+}
+
 // MoveFiles moves files from source to destination.
 func (c *client) MoveFiles(ctx context.Context, source []string, destination string, mode types.FileMoveMode) (result types.FileSystemTask, err error) {
+	if !mode.IsValid() {
+		return result, fmt.Errorf("%q: %w", mode, ErrInvalidConflictMode)
+	}
+
 	files := make([]types.Base64Path, len(source))
 	for i, p := range source {
 		files[i] = types.Base64Path(p)
@@ -135,7 +252,7 @@ func (c *client) MoveFiles(ctx context.Context, source []string, destination str
 		"mode":  mode,
 	}, c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == destinationConflictCode {
+		if errors.Is(err, ErrDestinationConflict) {
 			return result, ErrDestinationConflict
 		}
 
@@ -150,6 +267,10 @@ func (c *client) MoveFiles(ctx context.Context, source []string, destination str
 }
 
 func (c *client) CopyFiles(ctx context.Context, sources []string, destination string, mode types.FileCopyMode) (task types.FileSystemTask, err error) {
+	if !mode.IsValid() {
+		return task, fmt.Errorf("%q: %w", mode, ErrInvalidConflictMode)
+	}
+
 	files := make([]types.Base64Path, len(sources))
 	for i, p := range sources {
 		files[i] = types.Base64Path(p)
@@ -177,7 +298,7 @@ func (c *client) CreateDirectory(ctx context.Context, parent, name string) (stri
 		"dirname": name,
 	}, c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == destinationConflictCode {
+		if errors.Is(err, ErrDestinationConflict) {
 			return "", ErrDestinationConflict
 		}
 
@@ -192,6 +313,92 @@ func (c *client) CreateDirectory(ctx context.Context, parent, name string) (stri
 	return string(result), nil
 }
 
+// CreateDirectoryAll creates a directory along with any missing parent, like os.MkdirAll.
+// Already existing directories are left untouched.
+func (c *client) CreateDirectoryAll(ctx context.Context, path string) (string, error) {
+	parent := ""
+	if strings.HasPrefix(path, "/") {
+		parent = "/"
+	}
+
+	for _, name := range strings.Split(strings.Trim(path, "/"), "/") {
+		if name == "" {
+			continue
+		}
+
+		current := strings.TrimSuffix(parent, "/") + "/" + name
+		if parent == "" {
+			current = name
+		}
+
+		_, err := c.CreateDirectory(ctx, parent, name)
+		if err != nil {
+			if !errors.Is(err, ErrDestinationConflict) {
+				return "", fmt.Errorf("failed to create directory %s: %w", current, err)
+			}
+
+			info, err := c.GetFileInfo(ctx, current)
+			if err != nil {
+				return "", fmt.Errorf("failed to get info of existing path %s: %w", current, err)
+			}
+
+			if info.Type != types.FileTypeDirectory {
+				return "", fmt.Errorf("%s: %w", current, ErrNotADirectory)
+			}
+		}
+
+		parent = current
+	}
+
+	return parent, nil
+}
+
+// RenameFile renames a file or a directory in place.
+func (c *client) RenameFile(ctx context.Context, path, newName string) (result types.FileInfo, err error) {
+	response, err := c.post(ctx, "fs/rename/", map[string]interface{}{
+		"src": types.Base64Path(path),
+		"dst": newName,
+	}, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrPathNotFound) {
+			return result, ErrPathNotFound
+		}
+
+		if errors.Is(err, ErrDestinationConflict) {
+			return result, ErrDestinationConflict
+		}
+
+		return result, fmt.Errorf("failed to POST to fs/rename/ endpoint: %w", err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get file info from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetDiskUsage starts a task computing the disk usage of a directory tree.
+// Once done, the task TotalBytes and NumberFiles report the size and the file count of the tree.
+func (c *client) GetDiskUsage(ctx context.Context, path string) (task types.FileSystemTask, err error) {
+	response, err := c.post(ctx, "fs/du/", map[string]interface{}{
+		"src": types.Base64Path(path),
+	}, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrPathNotFound) {
+			return task, ErrPathNotFound
+		}
+
+		return task, fmt.Errorf("failed to POST to fs/du/ endpoint: %w", err)
+	}
+
+	if err = c.fromGenericResponse(response, &task); err != nil {
+		return task, fmt.Errorf("failed to get a filesystem task from a generic response: %w", err)
+	}
+
+	return task, nil
+}
+
 func (c *client) AddHashFileTask(ctx context.Context, payload types.HashPayload) (task types.FileSystemTask, err error) {
 	response, err := c.post(ctx, "fs/hash/", payload, c.withSession(ctx))
 	if err != nil {
@@ -218,33 +425,205 @@ func (c *client) GetHashResult(ctx context.Context, identifier int64) (result st
 	return result, nil
 }
 
-func (c *client) GetFile(ctx context.Context, path string) (result types.File, err error) {
+// ComputeFileHash hashes a file, waits for the hash task to complete and returns the digest.
+// The hash task is deleted once it is over, whether it succeeded or not.
+func (c *client) ComputeFileHash(ctx context.Context, path string, hashType types.HashType) (hash string, err error) {
+	task, err := c.AddHashFileTask(ctx, types.HashPayload{
+		HashType: hashType,
+		Path:     types.Base64Path(path),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		// the task is deleted even when the caller gave up waiting for it
+		if deleteErr := c.DeleteFileSystemTask(context.WithoutCancel(ctx), task.ID); deleteErr != nil && err == nil {
+			err = fmt.Errorf("failed to delete hash task %d: %w", task.ID, deleteErr)
+		}
+	}()
+
+	if err := c.waitForFileSystemTask(ctx, task.ID); err != nil {
+		return "", err
+	}
+
+	return c.GetHashResult(ctx, task.ID)
+}
+
+// waitForFileSystemTask blocks until a filesystem task is done, and returns an error if it failed.
+func (c *client) waitForFileSystemTask(ctx context.Context, identifier int64) error {
+	progress, err := c.WatchFileSystemTask(ctx, identifier)
+	if err != nil {
+		return err
+	}
+
+	for update := range progress {
+		if update.Error != nil {
+			return update.Error
+		}
+
+		switch update.State {
+		case types.FileTaskStateDone:
+			return nil
+		case types.FileTaskStateFailed:
+			return fmt.Errorf("task %d failed with error %q: %w", identifier, update.TaskError, ErrFileSystemTaskFailed)
+		}
+	}
+
+	return fmt.Errorf("stopped waiting for task %d: %w", identifier, ctx.Err())
+}
+
+// GetFile downloads a file. Options such as WithRange can be given to only fetch a part of it.
+func (c *client) GetFile(ctx context.Context, path string, options ...HTTPOption) (result types.File, err error) {
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/dl/%s", c.base, base64.StdEncoding.EncodeToString([]byte(path))), nil)
 	if err != nil {
 		return result, fmt.Errorf("failed to forge new request: %w", err)
 	}
 
-	if err := c.withSession(ctx)(request); err != nil {
-		return result, fmt.Errorf("failed to apply option to request: %w", err)
+	return c.download(request, append([]HTTPOption{c.withSession(ctx)}, options...)...)
+}
+
+// WithRange only requests length bytes of a file starting at offset.
+// A length lower or equal to zero requests everything from offset to the end of the file.
+func WithRange(offset, length int64) HTTPOption {
+	return func(req *http.Request) error {
+		if offset < 0 {
+			return fmt.Errorf("invalid range offset %d", offset)
+		}
+
+		if length <= 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		} else {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+		}
+
+		return nil
+	}
+}
+
+// GetFilesArchive downloads several files at once, bundled by the server into a single archive streamed back to the caller.
+func (c *client) GetFilesArchive(ctx context.Context, paths []string, format types.ArchiveFormat) (result types.File, err error) {
+	if len(paths) == 0 {
+		return result, errors.New("at least one path is required to build an archive")
+	}
+
+	form := url.Values{}
+	for _, path := range paths {
+		form.Add("files", base64.StdEncoding.EncodeToString([]byte(path)))
+	}
+
+	form.Set("archive_format", string(format))
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/dl/", c.base), strings.NewReader(form.Encode()))
+	if err != nil {
+		return result, fmt.Errorf("failed to forge new request: %w", err)
+	}
+
+	return c.download(request, c.withSession(ctx), c.withWWWFormURLEncodedContentType)
+}
+
+// download performs a request against the dl/ endpoint and returns the streamed body as a file.
+// The caller is responsible for closing the returned content.
+func (c *client) download(request *http.Request, options ...HTTPOption) (result types.File, err error) {
+	httpResponse, err := c.openDownload(request, options...)
+	if err != nil {
+		return result, err
+	}
+
+	result, err = fileFromHTTPResponse(httpResponse)
+	if err != nil {
+		return result, errors.Join(err, httpResponse.Body.Close())
+	}
+
+	return result, nil
+}
+
+// openDownload performs a request against the dl/ endpoint and checks its status.
+// The caller is responsible for closing the returned response body.
+func (c *client) openDownload(request *http.Request, options ...HTTPOption) (*http.Response, error) {
+	// the content is streamed as is to the caller, so it must not be compressed on the way
+	request.Header.Set("Accept-Encoding", "identity")
+
+	for _, option := range options {
+		if err := option(request); err != nil {
+			return nil, fmt.Errorf("failed to apply option to request: %w", err)
+		}
 	}
 
 	httpResponse, err := c.httpClient.Do(request)
 	if err != nil {
-		return result, fmt.Errorf("failed to perform request: %w", err)
+		return nil, fmt.Errorf("failed to perform request: %w", err)
 	}
 
-	if httpResponse.StatusCode != http.StatusOK {
+	if httpResponse.StatusCode != http.StatusOK && httpResponse.StatusCode != http.StatusPartialContent {
+		defer httpResponse.Body.Close()
+
 		content, err := io.ReadAll(httpResponse.Body)
 		if err != nil {
-			return result, errors.Join(
+			return nil, errors.Join(
 				fmt.Errorf("failed with status '%d'", httpResponse.StatusCode),
 				fmt.Errorf("failed to read response body: %w", err),
 			)
 		}
 
-		return result, fmt.Errorf("failed with status '%d': server returned '%s'", httpResponse.StatusCode, content)
+		return nil, fmt.Errorf("failed with status '%d': server returned '%s'", httpResponse.StatusCode, content)
+	}
+
+	httpResponse.Body = newContextReadCloser(request.Context(), httpResponse.Body)
+
+	if c.transferLimiter != nil {
+		httpResponse.Body = &rateLimitedReadCloser{
+			ReadCloser: httpResponse.Body,
+			ctx:        request.Context(),
+			limiter:    c.transferLimiter,
+		}
 	}
 
+	return httpResponse, nil
+}
+
+// contextReadCloser makes the reads of a response body honor the cancellation of a context, whatever the HTTPClient:
+// the body is closed as soon as the context is done, which unblocks a pending read on a stalled transfer, and reads
+// then fail with the error of the context.
+type contextReadCloser struct {
+	io.ReadCloser
+
+	ctx  context.Context //nolint:containedctx
+	stop func() bool
+}
+
+func newContextReadCloser(ctx context.Context, body io.ReadCloser) *contextReadCloser {
+	return &contextReadCloser{
+		ReadCloser: body,
+		ctx:        ctx,
+		stop: context.AfterFunc(ctx, func() {
+			_ = body.Close()
+		}),
+	}
+}
+
+func (r *contextReadCloser) Read(data []byte) (int, error) {
+	n, err := r.ReadCloser.Read(data)
+	if err != nil {
+		if ctxErr := r.ctx.Err(); ctxErr != nil {
+			return n, fmt.Errorf("download interrupted: %w", ctxErr)
+		}
+
+		if errors.Is(err, io.EOF) {
+			r.stop()
+		}
+	}
+
+	return n, err //nolint:wrapcheck
+}
+
+func (r *contextReadCloser) Close() error {
+	r.stop()
+
+	return r.ReadCloser.Close() //nolint:wrapcheck
+}
+
+func fileFromHTTPResponse(httpResponse *http.Response) (result types.File, err error) {
 	mediatype := ""
 	if contentType := httpResponse.Header.Get("Content-Type"); contentType != "" {
 		mediatype, _, err = mime.ParseMediaType(contentType)
@@ -264,13 +643,28 @@ func (c *client) GetFile(ctx context.Context, path string) (result types.File, e
 		filename = params["filename"]
 	}
 
+	// a missing or malformed date only leaves the modification time unknown
+	lastModified, _ := http.ParseTime(httpResponse.Header.Get("Last-Modified"))
+
 	return types.File{
-		ContentType: mediatype,
-		FileName:    filename,
-		Content:     bufio.NewReader(httpResponse.Body),
+		ContentType:   mediatype,
+		FileName:      filename,
+		ContentLength: httpResponse.ContentLength,
+		LastModified:  lastModified,
+		AcceptRanges:  httpResponse.Header.Get("Accept-Ranges") == "bytes",
+		Content: &bufferedReadCloser{
+			Reader: bufio.NewReader(httpResponse.Body),
+			Closer: httpResponse.Body,
+		},
 	}, nil
 }
 
+// bufferedReadCloser buffers the reads of a response body, which is closed by Close.
+type bufferedReadCloser struct {
+	*bufio.Reader
+	io.Closer
+}
+
 func (c *client) ExtractFile(ctx context.Context, payload types.ExtractFilePayload) (types.FileSystemTask, error) {
 	if !strings.HasPrefix(string(payload.Src), "/") {
 		payload.Src = types.Base64Path("/" + payload.Src)
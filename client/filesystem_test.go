@@ -3,9 +3,13 @@ package client_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -76,7 +80,7 @@ var _ = Describe("filesystem", func() {
 					Index:        0,
 					Link:         false,
 					Parent:       "Freebox/VMs",
-					Modification: 1711657506,
+					Modification: types.Timestamp{Time: time.Unix(1711657506, 0).UTC()},
 					Hidden:       false,
 					MimeType:     "application/x-raw-disk-image",
 					Name:         "file",
@@ -133,6 +137,207 @@ var _ = Describe("filesystem", func() {
 			})
 		})
 	})
+	Context("stating a file", func() {
+		const filePath = "path/to/file"
+		const filePathBase64 = "cGF0aC90by9maWxl"
+		var (
+			returnedFileInfo = new(types.FileInfo)
+			returnedExists   = new(bool)
+		)
+		JustBeforeEach(func() {
+			*returnedFileInfo, *returnedExists, *returnedErr = freeboxClient.StatFile(context.Background(), filePath)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/info/%s", version, filePathBase64)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"type": "file",
+								"name": "file",
+								"path": "cGF0aC90by9maWxl",
+								"size": 42
+							}
+						}`),
+					),
+				)
+			})
+			It("should return the file info", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedExists).To(BeTrue())
+				Expect(returnedFileInfo.Name).To(Equal("file"))
+				Expect(returnedFileInfo.Type).To(Equal(types.FileTypeFile))
+			})
+		})
+		Context("when the path does not exist", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/info/%s", version, filePathBase64)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "path_not_found"
+						}`),
+					),
+				)
+			})
+			It("should report the file is missing without error", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedExists).To(BeFalse())
+				Expect(*returnedFileInfo).To(BeZero())
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+				Expect(*returnedExists).To(BeFalse())
+			})
+		})
+	})
+	Context("checking a path exists", func() {
+		const filePathBase64 = "cGF0aC90by9maWxl"
+		returnedExists := new(bool)
+		JustBeforeEach(func() {
+			*returnedExists, *returnedErr = freeboxClient.PathExists(context.Background(), "path/to/file")
+		})
+		for code, exists := range map[string]bool{"": true, "path_not_found": false} {
+			code, exists := code, exists
+			Context(fmt.Sprintf("when the existence of the path is %t", exists), func() {
+				BeforeEach(func() {
+					response := `{ "success": true, "result": { "type": "dir", "name": "file" } }`
+					if code != "" {
+						response = `{ "success": false, "error_code": "` + code + `" }`
+					}
+
+					server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/info/%s", version, filePathBase64)),
+							verifyAuth(*sessionToken),
+							ghttp.RespondWith(http.StatusOK, response),
+						),
+					)
+				})
+				It("should tell whether the path exists", func() {
+					Expect(*returnedErr).To(BeNil())
+					Expect(*returnedExists).To(Equal(exists))
+				})
+			})
+		}
+		Context("when the server returns another error", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/info/%s", version, filePathBase64)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "internal_error" }`),
+					),
+				)
+			})
+			It("should return the error", func() {
+				Expect(*returnedErr).To(MatchError(&client.APIError{Code: "internal_error"}))
+			})
+		})
+	})
+	Context("listing files", func() {
+		const path = "path/to/dir"
+		returnedFiles := new([]types.FileInfo)
+		JustBeforeEach(func() {
+			*returnedFiles, *returnedErr = freeboxClient.ListFiles(context.Background(), path)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/ls/cGF0aC90by9kaXI=", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								{
+									"type": "dir",
+									"name": "sub",
+									"path": "cGF0aC90by9kaXIvc3Vi"
+								},
+								{
+									"type": "file",
+									"name": "file",
+									"path": "cGF0aC90by9kaXIvZmlsZQ==",
+									"size": 12
+								}
+							]
+						}`),
+					),
+				)
+			})
+			It("should return the files", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedFiles).To(Equal([]types.FileInfo{
+					{
+						Type: types.FileTypeDirectory,
+						Name: "sub",
+						Path: "path/to/dir/sub",
+					},
+					{
+						Type:      types.FileTypeFile,
+						Name:      "file",
+						Path:      "path/to/dir/file",
+						SizeBytes: 12,
+					},
+				}))
+			})
+		})
+		Context("when the directory does not exist", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/ls/cGF0aC90by9kaXI=", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "path_not_found"
+						}`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrPathNotFound))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the server returns an unexpected payload", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/ls/cGF0aC90by9kaXI=", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								"foo"
+							]
+						}`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
 	Context("removing files", func() {
 		var (
 			filePaths       = []string{"path/to/file"}
@@ -192,13 +397,13 @@ var _ = Describe("filesystem", func() {
 					CurrentBytesDone:              0,
 					TotalBytes:                    0,
 					NumberFilesDone:               0,
-					StartedTimestamp:              1711657904,
+					StartedTimestamp:              types.Timestamp{Time: time.Unix(1711657904, 0).UTC()},
 					DurationSeconds:               0,
-					DoneTimestamp:                 0,
+					DoneTimestamp:                 types.Timestamp{Time: time.Unix(0, 0).UTC()},
 					CurrentBytes:                  0,
 					To:                            "",
 					NumberFiles:                   0,
-					CreatedTimestamp:              1711657904,
+					CreatedTimestamp:              types.Timestamp{Time: time.Unix(1711657904, 0).UTC()},
 					TotalBytesDone:                0,
 					From:                          "path/to/file",
 					ProcessingRate:                0,
@@ -293,13 +498,13 @@ var _ = Describe("filesystem", func() {
 					CurrentBytesDone:              0,
 					TotalBytes:                    0,
 					NumberFilesDone:               1,
-					StartedTimestamp:              1712862825,
+					StartedTimestamp:              types.Timestamp{Time: time.Unix(1712862825, 0).UTC()},
 					DurationSeconds:               0,
-					DoneTimestamp:                 1712862825,
+					DoneTimestamp:                 types.Timestamp{Time: time.Unix(1712862825, 0).UTC()},
 					CurrentBytes:                  0,
 					To:                            "",
 					NumberFiles:                   1,
-					CreatedTimestamp:              1712862825,
+					CreatedTimestamp:              types.Timestamp{Time: time.Unix(1712862825, 0).UTC()},
 					TotalBytesDone:                0,
 					From:                          "Freebox/Other/free-go.svg",
 					ProcessingRate:                0,
@@ -393,13 +598,13 @@ var _ = Describe("filesystem", func() {
 					CurrentBytesDone:              0,
 					TotalBytes:                    0,
 					NumberFilesDone:               1,
-					StartedTimestamp:              1712862825,
+					StartedTimestamp:              types.Timestamp{Time: time.Unix(1712862825, 0).UTC()},
 					DurationSeconds:               0,
-					DoneTimestamp:                 1712862825,
+					DoneTimestamp:                 types.Timestamp{Time: time.Unix(1712862825, 0).UTC()},
 					CurrentBytes:                  0,
 					To:                            "",
 					NumberFiles:                   1,
-					CreatedTimestamp:              1712862825,
+					CreatedTimestamp:              types.Timestamp{Time: time.Unix(1712862825, 0).UTC()},
 					TotalBytesDone:                0,
 					From:                          "Freebox/Other/free-go.svg",
 					ProcessingRate:                0,
@@ -519,13 +724,13 @@ var _ = Describe("filesystem", func() {
 						CurrentBytesDone:              0,
 						TotalBytes:                    0,
 						NumberFilesDone:               0,
-						StartedTimestamp:              1355834253,
+						StartedTimestamp:              types.Timestamp{Time: time.Unix(1355834253, 0).UTC()},
 						DurationSeconds:               3,
-						DoneTimestamp:                 0,
+						DoneTimestamp:                 types.Timestamp{Time: time.Unix(0, 0).UTC()},
 						CurrentBytes:                  0,
 						To:                            "oxygennosvg/128x128/mimetypes/application_x_nzb.png",
 						NumberFiles:                   0,
-						CreatedTimestamp:              1355834253,
+						CreatedTimestamp:              types.Timestamp{Time: time.Unix(1355834253, 0).UTC()},
 						TotalBytesDone:                0,
 						From:                          "/Disque dur/tests/oxygennosvg.tar.gz",
 						ProcessingRate:                0,
@@ -544,13 +749,13 @@ var _ = Describe("filesystem", func() {
 						CurrentBytesDone:              0,
 						TotalBytes:                    0,
 						NumberFilesDone:               0,
-						StartedTimestamp:              1355834187,
+						StartedTimestamp:              types.Timestamp{Time: time.Unix(1355834187, 0).UTC()},
 						DurationSeconds:               0,
-						DoneTimestamp:                 1355834187,
+						DoneTimestamp:                 types.Timestamp{Time: time.Unix(1355834187, 0).UTC()},
 						CurrentBytes:                  0,
 						To:                            "",
 						NumberFiles:                   0,
-						CreatedTimestamp:              1355834187,
+						CreatedTimestamp:              types.Timestamp{Time: time.Unix(1355834187, 0).UTC()},
 						TotalBytesDone:                0,
 						From:                          "/Disque dur/test/testiso.1.iso",
 						ProcessingRate:                0,
@@ -636,12 +841,143 @@ var _ = Describe("filesystem", func() {
 			})
 		})
 	})
+	Context("watching a filesystem task", func() {
+		const identifier = int64(42)
+
+		var (
+			ctx             context.Context
+			cancel          context.CancelFunc
+			returnedChannel = new(<-chan types.FileSystemTaskProgress)
+		)
+		BeforeEach(func() {
+			client.FileSystemTaskPollInterval = time.Millisecond * 10
+
+			ctx, cancel = context.WithCancel(context.Background())
+			DeferCleanup(cancel)
+		})
+		JustBeforeEach(func() {
+			*returnedChannel, *returnedErr = freeboxClient.WatchFileSystemTask(ctx, identifier)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/tasks/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {"id": 42, "state": "running", "progress": 10, "rate": 1024, "eta": 60, "error": "none"}
+						}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/tasks/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {"id": 42, "state": "running", "progress": 10, "rate": 1024, "eta": 60, "error": "none"}
+						}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/tasks/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {"id": 42, "state": "done", "progress": 100, "rate": 0, "eta": 0, "error": "none"}
+						}`),
+					),
+				)
+			})
+			It("should send each distinct progress and close the channel", func() {
+				Expect(*returnedErr).To(BeNil())
+				Eventually(*returnedChannel).Should(Receive(Equal(types.FileSystemTaskProgress{
+					ID:                     identifier,
+					State:                  types.FileTaskStateRunning,
+					TaskError:              types.FileTaskErrorNone,
+					ProgressPercent:        10,
+					ProcessingRate:         1024,
+					EstimatedTimeRemaining: time.Minute,
+				})))
+				Eventually(*returnedChannel).Should(Receive(Equal(types.FileSystemTaskProgress{
+					ID:              identifier,
+					State:           types.FileTaskStateDone,
+					TaskError:       types.FileTaskErrorNone,
+					ProgressPercent: 100,
+				})))
+				Eventually(*returnedChannel).Should(BeClosed())
+			})
+		})
+		Context("when the task is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/tasks/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "invalid_id"
+						}`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).To(MatchError(client.ErrTaskNotFound))
+			})
+		})
+		Context("when polling the task fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/tasks/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {"id": 42, "state": "running"}
+						}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/tasks/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusInternalServerError, `internal error`),
+					),
+				)
+			})
+			It("should send the error and close the channel", func() {
+				Expect(*returnedErr).To(BeNil())
+				Eventually(*returnedChannel).Should(Receive(HaveField("State", types.FileTaskStateRunning)))
+				Eventually(*returnedChannel).Should(Receive(HaveField("Error", HaveOccurred())))
+				Eventually(*returnedChannel).Should(BeClosed())
+			})
+		})
+		Context("when the context is canceled", func() {
+			BeforeEach(func() {
+				server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/fs/tasks/%d", version, identifier), ghttp.CombineHandlers(
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": true,
+						"result": {"id": 42, "state": "paused"}
+					}`),
+				))
+			})
+			It("should close the channel", func() {
+				Expect(*returnedErr).To(BeNil())
+				Eventually(*returnedChannel).Should(Receive(HaveField("State", types.FileTaskStatePaused)))
+				cancel()
+				Eventually(*returnedChannel).Should(BeClosed())
+			})
+		})
+	})
 	Context("get a file", func() {
 		var (
+			ctx          context.Context
+			cancel       context.CancelFunc
 			path         = "path/to/file"
 			returnedFile = new(types.File)
 		)
-		JustBeforeEach(func(ctx SpecContext) {
+		BeforeEach(func() {
+			ctx, cancel = context.WithCancel(context.Background())
+			DeferCleanup(cancel)
+		})
+		JustBeforeEach(func() {
 			*returnedFile, *returnedErr = freeboxClient.GetFile(ctx, path)
 		})
 		Context("default", func() {
@@ -650,9 +986,11 @@ var _ = Describe("filesystem", func() {
 					ghttp.CombineHandlers(
 						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/dl/cGF0aC90by9maWxl", version)),
 						verifyAuth(*sessionToken),
+						ghttp.VerifyHeaderKV("Accept-Encoding", "identity"),
 						ghttp.RespondWith(http.StatusOK, `the-content`, http.Header{
 							"Content-Type":        []string{"application/octet-stream"},
 							"Content-Disposition": []string{`attachment; filename="file"`},
+							"Last-Modified":       []string{"Thu, 28 Mar 2024 20:25:06 GMT"},
 						}),
 					),
 				)
@@ -661,7 +999,85 @@ var _ = Describe("filesystem", func() {
 				Expect(*returnedErr).To(BeNil())
 				Expect(returnedFile.ContentType).To(Equal("application/octet-stream"))
 				Expect(returnedFile.FileName).To(Equal("file"))
+				Expect(returnedFile.ContentLength).To(BeEquivalentTo(len("the-content")))
+				Expect(returnedFile.LastModified).To(BeTemporally("==", time.Date(2024, time.March, 28, 20, 25, 6, 0, time.UTC)))
 				Expect(io.ReadAll(returnedFile.Content)).To(BeEquivalentTo([]byte("the-content")))
+				Expect(returnedFile.Close()).To(Succeed())
+			})
+		})
+		Context("when the content is closed before the end", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/dl/cGF0aC90by9maWxl", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, strings.Repeat("the-content", 1024)),
+					),
+				)
+			})
+			It("should not be readable anymore", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedFile.LastModified).To(BeZero())
+				Expect(returnedFile.Close()).To(Succeed())
+
+				_, err := io.ReadAll(returnedFile.Content)
+				Expect(err).ToNot(BeNil())
+			})
+		})
+		Context("when the content type is malformed", func() {
+			body := new(closeRecorder)
+			BeforeEach(func() {
+				*body = closeRecorder{Reader: strings.NewReader("the-content")}
+
+				_, err := freeboxClient.Login(context.Background())
+				Expect(err).To(BeNil())
+
+				freeboxClient = freeboxClient.WithHTTPClient(&httpClientMock{
+					response: func() (*http.Response, error) {
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Header:     http.Header{"Content-Type": []string{"application/"}},
+							Body:       body,
+						}, nil
+					},
+				})
+			})
+			It("should return an error and close the body", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+				Expect(body.closed).To(BeTrue())
+			})
+		})
+		Context("when the transfer stalls", func() {
+			BeforeEach(func() {
+				stalled := make(chan struct{})
+				DeferCleanup(func() { close(stalled) })
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/dl/cGF0aC90by9maWxl", version)),
+						verifyAuth(*sessionToken),
+						func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Content-Type", "application/octet-stream")
+							w.WriteHeader(http.StatusOK)
+							_, _ = w.Write([]byte("the-"))
+							w.(http.Flusher).Flush()
+							<-stalled
+						},
+					),
+				)
+			})
+			It("should interrupt the read when the context is canceled", func() {
+				Expect(*returnedErr).To(BeNil())
+
+				time.AfterFunc(50*time.Millisecond, cancel)
+
+				done := make(chan error)
+				go func() {
+					defer GinkgoRecover()
+					_, err := io.ReadAll(returnedFile.Content)
+					done <- err
+				}()
+				Eventually(done).Should(Receive(MatchError(context.Canceled)))
 			})
 		})
 		Context("when the server does not mention content disposition", func() {
@@ -724,24 +1140,173 @@ var _ = Describe("filesystem", func() {
 			})
 		})
 	})
-	Context("creating a directory", func() {
+	Context("get a file range", func() {
 		var (
-			parent       = "path/to/parent"
-			name         = "folder"
-			returnedPath = new(string)
+			path         = "path/to/file"
+			offset       = new(int64)
+			length       = new(int64)
+			returnedFile = new(types.File)
 		)
+		BeforeEach(func() {
+			*offset = 4
+			*length = 7
+		})
 		JustBeforeEach(func() {
-			*returnedPath, *returnedErr = freeboxClient.CreateDirectory(context.Background(), parent, name)
+			ctx, cancel := context.WithCancel(context.Background())
+			DeferCleanup(cancel)
+
+			*returnedFile, *returnedErr = freeboxClient.GetFile(ctx, path, client.WithRange(*offset, *length))
 		})
 		Context("default", func() {
 			BeforeEach(func() {
 				server.AppendHandlers(
 					ghttp.CombineHandlers(
-						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/mkdir/", version)),
-						ghttp.VerifyJSON(`{
-							"parent": "cGF0aC90by9wYXJlbnQ=",
-							"dirname": "folder"
-						}`),
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/dl/cGF0aC90by9maWxl", version)),
+						ghttp.VerifyHeaderKV("Range", "bytes=4-10"),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusPartialContent, `content`, http.Header{
+							"Content-Type":  []string{"application/octet-stream"},
+							"Accept-Ranges": []string{"bytes"},
+							"Content-Range": []string{"bytes 4-10/11"},
+						}),
+					),
+				)
+			})
+			It("should return the requested part of the file", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedFile.ContentLength).To(BeEquivalentTo(7))
+				Expect(returnedFile.AcceptRanges).To(BeTrue())
+				Expect(io.ReadAll(returnedFile.Content)).To(BeEquivalentTo([]byte("content")))
+			})
+		})
+		Context("when the length is not set", func() {
+			BeforeEach(func() {
+				*length = 0
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/dl/cGF0aC90by9maWxl", version)),
+						ghttp.VerifyHeaderKV("Range", "bytes=4-"),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusPartialContent, `content`),
+					),
+				)
+			})
+			It("should return the rest of the file", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(io.ReadAll(returnedFile.Content)).To(BeEquivalentTo([]byte("content")))
+			})
+		})
+		Context("when the offset is negative", func() {
+			BeforeEach(func() {
+				*offset = -1
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the range can not be satisfied", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/dl/cGF0aC90by9maWxl", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusRequestedRangeNotSatisfiable, ``),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("get an archive of files", func() {
+		var (
+			paths        = new([]string)
+			returnedFile = new(types.File)
+		)
+		BeforeEach(func() {
+			*paths = []string{"path/to/file1", "path/to/file2"}
+		})
+		JustBeforeEach(func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			DeferCleanup(cancel)
+
+			*returnedFile, *returnedErr = freeboxClient.GetFilesArchive(ctx, *paths, types.ArchiveFormatZip)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/dl/", version)),
+						ghttp.VerifyContentType("application/x-www-form-urlencoded"),
+						ghttp.VerifyForm(url.Values{
+							"files":          []string{"cGF0aC90by9maWxlMQ==", "cGF0aC90by9maWxlMg=="},
+							"archive_format": []string{"zip"},
+						}),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `the-archive`, http.Header{
+							"Content-Type":        []string{"application/zip"},
+							"Content-Disposition": []string{`attachment; filename="archive.zip"`},
+						}),
+					),
+				)
+			})
+			It("should return the archive", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedFile.ContentType).To(Equal("application/zip"))
+				Expect(returnedFile.FileName).To(Equal("archive.zip"))
+				Expect(io.ReadAll(returnedFile.Content)).To(BeEquivalentTo([]byte("the-archive")))
+			})
+		})
+		Context("when no path is given", func() {
+			BeforeEach(func() {
+				*paths = []string{}
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the server returns an unexpected payload", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/dl/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusBadRequest, `bad request`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("creating a directory", func() {
+		var (
+			parent       = "path/to/parent"
+			name         = "folder"
+			returnedPath = new(string)
+		)
+		JustBeforeEach(func() {
+			*returnedPath, *returnedErr = freeboxClient.CreateDirectory(context.Background(), parent, name)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/mkdir/", version)),
+						ghttp.VerifyJSON(`{
+							"parent": "cGF0aC90by9wYXJlbnQ=",
+							"dirname": "folder"
+						}`),
 						verifyAuth(*sessionToken),
 						ghttp.RespondWith(http.StatusOK, `{
 							"success": true,
@@ -802,6 +1367,297 @@ var _ = Describe("filesystem", func() {
 			})
 		})
 	})
+	Context("creating a directory and its parents", func() {
+		const path = "/Disque dur/parent/folder"
+
+		returnedPath := new(string)
+		JustBeforeEach(func() {
+			*returnedPath, *returnedErr = freeboxClient.CreateDirectoryAll(context.Background(), path)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/mkdir/", version)),
+						ghttp.VerifyJSON(`{
+							"parent": "Lw==",
+							"dirname": "Disque dur"
+						}`),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "destination_conflict"
+						}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/info/L0Rpc3F1ZSBkdXI=", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"type": "dir",
+								"name": "Disque dur"
+							}
+						}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/mkdir/", version)),
+						ghttp.VerifyJSON(`{
+							"parent": "L0Rpc3F1ZSBkdXI=",
+							"dirname": "parent"
+						}`),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": "L0Rpc3F1ZSBkdXIvcGFyZW50"
+						}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/mkdir/", version)),
+						ghttp.VerifyJSON(`{
+							"parent": "L0Rpc3F1ZSBkdXIvcGFyZW50",
+							"dirname": "folder"
+						}`),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": "L0Rpc3F1ZSBkdXIvcGFyZW50L2ZvbGRlcg=="
+						}`),
+					),
+				)
+			})
+			It("should create the missing directories", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedPath).To(Equal(path))
+			})
+		})
+		Context("when an existing segment is a file", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/mkdir/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "destination_conflict"
+						}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/info/L0Rpc3F1ZSBkdXI=", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"type": "file",
+								"name": "Disque dur"
+							}
+						}`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(MatchError(client.ErrNotADirectory))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("renaming a file", func() {
+		var (
+			path         = "path/to/file"
+			newName      = "renamed"
+			returnedInfo = new(types.FileInfo)
+		)
+		JustBeforeEach(func() {
+			*returnedInfo, *returnedErr = freeboxClient.RenameFile(context.Background(), path, newName)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/rename/", version)),
+						ghttp.VerifyJSON(`{
+							"src": "cGF0aC90by9maWxl",
+							"dst": "renamed"
+						}`),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"type": "file",
+								"name": "renamed",
+								"path": "cGF0aC90by9yZW5hbWVk",
+								"parent": "cGF0aC90bw==",
+								"size": 42
+							}
+						}`),
+					),
+				)
+			})
+			It("should return the renamed file info", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedInfo).To(Equal(types.FileInfo{
+					Type:      types.FileTypeFile,
+					Name:      "renamed",
+					Path:      "path/to/renamed",
+					Parent:    "path/to",
+					SizeBytes: 42,
+				}))
+			})
+		})
+		Context("when the destination already exists", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/rename/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"msg": "Le fichier existe déjà",
+							"success": false,
+							"error_code": "destination_conflict"
+						}`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrDestinationConflict))
+			})
+		})
+		Context("when the source does not exist", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/rename/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"msg": "Fichier introuvable",
+							"success": false,
+							"error_code": "path_not_found"
+						}`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrPathNotFound))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the server returns an unexpected payload", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/rename/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								"foo"
+							]
+						}`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("computing disk usage", func() {
+		const path = "path/to/file"
+
+		returnedTask := new(types.FileSystemTask)
+
+		JustBeforeEach(func(ctx context.Context) {
+			*returnedTask, *returnedErr = freeboxClient.GetDiskUsage(ctx, path)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/du/", version)),
+						ghttp.VerifyJSON(`{
+							"src": "cGF0aC90by9maWxl"
+						}`),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"id": 1234,
+								"type": "du",
+								"state": "done",
+								"total_bytes": 4096,
+								"nfiles": 3
+							}
+						}`),
+					),
+				)
+			})
+			It("should return the task", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedTask.ID).To(BeEquivalentTo(1234))
+				Expect(returnedTask.Type).To(Equal(types.FileTaskTypeDiskUsage))
+				Expect(returnedTask.TotalBytes).To(BeEquivalentTo(4096))
+				Expect(returnedTask.NumberFiles).To(BeEquivalentTo(3))
+			})
+		})
+		Context("when the path does not exist", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/du/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "path_not_found"
+						}`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrPathNotFound))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the server returns an unexpected payload", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/du/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								"foo"
+							]
+						}`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
 	Context("hash a file", func() {
 		const path = "path/to/file"
 
@@ -876,12 +1732,15 @@ var _ = Describe("filesystem", func() {
 		)
 
 		var (
+			mode         = new(types.FileCopyMode)
 			returnedTask = new(types.FileSystemTask)
 			returnedErr  = new(error)
 		)
-
+		BeforeEach(func() {
+			*mode = types.FileCopyModeSkip
+		})
 		JustBeforeEach(func(ctx context.Context) {
-			*returnedTask, *returnedErr = freeboxClient.CopyFiles(ctx, []string{path1, path2}, dest, types.FileCopyModeSkip)
+			*returnedTask, *returnedErr = freeboxClient.CopyFiles(ctx, []string{path1, path2}, dest, *mode)
 		})
 		Context("default", func() {
 			BeforeEach(func() {
@@ -911,6 +1770,14 @@ var _ = Describe("filesystem", func() {
 				Expect(*&returnedTask.ID).To(BeEquivalentTo(1234))
 			})
 		})
+		Context("when the mode is not supported", func() {
+			BeforeEach(func() {
+				*mode = "replace"
+			})
+			It("should return the correct error", func() {
+				Expect(errors.Is(*returnedErr, client.ErrInvalidConflictMode)).To(BeTrue())
+			})
+		})
 		Context("when server fails to respond", func() {
 			BeforeEach(func() {
 				server.Close()
@@ -947,12 +1814,15 @@ var _ = Describe("filesystem", func() {
 		)
 
 		var (
+			mode         = new(types.FileMoveMode)
 			returnedTask = new(types.FileSystemTask)
 			returnedErr  = new(error)
 		)
-
+		BeforeEach(func() {
+			*mode = types.FileMoveModeSkip
+		})
 		JustBeforeEach(func(ctx context.Context) {
-			*returnedTask, *returnedErr = freeboxClient.MoveFiles(ctx, []string{path1, path2}, dest, types.FileMoveModeSkip)
+			*returnedTask, *returnedErr = freeboxClient.MoveFiles(ctx, []string{path1, path2}, dest, *mode)
 		})
 		Context("default", func() {
 			BeforeEach(func() {
@@ -982,6 +1852,14 @@ var _ = Describe("filesystem", func() {
 				Expect(*&returnedTask.ID).To(BeEquivalentTo(1234))
 			})
 		})
+		Context("when the mode is not supported", func() {
+			BeforeEach(func() {
+				*mode = "replace"
+			})
+			It("should return the correct error", func() {
+				Expect(errors.Is(*returnedErr, client.ErrInvalidConflictMode)).To(BeTrue())
+			})
+		})
 		Context("when server fails to respond", func() {
 			BeforeEach(func() {
 				server.Close()
@@ -1067,6 +1945,117 @@ var _ = Describe("filesystem", func() {
 			})
 		})
 	})
+	Context("computing a file hash", func() {
+		const path = "path/to/file"
+
+		var (
+			hashType   = new(types.HashType)
+			hashResult = new(string)
+		)
+		BeforeEach(func() {
+			client.FileSystemTaskPollInterval = time.Millisecond * 10
+
+			*hashType = types.HashTypeSHA256
+		})
+		JustBeforeEach(func(ctx SpecContext) {
+			*hashResult, *returnedErr = freeboxClient.ComputeFileHash(ctx, path, *hashType)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/hash/", version)),
+						ghttp.VerifyJSON(`{
+							"hash_type": "sha256",
+							"src": "cGF0aC90by9maWxl"
+						}`),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {"id": 1234, "state": "queued"}
+						}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/tasks/1234", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {"id": 1234, "state": "running", "progress": 50, "error": "none"}
+						}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/tasks/1234", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {"id": 1234, "state": "done", "progress": 100, "error": "none"}
+						}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/tasks/1234/hash/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": "the-hash-result"
+						}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/fs/tasks/1234", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should return the hash and delete the task", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*hashResult).To(Equal("the-hash-result"))
+			})
+		})
+		Context("when the task fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/hash/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {"id": 1234, "state": "queued"}
+						}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/tasks/1234", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {"id": 1234, "state": "failed", "error": "file_not_found"}
+						}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/fs/tasks/1234", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should return the correct error and delete the task", func() {
+				Expect(errors.Is(*returnedErr, client.ErrFileSystemTaskFailed)).To(BeTrue())
+				Expect(*hashResult).To(BeEmpty())
+				Expect(server.ReceivedRequests()).To(HaveLen(5))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
 	Context("ExtractFile", func() {
 		returnedTask := new(types.FileSystemTask)
 
@@ -1129,13 +2118,13 @@ var _ = Describe("filesystem", func() {
 					CurrentBytesDone:              0,
 					TotalBytes:                    0,
 					NumberFilesDone:               0,
-					StartedTimestamp:              1355842252,
+					StartedTimestamp:              types.Timestamp{Time: time.Unix(1355842252, 0).UTC()},
 					DurationSeconds:               0,
-					DoneTimestamp:                 0,
+					DoneTimestamp:                 types.Timestamp{Time: time.Unix(0, 0).UTC()},
 					CurrentBytes:                  0,
 					To:                            "/Disque dur/old_hdd",
 					NumberFiles:                   0,
-					CreatedTimestamp:              1355842252,
+					CreatedTimestamp:              types.Timestamp{Time: time.Unix(1355842252, 0).UTC()},
 					TotalBytesDone:                0,
 					From:                          "/Disque dur/old_hdd/testiso.1.iso",
 					ProcessingRate:                0,
@@ -1177,3 +2166,15 @@ var _ = Describe("filesystem", func() {
 		})
 	})
 })
+
+// closeRecorder is a response body recording whether it was closed.
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+
+	return nil
+}
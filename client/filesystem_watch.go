@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// WatchDirectory reports the entries created, modified or deleted in a directory, for instance to import the files
+// dropped into a hot folder. The freebox does not notify filesystem changes, so the directory is listed every
+// DirectoryWatchPollInterval and compared with the previous listing: an entry is modified when its size or its
+// modification time changes. The entries found by the first listing are not reported.
+//
+// The channel is closed once the context is done, or right after a change holding an error, such as when the
+// directory is removed.
+func (c *client) WatchDirectory(ctx context.Context, path string) (<-chan types.DirectoryChange, error) {
+	files, err := c.ListFiles(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory %s: %w", path, err)
+	}
+
+	channel := make(chan types.DirectoryChange, 1)
+
+	go func() {
+		defer close(channel)
+
+		send := func(change types.DirectoryChange) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case channel <- change:
+				return true
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(DirectoryWatchPollInterval):
+			}
+
+			current, err := c.ListFiles(ctx, path)
+			if err != nil {
+				if ctx.Err() == nil {
+					send(types.DirectoryChange{Error: fmt.Errorf("failed to list directory %s: %w", path, err)})
+				}
+
+				return
+			}
+
+			for _, change := range directoryChanges(files, current) {
+				if !send(change) {
+					return
+				}
+			}
+
+			files = current
+		}
+	}()
+
+	return channel, nil
+}
+
+// directoryChanges compares two listings of a directory, ignoring its . and .. entries. The created and modified
+// entries come first, in the order of the current listing, followed by the deleted ones.
+func directoryChanges(previous, current []types.FileInfo) []types.DirectoryChange {
+	changes := []types.DirectoryChange{}
+
+	known := make(map[string]types.FileInfo, len(previous))
+	for _, file := range previous {
+		known[file.Name] = file
+	}
+
+	for _, file := range current {
+		if file.Name == "." || file.Name == ".." {
+			continue
+		}
+
+		before, ok := known[file.Name]
+
+		switch {
+		case !ok:
+			changes = append(changes, types.DirectoryChange{Type: types.DirectoryChangeCreated, File: file})
+		case before.SizeBytes != file.SizeBytes || !before.Modification.Equal(file.Modification.Time):
+			changes = append(changes, types.DirectoryChange{Type: types.DirectoryChangeModified, File: file})
+		}
+
+		delete(known, file.Name)
+	}
+
+	for _, file := range previous {
+		if _, deleted := known[file.Name]; deleted && file.Name != "." && file.Name != ".." {
+			changes = append(changes, types.DirectoryChange{Type: types.DirectoryChangeDeleted, File: file})
+		}
+	}
+
+	return changes
+}
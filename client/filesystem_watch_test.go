@@ -0,0 +1,135 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/onsi/gomega/gstruct"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("watching a directory", func() {
+	const directoryBase64 = "RnJlZWJveC9Ub3JyZW50cw==" // Freebox/Torrents
+
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken = new(string)
+
+		ctx    context.Context
+		cancel context.CancelFunc
+
+		returnedChannel = new(<-chan types.DirectoryChange)
+		returnedErr     = new(error)
+	)
+	respondWithListing := func(files string) http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/ls/%s", version, directoryBase64)),
+			verifyAuth(*sessionToken),
+			ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": [
+				{ "name": ".", "type": "dir" },
+				{ "name": "..", "type": "dir" },
+				`+files+`
+			] }`),
+		)
+	}
+	respondWithMissingDirectory := func() http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/ls/%s", version, directoryBase64)),
+			verifyAuth(*sessionToken),
+			ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "path_not_found" }`),
+		)
+	}
+	change := func(changeType types.DirectoryChangeType, name string, size uint64) gstruct.Fields {
+		return gstruct.Fields{
+			"Type":  Equal(changeType),
+			"File":  gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"Name": Equal(name), "SizeBytes": Equal(size)}),
+			"Error": BeNil(),
+		}
+	}
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+
+		interval := client.DirectoryWatchPollInterval
+		client.DirectoryWatchPollInterval = time.Millisecond * 10
+		DeferCleanup(func() { client.DirectoryWatchPollInterval = interval })
+
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+	})
+	JustBeforeEach(func() {
+		*returnedChannel, *returnedErr = freeboxClient.WatchDirectory(ctx, "Freebox/Torrents")
+	})
+	Context("default", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				respondWithListing(`
+					{ "name": "kept.torrent", "type": "file", "size": 10, "modification": 1700000000 },
+					{ "name": "touched.torrent", "type": "file", "size": 10, "modification": 1700000000 },
+					{ "name": "removed.torrent", "type": "file", "size": 10, "modification": 1700000000 }
+				`),
+				respondWithListing(`
+					{ "name": "kept.torrent", "type": "file", "size": 10, "modification": 1700000000 },
+					{ "name": "touched.torrent", "type": "file", "size": 10, "modification": 1700000060 },
+					{ "name": "dropped.torrent", "type": "file", "size": 20, "modification": 1700000060 }
+				`),
+				respondWithListing(`
+					{ "name": "kept.torrent", "type": "file", "size": 10, "modification": 1700000000 },
+					{ "name": "touched.torrent", "type": "file", "size": 10, "modification": 1700000060 },
+					{ "name": "dropped.torrent", "type": "file", "size": 30, "modification": 1700000060 }
+				`),
+				respondWithMissingDirectory(),
+			)
+		})
+		It("should send the changes between the listings", func() {
+			Expect(*returnedErr).To(BeNil())
+			Eventually(*returnedChannel).Should(Receive(gstruct.MatchAllFields(change(types.DirectoryChangeModified, "touched.torrent", 10))))
+			Eventually(*returnedChannel).Should(Receive(gstruct.MatchAllFields(change(types.DirectoryChangeCreated, "dropped.torrent", 20))))
+			Eventually(*returnedChannel).Should(Receive(gstruct.MatchAllFields(change(types.DirectoryChangeDeleted, "removed.torrent", 10))))
+			Eventually(*returnedChannel).Should(Receive(gstruct.MatchAllFields(change(types.DirectoryChangeModified, "dropped.torrent", 30))))
+		})
+		It("should send an error and close the channel once the directory is removed", func() {
+			changes := []types.DirectoryChange{}
+			for change := range *returnedChannel {
+				changes = append(changes, change)
+			}
+			Expect(changes).To(HaveLen(5))
+			Expect(changes[4].Error).To(MatchError(client.ErrPathNotFound))
+		})
+	})
+	Context("when the directory does not exist", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(respondWithMissingDirectory())
+		})
+		It("should return the error", func() {
+			Expect(*returnedErr).To(MatchError(client.ErrPathNotFound))
+			Expect(*returnedChannel).To(BeNil())
+		})
+	})
+	Context("when the context is canceled", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(respondWithListing(`{ "name": "kept.torrent", "type": "file" }`))
+			server.SetAllowUnhandledRequests(true)
+		})
+		It("should close the channel", func() {
+			Expect(*returnedErr).To(BeNil())
+			cancel()
+			Eventually(*returnedChannel).Should(BeClosed())
+		})
+	})
+})
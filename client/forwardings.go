@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// ListUPnPRedirections lists the port redirections requested by the LAN hosts through UPnP IGD.
+func (c *client) ListUPnPRedirections(ctx context.Context) ([]types.UPnPRedirection, error) {
+	response, err := c.get(ctx, "upnpigd/redir/", c.withSession(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET upnpigd/redir/ endpoint: %w", err)
+	}
+
+	result := make([]types.UPnPRedirection, 0)
+	if response.Result != nil {
+		if err = c.fromGenericResponse(response, &result); err != nil {
+			return nil, fmt.Errorf("failed to get UPnP redirections from generic response: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// ListAllForwardings lists the port forwarding rules then the UPnP redirections as one normalized list, to review
+// the exposure of the LAN on the WAN in a single call. The freebox API does not expose IPv6 firewall pinholes, which
+// are therefore not listed.
+func (c *client) ListAllForwardings(ctx context.Context) ([]types.Forwarding, error) {
+	rules, err := c.ListPortForwardingRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list port forwarding rules: %w", err)
+	}
+
+	redirections, err := c.ListUPnPRedirections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list UPnP redirections: %w", err)
+	}
+
+	result := make([]types.Forwarding, 0, len(rules)+len(redirections))
+
+	for _, rule := range rules {
+		result = append(result, rule.Forwarding())
+	}
+
+	for _, redirection := range redirections {
+		result = append(result, redirection.Forwarding())
+	}
+
+	return result, nil
+}
@@ -0,0 +1,195 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("forwardings", func() {
+	const upnpRedirectionsBody = `{
+		"success": true,
+		"result": [
+			{
+				"id": "0.0.0.0-51413-udp",
+				"enabled": true,
+				"ext_src_ip": "0.0.0.0",
+				"ext_port": 51413,
+				"int_ip": "192.168.1.20",
+				"int_port": 51413,
+				"proto": "udp",
+				"desc": "Transmission at 51413"
+			}
+		]
+	}`
+
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	expectedRedirection := types.UPnPRedirection{
+		ID:           "0.0.0.0-51413-udp",
+		Enabled:      true,
+		SourceIP:     netip.MustParseAddr("0.0.0.0"),
+		ExternalPort: 51413,
+		InternalIP:   netip.MustParseAddr("192.168.1.20"),
+		InternalPort: 51413,
+		Protocol:     types.UDP,
+		Description:  "Transmission at 51413",
+	}
+	Context("listing UPnP redirections", func() {
+		returnedRedirections := new([]types.UPnPRedirection)
+		JustBeforeEach(func() {
+			*returnedRedirections, *returnedErr = freeboxClient.ListUPnPRedirections(context.Background())
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/upnpigd/redir/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, upnpRedirectionsBody),
+					),
+				)
+			})
+			It("should return the correct redirections", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedRedirections).To(Equal([]types.UPnPRedirection{expectedRedirection}))
+			})
+		})
+		Context("when there is no redirection", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/upnpigd/redir/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+				)
+			})
+			It("should return an empty list", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedRedirections).To(BeEmpty())
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("listing every forwarding", func() {
+		returnedForwardings := new([]types.Forwarding)
+		JustBeforeEach(func() {
+			*returnedForwardings, *returnedErr = freeboxClient.ListAllForwardings(context.Background())
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fw/redir/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								{
+									"id": 5,
+									"enabled": false,
+									"comment": "ssh",
+									"ip_proto": "tcp",
+									"wan_port_start": 2222,
+									"wan_port_end": 2222,
+									"lan_ip": "192.168.1.10",
+									"lan_port": 22,
+									"src_ip": "203.0.113.7"
+								}
+							]
+						}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/upnpigd/redir/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, upnpRedirectionsBody),
+					),
+				)
+			})
+			It("should return the port forwarding rules then the UPnP redirections", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedForwardings).To(Equal([]types.Forwarding{
+					{
+						Source:       types.ForwardingSourcePortForwarding,
+						ID:           "5",
+						Enabled:      false,
+						Protocol:     types.TCP,
+						WanPortStart: 2222,
+						WanPortEnd:   2222,
+						LanIP:        netip.MustParseAddr("192.168.1.10"),
+						LanPort:      22,
+						SourceIP:     netip.MustParseAddr("203.0.113.7"),
+						Description:  "ssh",
+					},
+					{
+						Source:       types.ForwardingSourceUPnP,
+						ID:           "0.0.0.0-51413-udp",
+						Enabled:      true,
+						Protocol:     types.UDP,
+						WanPortStart: 51413,
+						WanPortEnd:   51413,
+						LanIP:        netip.MustParseAddr("192.168.1.20"),
+						LanPort:      51413,
+						SourceIP:     netip.MustParseAddr("0.0.0.0"),
+						Description:  "Transmission at 51413",
+					},
+				}))
+			})
+		})
+		Context("when listing the UPnP redirections fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fw/redir/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/upnpigd/redir/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "internal_error" }`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// ListHomeNodes returns the home automation devices paired with the freebox, such as sensors, shutters or switches.
+func (c *client) ListHomeNodes(ctx context.Context) (result []types.HomeNode, err error) {
+	response, err := c.get(ctx, "home/nodes", c.withSession(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET home/nodes endpoint: %w", err)
+	}
+
+	if response.Result == nil {
+		return
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to get home nodes from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetHomeNode returns a home automation device.
+func (c *client) GetHomeNode(ctx context.Context, identifier int64) (result types.HomeNode, err error) {
+	response, err := c.get(ctx, fmt.Sprintf("home/nodes/%d", identifier), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrDeviceNotFound) {
+			return result, ErrHomeNodeNotFound
+		}
+
+		return result, fmt.Errorf("failed to GET home/nodes/%d endpoint: %w", identifier, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a home node from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetHomeEndpointValue returns the current value of an endpoint of a home automation device.
+func (c *client) GetHomeEndpointValue(ctx context.Context, nodeID int64, endpointID int64) (result types.HomeEndpointValue, err error) {
+	response, err := c.get(ctx, fmt.Sprintf("home/endpoints/%d/%d", nodeID, endpointID), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrDeviceNotFound) {
+			return result, ErrHomeNodeNotFound
+		}
+
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrHomeEndpointNotFound
+		}
+
+		return result, fmt.Errorf("failed to GET home/endpoints/%d/%d endpoint: %w", nodeID, endpointID, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a home endpoint value from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// SetHomeEndpointValue sends a value to a slot endpoint of a home automation device, such as the position of a shutter
+// or the state of a switch. Endpoints of type void, such as the stop command of a shutter, expect a nil value.
+func (c *client) SetHomeEndpointValue(ctx context.Context, nodeID int64, endpointID int64, value interface{}) error {
+	_, err := c.put(ctx, fmt.Sprintf("home/endpoints/%d/%d", nodeID, endpointID), map[string]interface{}{
+		"value": value,
+	}, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrDeviceNotFound) {
+			return ErrHomeNodeNotFound
+		}
+
+		if errors.Is(err, ErrNotFound) {
+			return ErrHomeEndpointNotFound
+		}
+
+		return fmt.Errorf("failed to PUT home/endpoints/%d/%d endpoint: %w", nodeID, endpointID, err)
+	}
+
+	return nil
+}
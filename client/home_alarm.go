@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+const (
+	homeNodeCategoryAlarm = "alarm"
+
+	alarmStateEndpoint  = "state"
+	alarmDisarmEndpoint = "off"
+)
+
+// GetAlarmState returns the state of the alarm of a Freebox Delta.
+func (c *client) GetAlarmState(ctx context.Context, nodeID int64) (result types.AlarmState, err error) {
+	signals, _, err := c.alarmEndpoints(ctx, nodeID)
+	if err != nil {
+		return result, err
+	}
+
+	signal, ok := signals[alarmStateEndpoint]
+	if !ok {
+		return result, fmt.Errorf("signal %q of node %d: %w", alarmStateEndpoint, nodeID, ErrHomeEndpointNotFound)
+	}
+
+	value, err := c.GetHomeEndpointValue(ctx, nodeID, int64(signal.ID))
+	if err != nil {
+		return result, err
+	}
+
+	state, ok := value.Value.(string)
+	if !ok {
+		return result, fmt.Errorf("unexpected alarm state %v", value.Value)
+	}
+
+	return types.AlarmState(state), nil
+}
+
+// ArmAlarm arms the alarm of a Freebox Delta, either fully or partially. The alarm is armed once its activation delay
+// is elapsed.
+func (c *client) ArmAlarm(ctx context.Context, nodeID int64, mode types.AlarmMode) error {
+	_, slots, err := c.alarmEndpoints(ctx, nodeID)
+	if err != nil {
+		return err
+	}
+
+	slot, ok := slots[string(mode)]
+	if !ok {
+		return fmt.Errorf("slot %q of node %d: %w", mode, nodeID, ErrHomeEndpointNotFound)
+	}
+
+	return c.SetHomeEndpointValue(ctx, nodeID, int64(slot.ID), nil)
+}
+
+// DisarmAlarm disarms the alarm of a Freebox Delta with its PIN code.
+func (c *client) DisarmAlarm(ctx context.Context, nodeID int64, pin string) error {
+	_, slots, err := c.alarmEndpoints(ctx, nodeID)
+	if err != nil {
+		return err
+	}
+
+	slot, ok := slots[alarmDisarmEndpoint]
+	if !ok {
+		return fmt.Errorf("slot %q of node %d: %w", alarmDisarmEndpoint, nodeID, ErrHomeEndpointNotFound)
+	}
+
+	return c.SetHomeEndpointValue(ctx, nodeID, int64(slot.ID), pin)
+}
+
+// GetAlarmSettings returns the delays and the siren volume of the alarm of a Freebox Delta.
+func (c *client) GetAlarmSettings(ctx context.Context, nodeID int64) (result types.AlarmSettings, err error) {
+	signals, _, err := c.alarmEndpoints(ctx, nodeID)
+	if err != nil {
+		return result, err
+	}
+
+	values := make(map[string]interface{}, len(signals))
+	for name, signal := range signals {
+		values[name] = signal.Value
+	}
+
+	// Settings are exposed as signals named after the json tags of the settings
+	content, err := json.Marshal(values)
+	if err != nil {
+		return result, fmt.Errorf("failed to marshal alarm signals: %w", err)
+	}
+
+	if err = json.Unmarshal(content, &result); err != nil {
+		return result, fmt.Errorf("failed to get alarm settings from signals: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdateAlarmSettings updates the delays and the siren volume of the alarm of a Freebox Delta. Fields left empty are
+// not updated.
+func (c *client) UpdateAlarmSettings(ctx context.Context, nodeID int64, payload types.AlarmSettingsPayload) (types.AlarmSettings, error) {
+	_, slots, err := c.alarmEndpoints(ctx, nodeID)
+	if err != nil {
+		return types.AlarmSettings{}, err
+	}
+
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return types.AlarmSettings{}, fmt.Errorf("failed to marshal alarm settings: %w", err)
+	}
+
+	values := make(map[string]interface{})
+	if err = json.Unmarshal(content, &values); err != nil {
+		return types.AlarmSettings{}, fmt.Errorf("failed to unmarshal alarm settings: %w", err)
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		slot, ok := slots[name]
+		if !ok {
+			return types.AlarmSettings{}, fmt.Errorf("slot %q of node %d: %w", name, nodeID, ErrHomeEndpointNotFound)
+		}
+
+		if err = c.SetHomeEndpointValue(ctx, nodeID, int64(slot.ID), values[name]); err != nil {
+			return types.AlarmSettings{}, err
+		}
+	}
+
+	return c.GetAlarmSettings(ctx, nodeID)
+}
+
+// alarmEndpoints returns the signals and the slots of an alarm node indexed by their name.
+func (c *client) alarmEndpoints(ctx context.Context, nodeID int64) (signals map[string]types.HomeEndpoint, slots map[string]types.HomeEndpoint, err error) {
+	node, err := c.GetHomeNode(ctx, nodeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if node.Category != homeNodeCategoryAlarm {
+		return nil, nil, fmt.Errorf("node %d is a %q: %w", nodeID, node.Category, ErrHomeNodeNotAnAlarm)
+	}
+
+	signals = make(map[string]types.HomeEndpoint)
+	slots = make(map[string]types.HomeEndpoint)
+
+	for _, endpoint := range node.ShowEndpoints {
+		switch endpoint.Type {
+		case types.HomeEndpointTypeSignal:
+			signals[endpoint.Name] = endpoint
+		case types.HomeEndpointTypeSlot:
+			slots[endpoint.Name] = endpoint
+		}
+	}
+
+	return signals, slots, nil
+}
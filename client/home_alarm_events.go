@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// WatchAlarm sends the state of the alarm each time it changes, such as when it is armed or when an intrusion is
+// detected. The channel is closed when the context is canceled, or after an error is sent. In both cases the underlying
+// websocket is only released once the context is canceled.
+func (c *client) WatchAlarm(ctx context.Context) (<-chan types.HomeAlarmStateChanged, error) {
+	events, err := c.ListenEvents(ctx, []types.EventDescription{{
+		Source: types.EventSourceHome,
+		Name:   types.EventHomeAlarmStateChanged,
+	}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen to alarm events: %w", err)
+	}
+
+	channel := make(chan types.HomeAlarmStateChanged, 1)
+
+	go func() {
+		// drain the events until the listener closes its channel so that its goroutine can exit
+		defer func() {
+			for range events {
+			}
+		}()
+
+		defer close(channel)
+
+		send := func(change types.HomeAlarmStateChanged) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case channel <- change:
+				return true
+			}
+		}
+
+		for event := range events {
+			if event.Error != nil {
+				send(types.HomeAlarmStateChanged{Error: event.Error})
+
+				return
+			}
+
+			change, err := event.Notification.DecodeHomeAlarmStateChanged()
+			if err != nil {
+				send(types.HomeAlarmStateChanged{Error: err})
+
+				return
+			}
+
+			if !send(change) {
+				return
+			}
+		}
+	}()
+
+	return channel, nil
+}
@@ -0,0 +1,108 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("WatchAlarm", func() {
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken string
+
+		ctx    context.Context
+		cancel context.CancelFunc
+
+		notifications []string
+
+		returnedChannel <-chan types.HomeAlarmStateChanged
+		returnedErr     error
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		sessionToken = setupLoginFlow(server)
+
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/ws/event", version)),
+			verifyAuth(sessionToken),
+			wsHandler(func(ws *websocket.Conn) {
+				var register map[string]interface{}
+				Expect(readJSON(ws, &register)).To(Succeed())
+				Expect(register).To(HaveKeyWithValue("events", ConsistOf("home_alarm_state_changed")))
+
+				Expect(writeJSON(ws, map[string]interface{}{"action": "register", "success": true})).To(Succeed())
+
+				for _, notification := range notifications {
+					Expect(ws.WriteMessage(websocket.TextMessage, []byte(notification))).To(Succeed())
+				}
+
+				_, _, err := ws.ReadMessage()
+				Expect(websocket.IsCloseError(err, websocket.CloseNormalClosure)).To(BeTrue())
+			}),
+		))
+	})
+	JustBeforeEach(func() {
+		returnedChannel, returnedErr = freeboxClient.WatchAlarm(ctx)
+	})
+	Context("default", func() {
+		BeforeEach(func() {
+			notifications = []string{
+				`{ "action": "notification", "success": true, "source": "home", "event": "alarm_state_changed", "result": { "node_id": 7, "state": "alarm1_alert_timer" } }`,
+				`{ "action": "notification", "success": true, "source": "home", "event": "alarm_state_changed", "result": { "node_id": 7, "state": "alert" } }`,
+			}
+		})
+		It("should send the state changes of the alarm", func() {
+			Expect(returnedErr).To(BeNil())
+			Eventually(returnedChannel).Should(Receive(Equal(types.HomeAlarmStateChanged{NodeID: 7, State: types.AlarmStateFullAlertTimer})))
+			Eventually(returnedChannel).Should(Receive(Equal(types.HomeAlarmStateChanged{NodeID: 7, State: types.AlarmStateAlert})))
+
+			cancel()
+			Eventually(returnedChannel).Should(BeClosed())
+		})
+	})
+	Context("when the server returns an unexpected payload", func() {
+		BeforeEach(func() {
+			notifications = []string{
+				`{ "action": "notification", "success": true, "source": "home", "event": "alarm_state_changed", "result": [] }`,
+			}
+		})
+		It("should send an error then close the channel", func() {
+			Expect(returnedErr).To(BeNil())
+
+			var change types.HomeAlarmStateChanged
+			Eventually(returnedChannel).Should(Receive(&change))
+			Expect(change.Error).To(HaveOccurred())
+			Eventually(returnedChannel).Should(BeClosed())
+
+			cancel()
+		})
+	})
+	Context("when server fails to respond", func() {
+		BeforeEach(func() {
+			server.Close()
+		})
+		It("should return an error", func() {
+			Expect(returnedErr).To(HaveOccurred())
+		})
+	})
+})
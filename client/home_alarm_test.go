@@ -0,0 +1,217 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("home alarm", func() {
+	const (
+		nodeID    int64 = 7
+		alarmBody       = `{
+			"id": 7,
+			"category": "alarm",
+			"label": "Alarme",
+			"status": "active",
+			"show_endpoints": [
+				{ "id": 0, "ep_type": "slot", "name": "alarm1", "value_type": "void" },
+				{ "id": 1, "ep_type": "slot", "name": "alarm2", "value_type": "void" },
+				{ "id": 2, "ep_type": "slot", "name": "off", "value_type": "string" },
+				{ "id": 3, "ep_type": "slot", "name": "timeout1", "value_type": "int" },
+				{ "id": 4, "ep_type": "slot", "name": "timeout2", "value_type": "int" },
+				{ "id": 5, "ep_type": "slot", "name": "timeout3", "value_type": "int" },
+				{ "id": 6, "ep_type": "slot", "name": "volume", "value_type": "int" },
+				{ "id": 11, "ep_type": "signal", "name": "state", "value_type": "string", "value": "idle" },
+				{ "id": 13, "ep_type": "signal", "name": "timeout1", "value_type": "int", "value": 15 },
+				{ "id": 14, "ep_type": "signal", "name": "timeout2", "value_type": "int", "value": 180 },
+				{ "id": 15, "ep_type": "signal", "name": "timeout3", "value_type": "int", "value": 30 },
+				{ "id": 16, "ep_type": "signal", "name": "volume", "value_type": "int", "value": 80 }
+			]
+		}`
+	)
+
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/home/nodes/%d", version, nodeID)),
+				verifyAuth(*sessionToken),
+				ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %s }`, alarmBody)),
+			),
+		)
+	})
+	Context("getting the state", func() {
+		returnedState := new(types.AlarmState)
+		JustBeforeEach(func() {
+			*returnedState, *returnedErr = freeboxClient.GetAlarmState(context.Background(), nodeID)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/home/endpoints/%d/11", version, nodeID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "value": "alarm2_armed", "value_type": "string" } }`),
+					),
+				)
+			})
+			It("should return the current state", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedState).To(Equal(types.AlarmStatePartialArmed))
+			})
+		})
+		Context("when the node is not an alarm", func() {
+			BeforeEach(func() {
+				server.SetHandler(2, ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/home/nodes/%d", version, nodeID)),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "id": 7, "category": "shutter" } }`),
+				))
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(MatchError(client.ErrHomeNodeNotAnAlarm))
+			})
+		})
+		Context("when the node is not found", func() {
+			BeforeEach(func() {
+				server.SetHandler(2, ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/home/nodes/%d", version, nodeID)),
+					ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "nodev" }`),
+				))
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrHomeNodeNotFound))
+			})
+		})
+	})
+	Context("arming", func() {
+		for mode, slot := range map[types.AlarmMode]int{types.AlarmModeFull: 0, types.AlarmModePartial: 1} {
+			mode, slot := mode, slot
+			Context(fmt.Sprintf("when using %s", mode), func() {
+				BeforeEach(func() {
+					server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/home/endpoints/%d/%d", version, nodeID, slot)),
+							verifyAuth(*sessionToken),
+							ghttp.VerifyJSON(`{ "value": null }`),
+							ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+						),
+					)
+				})
+				It("should trigger the corresponding slot", func() {
+					Expect(freeboxClient.ArmAlarm(context.Background(), nodeID, mode)).To(Succeed())
+				})
+			})
+		}
+	})
+	Context("disarming", func() {
+		JustBeforeEach(func() {
+			*returnedErr = freeboxClient.DisarmAlarm(context.Background(), nodeID, "1234")
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/home/endpoints/%d/2", version, nodeID)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{ "value": "1234" }`),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the PIN is refused", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/home/endpoints/%d/2", version, nodeID)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "invalid_request" }`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("getting the settings", func() {
+		returnedSettings := new(types.AlarmSettings)
+		JustBeforeEach(func() {
+			*returnedSettings, *returnedErr = freeboxClient.GetAlarmSettings(context.Background(), nodeID)
+		})
+		It("should return the settings", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*returnedSettings).To(Equal(types.AlarmSettings{
+				ActivationDelay: 15,
+				AlertDuration:   180,
+				TriggerDelay:    30,
+				Volume:          80,
+			}))
+		})
+	})
+	Context("updating the settings", func() {
+		returnedSettings := new(types.AlarmSettings)
+		JustBeforeEach(func() {
+			activationDelay, volume := int64(60), int64(20)
+			*returnedSettings, *returnedErr = freeboxClient.UpdateAlarmSettings(context.Background(), nodeID, types.AlarmSettingsPayload{
+				ActivationDelay: &activationDelay,
+				Volume:          &volume,
+			})
+		})
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/home/endpoints/%d/3", version, nodeID)),
+					verifyAuth(*sessionToken),
+					ghttp.VerifyJSON(`{ "value": 60 }`),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/home/endpoints/%d/6", version, nodeID)),
+					verifyAuth(*sessionToken),
+					ghttp.VerifyJSON(`{ "value": 20 }`),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/home/nodes/%d", version, nodeID)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %s }`, alarmBody)),
+				),
+			)
+		})
+		It("should only update the given settings and return the settings read back from the alarm", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(returnedSettings.ActivationDelay).To(BeEquivalentTo(15))
+		})
+	})
+})
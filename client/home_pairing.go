@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+const (
+	homePairingActionStart = "start"
+	homePairingActionNext  = "next"
+	homePairingActionStop  = "stop"
+)
+
+// StartHomePairing starts the pairing of a new device on a home automation adapter, such as the RTS, IO-homecontrol or
+// Zigbee radio of the freebox. The returned step tells what is expected from the user.
+func (c *client) StartHomePairing(ctx context.Context, adapterID int64) (types.HomePairingStep, error) {
+	return c.homePairingAction(ctx, adapterID, map[string]interface{}{
+		"action": homePairingActionStart,
+	})
+}
+
+// GetHomePairingStep returns the current step of the pairing running on a home automation adapter, to follow the
+// progress of a pairing waiting for the device.
+func (c *client) GetHomePairingStep(ctx context.Context, adapterID int64) (result types.HomePairingStep, err error) {
+	response, err := c.get(ctx, fmt.Sprintf("home/pairing/%d", adapterID), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrDeviceNotFound) {
+			return result, ErrHomeAdapterNotFound
+		}
+
+		return result, fmt.Errorf("failed to GET home/pairing/%d endpoint: %w", adapterID, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a pairing step from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// AnswerHomePairingStep answers the prompts of the current page of a pairing, such as a PIN code or the confirmation
+// that a button of the device has been pressed, and returns the next step.
+func (c *client) AnswerHomePairingStep(ctx context.Context, adapterID int64, answer types.HomePairingAnswer) (types.HomePairingStep, error) {
+	fields := answer.Fields
+	if fields == nil {
+		fields = []types.HomePairingFieldAnswer{}
+	}
+
+	return c.homePairingAction(ctx, adapterID, map[string]interface{}{
+		"action": homePairingActionNext,
+		"page":   answer.Page,
+		"fields": fields,
+	})
+}
+
+// StopHomePairing aborts the pairing running on a home automation adapter.
+func (c *client) StopHomePairing(ctx context.Context, adapterID int64) error {
+	_, err := c.homePairingAction(ctx, adapterID, map[string]interface{}{
+		"action": homePairingActionStop,
+	})
+
+	return err
+}
+
+func (c *client) homePairingAction(ctx context.Context, adapterID int64, body map[string]interface{}) (result types.HomePairingStep, err error) {
+	response, err := c.post(ctx, fmt.Sprintf("home/pairing/%d", adapterID), body, c.withSession(ctx))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrDeviceNotFound):
+			return result, ErrHomeAdapterNotFound
+		case errors.Is(err, ErrBusy):
+			return result, ErrHomePairingInProgress
+		}
+
+		return result, fmt.Errorf("failed to %s pairing with POST home/pairing/%d endpoint: %w", body["action"], adapterID, err)
+	}
+
+	if response.Result == nil {
+		return
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a pairing step from generic response: %w", err)
+	}
+
+	return result, nil
+}
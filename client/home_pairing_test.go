@@ -0,0 +1,174 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("home pairing", func() {
+	const adapterID int64 = 3
+
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr  = new(error)
+		returnedStep = new(types.HomePairingStep)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	Context("starting a pairing", func() {
+		JustBeforeEach(func() {
+			*returnedStep, *returnedErr = freeboxClient.StartHomePairing(context.Background(), adapterID)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/home/pairing/%d", version, adapterID)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{ "action": "start" }`),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"status": "running",
+								"page": 0,
+								"page_count": 2,
+								"title": "Appairage",
+								"fields": [
+									{ "id": "prog", "type": "button", "label": "Appuyez sur le bouton PROG de la télécommande" }
+								]
+							}
+						}`),
+					),
+				)
+			})
+			It("should return the first step", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedStep).To(Equal(types.HomePairingStep{
+					Status:    types.HomePairingStatusRunning,
+					PageCount: 2,
+					Title:     "Appairage",
+					Fields: []types.HomePairingField{
+						{
+							ID:    "prog",
+							Type:  types.HomePairingFieldTypeButton,
+							Label: "Appuyez sur le bouton PROG de la télécommande",
+						},
+					},
+				}))
+			})
+		})
+		for code, expectedErr := range map[string]error{"nodev": client.ErrHomeAdapterNotFound, "busy": client.ErrHomePairingInProgress} {
+			code, expectedErr := code, expectedErr
+			Context(fmt.Sprintf("when the server returns %s", code), func() {
+				BeforeEach(func() {
+					server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/home/pairing/%d", version, adapterID)),
+							ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": false, "error_code": %q }`, code)),
+						),
+					)
+				})
+				It("should return the correct error", func() {
+					Expect(*returnedErr).To(Equal(expectedErr))
+				})
+			})
+		}
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("getting the current step", func() {
+		JustBeforeEach(func() {
+			*returnedStep, *returnedErr = freeboxClient.GetHomePairingStep(context.Background(), adapterID)
+		})
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/home/pairing/%d", version, adapterID)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "status": "success", "page": 1, "page_count": 2, "node_id": 12 } }`),
+				),
+			)
+		})
+		It("should return the current step", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*returnedStep).To(Equal(types.HomePairingStep{
+				Status:    types.HomePairingStatusSuccess,
+				Page:      1,
+				PageCount: 2,
+				NodeID:    12,
+			}))
+		})
+	})
+	Context("answering a step", func() {
+		JustBeforeEach(func() {
+			*returnedStep, *returnedErr = freeboxClient.AnswerHomePairingStep(context.Background(), adapterID, types.HomePairingAnswer{
+				Page: 1,
+				Fields: []types.HomePairingFieldAnswer{
+					{ID: "pin", Value: "0000"},
+				},
+			})
+		})
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/home/pairing/%d", version, adapterID)),
+					verifyAuth(*sessionToken),
+					ghttp.VerifyJSON(`{ "action": "next", "page": 1, "fields": [ { "id": "pin", "value": "0000" } ] }`),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "status": "running", "page": 2, "page_count": 2 } }`),
+				),
+			)
+		})
+		It("should return the next step", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(returnedStep.Page).To(BeEquivalentTo(2))
+		})
+	})
+	Context("stopping a pairing", func() {
+		JustBeforeEach(func() {
+			*returnedErr = freeboxClient.StopHomePairing(context.Background(), adapterID)
+		})
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/home/pairing/%d", version, adapterID)),
+					verifyAuth(*sessionToken),
+					ghttp.VerifyJSON(`{ "action": "stop" }`),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+				),
+			)
+		})
+		It("should not return an error", func() {
+			Expect(*returnedErr).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,262 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("home", func() {
+	const nodeBody = `{
+		"id": 5,
+		"adapter": 2,
+		"category": "shutter",
+		"name": "node_5",
+		"label": "Volet salon",
+		"status": "active",
+		"group": { "label": "Salon" },
+		"type": { "name": "RTS_shutter", "label": "Volet RTS", "icon": "/resources/images/home/pictos/volet_roulant.png", "inherit": "node::rts", "abstract": false, "generic": false },
+		"props": { "Address": 10231 },
+		"show_endpoints": [
+			{ "id": 0, "ep_type": "slot", "name": "stop", "label": "Stop", "visibility": "normal", "access": "w", "value_type": "void" },
+			{ "id": 3, "ep_type": "signal", "name": "position_set", "label": "Position", "visibility": "normal", "access": "rw", "value_type": "int", "value": 42 }
+		]
+	}`
+
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	expectedNode := types.HomeNode{
+		ID:       5,
+		Adapter:  2,
+		Category: "shutter",
+		Name:     "node_5",
+		Label:    "Volet salon",
+		Status:   types.HomeNodeStatusActive,
+		Group:    types.HomeNodeGroup{Label: "Salon"},
+		Type: types.HomeNodeType{
+			Name:    "RTS_shutter",
+			Label:   "Volet RTS",
+			Icon:    "/resources/images/home/pictos/volet_roulant.png",
+			Inherit: "node::rts",
+		},
+		Props: map[string]interface{}{"Address": float64(10231)},
+		ShowEndpoints: []types.HomeEndpoint{
+			{
+				ID:         0,
+				Type:       types.HomeEndpointTypeSlot,
+				Name:       "stop",
+				Label:      "Stop",
+				Visibility: "normal",
+				Access:     "w",
+				ValueType:  types.HomeEndpointValueTypeVoid,
+			},
+			{
+				ID:         3,
+				Type:       types.HomeEndpointTypeSignal,
+				Name:       "position_set",
+				Label:      "Position",
+				Visibility: "normal",
+				Access:     "rw",
+				ValueType:  types.HomeEndpointValueTypeInt,
+				Value:      float64(42),
+			},
+		},
+	}
+	Context("listing nodes", func() {
+		returnedNodes := new([]types.HomeNode)
+		JustBeforeEach(func() {
+			*returnedNodes, *returnedErr = freeboxClient.ListHomeNodes(context.Background())
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/home/nodes", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": [%s] }`, nodeBody)),
+					),
+				)
+			})
+			It("should return the correct nodes", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedNodes).To(Equal([]types.HomeNode{expectedNode}))
+			})
+		})
+		Context("when there are no nodes", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/home/nodes", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+				)
+			})
+			It("should return an empty list", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedNodes).To(BeEmpty())
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("getting a node", func() {
+		returnedNode := new(types.HomeNode)
+		JustBeforeEach(func() {
+			*returnedNode, *returnedErr = freeboxClient.GetHomeNode(context.Background(), 5)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/home/nodes/5", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %s }`, nodeBody)),
+					),
+				)
+			})
+			It("should return the correct node", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedNode).To(Equal(expectedNode))
+			})
+		})
+		Context("when the node is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/home/nodes/5", version)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "nodev" }`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrHomeNodeNotFound))
+			})
+		})
+	})
+	Context("getting an endpoint value", func() {
+		returnedValue := new(types.HomeEndpointValue)
+		JustBeforeEach(func() {
+			*returnedValue, *returnedErr = freeboxClient.GetHomeEndpointValue(context.Background(), 5, 3)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/home/endpoints/5/3", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "value": 42, "value_type": "int", "refresh": 2000 } }`),
+					),
+				)
+			})
+			It("should return the correct value", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedValue).To(Equal(types.HomeEndpointValue{
+					Value:     float64(42),
+					ValueType: types.HomeEndpointValueTypeInt,
+					Refresh:   2000,
+				}))
+			})
+		})
+		for code, expectedErr := range map[string]error{"nodev": client.ErrHomeNodeNotFound, "noent": client.ErrHomeEndpointNotFound} {
+			code, expectedErr := code, expectedErr
+			Context(fmt.Sprintf("when the server returns %s", code), func() {
+				BeforeEach(func() {
+					server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/home/endpoints/5/3", version)),
+							ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": false, "error_code": %q }`, code)),
+						),
+					)
+				})
+				It("should return the correct error", func() {
+					Expect(*returnedErr).To(Equal(expectedErr))
+				})
+			})
+		}
+	})
+	Context("setting an endpoint value", func() {
+		value := new(interface{})
+		JustBeforeEach(func() {
+			*returnedErr = freeboxClient.SetHomeEndpointValue(context.Background(), 5, 3, *value)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				*value = 100
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/home/endpoints/5/3", version)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{ "value": 100 }`),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the endpoint does not carry a value", func() {
+			BeforeEach(func() {
+				*value = nil
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/home/endpoints/5/3", version)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{ "value": null }`),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the endpoint is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/home/endpoints/5/3", version)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "noent" }`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrHomeEndpointNotFound))
+			})
+		})
+	})
+})
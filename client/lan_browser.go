@@ -2,14 +2,16 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/nikolalohinski/free-go/types"
 )
 
 const (
-	interfaceNotFoundCode     = "nodev"
-	interfaceHostNotFoundCode = "nohost"
+	hostMACIdentifierPrefix = "ether-"
 )
 
 func (c *client) ListLanInterfaceInfo(ctx context.Context) (result []types.LanInfo, err error) {
@@ -26,30 +28,30 @@ func (c *client) ListLanInterfaceInfo(ctx context.Context) (result []types.LanIn
 }
 
 func (c *client) GetLanInterface(ctx context.Context, name string) (result []types.LanInterfaceHost, err error) {
-	response, err := c.get(ctx, "lan/browser/"+name, c.withSession(ctx))
+	_, err = getList(ctx, c, "lan/browser/"+name, &result, c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == interfaceNotFoundCode {
-			return result, ErrInterfaceNotFound
+		if errors.Is(err, ErrDeviceNotFound) {
+			return nil, ErrInterfaceNotFound
 		}
 
-		return result, fmt.Errorf("failed to GET lan/browser/%s endpoint: %w", name, err)
-	}
-
-	if err = c.fromGenericResponse(response, &result); err != nil {
-		return result, fmt.Errorf("failed to get lan interface from generic response: %w", err)
+		return nil, fmt.Errorf("failed to GET lan/browser/%s endpoint: %w", name, err)
 	}
 
 	return result, nil
 }
 
+// GetLanInterfaceHost returns a host of an interface. The identifier of a host with a mac address can be given as the
+// mac address alone, in any common format, as well as ether- followed by the mac address.
 func (c *client) GetLanInterfaceHost(ctx context.Context, interfaceName, identifier string) (result types.LanInterfaceHost, err error) {
+	identifier = normalizeHostIdentifier(identifier)
+
 	response, err := c.get(ctx, fmt.Sprintf("lan/browser/%s/%s", interfaceName, identifier), c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == interfaceNotFoundCode {
+		if errors.Is(err, ErrDeviceNotFound) {
 			return result, ErrInterfaceNotFound
 		}
 
-		if response != nil && response.ErrorCode == interfaceHostNotFoundCode {
+		if errors.Is(err, ErrInterfaceHostNotFound) {
 			return result, ErrInterfaceHostNotFound
 		}
 
@@ -62,3 +64,78 @@ func (c *client) GetLanInterfaceHost(ctx context.Context, interfaceName, identif
 
 	return result, nil
 }
+
+// GetLanInterfaceHosts fetches the given hosts of an interface with at most concurrency requests at once.
+// The hosts are returned in the order of the identifiers. Errors on individual hosts do not stop the others: the
+// hosts which could not be fetched are left out of the result and their errors are all returned once every host has
+// been processed.
+func (c *client) GetLanInterfaceHosts(ctx context.Context, interfaceName string, identifiers []string, concurrency int) ([]types.LanInterfaceHost, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	// log in beforehand so that the concurrent requests share the same session
+	if _, err := c.ensureSession(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get a session: %w", err)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		hosts     = make([]types.LanInterfaceHost, len(identifiers))
+		errs      = make([]error, len(identifiers))
+		semaphore = make(chan struct{}, concurrency)
+	)
+
+	for index, identifier := range identifiers {
+		if err := ctx.Err(); err != nil {
+			errs[index] = err
+
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			errs[index] = ctx.Err()
+
+			continue
+		case semaphore <- struct{}{}:
+		}
+
+		wg.Add(1)
+
+		go func(index int, identifier string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			hosts[index], errs[index] = c.GetLanInterfaceHost(ctx, interfaceName, identifier)
+		}(index, identifier)
+	}
+
+	wg.Wait()
+
+	result := make([]types.LanInterfaceHost, 0, len(identifiers))
+	failures := make([]error, 0)
+
+	for index, err := range errs {
+		if err != nil {
+			failures = append(failures, fmt.Errorf("failed to get host %s: %w", identifiers[index], err))
+
+			continue
+		}
+
+		result = append(result, hosts[index])
+	}
+
+	return result, errors.Join(failures...)
+}
+
+// normalizeHostIdentifier formats an identifier which is a mac address, with or without the ether- prefix, the way the
+// freebox expects it, such as ether-7e:ec:37:cd:5b:6a. Other identifiers are returned as is.
+func normalizeHostIdentifier(identifier string) string {
+	address, err := types.ParseMACAddress(strings.TrimPrefix(identifier, hostMACIdentifierPrefix))
+	if err != nil {
+		return identifier
+	}
+
+	return hostMACIdentifierPrefix + address.String()
+}
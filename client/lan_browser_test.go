@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/netip"
 	"strings"
+	"sync"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -247,7 +249,7 @@ var _ = Describe("lan browser", func() {
 						},
 						L3Connectivities: []types.L3Connectivity{
 							{
-								Address:   "192.168.1.254",
+								Address:   netip.MustParseAddr("192.168.1.254"),
 								Active:    false,
 								Reachable: false,
 								LastActivity: types.Timestamp{
@@ -317,9 +319,13 @@ var _ = Describe("lan browser", func() {
 			interfaceName  = "pub"
 			hostIdentifier = "ether-7e:ec:37:cd:5b:6a"
 		)
+		var identifier string
 		returnedLanInterfaceHost := new(types.LanInterfaceHost)
+		BeforeEach(func() {
+			identifier = hostIdentifier
+		})
 		JustBeforeEach(func() {
-			*returnedLanInterfaceHost, *returnedErr = freeboxClient.GetLanInterfaceHost(context.Background(), interfaceName, hostIdentifier)
+			*returnedLanInterfaceHost, *returnedErr = freeboxClient.GetLanInterfaceHost(context.Background(), interfaceName, identifier)
 		})
 		Context("default", func() {
 			BeforeEach(func() {
@@ -399,7 +405,7 @@ var _ = Describe("lan browser", func() {
 					},
 					L3Connectivities: []types.L3Connectivity{
 						{
-							Address:   "192.168.1.254",
+							Address:   netip.MustParseAddr("192.168.1.254"),
 							Active:    false,
 							Reachable: false,
 							LastActivity: types.Timestamp{
@@ -480,5 +486,115 @@ var _ = Describe("lan browser", func() {
 				Expect(*returnedErr).ToNot(BeNil())
 			})
 		})
+		for _, raw := range []string{"7E-EC-37-CD-5B-6A", "ether-7E:EC:37:CD:5B:6A", "7eec37cd5b6a"} {
+			raw := raw
+			Context(fmt.Sprintf("when the host is identified by %q", raw), func() {
+				BeforeEach(func() {
+					identifier = raw
+
+					server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/lan/browser/%s/%s", version, interfaceName, hostIdentifier)),
+							verifyAuth(*sessionToken),
+							ghttp.RespondWith(http.StatusOK, `{
+								"success": true,
+								"result": {
+									"id": "ether-7e:ec:37:cd:5b:6a",
+									"interface": "pub"
+								}
+							}`),
+						),
+					)
+				})
+				It("should request the normalized host identifier", func() {
+					Expect(*returnedErr).To(BeNil())
+					Expect(returnedLanInterfaceHost.ID).To(Equal(hostIdentifier))
+				})
+			})
+		}
+	})
+	Context("getting several lan interface hosts", func() {
+		const interfaceName = "pub"
+
+		var (
+			identifiers []string
+			concurrency int
+
+			lock        *sync.Mutex
+			inFlight    *int
+			maxInFlight *int
+
+			returnedHosts = new([]types.LanInterfaceHost)
+		)
+		BeforeEach(func() {
+			identifiers = []string{"ether-00:00:00:00:00:01", "ether-00:00:00:00:00:02", "ether-00:00:00:00:00:03", "ether-00:00:00:00:00:04"}
+			concurrency = 2
+
+			lock = new(sync.Mutex)
+			inFlight, maxInFlight = new(int), new(int)
+
+			for _, identifier := range identifiers {
+				identifier := identifier
+				server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/lan/browser/%s/%s", version, interfaceName, identifier), ghttp.CombineHandlers(
+					verifyAuth(*sessionToken),
+					func(w http.ResponseWriter, r *http.Request) {
+						lock.Lock()
+						*inFlight++
+						*maxInFlight = max(*maxInFlight, *inFlight)
+						lock.Unlock()
+
+						time.Sleep(20 * time.Millisecond)
+
+						lock.Lock()
+						*inFlight--
+						lock.Unlock()
+					},
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": true,
+						"result": { "id": "`+identifier+`", "interface": "pub" }
+					}`),
+				))
+			}
+		})
+		JustBeforeEach(func() {
+			*returnedHosts, *returnedErr = freeboxClient.GetLanInterfaceHosts(context.Background(), interfaceName, identifiers, concurrency)
+		})
+		Context("default", func() {
+			It("should return every host in order with a bounded concurrency", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedHosts).To(HaveLen(len(identifiers)))
+				for index, host := range *returnedHosts {
+					Expect(host.ID).To(Equal(identifiers[index]))
+				}
+				Expect(*maxInFlight).To(BeNumerically("<=", concurrency))
+			})
+		})
+		Context("when some hosts do not exist", func() {
+			BeforeEach(func() {
+				identifiers = append(identifiers, "ether-00:00:00:00:00:05")
+				server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/lan/browser/%s/%s", version, interfaceName, "ether-00:00:00:00:00:05"), ghttp.CombineHandlers(
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"msg": "Impossible de récupérer l'hôte",
+						"success": false,
+						"error_code": "nohost"
+					}`),
+				))
+			})
+			It("should return the other hosts along with the errors", func() {
+				Expect(*returnedErr).To(MatchError(client.ErrInterfaceHostNotFound))
+				Expect(*returnedErr).To(MatchError(ContainSubstring("ether-00:00:00:00:00:05")))
+				Expect(*returnedHosts).To(HaveLen(len(identifiers) - 1))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+				Expect(*returnedHosts).To(BeEmpty())
+			})
+		})
 	})
 })
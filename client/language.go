@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// GetLanguageSettings returns the language of the Freebox OS interface, and the languages it supports.
+//
+// The published API does not expose the timezone nor the NTP servers of the freebox, whose clock is synchronized by
+// the freebox itself, so only the language can be normalized across boxes.
+func (c *client) GetLanguageSettings(ctx context.Context) (result types.LanguageSettings, err error) {
+	response, err := c.get(ctx, "lang/", c.withSession(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to GET lang/ endpoint: %w", err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get language settings from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdateLanguageSettings switches the Freebox OS interface to another language.
+func (c *client) UpdateLanguageSettings(ctx context.Context, payload types.LanguageSettingsPayload) error {
+	if _, err := c.post(ctx, "lang/", payload, c.withSession(ctx)); err != nil {
+		return fmt.Errorf("failed to POST lang/ endpoint: %w", err)
+	}
+
+	return nil
+}
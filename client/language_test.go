@@ -0,0 +1,121 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("language", func() {
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	Context("getting the language settings", func() {
+		returnedSettings := new(types.LanguageSettings)
+		JustBeforeEach(func() {
+			*returnedSettings, *returnedErr = freeboxClient.GetLanguageSettings(context.Background())
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/lang/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"lang": "fra",
+								"avalaible": ["fra", "eng", "ita"]
+							}
+						}`),
+					),
+				)
+			})
+			It("should return the correct settings", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedSettings).To(Equal(types.LanguageSettings{
+					Language:  "fra",
+					Available: []string{"fra", "eng", "ita"},
+				}))
+				Expect(returnedSettings.Supports("eng")).To(BeTrue())
+				Expect(returnedSettings.Supports("deu")).To(BeFalse())
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("updating the language settings", func() {
+		JustBeforeEach(func() {
+			*returnedErr = freeboxClient.UpdateLanguageSettings(context.Background(), types.LanguageSettingsPayload{
+				Language: "eng",
+			})
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/lang/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{"lang": "eng"}`),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the language is not supported", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/lang/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "invalid_lang",
+							"msg": "Langue invalide"
+						}`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).To(MatchError(ContainSubstring("Langue invalide")))
+			})
+		})
+	})
+})
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// ListNetworkControls returns the network control of every profile.
+func (c *client) ListNetworkControls(ctx context.Context) (result []types.NetworkControl, err error) {
+	response, err := c.get(ctx, "network_control/", c.withSession(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET network_control/ endpoint: %w", err)
+	}
+
+	if response.Result == nil {
+		return
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to get network controls from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetNetworkControl returns the network control of a profile.
+func (c *client) GetNetworkControl(ctx context.Context, profileID int64) (result types.NetworkControl, err error) {
+	response, err := c.get(ctx, fmt.Sprintf("network_control/%d", profileID), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrProfileNotFound
+		}
+
+		return result, fmt.Errorf("failed to GET network_control/%d endpoint: %w", profileID, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a network control from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdateNetworkControl updates the network control of a profile. Fields left empty are not updated.
+func (c *client) UpdateNetworkControl(ctx context.Context, profileID int64, payload types.NetworkControlPayload) (result types.NetworkControl, err error) {
+	response, err := c.put(ctx, fmt.Sprintf("network_control/%d", profileID), payload, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrProfileNotFound
+		}
+
+		return result, fmt.Errorf("failed to PUT network_control/%d endpoint: %w", profileID, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a network control from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// OverrideNetworkControl temporarily applies a mode to the devices of a profile, such as pausing internet for an hour.
+// The profile goes back to its rule mode once the duration is elapsed.
+func (c *client) OverrideNetworkControl(ctx context.Context, profileID int64, mode types.NetworkControlMode, duration time.Duration) (types.NetworkControl, error) {
+	override := true
+
+	return c.UpdateNetworkControl(ctx, profileID, types.NetworkControlPayload{
+		Override:      &override,
+		OverrideMode:  mode,
+		OverrideUntil: &types.Timestamp{Time: time.Now().Add(duration)},
+	})
+}
+
+// ClearNetworkControlOverride ends the temporary mode of a profile before its expiration.
+func (c *client) ClearNetworkControlOverride(ctx context.Context, profileID int64) (types.NetworkControl, error) {
+	override := false
+
+	return c.UpdateNetworkControl(ctx, profileID, types.NetworkControlPayload{
+		Override: &override,
+	})
+}
+
+// GetNetworkControlPlanning returns the weekly schedule of a profile.
+func (c *client) GetNetworkControlPlanning(ctx context.Context, profileID int64) (result types.NetworkControlPlanning, err error) {
+	response, err := c.get(ctx, fmt.Sprintf("network_control/%d/planning", profileID), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrProfileNotFound
+		}
+
+		return result, fmt.Errorf("failed to GET network_control/%d/planning endpoint: %w", profileID, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a network control planning from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdateNetworkControlPlanning replaces the weekly schedule of a profile. The mapping must hold 7 times resolution slots.
+func (c *client) UpdateNetworkControlPlanning(ctx context.Context, profileID int64, planning types.NetworkControlPlanning) (result types.NetworkControlPlanning, err error) {
+	if int64(len(planning.Mapping)) != 7*planning.Resolution {
+		return result, fmt.Errorf("%d slots for a resolution of %d: %w", len(planning.Mapping), planning.Resolution, ErrInvalidNetworkControlPlanning)
+	}
+
+	response, err := c.put(ctx, fmt.Sprintf("network_control/%d/planning", profileID), planning, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrProfileNotFound
+		}
+
+		return result, fmt.Errorf("failed to PUT network_control/%d/planning endpoint: %w", profileID, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a network control planning from generic response: %w", err)
+	}
+
+	return result, nil
+}
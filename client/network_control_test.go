@@ -0,0 +1,264 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("network control", func() {
+	const (
+		profileID          int64 = 4
+		networkControlBody       = `{
+			"profile_id": 4,
+			"rule_mode": "filtered",
+			"override": true,
+			"override_mode": "denied",
+			"override_until": 1700003600,
+			"current_mode": "denied",
+			"next_change": 1700003600,
+			"macs": ["00:11:22:33:44:55"]
+		}`
+	)
+
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	override := true
+	expectedNetworkControl := types.NetworkControl{
+		NetworkControlPayload: types.NetworkControlPayload{
+			RuleMode:      types.NetworkControlRuleModeFiltered,
+			Override:      &override,
+			OverrideMode:  types.NetworkControlModeDenied,
+			OverrideUntil: &types.Timestamp{Time: time.Unix(1700003600, 0).UTC()},
+		},
+		ProfileID:   profileID,
+		CurrentMode: types.NetworkControlModeDenied,
+		NextChange:  types.Timestamp{Time: time.Unix(1700003600, 0).UTC()},
+		MACs:        []types.MACAddress{Must(types.ParseMACAddress("00:11:22:33:44:55"))},
+	}
+	Context("listing network controls", func() {
+		returnedNetworkControls := new([]types.NetworkControl)
+		JustBeforeEach(func() {
+			*returnedNetworkControls, *returnedErr = freeboxClient.ListNetworkControls(context.Background())
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/network_control/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": [%s] }`, networkControlBody)),
+					),
+				)
+			})
+			It("should return the correct network controls", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedNetworkControls).To(Equal([]types.NetworkControl{expectedNetworkControl}))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("getting a network control", func() {
+		returnedNetworkControl := new(types.NetworkControl)
+		JustBeforeEach(func() {
+			*returnedNetworkControl, *returnedErr = freeboxClient.GetNetworkControl(context.Background(), profileID)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/network_control/%d", version, profileID)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %s }`, networkControlBody)),
+					),
+				)
+			})
+			It("should return the correct network control", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedNetworkControl).To(Equal(expectedNetworkControl))
+			})
+		})
+		Context("when the profile is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/network_control/%d", version, profileID)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "noent" }`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrProfileNotFound))
+			})
+		})
+	})
+	Context("updating a network control", func() {
+		returnedNetworkControl := new(types.NetworkControl)
+		JustBeforeEach(func() {
+			*returnedNetworkControl, *returnedErr = freeboxClient.UpdateNetworkControl(context.Background(), profileID, types.NetworkControlPayload{
+				RuleMode: types.NetworkControlRuleModeFiltered,
+			})
+		})
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/network_control/%d", version, profileID)),
+					verifyAuth(*sessionToken),
+					ghttp.VerifyJSON(`{ "rule_mode": "filtered" }`),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %s }`, networkControlBody)),
+				),
+			)
+		})
+		It("should return the updated network control", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*returnedNetworkControl).To(Equal(expectedNetworkControl))
+		})
+	})
+	Context("overriding a network control", func() {
+		var requestedUntil = new(time.Time)
+		JustBeforeEach(func() {
+			_, *returnedErr = freeboxClient.OverrideNetworkControl(context.Background(), profileID, types.NetworkControlModeDenied, time.Hour)
+		})
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/network_control/%d", version, profileID)),
+					verifyAuth(*sessionToken),
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := io.ReadAll(r.Body)
+						Expect(err).To(BeNil())
+
+						var payload types.NetworkControlPayload
+						Expect(json.Unmarshal(body, &payload)).To(Succeed())
+						Expect(*payload.Override).To(BeTrue())
+						Expect(payload.OverrideMode).To(Equal(types.NetworkControlModeDenied))
+						*requestedUntil = payload.OverrideUntil.Time
+					},
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %s }`, networkControlBody)),
+				),
+			)
+		})
+		It("should deny the access for the given duration", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*requestedUntil).To(BeTemporally("~", time.Now().Add(time.Hour), 5*time.Second))
+		})
+	})
+	Context("clearing an override", func() {
+		JustBeforeEach(func() {
+			_, *returnedErr = freeboxClient.ClearNetworkControlOverride(context.Background(), profileID)
+		})
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/network_control/%d", version, profileID)),
+					verifyAuth(*sessionToken),
+					ghttp.VerifyJSON(`{ "override": false }`),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %s }`, networkControlBody)),
+				),
+			)
+		})
+		It("should not return an error", func() {
+			Expect(*returnedErr).To(BeNil())
+		})
+	})
+	Context("getting a planning", func() {
+		returnedPlanning := new(types.NetworkControlPlanning)
+		JustBeforeEach(func() {
+			*returnedPlanning, *returnedErr = freeboxClient.GetNetworkControlPlanning(context.Background(), profileID)
+		})
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/network_control/%d/planning", version, profileID)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "resolution": 1, "mapping": ["allowed", "allowed", "allowed", "allowed", "allowed", "denied", "denied"] } }`),
+				),
+			)
+		})
+		It("should return the correct planning", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(returnedPlanning.Resolution).To(BeEquivalentTo(1))
+			Expect(returnedPlanning.Mapping).To(HaveLen(7))
+			Expect(returnedPlanning.Mapping[6]).To(Equal(types.NetworkControlModeDenied))
+		})
+	})
+	Context("updating a planning", func() {
+		planning := new(types.NetworkControlPlanning)
+		JustBeforeEach(func() {
+			_, *returnedErr = freeboxClient.UpdateNetworkControlPlanning(context.Background(), profileID, *planning)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				*planning = types.NetworkControlPlanning{
+					Resolution: 1,
+					Mapping: []types.NetworkControlMode{
+						types.NetworkControlModeAllowed, types.NetworkControlModeAllowed, types.NetworkControlModeAllowed,
+						types.NetworkControlModeAllowed, types.NetworkControlModeAllowed, types.NetworkControlModeWebOnly,
+						types.NetworkControlModeWebOnly,
+					},
+				}
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/network_control/%d/planning", version, profileID)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{ "resolution": 1, "mapping": ["allowed", "allowed", "allowed", "allowed", "allowed", "webonly", "webonly"] }`),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "resolution": 1, "mapping": ["allowed", "allowed", "allowed", "allowed", "allowed", "webonly", "webonly"] } }`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the mapping does not cover the week", func() {
+			BeforeEach(func() {
+				*planning = types.NetworkControlPlanning{
+					Resolution: 48,
+					Mapping:    []types.NetworkControlMode{types.NetworkControlModeAllowed},
+				}
+				server.Reset()
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(MatchError(client.ErrInvalidNetworkControlPlanning))
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+})
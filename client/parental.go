@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// GetParentalConfig returns the parental control configuration.
+func (c *client) GetParentalConfig(ctx context.Context) (result types.ParentalConfig, err error) {
+	response, err := c.get(ctx, "parental/config/", c.withSession(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to GET parental/config/ endpoint: %w", err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get the parental config from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdateParentalConfig updates the parental control configuration.
+func (c *client) UpdateParentalConfig(ctx context.Context, payload types.ParentalConfig) (result types.ParentalConfig, err error) {
+	response, err := c.put(ctx, "parental/config/", payload, c.withSession(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to PUT parental/config/ endpoint: %w", err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get the parental config from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// ListParentalFilters returns the parental control filters.
+func (c *client) ListParentalFilters(ctx context.Context) (result []types.ParentalFilter, err error) {
+	response, err := c.get(ctx, "parental/filter/", c.withSession(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET parental/filter/ endpoint: %w", err)
+	}
+
+	if response.Result == nil {
+		return
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to get parental filters from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetParentalFilter returns a parental control filter.
+func (c *client) GetParentalFilter(ctx context.Context, identifier int64) (result types.ParentalFilter, err error) {
+	response, err := c.get(ctx, fmt.Sprintf("parental/filter/%d", identifier), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrParentalFilterNotFound
+		}
+
+		return result, fmt.Errorf("failed to GET parental/filter/%d endpoint: %w", identifier, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a parental filter from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// CreateParentalFilter creates a parental control filter for a set of devices.
+func (c *client) CreateParentalFilter(ctx context.Context, payload types.ParentalFilterPayload) (result types.ParentalFilter, err error) {
+	response, err := c.post(ctx, "parental/filter/", payload, c.withSession(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to POST to parental/filter/ endpoint: %w", err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a parental filter from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdateParentalFilter updates a parental control filter.
+func (c *client) UpdateParentalFilter(ctx context.Context, identifier int64, payload types.ParentalFilterPayload) (result types.ParentalFilter, err error) {
+	response, err := c.put(ctx, fmt.Sprintf("parental/filter/%d", identifier), payload, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrParentalFilterNotFound
+		}
+
+		return result, fmt.Errorf("failed to PUT parental/filter/%d endpoint: %w", identifier, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a parental filter from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// DeleteParentalFilter deletes a parental control filter.
+func (c *client) DeleteParentalFilter(ctx context.Context, identifier int64) error {
+	_, err := c.delete(ctx, fmt.Sprintf("parental/filter/%d", identifier), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrParentalFilterNotFound
+		}
+
+		return fmt.Errorf("failed to DELETE parental/filter/%d endpoint: %w", identifier, err)
+	}
+
+	return nil
+}
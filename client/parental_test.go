@@ -0,0 +1,276 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("parental control", func() {
+	const (
+		identifier int64 = 2
+		filterBody       = `{
+			"id": 2,
+			"desc": "Tablette des enfants",
+			"macs": ["00:11:22:33:44:55"],
+			"mode": "planning",
+			"forced": false,
+			"current_mode": "denied"
+		}`
+	)
+
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	forced := false
+	expectedFilter := types.ParentalFilter{
+		ParentalFilterPayload: types.ParentalFilterPayload{
+			Description: "Tablette des enfants",
+			MACs:        []types.MACAddress{Must(types.ParseMACAddress("00:11:22:33:44:55"))},
+			Mode:        types.ParentalFilterModePlanning,
+			Forced:      &forced,
+		},
+		ID:          identifier,
+		CurrentMode: types.ParentalFilterModeDenied,
+	}
+	Context("getting the config", func() {
+		returnedConfig := new(types.ParentalConfig)
+		JustBeforeEach(func() {
+			*returnedConfig, *returnedErr = freeboxClient.GetParentalConfig(context.Background())
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/parental/config/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "default_filter_mode": "allowed" } }`),
+					),
+				)
+			})
+			It("should return the correct config", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedConfig).To(Equal(types.ParentalConfig{DefaultFilterMode: types.ParentalFilterModeAllowed}))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("updating the config", func() {
+		returnedConfig := new(types.ParentalConfig)
+		JustBeforeEach(func() {
+			*returnedConfig, *returnedErr = freeboxClient.UpdateParentalConfig(context.Background(), types.ParentalConfig{
+				DefaultFilterMode: types.ParentalFilterModeWebOnly,
+			})
+		})
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/parental/config/", version)),
+					verifyAuth(*sessionToken),
+					ghttp.VerifyJSON(`{ "default_filter_mode": "webonly" }`),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "default_filter_mode": "webonly" } }`),
+				),
+			)
+		})
+		It("should return the updated config", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*returnedConfig).To(Equal(types.ParentalConfig{DefaultFilterMode: types.ParentalFilterModeWebOnly}))
+		})
+	})
+	Context("listing filters", func() {
+		returnedFilters := new([]types.ParentalFilter)
+		JustBeforeEach(func() {
+			*returnedFilters, *returnedErr = freeboxClient.ListParentalFilters(context.Background())
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/parental/filter/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": [%s] }`, filterBody)),
+					),
+				)
+			})
+			It("should return the correct filters", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedFilters).To(Equal([]types.ParentalFilter{expectedFilter}))
+			})
+		})
+		Context("when there are no filters", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/parental/filter/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+				)
+			})
+			It("should return an empty list", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedFilters).To(BeEmpty())
+			})
+		})
+	})
+	Context("getting a filter", func() {
+		returnedFilter := new(types.ParentalFilter)
+		JustBeforeEach(func() {
+			*returnedFilter, *returnedErr = freeboxClient.GetParentalFilter(context.Background(), identifier)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/parental/filter/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %s }`, filterBody)),
+					),
+				)
+			})
+			It("should return the correct filter", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedFilter).To(Equal(expectedFilter))
+			})
+		})
+		Context("when the filter is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/parental/filter/%d", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "noent" }`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrParentalFilterNotFound))
+			})
+		})
+	})
+	Context("creating a filter", func() {
+		returnedFilter := new(types.ParentalFilter)
+		JustBeforeEach(func() {
+			*returnedFilter, *returnedErr = freeboxClient.CreateParentalFilter(context.Background(), types.ParentalFilterPayload{
+				Description: "Tablette des enfants",
+				MACs:        []types.MACAddress{Must(types.ParseMACAddress("00:11:22:33:44:55"))},
+				Mode:        types.ParentalFilterModePlanning,
+			})
+		})
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/parental/filter/", version)),
+					verifyAuth(*sessionToken),
+					ghttp.VerifyJSON(`{ "desc": "Tablette des enfants", "macs": ["00:11:22:33:44:55"], "mode": "planning" }`),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %s }`, filterBody)),
+				),
+			)
+		})
+		It("should return the created filter", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*returnedFilter).To(Equal(expectedFilter))
+		})
+	})
+	Context("updating a filter", func() {
+		returnedFilter := new(types.ParentalFilter)
+		JustBeforeEach(func() {
+			*returnedFilter, *returnedErr = freeboxClient.UpdateParentalFilter(context.Background(), identifier, types.ParentalFilterPayload{
+				Mode: types.ParentalFilterModeDenied,
+			})
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/parental/filter/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{ "mode": "denied" }`),
+						ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %s }`, filterBody)),
+					),
+				)
+			})
+			It("should return the updated filter", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedFilter.ID).To(Equal(identifier))
+			})
+		})
+		Context("when the filter is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/parental/filter/%d", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "noent" }`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrParentalFilterNotFound))
+			})
+		})
+	})
+	Context("deleting a filter", func() {
+		JustBeforeEach(func() {
+			*returnedErr = freeboxClient.DeleteParentalFilter(context.Background(), identifier)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/parental/filter/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the filter is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/parental/filter/%d", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "noent" }`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrParentalFilterNotFound))
+			})
+		})
+	})
+})
@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// ListPhones returns the status of the phones handled by the freebox.
+func (c *client) ListPhones(ctx context.Context) (result []types.PhoneStatus, err error) {
+	response, err := c.get(ctx, "phone/", c.withSession(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET phone/ endpoint: %w", err)
+	}
+
+	if response.Result == nil {
+		return
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to get phones from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetPhone returns the status of a phone handled by the freebox.
+func (c *client) GetPhone(ctx context.Context, identifier int64) (result types.PhoneStatus, err error) {
+	response, err := c.get(ctx, fmt.Sprintf("phone/%d", identifier), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrPhoneNotFound
+		}
+
+		return result, fmt.Errorf("failed to GET phone/%d endpoint: %w", identifier, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a phone from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// ListDECTHandsets returns the status of the handsets paired with the DECT base.
+func (c *client) ListDECTHandsets(ctx context.Context) ([]types.PhoneStatus, error) {
+	phones, err := c.ListPhones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	handsets := make([]types.PhoneStatus, 0, len(phones))
+	for _, phone := range phones {
+		if phone.Type == types.PhoneTypeDECT {
+			handsets = append(handsets, phone)
+		}
+	}
+
+	return handsets, nil
+}
+
+// GetPhoneConfig returns the telephony configuration.
+func (c *client) GetPhoneConfig(ctx context.Context) (result types.PhoneConfig, err error) {
+	response, err := c.get(ctx, "phone/config/", c.withSession(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to GET phone/config/ endpoint: %w", err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get phone config from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdatePhoneConfig updates the telephony configuration. Fields left empty are not updated.
+func (c *client) UpdatePhoneConfig(ctx context.Context, payload types.PhoneConfigPayload) (result types.PhoneConfig, err error) {
+	response, err := c.put(ctx, "phone/config/", payload, c.withSession(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to PUT phone/config/ endpoint: %w", err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get phone config from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// StartDECTPairing lets new handsets be paired with the DECT base.
+func (c *client) StartDECTPairing(ctx context.Context) error {
+	return c.setDECTRegistration(ctx, true)
+}
+
+// StopDECTPairing stops accepting new handsets on the DECT base.
+func (c *client) StopDECTPairing(ctx context.Context) error {
+	return c.setDECTRegistration(ctx, false)
+}
+
+func (c *client) setDECTRegistration(ctx context.Context, enabled bool) error {
+	if _, err := c.UpdatePhoneConfig(ctx, types.PhoneConfigPayload{
+		DECTRegistration: &enabled,
+	}); err != nil {
+		return fmt.Errorf("failed to set DECT registration to %t: %w", enabled, err)
+	}
+
+	return nil
+}
+
+// StartFXSRing makes the analog phone plugged into the freebox ring, to check the line works. The published API has
+// no echo test action, so ringing is the only check of the line it offers.
+func (c *client) StartFXSRing(ctx context.Context) error {
+	if _, err := c.post(ctx, "phone/fxs_ring_start/", nil, c.withSession(ctx)); err != nil {
+		return fmt.Errorf("failed to POST to phone/fxs_ring_start/ endpoint: %w", err)
+	}
+
+	return nil
+}
+
+// StopFXSRing stops the ringing started by StartFXSRing.
+func (c *client) StopFXSRing(ctx context.Context) error {
+	if _, err := c.post(ctx, "phone/fxs_ring_stop/", nil, c.withSession(ctx)); err != nil {
+		return fmt.Errorf("failed to POST to phone/fxs_ring_stop/ endpoint: %w", err)
+	}
+
+	return nil
+}
+
+// StartDECTPaging makes every handset paired with the DECT base ring, to find a lost one.
+func (c *client) StartDECTPaging(ctx context.Context) error {
+	if _, err := c.post(ctx, "phone/dect_page_start/", nil, c.withSession(ctx)); err != nil {
+		return fmt.Errorf("failed to POST to phone/dect_page_start/ endpoint: %w", err)
+	}
+
+	return nil
+}
+
+// StopDECTPaging stops the ringing started by StartDECTPaging.
+func (c *client) StopDECTPaging(ctx context.Context) error {
+	if _, err := c.post(ctx, "phone/dect_page_stop/", nil, c.withSession(ctx)); err != nil {
+		return fmt.Errorf("failed to POST to phone/dect_page_stop/ endpoint: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,397 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("phone", func() {
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	phonesHandler := func() http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/phone/", version)),
+			verifyAuth(*sessionToken),
+			ghttp.RespondWith(http.StatusOK, `{
+				"success": true,
+				"result": [
+					{
+						"id": 0,
+						"type": "fxs",
+						"vendor": "unknown",
+						"is_ringing": false,
+						"on_hook": true,
+						"hardware_defect": false,
+						"gain_rx": 6,
+						"gain_tx": 6
+					},
+					{
+						"id": 1,
+						"type": "dect",
+						"vendor": "gigaset",
+						"is_ringing": true,
+						"on_hook": true,
+						"hardware_defect": false
+					}
+				]
+			}`),
+		)
+	}
+	Context("listing phones", func() {
+		returnedPhones := new([]types.PhoneStatus)
+		JustBeforeEach(func() {
+			*returnedPhones, *returnedErr = freeboxClient.ListPhones(context.Background())
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(phonesHandler())
+			})
+			It("should return the correct phones", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedPhones).To(Equal([]types.PhoneStatus{
+					{
+						ID:     0,
+						Type:   types.PhoneTypeFXS,
+						Vendor: "unknown",
+						OnHook: true,
+						GainRX: 6,
+						GainTX: 6,
+					},
+					{
+						ID:        1,
+						Type:      types.PhoneTypeDECT,
+						Vendor:    "gigaset",
+						IsRinging: true,
+						OnHook:    true,
+					},
+				}))
+			})
+		})
+		Context("when there are no phones", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/phone/", version)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+				)
+			})
+			It("should return an empty list", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedPhones).To(BeEmpty())
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("getting a phone", func() {
+		const identifier int64 = 0
+		returnedPhone := new(types.PhoneStatus)
+		JustBeforeEach(func() {
+			*returnedPhone, *returnedErr = freeboxClient.GetPhone(context.Background(), identifier)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/phone/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"id": 0,
+								"type": "fxs",
+								"vendor": "unknown",
+								"is_ringing": false,
+								"on_hook": false,
+								"hardware_defect": true
+							}
+						}`),
+					),
+				)
+			})
+			It("should return the correct phone", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedPhone).To(Equal(types.PhoneStatus{
+					ID:             identifier,
+					Type:           types.PhoneTypeFXS,
+					Vendor:         "unknown",
+					HardwareDefect: true,
+				}))
+			})
+		})
+		Context("when the phone is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/phone/%d", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "noent" }`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrPhoneNotFound))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("ringing the FXS phone", func() {
+		var action = new(string)
+		JustBeforeEach(func() {
+			if *action == "start" {
+				*returnedErr = freeboxClient.StartFXSRing(context.Background())
+			} else {
+				*returnedErr = freeboxClient.StopFXSRing(context.Background())
+			}
+		})
+		for _, a := range []string{"start", "stop"} {
+			a := a
+			Context(fmt.Sprintf("when calling fxs_ring_%s", a), func() {
+				BeforeEach(func() {
+					*action = a
+					server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/phone/fxs_ring_%s/", version, a)),
+							verifyAuth(*sessionToken),
+							ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+						),
+					)
+				})
+				It("should not return an error", func() {
+					Expect(*returnedErr).To(BeNil())
+				})
+			})
+		}
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				*action = "stop"
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("listing DECT handsets", func() {
+		returnedHandsets := new([]types.PhoneStatus)
+		JustBeforeEach(func() {
+			*returnedHandsets, *returnedErr = freeboxClient.ListDECTHandsets(context.Background())
+		})
+		BeforeEach(func() {
+			server.AppendHandlers(phonesHandler())
+		})
+		It("should only return the DECT handsets", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*returnedHandsets).To(HaveLen(1))
+			Expect((*returnedHandsets)[0].ID).To(BeEquivalentTo(1))
+		})
+	})
+	Context("getting the phone configuration", func() {
+		returnedConfig := new(types.PhoneConfig)
+		JustBeforeEach(func() {
+			*returnedConfig, *returnedErr = freeboxClient.GetPhoneConfig(context.Background())
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/phone/config/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"network": true,
+								"dect_eco_mode": false,
+								"dect_pin": "1234",
+								"dect_ring_pattern": 1,
+								"dect_registration": false,
+								"dect_nemo_mode": true,
+								"dect_enabled": true,
+								"dect_ring_on_off": true
+							}
+						}`),
+					),
+				)
+			})
+			It("should return the correct configuration", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedConfig).To(Equal(types.PhoneConfig{
+					Network:         true,
+					DECTEnabled:     true,
+					DECTNemoMode:    true,
+					DECTRingOnOff:   true,
+					DECTPin:         "1234",
+					DECTRingPattern: 1,
+				}))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("updating the phone configuration", func() {
+		returnedConfig := new(types.PhoneConfig)
+		JustBeforeEach(func() {
+			enabled := false
+			*returnedConfig, *returnedErr = freeboxClient.UpdatePhoneConfig(context.Background(), types.PhoneConfigPayload{
+				DECTEnabled: &enabled,
+			})
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/phone/config/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{ "dect_enabled": false }`),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"network": true,
+								"dect_enabled": false
+							}
+						}`),
+					),
+				)
+			})
+			It("should return the updated configuration", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedConfig.DECTEnabled).To(BeFalse())
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("pairing DECT handsets", func() {
+		var start = new(bool)
+		JustBeforeEach(func() {
+			if *start {
+				*returnedErr = freeboxClient.StartDECTPairing(context.Background())
+			} else {
+				*returnedErr = freeboxClient.StopDECTPairing(context.Background())
+			}
+		})
+		for _, enabled := range []bool{true, false} {
+			enabled := enabled
+			Context(fmt.Sprintf("when setting the registration to %t", enabled), func() {
+				BeforeEach(func() {
+					*start = enabled
+					server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/phone/config/", version)),
+							verifyAuth(*sessionToken),
+							ghttp.VerifyJSON(fmt.Sprintf(`{ "dect_registration": %t }`, enabled)),
+							ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": { "dect_registration": %t } }`, enabled)),
+						),
+					)
+				})
+				It("should update the configuration", func() {
+					Expect(*returnedErr).To(BeNil())
+				})
+			})
+		}
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				*start = true
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("paging DECT handsets", func() {
+		var action = new(string)
+		JustBeforeEach(func() {
+			if *action == "start" {
+				*returnedErr = freeboxClient.StartDECTPaging(context.Background())
+			} else {
+				*returnedErr = freeboxClient.StopDECTPaging(context.Background())
+			}
+		})
+		for _, a := range []string{"start", "stop"} {
+			a := a
+			Context(fmt.Sprintf("when calling dect_page_%s", a), func() {
+				BeforeEach(func() {
+					*action = a
+					server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/phone/dect_page_%s/", version, a)),
+							verifyAuth(*sessionToken),
+							ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+						),
+					)
+				})
+				It("should not return an error", func() {
+					Expect(*returnedErr).To(BeNil())
+				})
+			})
+		}
+		Context("when the freebox refuses", func() {
+			BeforeEach(func() {
+				*action = "start"
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/phone/dect_page_start/", version)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "invalid_request" }`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+})
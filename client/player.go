@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// ListPlayers returns the Freebox Players known by the freebox.
+func (c *client) ListPlayers(ctx context.Context) (result []types.Player, err error) {
+	response, err := c.get(ctx, "player", c.withSession(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET player endpoint: %w", err)
+	}
+
+	if response.Result == nil {
+		return
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to get players from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetPlayerStatus returns the power state of a Freebox Player, along with what it is displaying.
+func (c *client) GetPlayerStatus(ctx context.Context, identifier int64) (result types.PlayerStatus, err error) {
+	path, err := c.playerPath(ctx, identifier, "status/")
+	if err != nil {
+		return result, err
+	}
+
+	response, err := c.get(ctx, path, c.withSession(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to GET %s endpoint: %w", path, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a player status from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// playerPath returns the path of an endpoint of the API of a player, which is versioned independently of the
+// freebox API: /api/<version>/player/<id>/api/<player version>/<endpoint>.
+func (c *client) playerPath(ctx context.Context, identifier int64, endpoint string) (string, error) {
+	players, err := c.ListPlayers(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for _, player := range players {
+		if player.ID != identifier {
+			continue
+		}
+
+		if !player.APIAvailable || !player.Reachable || player.APIVersion == "" {
+			return "", fmt.Errorf("player %d: %w", identifier, ErrPlayerUnavailable)
+		}
+
+		major, _, _ := strings.Cut(player.APIVersion, ".")
+
+		return fmt.Sprintf("player/%d/api/v%s/%s", identifier, major, endpoint), nil
+	}
+
+	return "", ErrPlayerNotFound
+}
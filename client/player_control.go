@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// SendPlayerKey sends a media control key to a Freebox Player, as if pressed on its remote.
+func (c *client) SendPlayerKey(ctx context.Context, identifier int64, key types.PlayerKey) error {
+	path, err := c.playerPath(ctx, identifier, "control/mediactrl")
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.post(ctx, path, map[string]interface{}{"name": key}, c.withSession(ctx)); err != nil {
+		return fmt.Errorf("failed to POST to %s endpoint: %w", path, err)
+	}
+
+	return nil
+}
+
+// GetPlayerVolume returns the volume of a Freebox Player.
+func (c *client) GetPlayerVolume(ctx context.Context, identifier int64) (result types.PlayerVolume, err error) {
+	path, err := c.playerPath(ctx, identifier, "control/volume")
+	if err != nil {
+		return result, err
+	}
+
+	response, err := c.get(ctx, path, c.withSession(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to GET %s endpoint: %w", path, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a player volume from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// SetPlayerVolume sets the volume of a Freebox Player, or mutes it. Fields left empty are not updated.
+func (c *client) SetPlayerVolume(ctx context.Context, identifier int64, payload types.PlayerVolumePayload) (result types.PlayerVolume, err error) {
+	if payload.Volume != nil && (*payload.Volume < 0 || *payload.Volume > 100) {
+		return result, fmt.Errorf("%d: %w", *payload.Volume, ErrInvalidPlayerVolume)
+	}
+
+	path, err := c.playerPath(ctx, identifier, "control/volume")
+	if err != nil {
+		return result, err
+	}
+
+	response, err := c.put(ctx, path, payload, c.withSession(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to PUT %s endpoint: %w", path, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a player volume from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// OpenPlayerURL makes a Freebox Player open a URL, such as the URL of a media to play.
+func (c *client) OpenPlayerURL(ctx context.Context, identifier int64, url string) error {
+	path, err := c.playerPath(ctx, identifier, "control/open")
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.post(ctx, path, map[string]interface{}{"url": url}, c.withSession(ctx)); err != nil {
+		return fmt.Errorf("failed to POST to %s endpoint: %w", path, err)
+	}
+
+	return nil
+}
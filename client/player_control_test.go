@@ -0,0 +1,173 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("player control", func() {
+	const identifier int64 = 1
+
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/player", version)),
+				verifyAuth(*sessionToken),
+				ghttp.RespondWith(http.StatusOK, `{
+					"success": true,
+					"result": [
+						{ "id": 1, "device_name": "Freebox Player POP", "api_version": "6.2", "api_available": true, "reachable": true }
+					]
+				}`),
+			),
+		)
+	})
+	Context("sending a key", func() {
+		JustBeforeEach(func() {
+			*returnedErr = freeboxClient.SendPlayerKey(context.Background(), identifier, types.PlayerKeyPause)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/player/%d/api/v6/control/mediactrl", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{ "name": "pause" }`),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the player refuses the key", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/player/%d/api/v6/control/mediactrl", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "invalid_request" }`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("getting the volume", func() {
+		returnedVolume := new(types.PlayerVolume)
+		JustBeforeEach(func() {
+			*returnedVolume, *returnedErr = freeboxClient.GetPlayerVolume(context.Background(), identifier)
+		})
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/player/%d/api/v6/control/volume", version, identifier)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "volume": 42, "mute": false } }`),
+				),
+			)
+		})
+		It("should return the correct volume", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*returnedVolume).To(Equal(types.PlayerVolume{Volume: 42}))
+		})
+	})
+	Context("setting the volume", func() {
+		payload := new(types.PlayerVolumePayload)
+		returnedVolume := new(types.PlayerVolume)
+		JustBeforeEach(func() {
+			*returnedVolume, *returnedErr = freeboxClient.SetPlayerVolume(context.Background(), identifier, *payload)
+		})
+		Context("when muting the player", func() {
+			BeforeEach(func() {
+				mute := true
+				*payload = types.PlayerVolumePayload{Mute: &mute}
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/player/%d/api/v6/control/volume", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{ "mute": true }`),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "volume": 42, "mute": true } }`),
+					),
+				)
+			})
+			It("should return the updated volume", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedVolume).To(Equal(types.PlayerVolume{Volume: 42, Mute: true}))
+			})
+		})
+		Context("when the volume is out of range", func() {
+			BeforeEach(func() {
+				volume := int64(101)
+				*payload = types.PlayerVolumePayload{Volume: &volume}
+				server.Reset()
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(MatchError(client.ErrInvalidPlayerVolume))
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+	Context("opening a url", func() {
+		JustBeforeEach(func() {
+			*returnedErr = freeboxClient.OpenPlayerURL(context.Background(), identifier, "https://example.com/video.mp4")
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/player/%d/api/v6/control/open", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{ "url": "https://example.com/video.mp4" }`),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the player is not found", func() {
+			BeforeEach(func() {
+				server.SetHandler(2, ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/player", version)),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": [] }`),
+				))
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrPlayerNotFound))
+			})
+		})
+	})
+})
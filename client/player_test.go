@@ -0,0 +1,206 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("player", func() {
+	const identifier int64 = 1
+
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	playersHandler := func(apiAvailable bool) http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/player", version)),
+			verifyAuth(*sessionToken),
+			ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+				"success": true,
+				"result": [
+					{
+						"id": 1,
+						"device_name": "Freebox Player POP",
+						"device_model": "fbx8am",
+						"uid": "6ed6b3b0d8b8e0a5e3bd8f3f1c0c7f4a",
+						"stb_type": "android_tv",
+						"api_version": "6.2",
+						"api_available": %t,
+						"reachable": true
+					}
+				]
+			}`, apiAvailable)),
+		)
+	}
+	Context("listing players", func() {
+		returnedPlayers := new([]types.Player)
+		JustBeforeEach(func() {
+			*returnedPlayers, *returnedErr = freeboxClient.ListPlayers(context.Background())
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(playersHandler(true))
+			})
+			It("should return the correct players", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedPlayers).To(Equal([]types.Player{
+					{
+						ID:           identifier,
+						DeviceName:   "Freebox Player POP",
+						DeviceModel:  "fbx8am",
+						UID:          "6ed6b3b0d8b8e0a5e3bd8f3f1c0c7f4a",
+						STBType:      "android_tv",
+						APIVersion:   "6.2",
+						APIAvailable: true,
+						Reachable:    true,
+					},
+				}))
+			})
+		})
+		Context("when there are no players", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/player", version)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+				)
+			})
+			It("should return an empty list", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedPlayers).To(BeEmpty())
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("getting the status of a player", func() {
+		var playerID = new(int64)
+		returnedStatus := new(types.PlayerStatus)
+		BeforeEach(func() {
+			*playerID = identifier
+		})
+		JustBeforeEach(func() {
+			*returnedStatus, *returnedErr = freeboxClient.GetPlayerStatus(context.Background(), *playerID)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					playersHandler(true),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/player/%d/api/v6/status/", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"power_state": "running",
+								"foreground_app": {
+									"package_id": 23,
+									"package": "fr.freebox.tv",
+									"curl_url": "tv",
+									"context": {
+										"channel": {
+											"channelName": "France 2",
+											"channelNumber": 2,
+											"channelUuid": "uuid-webtv-201"
+										},
+										"show": {
+											"title": "Le journal",
+											"subtitle": "Édition du soir"
+										}
+									}
+								}
+							}
+						}`),
+					),
+				)
+			})
+			It("should return the correct status", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedStatus).To(Equal(types.PlayerStatus{
+					PowerState: types.PlayerPowerStateRunning,
+					ForegroundApp: types.PlayerForegroundApp{
+						PackageID: 23,
+						Package:   "fr.freebox.tv",
+						CurlURL:   "tv",
+						Context: types.PlayerAppContext{
+							Channel: &types.PlayerChannel{
+								Name:   "France 2",
+								Number: 2,
+								UUID:   "uuid-webtv-201",
+							},
+							Show: &types.PlayerShow{
+								Title:    "Le journal",
+								Subtitle: "Édition du soir",
+							},
+						},
+					},
+				}))
+			})
+		})
+		Context("when the player is not found", func() {
+			BeforeEach(func() {
+				*playerID = 2
+				server.AppendHandlers(playersHandler(true))
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrPlayerNotFound))
+			})
+		})
+		Context("when the player api is not available", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(playersHandler(false))
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(MatchError(client.ErrPlayerUnavailable))
+			})
+		})
+		Context("when the player fails to respond", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					playersHandler(true),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/player/%d/api/v6/status/", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "internal_error" }`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+})
@@ -2,15 +2,12 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/nikolalohinski/free-go/types"
 )
 
-const (
-	codePortForwardingNotFound = "noent"
-)
-
 func (c *client) ListPortForwardingRules(ctx context.Context) ([]types.PortForwardingRule, error) {
 	response, err := c.get(ctx, "fw/redir/", c.withSession(ctx))
 	if err != nil {
@@ -30,7 +27,7 @@ func (c *client) ListPortForwardingRules(ctx context.Context) ([]types.PortForwa
 func (c *client) GetPortForwardingRule(ctx context.Context, identifier int64) (rule types.PortForwardingRule, err error) {
 	response, err := c.get(ctx, fmt.Sprintf("fw/redir/%d", identifier), c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == codePortForwardingNotFound {
+		if errors.Is(err, ErrNotFound) {
 			return rule, ErrPortForwardingRuleNotFound
 		}
 
@@ -61,9 +58,9 @@ func (c *client) CreatePortForwardingRule(
 }
 
 func (c *client) DeletePortForwardingRule(ctx context.Context, identifier int64) error {
-	response, err := c.delete(ctx, fmt.Sprintf("fw/redir/%d", identifier), c.withSession(ctx))
+	_, err := c.delete(ctx, fmt.Sprintf("fw/redir/%d", identifier), c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == codePortForwardingNotFound {
+		if errors.Is(err, ErrNotFound) {
 			return ErrPortForwardingRuleNotFound
 		}
 
@@ -80,7 +77,7 @@ func (c *client) UpdatePortForwardingRule(
 ) (rule types.PortForwardingRule, err error) {
 	response, err := c.put(ctx, fmt.Sprintf("fw/redir/%d", identifier), payload, c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == codePortForwardingNotFound {
+		if errors.Is(err, ErrNotFound) {
 			return rule, ErrPortForwardingRuleNotFound
 		}
 
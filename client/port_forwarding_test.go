@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/netip"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -315,15 +316,15 @@ var _ = Describe("port forwarding", func() {
 			payload      = new(types.PortForwardingRulePayload)
 		)
 		BeforeEach(func() {
-			enabled := true
+			sourceIP, lanIP := netip.MustParseAddr("0.0.0.0"), netip.MustParseAddr("192.168.1.254")
 			*payload = types.PortForwardingRulePayload{
-				Enabled:      &enabled,
+				Enabled:      types.NewOptional(true),
 				Comment:      "test",
-				SourceIP:     "0.0.0.0",
+				SourceIP:     types.NewOptional(sourceIP),
 				LanPort:      80,
 				WanPortStart: 12345,
 				WanPortEnd:   12345,
-				LanIP:        "192.168.1.254",
+				LanIP:        types.NewOptional(lanIP),
 				IPProtocol:   types.TCP,
 			}
 		})
@@ -514,7 +515,7 @@ var _ = Describe("port forwarding", func() {
 		)
 		BeforeEach(func() {
 			*payload = types.PortForwardingRulePayload{
-				Enabled: new(bool),
+				Enabled: types.NewOptional(false),
 			}
 		})
 		JustBeforeEach(func() {
@@ -595,7 +596,7 @@ var _ = Describe("port forwarding", func() {
 					"ID":    Equal(int64(5)),
 					"Valid": Equal(true),
 					"PortForwardingRulePayload": MatchFields(IgnoreExtras, Fields{
-						"Enabled":    PointTo(Equal(false)),
+						"Enabled":    Equal(types.NewOptional(false)),
 						"LanPort":    Equal(int64(80)),
 						"IPProtocol": Equal(types.TCP),
 					}),
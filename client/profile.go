@@ -0,0 +1,155 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// ListProfiles returns the profiles used to group the devices of a person, such as for network control.
+func (c *client) ListProfiles(ctx context.Context) (result []types.Profile, err error) {
+	response, err := c.get(ctx, "profile/", c.withSession(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET profile/ endpoint: %w", err)
+	}
+
+	if response.Result == nil {
+		return
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to get profiles from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetProfile returns a profile.
+func (c *client) GetProfile(ctx context.Context, identifier int64) (result types.Profile, err error) {
+	response, err := c.get(ctx, fmt.Sprintf("profile/%d", identifier), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrProfileNotFound
+		}
+
+		return result, fmt.Errorf("failed to GET profile/%d endpoint: %w", identifier, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a profile from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// CreateProfile creates a profile.
+func (c *client) CreateProfile(ctx context.Context, payload types.ProfilePayload) (result types.Profile, err error) {
+	response, err := c.post(ctx, "profile/", payload, c.withSession(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to POST to profile/ endpoint: %w", err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a profile from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdateProfile updates the name or the icon of a profile.
+func (c *client) UpdateProfile(ctx context.Context, identifier int64, payload types.ProfilePayload) (result types.Profile, err error) {
+	response, err := c.put(ctx, fmt.Sprintf("profile/%d", identifier), payload, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrProfileNotFound
+		}
+
+		return result, fmt.Errorf("failed to PUT profile/%d endpoint: %w", identifier, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a profile from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// DeleteProfile deletes a profile. The devices of the profile are released from its network control.
+func (c *client) DeleteProfile(ctx context.Context, identifier int64) error {
+	_, err := c.delete(ctx, fmt.Sprintf("profile/%d", identifier), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrProfileNotFound
+		}
+
+		return fmt.Errorf("failed to DELETE profile/%d endpoint: %w", identifier, err)
+	}
+
+	return nil
+}
+
+// SetProfileHosts replaces the LAN hosts of a profile, identified by their MAC address.
+func (c *client) SetProfileHosts(ctx context.Context, identifier int64, macs []types.MACAddress) (result types.NetworkControl, err error) {
+	if macs == nil {
+		macs = []types.MACAddress{}
+	}
+
+	response, err := c.put(ctx, fmt.Sprintf("network_control/%d", identifier), map[string]interface{}{
+		"macs": macs,
+	}, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrProfileNotFound
+		}
+
+		return result, fmt.Errorf("failed to PUT network_control/%d endpoint: %w", identifier, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a network control from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// AddProfileHost attaches a LAN host to a profile, identified by its MAC address. Adding a host already attached to the
+// profile does nothing.
+func (c *client) AddProfileHost(ctx context.Context, identifier int64, mac types.MACAddress) (types.NetworkControl, error) {
+	networkControl, err := c.GetNetworkControl(ctx, identifier)
+	if err != nil {
+		return types.NetworkControl{}, err
+	}
+
+	for _, existing := range networkControl.MACs {
+		if bytes.Equal(existing, mac) {
+			return networkControl, nil
+		}
+	}
+
+	return c.SetProfileHosts(ctx, identifier, append(networkControl.MACs, mac))
+}
+
+// RemoveProfileHost detaches a LAN host from a profile. Removing a host which is not attached to the profile does
+// nothing.
+func (c *client) RemoveProfileHost(ctx context.Context, identifier int64, mac types.MACAddress) (types.NetworkControl, error) {
+	networkControl, err := c.GetNetworkControl(ctx, identifier)
+	if err != nil {
+		return types.NetworkControl{}, err
+	}
+
+	macs := make([]types.MACAddress, 0, len(networkControl.MACs))
+	for _, existing := range networkControl.MACs {
+		if !bytes.Equal(existing, mac) {
+			macs = append(macs, existing)
+		}
+	}
+
+	if len(macs) == len(networkControl.MACs) {
+		return networkControl, nil
+	}
+
+	return c.SetProfileHosts(ctx, identifier, macs)
+}
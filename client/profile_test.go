@@ -0,0 +1,288 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("profile", func() {
+	const identifier int64 = 4
+
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	Context("listing profiles", func() {
+		returnedProfiles := new([]types.Profile)
+		JustBeforeEach(func() {
+			*returnedProfiles, *returnedErr = freeboxClient.ListProfiles(context.Background())
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/profile/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								{ "id": 4, "name": "Léa", "icon": "/resources/images/profile/profile_02.png" }
+							]
+						}`),
+					),
+				)
+			})
+			It("should return the correct profiles", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedProfiles).To(Equal([]types.Profile{
+					{ID: identifier, ProfilePayload: types.ProfilePayload{Name: "Léa", Icon: "/resources/images/profile/profile_02.png"}},
+				}))
+			})
+		})
+		Context("when there are no profiles", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/profile/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+				)
+			})
+			It("should return an empty list", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedProfiles).To(BeEmpty())
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("getting a profile", func() {
+		returnedProfile := new(types.Profile)
+		JustBeforeEach(func() {
+			*returnedProfile, *returnedErr = freeboxClient.GetProfile(context.Background(), identifier)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/profile/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "id": 4, "name": "Léa" } }`),
+					),
+				)
+			})
+			It("should return the correct profile", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedProfile).To(Equal(types.Profile{ID: identifier, ProfilePayload: types.ProfilePayload{Name: "Léa"}}))
+			})
+		})
+		Context("when the profile is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/profile/%d", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "noent" }`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrProfileNotFound))
+			})
+		})
+	})
+	Context("creating a profile", func() {
+		returnedProfile := new(types.Profile)
+		JustBeforeEach(func() {
+			*returnedProfile, *returnedErr = freeboxClient.CreateProfile(context.Background(), types.ProfilePayload{Name: "Léa"})
+		})
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/profile/", version)),
+					verifyAuth(*sessionToken),
+					ghttp.VerifyJSON(`{ "name": "Léa" }`),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "id": 4, "name": "Léa" } }`),
+				),
+			)
+		})
+		It("should return the created profile", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*returnedProfile).To(Equal(types.Profile{ID: identifier, ProfilePayload: types.ProfilePayload{Name: "Léa"}}))
+		})
+	})
+	Context("updating a profile", func() {
+		returnedProfile := new(types.Profile)
+		JustBeforeEach(func() {
+			*returnedProfile, *returnedErr = freeboxClient.UpdateProfile(context.Background(), identifier, types.ProfilePayload{Icon: "/resources/images/profile/profile_03.png"})
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/profile/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{ "icon": "/resources/images/profile/profile_03.png" }`),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "id": 4, "name": "Léa", "icon": "/resources/images/profile/profile_03.png" } }`),
+					),
+				)
+			})
+			It("should return the updated profile", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedProfile.Icon).To(Equal("/resources/images/profile/profile_03.png"))
+			})
+		})
+		Context("when the profile is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/profile/%d", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "noent" }`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrProfileNotFound))
+			})
+		})
+	})
+	Context("deleting a profile", func() {
+		JustBeforeEach(func() {
+			*returnedErr = freeboxClient.DeleteProfile(context.Background(), identifier)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/profile/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the profile is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/profile/%d", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "noent" }`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrProfileNotFound))
+			})
+		})
+	})
+	Context("managing the hosts of a profile", func() {
+		mac := Must(types.ParseMACAddress("66:77:88:99:AA:BB"))
+		returnedNetworkControl := new(types.NetworkControl)
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/network_control/%d", version, identifier)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "profile_id": 4, "next_change": 0, "macs": ["00:11:22:33:44:55", "66:77:88:99:aa:bb"] } }`),
+				),
+			)
+		})
+		Context("when adding a host", func() {
+			JustBeforeEach(func() {
+				*returnedNetworkControl, *returnedErr = freeboxClient.AddProfileHost(context.Background(), identifier, Must(types.ParseMACAddress("12:34:56:78:9A:BC")))
+			})
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/network_control/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{ "macs": ["00:11:22:33:44:55", "66:77:88:99:aa:bb", "12:34:56:78:9a:bc"] }`),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "profile_id": 4, "next_change": 0, "macs": ["00:11:22:33:44:55", "66:77:88:99:aa:bb", "12:34:56:78:9a:bc"] } }`),
+					),
+				)
+			})
+			It("should attach the host to the profile", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedNetworkControl.MACs).To(HaveLen(3))
+			})
+		})
+		Context("when adding a host already attached", func() {
+			JustBeforeEach(func() {
+				*returnedNetworkControl, *returnedErr = freeboxClient.AddProfileHost(context.Background(), identifier, mac)
+			})
+			It("should not update the profile", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedNetworkControl.MACs).To(HaveLen(2))
+				Expect(server.ReceivedRequests()).To(HaveLen(3))
+			})
+		})
+		Context("when removing a host", func() {
+			JustBeforeEach(func() {
+				*returnedNetworkControl, *returnedErr = freeboxClient.RemoveProfileHost(context.Background(), identifier, mac)
+			})
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/network_control/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{ "macs": ["00:11:22:33:44:55"] }`),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "profile_id": 4, "next_change": 0, "macs": ["00:11:22:33:44:55"] } }`),
+					),
+				)
+			})
+			It("should detach the host from the profile", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedNetworkControl.MACs).To(Equal([]types.MACAddress{Must(types.ParseMACAddress("00:11:22:33:44:55"))}))
+			})
+		})
+		Context("when the profile is not found", func() {
+			JustBeforeEach(func() {
+				*returnedNetworkControl, *returnedErr = freeboxClient.RemoveProfileHost(context.Background(), identifier, mac)
+			})
+			BeforeEach(func() {
+				server.SetHandler(2, ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/network_control/%d", version, identifier)),
+					ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "noent" }`),
+				))
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrProfileNotFound))
+			})
+		})
+	})
+})
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// ListPVRFinishedRecords returns the finished recordings.
+func (c *client) ListPVRFinishedRecords(ctx context.Context) (result []types.PVRFinishedRecord, err error) {
+	response, err := c.get(ctx, "pvr/finished/", c.withSession(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET pvr/finished/ endpoint: %w", err)
+	}
+
+	if response.Result == nil {
+		return
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to get finished records from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetPVRFinishedRecord returns a finished recording.
+func (c *client) GetPVRFinishedRecord(ctx context.Context, identifier int64) (result types.PVRFinishedRecord, err error) {
+	response, err := c.get(ctx, fmt.Sprintf("pvr/finished/%d", identifier), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrPVRRecordNotFound
+		}
+
+		return result, fmt.Errorf("failed to GET pvr/finished/%d endpoint: %w", identifier, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a finished record from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdatePVRFinishedRecord renames a finished recording.
+func (c *client) UpdatePVRFinishedRecord(ctx context.Context, identifier int64, payload types.PVRFinishedRecordPayload) (result types.PVRFinishedRecord, err error) {
+	response, err := c.put(ctx, fmt.Sprintf("pvr/finished/%d", identifier), payload, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrPVRRecordNotFound
+		}
+
+		return result, fmt.Errorf("failed to PUT pvr/finished/%d endpoint: %w", identifier, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a finished record from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// DeletePVRFinishedRecord deletes a finished recording along with its file.
+func (c *client) DeletePVRFinishedRecord(ctx context.Context, identifier int64) error {
+	_, err := c.delete(ctx, fmt.Sprintf("pvr/finished/%d", identifier), c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrPVRRecordNotFound
+		}
+
+		return fmt.Errorf("failed to DELETE pvr/finished/%d endpoint: %w", identifier, err)
+	}
+
+	return nil
+}
+
+// CopyPVRFinishedRecord starts copying the file of a finished recording into the destination directory, such as a
+// directory of a NAS mounted on the freebox.
+func (c *client) CopyPVRFinishedRecord(ctx context.Context, identifier int64, destination string) (types.FileSystemTask, error) {
+	file, err := c.pvrFinishedRecordFile(ctx, identifier)
+	if err != nil {
+		return types.FileSystemTask{}, err
+	}
+
+	return c.CopyFiles(ctx, []string{file}, destination, types.FileCopyModeSkip)
+}
+
+// MovePVRFinishedRecord starts moving the file of a finished recording into the destination directory, to free space
+// on the recordings partition.
+func (c *client) MovePVRFinishedRecord(ctx context.Context, identifier int64, destination string) (types.FileSystemTask, error) {
+	file, err := c.pvrFinishedRecordFile(ctx, identifier)
+	if err != nil {
+		return types.FileSystemTask{}, err
+	}
+
+	return c.MoveFiles(ctx, []string{file}, destination, types.FileMoveModeSkip)
+}
+
+// GetPVRFinishedRecordFile downloads the file of a finished recording, for instance to offload it to an external storage.
+// Options such as WithRange can be given to resume an interrupted transfer. The caller is responsible for consuming the
+// returned content.
+func (c *client) GetPVRFinishedRecordFile(ctx context.Context, identifier int64, options ...HTTPOption) (types.File, error) {
+	file, err := c.pvrFinishedRecordFile(ctx, identifier)
+	if err != nil {
+		return types.File{}, err
+	}
+
+	return c.GetFile(ctx, file, options...)
+}
+
+func (c *client) pvrFinishedRecordFile(ctx context.Context, identifier int64) (string, error) {
+	record, err := c.GetPVRFinishedRecord(ctx, identifier)
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(string(record.Path), record.Filename), nil
+}
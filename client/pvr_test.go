@@ -0,0 +1,332 @@
+package client_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("pvr", func() {
+	const identifier int64 = 12
+
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	recordBody := fmt.Sprintf(`{
+		"id": 12,
+		"start": 1711656000,
+		"end": 1711659600,
+		"name": "Le journal",
+		"subname": "Édition du soir",
+		"channel_number": 2,
+		"channel_name": "France 2",
+		"channel_uuid": "uuid-webtv-201",
+		"channel_type": "iptv",
+		"channel_quality": "hd",
+		"channel_source": "iptv",
+		"broadcast_type": "tv",
+		"media": "Disque dur",
+		"path": %q,
+		"filename": "Le journal.m2ts",
+		"byte_size": 2147483648,
+		"secure": false,
+		"state": "finished",
+		"error": "none"
+	}`, base64.StdEncoding.EncodeToString([]byte("/Freebox/Enregistrements")))
+	expectedRecord := types.PVRFinishedRecord{
+		PVRFinishedRecordPayload: types.PVRFinishedRecordPayload{
+			Name:    "Le journal",
+			Subname: "Édition du soir",
+		},
+		ID:             identifier,
+		Start:          types.Timestamp{Time: time.Unix(1711656000, 0).UTC()},
+		End:            types.Timestamp{Time: time.Unix(1711659600, 0).UTC()},
+		ChannelNumber:  2,
+		ChannelName:    "France 2",
+		ChannelUUID:    "uuid-webtv-201",
+		ChannelType:    "iptv",
+		ChannelQuality: "hd",
+		ChannelSource:  "iptv",
+		BroadcastType:  "tv",
+		Media:          "Disque dur",
+		Path:           "/Freebox/Enregistrements",
+		Filename:       "Le journal.m2ts",
+		ByteSize:       2147483648,
+		State:          types.PVRRecordStateFinished,
+		Error:          types.PVRRecordErrorNone,
+	}
+	Context("listing finished records", func() {
+		returnedRecords := new([]types.PVRFinishedRecord)
+		JustBeforeEach(func() {
+			*returnedRecords, *returnedErr = freeboxClient.ListPVRFinishedRecords(context.Background())
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/pvr/finished/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": [%s] }`, recordBody)),
+					),
+				)
+			})
+			It("should return the correct records", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedRecords).To(Equal([]types.PVRFinishedRecord{expectedRecord}))
+			})
+		})
+		Context("when there are no records", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/pvr/finished/", version)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+				)
+			})
+			It("should return an empty list", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedRecords).To(BeEmpty())
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("getting a finished record", func() {
+		returnedRecord := new(types.PVRFinishedRecord)
+		JustBeforeEach(func() {
+			*returnedRecord, *returnedErr = freeboxClient.GetPVRFinishedRecord(context.Background(), identifier)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/pvr/finished/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %s }`, recordBody)),
+					),
+				)
+			})
+			It("should return the correct record", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedRecord).To(Equal(expectedRecord))
+			})
+		})
+		Context("when the record is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/pvr/finished/%d", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "noent" }`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrPVRRecordNotFound))
+			})
+		})
+	})
+	Context("updating a finished record", func() {
+		returnedRecord := new(types.PVRFinishedRecord)
+		JustBeforeEach(func() {
+			*returnedRecord, *returnedErr = freeboxClient.UpdatePVRFinishedRecord(context.Background(), identifier, types.PVRFinishedRecordPayload{
+				Name: "Le journal",
+			})
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/pvr/finished/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{ "name": "Le journal" }`),
+						ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %s }`, recordBody)),
+					),
+				)
+			})
+			It("should return the updated record", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedRecord).To(Equal(expectedRecord))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("deleting a finished record", func() {
+		JustBeforeEach(func() {
+			*returnedErr = freeboxClient.DeletePVRFinishedRecord(context.Background(), identifier)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/pvr/finished/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the record is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/pvr/finished/%d", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "noent" }`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrPVRRecordNotFound))
+			})
+		})
+	})
+	Context("exporting a finished record", func() {
+		const destination = "/NAS/Videos"
+		var move = new(bool)
+		returnedTask := new(types.FileSystemTask)
+		JustBeforeEach(func() {
+			if *move {
+				*returnedTask, *returnedErr = freeboxClient.MovePVRFinishedRecord(context.Background(), identifier, destination)
+			} else {
+				*returnedTask, *returnedErr = freeboxClient.CopyPVRFinishedRecord(context.Background(), identifier, destination)
+			}
+		})
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/pvr/finished/%d", version, identifier)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %s }`, recordBody)),
+				),
+			)
+		})
+		for operation, moving := range map[string]bool{"cp": false, "mv": true} {
+			operation, moving := operation, moving
+			Context(fmt.Sprintf("when calling fs/%s", operation), func() {
+				BeforeEach(func() {
+					*move = moving
+					server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/%s/", version, operation)),
+							verifyAuth(*sessionToken),
+							ghttp.VerifyJSON(fmt.Sprintf(`{ "files": [%q], "dst": %q, "mode": "skip" }`,
+								base64.StdEncoding.EncodeToString([]byte("/Freebox/Enregistrements/Le journal.m2ts")),
+								base64.StdEncoding.EncodeToString([]byte(destination)),
+							)),
+							ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "id": 31, "state": "running" } }`),
+						),
+					)
+				})
+				It("should start a filesystem task on the record file", func() {
+					Expect(*returnedErr).To(BeNil())
+					Expect(returnedTask.ID).To(BeEquivalentTo(31))
+				})
+			})
+		}
+		Context("when the record is not found", func() {
+			BeforeEach(func() {
+				server.SetHandler(2, ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/pvr/finished/%d", version, identifier)),
+					ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "noent" }`),
+				))
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrPVRRecordNotFound))
+			})
+		})
+	})
+	Context("downloading a finished record", func() {
+		returnedFile := new(types.File)
+		JustBeforeEach(func() {
+			*returnedFile, *returnedErr = freeboxClient.GetPVRFinishedRecordFile(context.Background(), identifier, client.WithRange(4, 7))
+		})
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/pvr/finished/%d", version, identifier)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %s }`, recordBody)),
+				),
+			)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/dl/%s", version,
+							base64.StdEncoding.EncodeToString([]byte("/Freebox/Enregistrements/Le journal.m2ts")),
+						)),
+						ghttp.VerifyHeaderKV("Range", "bytes=4-10"),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusPartialContent, `content`, http.Header{
+							"Content-Type":  []string{"video/mp2t"},
+							"Accept-Ranges": []string{"bytes"},
+						}),
+					),
+				)
+			})
+			It("should stream the requested part of the record file", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedFile.ContentType).To(Equal("video/mp2t"))
+				Expect(returnedFile.AcceptRanges).To(BeTrue())
+				Expect(io.ReadAll(returnedFile.Content)).To(BeEquivalentTo([]byte("content")))
+			})
+		})
+		Context("when the record is not found", func() {
+			BeforeEach(func() {
+				server.SetHandler(2, ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/pvr/finished/%d", version, identifier)),
+					ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "noent" }`),
+				))
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrPVRRecordNotFound))
+			})
+		})
+	})
+})
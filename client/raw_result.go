@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+type rawResultKey struct{}
+
+// RawResult holds the untouched `result` JSON returned by the freebox to a call performed with WithRawResult. It is
+// safe for concurrent use, as a call may perform several requests at once.
+type RawResult struct {
+	mutex sync.Mutex
+	value json.RawMessage
+}
+
+// Get returns a copy of the result JSON of the last request, or nil when none succeeded.
+func (r *RawResult) Get() json.RawMessage {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.value == nil {
+		return nil
+	}
+
+	return append(json.RawMessage(nil), r.value...)
+}
+
+func (r *RawResult) set(value json.RawMessage) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.value = append(json.RawMessage(nil), value...)
+}
+
+// WithRawResult returns a copy of ctx which makes any call performed with it store the untouched `result` JSON returned
+// by the freebox into destination, so that fields added by newer firmwares can be read before free-go models them.
+//
+// When a call performs several requests, destination holds the result of the last one to complete.
+func WithRawResult(ctx context.Context, destination *RawResult) context.Context {
+	return context.WithValue(ctx, rawResultKey{}, destination)
+}
+
+func storeRawResult(ctx context.Context, response *genericResponse) {
+	if response == nil {
+		return
+	}
+
+	if destination, ok := ctx.Value(rawResultKey{}).(*RawResult); ok && destination != nil {
+		destination.set(response.Result)
+	}
+}
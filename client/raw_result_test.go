@@ -0,0 +1,99 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("raw result", func() {
+	const identifier int64 = 4
+
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		rawResult       *client.RawResult
+		returnedProfile = new(types.Profile)
+		returnedErr     = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+
+		rawResult = new(client.RawResult)
+	})
+	JustBeforeEach(func() {
+		*returnedProfile, *returnedErr = freeboxClient.GetProfile(client.WithRawResult(context.Background(), rawResult), identifier)
+	})
+	Context("default", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/profile/%d", version, identifier)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": true,
+						"result": { "id": 4, "name": "Léa", "icon": "/resources/images/profile/profile_02.png", "new_field": 42 }
+					}`),
+				),
+			)
+		})
+		It("should return the typed result along with the untouched result JSON", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(returnedProfile.Name).To(Equal("Léa"))
+			Expect(string(rawResult.Get())).To(MatchJSON(`{
+				"id": 4,
+				"name": "Léa",
+				"icon": "/resources/images/profile/profile_02.png",
+				"new_field": 42
+			}`))
+		})
+	})
+	Context("when the server returns an error", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/profile/%d", version, identifier)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": false,
+						"error_code": "internal_error"
+					}`),
+				),
+			)
+		})
+		It("should return an error and leave the raw result empty", func() {
+			Expect(*returnedErr).ToNot(BeNil())
+			Expect(rawResult.Get()).To(BeNil())
+		})
+	})
+	Context("when server fails to respond", func() {
+		BeforeEach(func() {
+			server.Close()
+		})
+		It("should return an error and leave the raw result empty", func() {
+			Expect(*returnedErr).ToNot(BeNil())
+			Expect(rawResult.Get()).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"net/http"
+)
+
+// WithAutomaticRelogin sets whether a call rejected by the freebox because of its session, with the auth_required or
+// invalid_token error codes, logs in again and is replayed once. This happens when the session was revoked or timed
+// out before LoginSessionTTL, and is enabled by default.
+func (c *client) WithAutomaticRelogin(enabled bool) Client {
+	c.relogin = enabled
+
+	return c
+}
+
+// replayable returns a copy of the request, taken before the options are applied, to replay it with a new session.
+// It returns nil when the request must not or can not be replayed.
+func (c *client) replayable(request *http.Request) *http.Request {
+	if !c.relogin {
+		return nil
+	}
+
+	replay := request.Clone(request.Context())
+
+	if request.Body != nil && request.Body != http.NoBody {
+		if request.GetBody == nil {
+			return nil
+		}
+
+		body, err := request.GetBody()
+		if err != nil {
+			return nil
+		}
+
+		replay.Body = body
+	}
+
+	return replay
+}
+
+// sessionRejected tells whether the response denies the session the request was sent with. When it does, the session
+// is dropped so that the next authenticated request logs in again, unless another request already replaced it.
+func (c *client) sessionRejected(request *http.Request, response *genericResponse) bool {
+	token := request.Header.Get(AuthHeader)
+	if response == nil || token == "" {
+		return false
+	}
+
+	if response.ErrorCode != codeAuthRequired && response.ErrorCode != codeInvalidToken {
+		return false
+	}
+
+	c.session.drop(token)
+
+	return true
+}
@@ -0,0 +1,220 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("automatic relogin", func() {
+	const identifier int64 = 4
+
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	rejectSession := func(code string) http.HandlerFunc {
+		return ghttp.RespondWith(http.StatusForbidden, `{
+			"success": false,
+			"msg": "Vous devez vous connecter pour accéder à cette fonction",
+			"error_code": "`+code+`"
+		}`)
+	}
+	Context("getting a profile", func() {
+		returnedProfile := new(types.Profile)
+		JustBeforeEach(func() {
+			*returnedProfile, *returnedErr = freeboxClient.GetProfile(context.Background(), identifier)
+		})
+		for _, code := range []string{"auth_required", "invalid_token"} {
+			code := code
+			Context(fmt.Sprintf("when the session is rejected with %s", code), func() {
+				BeforeEach(func() {
+					server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/profile/%d", version, identifier)),
+							verifyAuth(*sessionToken),
+							rejectSession(code),
+						),
+					)
+					setupLoginFlow(server)
+					server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/profile/%d", version, identifier)),
+							verifyAuth(*sessionToken),
+							ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "id": 4, "name": "Léa" } }`),
+						),
+					)
+				})
+				It("should login again and replay the request", func() {
+					Expect(*returnedErr).To(BeNil())
+					Expect(returnedProfile.Name).To(Equal("Léa"))
+					Expect(server.ReceivedRequests()).To(HaveLen(6))
+				})
+			})
+		}
+		Context("when the replayed request is rejected too", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/profile/%d", version, identifier)),
+						rejectSession("auth_required"),
+					),
+				)
+				setupLoginFlow(server)
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/profile/%d", version, identifier)),
+						rejectSession("auth_required"),
+					),
+				)
+			})
+			It("should return the error without replaying it again", func() {
+				Expect(*returnedErr).To(MatchError(&client.APIError{Code: "auth_required"}))
+				Expect(server.ReceivedRequests()).To(HaveLen(6))
+			})
+		})
+		Context("when the automatic relogin is disabled", func() {
+			BeforeEach(func() {
+				freeboxClient = freeboxClient.WithAutomaticRelogin(false)
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/profile/%d", version, identifier)),
+						rejectSession("auth_required"),
+					),
+				)
+			})
+			It("should return the error", func() {
+				Expect(*returnedErr).To(MatchError(&client.APIError{Code: "auth_required"}))
+				Expect(server.ReceivedRequests()).To(HaveLen(3))
+			})
+		})
+		Context("when the request fails for another reason", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/profile/%d", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "insufficient_rights" }`),
+					),
+				)
+			})
+			It("should not replay the request", func() {
+				Expect(*returnedErr).To(MatchError(&client.APIError{Code: "insufficient_rights"}))
+				Expect(server.ReceivedRequests()).To(HaveLen(3))
+			})
+		})
+	})
+	Context("creating a profile", func() {
+		returnedProfile := new(types.Profile)
+		JustBeforeEach(func() {
+			*returnedProfile, *returnedErr = freeboxClient.CreateProfile(context.Background(), types.ProfilePayload{Name: "Léa"})
+		})
+		Context("when the session is rejected", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/profile/", version)),
+						ghttp.VerifyJSON(`{ "name": "Léa" }`),
+						rejectSession("auth_required"),
+					),
+				)
+				setupLoginFlow(server)
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/profile/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyContentType("application/json"),
+						ghttp.VerifyJSON(`{ "name": "Léa" }`),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "id": 4, "name": "Léa" } }`),
+					),
+				)
+			})
+			It("should replay the request with its body", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedProfile.ID).To(Equal(identifier))
+			})
+		})
+	})
+	Context("listing calls", func() {
+		returnedCalls := new([]types.CallEntry)
+		JustBeforeEach(func() {
+			*returnedCalls, *returnedErr = freeboxClient.ListCalls(context.Background())
+		})
+		Context("when the session is rejected", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/log/", version)),
+						rejectSession("invalid_token"),
+					),
+				)
+				setupLoginFlow(server)
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/log/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": [ { "id": 42, "number": "0102030405" } ] }`),
+					),
+				)
+			})
+			It("should login again and replay the request", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedCalls).To(HaveLen(1))
+			})
+		})
+	})
+	Context("when concurrent requests need a session", func() {
+		const concurrency = 8
+		BeforeEach(func() {
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/profile/%d", version, identifier), ghttp.CombineHandlers(
+				verifyAuth(*sessionToken),
+				ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "id": 4, "name": "Léa" } }`),
+			))
+		})
+		JustBeforeEach(func() {
+			*returnedErr = nil
+
+			errs := make(chan error, concurrency)
+			for i := 0; i < concurrency; i++ {
+				go func() {
+					_, err := freeboxClient.GetProfile(context.Background(), identifier)
+					errs <- err
+				}()
+			}
+			for i := 0; i < concurrency; i++ {
+				if err := <-errs; err != nil {
+					*returnedErr = err
+				}
+			}
+		})
+		It("should share a single login", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(server.ReceivedRequests()).To(HaveLen(2 + concurrency))
+		})
+	})
+})
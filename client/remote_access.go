@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// NewWithRemoteAccess returns a client which works both inside and outside the local network of the box: it targets
+// lanEndpoint when the box answers there, and falls back to the public domain of the box otherwise.
+//
+// The remote access settings are the api_domain, https_port and https_available fields returned by APIVersion, which
+// are to be stored while on the local network. The certificate of the box is checked against the name of its domain
+// and rootCAs, which must hold the certificate authority of the vendor of the box; a nil pool uses the roots of the
+// system. The local endpoint is probed for at most RemoteAccessProbeTimeout.
+func NewWithRemoteAccess(ctx context.Context, lanEndpoint, version string, remote types.APIVersion, rootCAs *x509.CertPool) (Client, error) {
+	lan, err := New(lanEndpoint, version)
+	if err != nil {
+		return nil, err
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, RemoteAccessProbeTimeout)
+	defer cancel()
+
+	if _, err := lan.APIVersion(probeCtx); err == nil {
+		return lan, nil
+	}
+
+	if !remote.HTTPSAvailable || remote.APIDomain == "" || remote.HTTPSPort == 0 {
+		return nil, ErrRemoteAccessUnavailable
+	}
+
+	freebox, err := New(fmt.Sprintf("https://%s:%d", remote.APIDomain, remote.HTTPSPort), version)
+	if err != nil {
+		return nil, err
+	}
+
+	return freebox.WithTLSConfig(&tls.Config{
+		ServerName: remote.APIDomain,
+		RootCAs:    rootCAs,
+		MinVersion: tls.VersionTLS12,
+	}), nil
+}
@@ -0,0 +1,119 @@
+package client_test
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("remote access", func() {
+	var (
+		freeboxClient client.Client
+
+		lanServer    *ghttp.Server
+		lanEndpoint  = new(string)
+		remoteServer *ghttp.Server
+
+		remote  = new(types.APIVersion)
+		rootCAs *x509.CertPool
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		lanServer = ghttp.NewServer()
+		DeferCleanup(lanServer.Close)
+
+		*lanEndpoint = lanServer.Addr()
+
+		remoteServer = ghttp.NewTLSServer()
+		DeferCleanup(remoteServer.Close)
+
+		host, port, err := net.SplitHostPort(remoteServer.Addr())
+		Expect(err).To(BeNil())
+
+		*remote = types.APIVersion{
+			APIDomain:      host,
+			HTTPSPort:      Must(strconv.Atoi(port)),
+			HTTPSAvailable: true,
+		}
+
+		rootCAs = x509.NewCertPool()
+		rootCAs.AddCert(remoteServer.HTTPTestServer.Certificate())
+	})
+	JustBeforeEach(func(ctx SpecContext) {
+		freeboxClient, *returnedErr = client.NewWithRemoteAccess(ctx, *lanEndpoint, version, *remote, rootCAs)
+	})
+	apiVersionHandler := ghttp.CombineHandlers(
+		ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/api_version", version)),
+		ghttp.RespondWith(http.StatusOK, `{ "api_domain": "test.fbxos.fr", "https_port": 12345, "https_available": true }`),
+	)
+	Context("when the box answers on the local network", func() {
+		BeforeEach(func() {
+			lanServer.AppendHandlers(apiVersionHandler, apiVersionHandler)
+		})
+		It("should target the local network", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(freeboxClient.APIVersion(context.Background())).Error().To(BeNil())
+			Expect(lanServer.ReceivedRequests()).To(HaveLen(2))
+			Expect(remoteServer.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+	Context("when the box does not answer on the local network", func() {
+		BeforeEach(func() {
+			lanServer.Close()
+
+			remoteServer.AppendHandlers(apiVersionHandler)
+		})
+		It("should target the domain of the box", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(freeboxClient.APIVersion(context.Background())).Error().To(BeNil())
+			Expect(remoteServer.ReceivedRequests()).To(HaveLen(1))
+		})
+		Context("when the certificate of the box is not trusted", func() {
+			BeforeEach(func() {
+				rootCAs = x509.NewCertPool()
+			})
+			It("should fail to reach the box", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(freeboxClient.APIVersion(context.Background())).Error().To(MatchError(ContainSubstring("certificate")))
+			})
+		})
+		Context("when the remote access is disabled", func() {
+			BeforeEach(func() {
+				remote.HTTPSAvailable = false
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).To(MatchError(client.ErrRemoteAccessUnavailable))
+			})
+		})
+	})
+	Context("when the box answers too slowly on the local network", func() {
+		BeforeEach(func() {
+			probeTimeout := client.RemoteAccessProbeTimeout
+			client.RemoteAccessProbeTimeout = 10 * time.Millisecond
+			DeferCleanup(func() { client.RemoteAccessProbeTimeout = probeTimeout })
+
+			unblock := make(chan struct{})
+			DeferCleanup(func() { close(unblock) })
+
+			lanServer.AppendHandlers(func(http.ResponseWriter, *http.Request) { <-unblock })
+			remoteServer.AppendHandlers(apiVersionHandler)
+		})
+		It("should target the domain of the box", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(freeboxClient.APIVersion(context.Background())).Error().To(BeNil())
+			Expect(remoteServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// sessionHolder guards the session shared by the concurrent requests of the client. The requests needing a session
+// while one is being opened wait for it instead of logging in on their own.
+type sessionHolder struct {
+	mutex   sync.Mutex
+	current *session      // nil until logged in, or once the freebox rejected it
+	opening *sessionLogin // nil when no login is in progress
+}
+
+// sessionLogin is a login shared by the callers asking for a session while it is in progress.
+type sessionLogin struct {
+	done        chan struct{} // closed once the login is over
+	session     *session
+	permissions types.Permissions
+	err         error
+}
+
+// get returns the current session, or nil when there is none.
+func (h *sessionHolder) get() *session {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.current
+}
+
+// drop forgets the current session when its token is the given one, so that a session rejected by the freebox does
+// not also drop the one opened since.
+func (h *sessionHolder) drop(token string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.current != nil && h.current.token == token {
+		h.current = nil
+	}
+}
+
+// open logs in with the given function, or waits for the login already in progress. The new session replaces the
+// current one when the login succeeds.
+func (h *sessionHolder) open(ctx context.Context, login func() (*session, types.Permissions, error)) (*session, types.Permissions, error) {
+	h.mutex.Lock()
+
+	if pending := h.opening; pending != nil {
+		h.mutex.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, types.Permissions{}, ctx.Err()
+		case <-pending.done:
+			return pending.session, pending.permissions, pending.err
+		}
+	}
+
+	pending := &sessionLogin{done: make(chan struct{})}
+	h.opening = pending
+	h.mutex.Unlock()
+
+	pending.session, pending.permissions, pending.err = login()
+
+	h.mutex.Lock()
+	if pending.err == nil {
+		h.current = pending.session
+	}
+	h.opening = nil
+	h.mutex.Unlock()
+
+	close(pending.done)
+
+	return pending.session, pending.permissions, pending.err
+}
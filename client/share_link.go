@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// ListShareLinks returns every public share link.
+func (c *client) ListShareLinks(ctx context.Context) (result []types.ShareLink, err error) {
+	response, err := c.get(ctx, "share_link/", c.withSession(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET share_link/ endpoint: %w", err)
+	}
+
+	if response.Result == nil {
+		return
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to get share links from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetShareLink returns a share link by its token.
+func (c *client) GetShareLink(ctx context.Context, token string) (result types.ShareLink, err error) {
+	response, err := c.get(ctx, "share_link/"+token, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrShareLinkNotFound
+		}
+
+		return result, fmt.Errorf("failed to GET share_link/%s endpoint: %w", token, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a share link from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// CreateShareLink creates a public download link for a path.
+func (c *client) CreateShareLink(ctx context.Context, request types.ShareLinkRequest) (result types.ShareLink, err error) {
+	payload := map[string]interface{}{
+		"path":   types.Base64Path(request.Path),
+		"expire": 0,
+	}
+
+	if !request.Expire.IsZero() {
+		payload["expire"] = request.Expire.Unix()
+	}
+
+	if request.FullURL != "" {
+		payload["fullurl"] = request.FullURL
+	}
+
+	response, err := c.post(ctx, "share_link/", payload, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrPathNotFound) {
+			return result, ErrPathNotFound
+		}
+
+		return result, fmt.Errorf("failed to POST to share_link/ endpoint: %w", err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a share link from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// DeleteShareLink deletes a share link by its token.
+func (c *client) DeleteShareLink(ctx context.Context, token string) error {
+	_, err := c.delete(ctx, "share_link/"+token, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrShareLinkNotFound
+		}
+
+		return fmt.Errorf("failed to DELETE share_link/%s endpoint: %w", token, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,346 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("share links", func() {
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	Context("listing share links", func() {
+		returnedLinks := new([]types.ShareLink)
+		JustBeforeEach(func() {
+			*returnedLinks, *returnedErr = freeboxClient.ListShareLinks(context.Background())
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/share_link/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								{
+									"token": "tKmk0L2eP8nD2cJw",
+									"path": "cGF0aC90by9maWxl",
+									"name": "file",
+									"expire": 1711656593,
+									"fullurl": "https://example.freeboxos.fr/share/tKmk0L2eP8nD2cJw/file"
+								}
+							]
+						}`),
+					),
+				)
+			})
+			It("should return the correct share links", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedLinks).To(Equal([]types.ShareLink{
+					{
+						Token:   "tKmk0L2eP8nD2cJw",
+						Path:    "path/to/file",
+						Name:    "file",
+						Expire:  types.Timestamp{Time: time.Unix(1711656593, 0).UTC()},
+						FullURL: "https://example.freeboxos.fr/share/tKmk0L2eP8nD2cJw/file",
+					},
+				}))
+			})
+		})
+		Context("when there are no share links", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/share_link/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should return an empty list", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedLinks).To(BeEmpty())
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the server returns an unexpected payload", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/share_link/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								"foo"
+							]
+						}`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("getting a share link", func() {
+		const token = "tKmk0L2eP8nD2cJw"
+		returnedLink := new(types.ShareLink)
+		JustBeforeEach(func() {
+			*returnedLink, *returnedErr = freeboxClient.GetShareLink(context.Background(), token)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/share_link/%s", version, token)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"token": "tKmk0L2eP8nD2cJw",
+								"path": "cGF0aC90by9maWxl",
+								"name": "file",
+								"expire": 0,
+								"fullurl": "https://example.freeboxos.fr/share/tKmk0L2eP8nD2cJw/file"
+							}
+						}`),
+					),
+				)
+			})
+			It("should return the correct share link", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedLink).To(Equal(types.ShareLink{
+					Token:   token,
+					Path:    "path/to/file",
+					Name:    "file",
+					Expire:  types.Timestamp{Time: time.Unix(0, 0).UTC()},
+					FullURL: "https://example.freeboxos.fr/share/tKmk0L2eP8nD2cJw/file",
+				}))
+			})
+		})
+		Context("when the share link is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/share_link/%s", version, token)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "noent"
+						}`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrShareLinkNotFound))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("creating a share link", func() {
+		var (
+			request      = new(types.ShareLinkRequest)
+			returnedLink = new(types.ShareLink)
+		)
+		BeforeEach(func() {
+			*request = types.ShareLinkRequest{
+				Path:   "path/to/file",
+				Expire: time.Unix(1711656593, 0),
+			}
+		})
+		JustBeforeEach(func() {
+			*returnedLink, *returnedErr = freeboxClient.CreateShareLink(context.Background(), *request)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/share_link/", version)),
+						ghttp.VerifyJSON(`{
+							"path": "cGF0aC90by9maWxl",
+							"expire": 1711656593
+						}`),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"token": "tKmk0L2eP8nD2cJw",
+								"path": "cGF0aC90by9maWxl",
+								"name": "file",
+								"expire": 1711656593,
+								"fullurl": "https://example.freeboxos.fr/share/tKmk0L2eP8nD2cJw/file"
+							}
+						}`),
+					),
+				)
+			})
+			It("should return the created share link", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedLink.Token).To(Equal("tKmk0L2eP8nD2cJw"))
+				Expect(returnedLink.Expire).To(Equal(types.Timestamp{Time: time.Unix(1711656593, 0).UTC()}))
+			})
+		})
+		Context("when no expiration date is given", func() {
+			BeforeEach(func() {
+				request.Expire = time.Time{}
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/share_link/", version)),
+						ghttp.VerifyJSON(`{
+							"path": "cGF0aC90by9maWxl",
+							"expire": 0
+						}`),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"token": "tKmk0L2eP8nD2cJw"
+							}
+						}`),
+					),
+				)
+			})
+			It("should create a link that never expires", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedLink.Token).To(Equal("tKmk0L2eP8nD2cJw"))
+			})
+		})
+		Context("when the path does not exist", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/share_link/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "path_not_found"
+						}`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrPathNotFound))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the server returns an unexpected payload", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/share_link/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								"foo"
+							]
+						}`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("deleting a share link", func() {
+		const token = "tKmk0L2eP8nD2cJw"
+		JustBeforeEach(func() {
+			*returnedErr = freeboxClient.DeleteShareLink(context.Background(), token)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/share_link/%s", version, token)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the share link is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/share_link/%s", version, token)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "noent"
+						}`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrShareLinkNotFound))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,26 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// ListStoragePartitions lists the partitions of the disks of the box.
+func (c *client) ListStoragePartitions(ctx context.Context) (result []types.StoragePartition, err error) {
+	response, err := c.get(ctx, "storage/partition/", c.withSession(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET storage/partition/ endpoint: %w", err)
+	}
+
+	if response.Result == nil {
+		return
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to get storage partitions from generic response: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,110 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("storage", func() {
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	Context("listing partitions", func() {
+		returnedPartitions := new([]types.StoragePartition)
+		JustBeforeEach(func() {
+			*returnedPartitions, *returnedErr = freeboxClient.ListStoragePartitions(context.Background())
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/storage/partition/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								{
+									"id": 1000,
+									"disk_id": 1,
+									"state": "mounted",
+									"fstype": "ext4",
+									"label": "Disque dur",
+									"path": "L0Rpc3F1ZSBkdXI=",
+									"total_bytes": 1000000,
+									"used_bytes": 400000,
+									"free_bytes": 600000
+								}
+							]
+						}`),
+					),
+				)
+			})
+			It("should return the correct partitions", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedPartitions).To(Equal([]types.StoragePartition{
+					{
+						ID:         1000,
+						DiskID:     1,
+						State:      types.StoragePartitionStateMounted,
+						FSType:     "ext4",
+						Label:      "Disque dur",
+						Path:       "/Disque dur",
+						TotalBytes: 1000000,
+						UsedBytes:  400000,
+						FreeBytes:  600000,
+					},
+				}))
+			})
+		})
+		Context("when there is no partition", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/storage/partition/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+				)
+			})
+			It("should return an empty list", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedPartitions).To(BeEmpty())
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+})
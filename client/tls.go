@@ -0,0 +1,100 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+)
+
+// WithTLSConfig sets the TLS configuration used to reach the box over HTTPS.
+// Like the other TLS options, it applies to an HTTPClient which is an *http.Client using an *http.Transport, as the
+// default one, or to a CircuitBreaker wrapping such a client. Other implementations are left untouched and must be
+// configured by the caller: set the HTTP client first.
+func (c *client) WithTLSConfig(config *tls.Config) Client {
+	c.updateTransport(func(transport *http.Transport) {
+		transport.TLSClientConfig = config.Clone()
+	})
+
+	return c
+}
+
+// WithInsecureSkipVerify sets whether the certificate of the box is trusted without being checked, for boxes behind
+// TLS-intercepting proxies or running older firmware with certificate quirks. It exposes the session to anyone able
+// to intercept the traffic, so prefer WithAdditionalCAs whenever possible.
+func (c *client) WithInsecureSkipVerify(skip bool) Client {
+	c.updateTransport(func(transport *http.Transport) {
+		transport.TLSClientConfig.InsecureSkipVerify = skip //nolint:gosec
+	})
+
+	return c
+}
+
+// WithAdditionalCAs trusts the given certificate authorities on top of the ones already trusted, which are the roots
+// of the system by default, such as the certificate authority of the vendor of the box or of a proxy.
+func (c *client) WithAdditionalCAs(certificates ...*x509.Certificate) Client {
+	c.updateTransport(func(transport *http.Transport) {
+		pool := transport.TLSClientConfig.RootCAs
+		if pool == nil {
+			var err error
+			if pool, err = x509.SystemCertPool(); err != nil {
+				pool = x509.NewCertPool()
+			}
+		} else {
+			pool = pool.Clone()
+		}
+
+		for _, certificate := range certificates {
+			pool.AddCert(certificate)
+		}
+
+		transport.TLSClientConfig.RootCAs = pool
+	})
+
+	return c
+}
+
+// updateTransport applies update to a copy of the transport of the HTTP client, with a TLS configuration set, so that
+// a shared client such as http.DefaultClient is never modified. The client wrapped by a CircuitBreaker is replaced
+// within the breaker, so that its state is kept.
+func (c *client) updateTransport(update func(*http.Transport)) {
+	if breaker, ok := c.httpClient.(*CircuitBreaker); ok {
+		breaker.updateHTTPClient(func(httpClient HTTPClient) HTTPClient {
+			return withUpdatedTransport(httpClient, update)
+		})
+
+		return
+	}
+
+	c.httpClient = withUpdatedTransport(c.httpClient, update)
+}
+
+// withUpdatedTransport returns a copy of the HTTP client with its transport updated, or the HTTP client itself when it
+// is not an *http.Client using an *http.Transport.
+func withUpdatedTransport(client HTTPClient, update func(*http.Transport)) HTTPClient {
+	httpClient, ok := client.(*http.Client)
+	if !ok {
+		return client
+	}
+
+	var transport *http.Transport
+
+	switch current := httpClient.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	case *http.Transport:
+		transport = current.Clone()
+	default:
+		return client
+	}
+
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	update(transport)
+
+	updated := *httpClient
+	updated.Transport = transport
+
+	return &updated
+}
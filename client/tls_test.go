@@ -0,0 +1,93 @@
+package client_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+)
+
+var _ = Describe("tls", func() {
+	var (
+		freeboxClient client.Client
+
+		server *ghttp.Server
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewTLSServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(fmt.Sprintf("https://%s", server.Addr()), version))
+
+		server.AllowUnhandledRequests = true
+		server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/api_version", version), ghttp.RespondWith(http.StatusOK, `{}`))
+	})
+	JustBeforeEach(func() {
+		_, *returnedErr = freeboxClient.APIVersion(context.Background())
+	})
+	Context("default", func() {
+		It("should reject the certificate of the server", func() {
+			Expect(*returnedErr).To(MatchError(ContainSubstring("certificate")))
+		})
+	})
+	Context("when the certificate is not checked", func() {
+		BeforeEach(func() {
+			freeboxClient = freeboxClient.WithInsecureSkipVerify(true)
+		})
+		It("should reach the server", func() {
+			Expect(*returnedErr).To(BeNil())
+		})
+		It("should not change the default HTTP client", func() {
+			Expect(http.DefaultClient.Transport).To(BeNil())
+			if config := http.DefaultTransport.(*http.Transport).TLSClientConfig; config != nil {
+				Expect(config.InsecureSkipVerify).To(BeFalse())
+			}
+		})
+		Context("when it is checked again", func() {
+			BeforeEach(func() {
+				freeboxClient = freeboxClient.WithInsecureSkipVerify(false)
+			})
+			It("should reject the certificate of the server", func() {
+				Expect(*returnedErr).To(MatchError(ContainSubstring("certificate")))
+			})
+		})
+	})
+	Context("when the certificate authority of the server is added", func() {
+		BeforeEach(func() {
+			freeboxClient = freeboxClient.WithAdditionalCAs(server.HTTPTestServer.Certificate())
+		})
+		It("should reach the server", func() {
+			Expect(*returnedErr).To(BeNil())
+		})
+	})
+	Context("with a TLS configuration", func() {
+		BeforeEach(func() {
+			rootCAs := x509.NewCertPool()
+			rootCAs.AddCert(server.HTTPTestServer.Certificate())
+
+			freeboxClient = freeboxClient.WithTLSConfig(&tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12})
+		})
+		It("should reach the server", func() {
+			Expect(*returnedErr).To(BeNil())
+		})
+	})
+	Context("with a circuit breaker", func() {
+		BeforeEach(func() {
+			freeboxClient = freeboxClient.
+				WithHTTPClient(client.NewCircuitBreaker(http.DefaultClient, 5, 0)).
+				WithInsecureSkipVerify(true)
+		})
+		It("should configure the wrapped HTTP client", func() {
+			Expect(*returnedErr).To(BeNil())
+		})
+	})
+})
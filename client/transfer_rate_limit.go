@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// WithTransferRateLimit caps the throughput of file transfers, which are GetFile, DownloadFile and the uploads, so
+// that they do not saturate the network or the disk of the freebox. The rate is shared by every transfer of the client
+// running at the same time. A rate lower than a byte per second removes the limit.
+func (c *client) WithTransferRateLimit(rate types.BitRate) Client {
+	if rate.BytesPerSecond() < 1 {
+		c.transferLimiter = nil
+	} else {
+		c.transferLimiter = &rateLimiter{bytesPerSecond: rate.BytesPerSecond()}
+	}
+
+	return c
+}
+
+// rateLimiter spreads transfers over time: each transfer reserves the duration it takes at the given rate after the
+// previous reservations, and waits for its turn.
+type rateLimiter struct {
+	bytesPerSecond int64
+
+	mutex sync.Mutex
+	next  time.Time // time at which every reservation is over
+}
+
+// wait blocks until the transfer of size bytes fits in the rate, or the context is done.
+func (l *rateLimiter) wait(ctx context.Context, size int) error {
+	l.mutex.Lock()
+
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(time.Duration(int64(size) * int64(time.Second) / l.bytesPerSecond))
+
+	l.mutex.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("transfer interrupted: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rateLimitedReadCloser waits for the rate limiter before handing over the bytes it reads.
+type rateLimitedReadCloser struct {
+	io.ReadCloser
+
+	ctx     context.Context //nolint:containedctx
+	limiter *rateLimiter
+}
+
+func (r *rateLimitedReadCloser) Read(data []byte) (int, error) {
+	if len(data) > downloadBufferSize {
+		data = data[:downloadBufferSize]
+	}
+
+	n, err := r.ReadCloser.Read(data)
+	if n > 0 {
+		if waitErr := r.limiter.wait(r.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+
+	return n, err //nolint:wrapcheck
+}
@@ -0,0 +1,121 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("transfer rate limit", func() {
+	const rate = 500000 * types.BytePerSecond
+
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken string
+
+		content = strings.Repeat("a", 100000)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken).
+			WithTransferRateLimit(rate)
+
+		sessionToken = setupLoginFlow(server)
+	})
+	Context("downloading a file", func() {
+		var (
+			ctx     context.Context
+			buffer  *bytes.Buffer
+			elapsed = new(time.Duration)
+		)
+		BeforeEach(func() {
+			ctx = context.Background()
+			buffer = new(bytes.Buffer)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/dl/cGF0aC90by9maWxl", version)),
+					verifyAuth(sessionToken),
+					ghttp.RespondWith(http.StatusOK, content),
+				),
+			)
+		})
+		JustBeforeEach(func() {
+			start := time.Now()
+			_, *returnedErr = freeboxClient.DownloadFile(ctx, "path/to/file", buffer)
+			*elapsed = time.Since(start)
+		})
+		Context("default", func() {
+			It("should spread the download over time", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(buffer.String()).To(Equal(content))
+				// the last chunk read is not waited for
+				Expect(*elapsed).To(BeNumerically(">=", 100*time.Millisecond))
+			})
+		})
+		Context("when the context is done while waiting", func() {
+			BeforeEach(func() {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, 20*time.Millisecond)
+				DeferCleanup(cancel)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).To(MatchError(context.DeadlineExceeded))
+				Expect(*elapsed).To(BeNumerically("<", 100*time.Millisecond))
+			})
+		})
+	})
+	Context("uploading files", func() {
+		var (
+			lock    *sync.Mutex
+			uploads map[string]string
+			elapsed = new(time.Duration)
+		)
+		BeforeEach(func() {
+			chunkSize := client.FileUploadChunkSize
+			client.FileUploadChunkSize = 25000
+			DeferCleanup(func() {
+				client.FileUploadChunkSize = chunkSize
+			})
+
+			lock = new(sync.Mutex)
+			uploads = make(map[string]string)
+
+			server.AppendHandlers(ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				fakeUploadHandler(lock, uploads),
+			))
+		})
+		JustBeforeEach(func(ctx SpecContext) {
+			start := time.Now()
+			_, *returnedErr = freeboxClient.UploadFiles(ctx, []types.UploadSpec{
+				{Reader: strings.NewReader(content), Size: int64(len(content)), Dirname: "/backup", Filename: "a.txt"},
+			}, 1)
+			*elapsed = time.Since(start)
+		})
+		It("should spread the upload over time", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(uploads).To(Equal(map[string]string{"/backup/a.txt": content}))
+			Expect(*elapsed).To(BeNumerically(">=", 100*time.Millisecond))
+		})
+	})
+})
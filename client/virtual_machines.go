@@ -2,15 +2,12 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/nikolalohinski/free-go/types"
 )
 
-const (
-	codeVirtualMachineNotFound = "no_such_vm"
-)
-
 func (c *client) GetVirtualMachineInfo(ctx context.Context) (result types.VirtualMachinesInfo, err error) {
 	response, err := c.get(ctx, "vm/info/", c.withSession(ctx))
 	if err != nil {
@@ -80,10 +77,39 @@ func (c *client) UpdateVirtualMachine(ctx context.Context, identifier int64, pay
 	return result, nil
 }
 
+// ValidateVirtualMachinePayload checks the payload of a new virtual machine against the current capacity of the
+// hypervisor. CreateVirtualMachine does not run this check, callers wanting it must do so before creating the machine.
+// Invalid fields are reported as *types.VirtualMachinePayloadError.
+func (c *client) ValidateVirtualMachinePayload(ctx context.Context, payload types.VirtualMachinePayload) error {
+	info, err := c.GetVirtualMachineInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	return payload.Validate(info)
+}
+
+// ValidateVirtualMachineUpdate checks the payload updating a virtual machine against the current capacity of the
+// hypervisor, the resources already allocated to the machine being available to it. UpdateVirtualMachine does not run
+// this check either. Invalid fields are reported as *types.VirtualMachinePayloadError.
+func (c *client) ValidateVirtualMachineUpdate(ctx context.Context, identifier int64, payload types.VirtualMachinePayload) error {
+	info, err := c.GetVirtualMachineInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	current, err := c.GetVirtualMachine(ctx, identifier)
+	if err != nil {
+		return err
+	}
+
+	return payload.ValidateUpdate(info, current)
+}
+
 func (c *client) GetVirtualMachine(ctx context.Context, identifier int64) (result types.VirtualMachine, err error) {
 	response, err := c.get(ctx, fmt.Sprintf("vm/%d", identifier), c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == codeVirtualMachineNotFound {
+		if errors.Is(err, ErrVirtualMachineNotFound) {
 			return result, ErrVirtualMachineNotFound
 		}
 
@@ -98,9 +124,9 @@ func (c *client) GetVirtualMachine(ctx context.Context, identifier int64) (resul
 }
 
 func (c *client) DeleteVirtualMachine(ctx context.Context, identifier int64) error {
-	response, err := c.delete(ctx, fmt.Sprintf("vm/%d", identifier), c.withSession(ctx))
+	_, err := c.delete(ctx, fmt.Sprintf("vm/%d", identifier), c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == codeVirtualMachineNotFound {
+		if errors.Is(err, ErrVirtualMachineNotFound) {
 			return ErrVirtualMachineNotFound
 		}
 
@@ -111,8 +137,8 @@ func (c *client) DeleteVirtualMachine(ctx context.Context, identifier int64) err
 }
 
 func (c *client) StartVirtualMachine(ctx context.Context, identifier int64) error {
-	if response, err := c.post(ctx, fmt.Sprintf("vm/%d/start", identifier), nil, c.withSession(ctx)); err != nil {
-		if response != nil && response.ErrorCode == codeVirtualMachineNotFound {
+	if _, err := c.post(ctx, fmt.Sprintf("vm/%d/start", identifier), nil, c.withSession(ctx)); err != nil {
+		if errors.Is(err, ErrVirtualMachineNotFound) {
 			return ErrVirtualMachineNotFound
 		}
 
@@ -123,8 +149,8 @@ func (c *client) StartVirtualMachine(ctx context.Context, identifier int64) erro
 }
 
 func (c *client) KillVirtualMachine(ctx context.Context, identifier int64) error {
-	if response, err := c.post(ctx, fmt.Sprintf("vm/%d/stop", identifier), nil, c.withSession(ctx)); err != nil {
-		if response != nil && response.ErrorCode == codeVirtualMachineNotFound {
+	if _, err := c.post(ctx, fmt.Sprintf("vm/%d/stop", identifier), nil, c.withSession(ctx)); err != nil {
+		if errors.Is(err, ErrVirtualMachineNotFound) {
 			return ErrVirtualMachineNotFound
 		}
 
@@ -135,8 +161,8 @@ func (c *client) KillVirtualMachine(ctx context.Context, identifier int64) error
 }
 
 func (c *client) StopVirtualMachine(ctx context.Context, identifier int64) error {
-	if response, err := c.post(ctx, fmt.Sprintf("vm/%d/powerbutton", identifier), nil, c.withSession(ctx)); err != nil {
-		if response != nil && response.ErrorCode == codeVirtualMachineNotFound {
+	if _, err := c.post(ctx, fmt.Sprintf("vm/%d/powerbutton", identifier), nil, c.withSession(ctx)); err != nil {
+		if errors.Is(err, ErrVirtualMachineNotFound) {
 			return ErrVirtualMachineNotFound
 		}
 
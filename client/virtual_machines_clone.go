@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// CloneVirtualMachine copies the disk of a virtual machine and creates a new virtual machine booting on the copy.
+// The settings of the source virtual machine are used for the fields left empty in spec, except the USB ports
+// which can not be shared. The disk is copied to spec.DiskPath, or next to the source disk and named after the
+// new virtual machine when empty. The freebox assigns a new MAC address to the clone.
+//
+// The source virtual machine must be stopped, so that its disk is not copied while being written to, otherwise
+// ErrVirtualMachineNotStopped is returned. A name is required for the clone, otherwise
+// ErrVirtualMachineNameRequired is returned.
+func (c *client) CloneVirtualMachine(ctx context.Context, identifier int64, spec types.VirtualMachinePayload) (types.VirtualMachine, error) {
+	if spec.Name == "" {
+		return types.VirtualMachine{}, ErrVirtualMachineNameRequired
+	}
+
+	source, err := c.GetVirtualMachine(ctx, identifier)
+	if err != nil {
+		return types.VirtualMachine{}, err
+	}
+
+	if source.Status != types.StoppedStatus {
+		return types.VirtualMachine{}, fmt.Errorf("virtual machine %d is %s: %w", identifier, source.Status, ErrVirtualMachineNotStopped)
+	}
+
+	sourceDisk := string(source.DiskPath)
+
+	diskPath := string(spec.DiskPath)
+	if diskPath == "" {
+		diskPath = path.Join(path.Dir(sourceDisk), spec.Name+path.Ext(sourceDisk))
+	}
+
+	if err := c.copyVirtualMachineDisk(ctx, sourceDisk, diskPath); err != nil {
+		return types.VirtualMachine{}, fmt.Errorf("failed to copy disk %s to %s: %w", sourceDisk, diskPath, err)
+	}
+
+	payload := cloneVirtualMachinePayload(source.VirtualMachinePayload, spec)
+	payload.DiskPath = types.Base64Path(diskPath)
+
+	machine, err := c.CreateVirtualMachine(ctx, payload)
+	if err != nil {
+		return machine, fmt.Errorf("failed to create virtual machine: %w", err)
+	}
+
+	return machine, nil
+}
+
+// copyVirtualMachineDisk copies a file under another name. The freebox only copies files into a directory,
+// so the copy is made in a staging directory where it is renamed before being moved to its destination.
+func (c *client) copyVirtualMachineDisk(ctx context.Context, source, destination string) (err error) {
+	if exists, err := c.PathExists(ctx, destination); err != nil {
+		return fmt.Errorf("failed to check destination: %w", err)
+	} else if exists {
+		return ErrDestinationConflict
+	}
+
+	directory, name := path.Split(destination)
+
+	staging, err := c.CreateDirectory(ctx, directory, ".clone-"+name)
+	if err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+
+	defer func() {
+		if removeErr := c.removeFiles(context.WithoutCancel(ctx), staging); removeErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to remove staging directory %s: %w", staging, removeErr))
+		}
+	}()
+
+	task, err := c.CopyFiles(ctx, []string{source}, staging, types.FileCopyModeOverwrite)
+	if err != nil {
+		return err
+	}
+
+	if err := c.waitForFileSystemTask(ctx, task.ID); err != nil {
+		return err
+	}
+
+	if path.Base(source) != name {
+		if _, err := c.RenameFile(ctx, path.Join(staging, path.Base(source)), name); err != nil {
+			return fmt.Errorf("failed to rename copy: %w", err)
+		}
+	}
+
+	task, err = c.MoveFiles(ctx, []string{path.Join(staging, name)}, directory, types.FileMoveModeSkip)
+	if err != nil {
+		return err
+	}
+
+	return c.waitForFileSystemTask(ctx, task.ID)
+}
+
+func (c *client) removeFiles(ctx context.Context, paths ...string) error {
+	task, err := c.RemoveFiles(ctx, paths)
+	if err != nil {
+		return err
+	}
+
+	return c.waitForFileSystemTask(ctx, task.ID)
+}
+
+func cloneVirtualMachinePayload(source, spec types.VirtualMachinePayload) types.VirtualMachinePayload {
+	payload := spec
+
+	if payload.DiskType == "" {
+		payload.DiskType = source.DiskType
+	}
+
+	if payload.CDPath == "" {
+		payload.CDPath = source.CDPath
+	}
+
+	if payload.Memory == 0 {
+		payload.Memory = source.Memory
+	}
+
+	if payload.OS == "" {
+		payload.OS = source.OS
+	}
+
+	if payload.VCPUs == 0 {
+		payload.VCPUs = source.VCPUs
+	}
+
+	if !payload.EnableScreen.IsSet() {
+		payload.EnableScreen = source.EnableScreen
+	}
+
+	if !payload.EnableCloudInit.IsSet() {
+		payload.EnableCloudInit = source.EnableCloudInit
+	}
+
+	if payload.CloudInitUserData == "" {
+		payload.CloudInitUserData = source.CloudInitUserData
+	}
+
+	if payload.CloudHostName == "" && payload.EnableCloudInit.Value() {
+		payload.CloudHostName = payload.Name
+	}
+
+	return payload
+}
@@ -0,0 +1,243 @@
+package client_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("CloneVirtualMachine", func() {
+	const (
+		sourceID = int64(3)
+		cloneID  = int64(4)
+
+		copyTaskID   = int64(11)
+		moveTaskID   = int64(12)
+		removeTaskID = int64(13)
+	)
+
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken string
+
+		spec types.VirtualMachinePayload
+
+		returnedMachine types.VirtualMachine
+		returnedErr     error
+	)
+
+	encode := func(path string) string {
+		return base64.StdEncoding.EncodeToString([]byte(path))
+	}
+
+	taskHandler := func(identifier int64, state string) http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/tasks/%d", version, identifier)),
+			verifyAuth(sessionToken),
+			ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": { "id": %d, "state": %q } }`, identifier, state)),
+		)
+	}
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		sessionToken = setupLoginFlow(server)
+
+		spec = types.VirtualMachinePayload{
+			Name:   "web",
+			Memory: 4096 * types.Megabyte,
+		}
+
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vm/%d", version, sourceID)),
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+					"success": true,
+					"result": {
+						"id": %d,
+						"name": "template",
+						"mac": "f6:69:9c:e6:7d:fd",
+						"disk_path": %q,
+						"disk_type": "qcow2",
+						"memory": 2048,
+						"os": "debian",
+						"vcpus": 2,
+						"enable_cloudinit": true,
+						"cloudinit_hostname": "template",
+						"cloudinit_userdata": "#cloud-config\n",
+						"bind_usb_ports": ["usb-external-type-a"],
+						"status": "stopped"
+					}
+				}`, sourceID, encode("/Freebox/VMs/template.qcow2"))),
+			),
+		)
+	})
+	JustBeforeEach(func(ctx SpecContext) {
+		returnedMachine, returnedErr = freeboxClient.CloneVirtualMachine(ctx, sourceID, spec)
+	})
+	Context("default", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/info/%s", version, encode("/Freebox/VMs/web.qcow2"))),
+					verifyAuth(sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "path_not_found" }`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/mkdir/", version)),
+					verifyAuth(sessionToken),
+					ghttp.VerifyJSON(fmt.Sprintf(`{ "parent": %q, "dirname": ".clone-web.qcow2" }`, encode("/Freebox/VMs/"))),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %q }`, encode("/Freebox/VMs/.clone-web.qcow2"))),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/cp/", version)),
+					verifyAuth(sessionToken),
+					ghttp.VerifyJSON(fmt.Sprintf(`{ "files": [%q], "dst": %q, "mode": "overwrite" }`, encode("/Freebox/VMs/template.qcow2"), encode("/Freebox/VMs/.clone-web.qcow2"))),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": { "id": %d, "state": "running" } }`, copyTaskID)),
+				),
+				taskHandler(copyTaskID, "done"),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/rename/", version)),
+					verifyAuth(sessionToken),
+					ghttp.VerifyJSON(fmt.Sprintf(`{ "src": %q, "dst": "web.qcow2" }`, encode("/Freebox/VMs/.clone-web.qcow2/template.qcow2"))),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "name": "web.qcow2" } }`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/mv/", version)),
+					verifyAuth(sessionToken),
+					ghttp.VerifyJSON(fmt.Sprintf(`{ "files": [%q], "dst": %q, "mode": "skip" }`, encode("/Freebox/VMs/.clone-web.qcow2/web.qcow2"), encode("/Freebox/VMs/"))),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": { "id": %d, "state": "running" } }`, moveTaskID)),
+				),
+				taskHandler(moveTaskID, "done"),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/rm/", version)),
+					verifyAuth(sessionToken),
+					ghttp.VerifyJSON(fmt.Sprintf(`{ "files": [%q] }`, encode("/Freebox/VMs/.clone-web.qcow2"))),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": { "id": %d, "state": "running" } }`, removeTaskID)),
+				),
+				taskHandler(removeTaskID, "done"),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/vm/", version)),
+					verifyAuth(sessionToken),
+					ghttp.VerifyJSON(fmt.Sprintf(`{
+						"name": "web",
+						"disk_path": %q,
+						"disk_type": "qcow2",
+						"memory": 4096,
+						"os": "debian",
+						"vcpus": 2,
+						"enable_cloudinit": true,
+						"cloudinit_hostname": "web",
+						"cloudinit_userdata": "#cloud-config\n"
+					}`, encode("/Freebox/VMs/web.qcow2"))),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+						"success": true,
+						"result": {
+							"id": %d,
+							"name": "web",
+							"mac": "f6:69:9c:e6:7d:fe",
+							"disk_path": %q,
+							"disk_type": "qcow2",
+							"memory": 4096,
+							"os": "debian",
+							"vcpus": 2,
+							"status": "stopped",
+							"bind_usb_ports": ""
+						}
+					}`, cloneID, encode("/Freebox/VMs/web.qcow2"))),
+				),
+			)
+		})
+		It("should copy the disk and create the virtual machine", func() {
+			Expect(returnedErr).To(BeNil())
+			Expect(returnedMachine.ID).To(Equal(cloneID))
+			Expect(returnedMachine.Mac.String()).To(Equal("f6:69:9c:e6:7d:fe"))
+			Expect(returnedMachine.DiskPath).To(BeEquivalentTo("/Freebox/VMs/web.qcow2"))
+			Expect(server.ReceivedRequests()).To(HaveLen(13))
+		})
+	})
+	Context("when no name is given", func() {
+		BeforeEach(func() {
+			spec.Name = ""
+		})
+		It("should return the correct error without calling the freebox", func() {
+			Expect(returnedErr).To(MatchError(client.ErrVirtualMachineNameRequired))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+	Context("when the source virtual machine is running", func() {
+		BeforeEach(func() {
+			server.SetHandler(2, ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vm/%d", version, sourceID)),
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+					"success": true,
+					"result": { "id": %d, "name": "template", "disk_path": %q, "status": "running" }
+				}`, sourceID, encode("/Freebox/VMs/template.qcow2"))),
+			))
+		})
+		It("should return the correct error without copying the disk", func() {
+			Expect(returnedErr).To(MatchError(client.ErrVirtualMachineNotStopped))
+			Expect(server.ReceivedRequests()).To(HaveLen(3))
+		})
+	})
+	Context("when the destination disk already exists", func() {
+		BeforeEach(func() {
+			spec.DiskPath = "/Freebox/Disks/web.qcow2"
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/info/%s", version, encode("/Freebox/Disks/web.qcow2"))),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "name": "web.qcow2", "type": "file" } }`),
+				),
+			)
+		})
+		It("should return the correct error without creating the virtual machine", func() {
+			Expect(returnedErr).To(MatchError(client.ErrDestinationConflict))
+			Expect(server.ReceivedRequests()).To(HaveLen(4))
+		})
+	})
+	Context("when the copy fails", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fs/info/%s", version, encode("/Freebox/VMs/web.qcow2"))),
+					ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "path_not_found" }`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/mkdir/", version)),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": %q }`, encode("/Freebox/VMs/.clone-web.qcow2"))),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/cp/", version)),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": { "id": %d, "state": "running" } }`, copyTaskID)),
+				),
+				taskHandler(copyTaskID, "failed"),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/fs/rm/", version)),
+					ghttp.VerifyJSON(fmt.Sprintf(`{ "files": [%q] }`, encode("/Freebox/VMs/.clone-web.qcow2"))),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": { "id": %d, "state": "running" } }`, removeTaskID)),
+				),
+				taskHandler(removeTaskID, "done"),
+			)
+		})
+		It("should clean up the staging directory and return the correct error", func() {
+			Expect(returnedErr).To(MatchError(client.ErrFileSystemTaskFailed))
+			Expect(server.ReceivedRequests()).To(HaveLen(9))
+		})
+	})
+})
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// OpenVirtualMachineConsole connects to the serial console of a virtual machine. What the machine
+// prints is read from the returned stream, and what is written to it is sent as keyboard input.
+// The connection is closed when the context is done or the stream is closed.
+func (c *client) OpenVirtualMachineConsole(ctx context.Context, identifier int64) (io.ReadWriteCloser, error) {
+	return c.openVirtualMachineStream(ctx, fmt.Sprintf("vm/%d/console", identifier))
+}
+
+// OpenVirtualMachineScreen connects to the screen of a virtual machine. The returned stream carries the
+// raw VNC (RFB) protocol, so it can be bridged to a local VNC client.
+// The connection is closed when the context is done or the stream is closed.
+func (c *client) OpenVirtualMachineScreen(ctx context.Context, identifier int64) (io.ReadWriteCloser, error) {
+	return c.openVirtualMachineStream(ctx, fmt.Sprintf("vm/%d/vnc", identifier))
+}
+
+func (c *client) openVirtualMachineStream(ctx context.Context, path string) (io.ReadWriteCloser, error) {
+	ws, dialResponse, err := c.dialWebSocket(ctx, path)
+	if err != nil {
+		if dialResponse != nil && dialResponse.StatusCode == http.StatusNotFound {
+			return nil, ErrVirtualMachineNotFound
+		}
+
+		return nil, fmt.Errorf("failed to open %s websocket: %w", path, err)
+	}
+
+	return newWebSocketStream(ctx, ws), nil
+}
@@ -0,0 +1,209 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+)
+
+var _ = Describe("virtual machine console", func() {
+	const vmID = int64(12)
+
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken string
+
+		returnedStream io.ReadWriteCloser
+		returnedErr    error
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		sessionToken = setupLoginFlow(server)
+	})
+	JustBeforeEach(func() {
+		// the stream outlives this node, so it cannot be bound to the spec context
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		DeferCleanup(cancel)
+
+		returnedStream, returnedErr = freeboxClient.OpenVirtualMachineConsole(ctx, vmID)
+	})
+	Context("default", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vm/%d/console", version, vmID)),
+					verifyAuth(sessionToken),
+					wsHandler(func(ws *websocket.Conn) {
+						Expect(ws.WriteMessage(websocket.TextMessage, []byte("Welcome\n"))).To(Succeed())
+						Expect(ws.WriteMessage(websocket.BinaryMessage, []byte("login: "))).To(Succeed())
+
+						received, err := readChunk(ws)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(received)).To(Equal("root\n"))
+
+						_, _, err = ws.ReadMessage()
+						Expect(websocket.IsCloseError(err, websocket.CloseNormalClosure)).To(BeTrue())
+					}),
+				),
+			)
+		})
+		It("should stream the console both ways", func() {
+			Expect(returnedErr).To(BeNil())
+
+			buffer := make([]byte, len("Welcome\nlogin: "))
+			Expect(io.ReadFull(returnedStream, buffer)).To(Equal(len(buffer)))
+			Expect(string(buffer)).To(Equal("Welcome\nlogin: "))
+
+			Expect(returnedStream.Write([]byte("root\n"))).To(Equal(5))
+
+			Expect(returnedStream.Close()).To(Succeed())
+			Expect(returnedStream.Close()).To(Succeed())
+		})
+	})
+	Context("when the connection is closed by the freebox", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vm/%d/console", version, vmID)),
+					verifyAuth(sessionToken),
+					wsHandler(func(ws *websocket.Conn) {
+						Expect(ws.WriteMessage(websocket.TextMessage, []byte("Power down.\n"))).To(Succeed())
+						Expect(ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))).To(Succeed())
+					}),
+				),
+			)
+		})
+		It("should read until the end of the stream", func() {
+			Expect(returnedErr).To(BeNil())
+			Expect(io.ReadAll(returnedStream)).To(BeEquivalentTo("Power down.\n"))
+			Expect(returnedStream.Close()).To(Succeed())
+		})
+	})
+	Context("when the virtual machine does not exist", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vm/%d/console", version, vmID)),
+					verifyAuth(sessionToken),
+					ghttp.RespondWith(http.StatusNotFound, `{
+						"success": false,
+						"error_code": "no_such_vm",
+						"msg": "Cette VM n'existe pas"
+					}`),
+				),
+			)
+		})
+		It("should return the correct error", func() {
+			Expect(returnedErr).To(MatchError(client.ErrVirtualMachineNotFound))
+		})
+	})
+	Context("when server fails to respond", func() {
+		BeforeEach(func() {
+			server.Close()
+		})
+		It("should return an error", func() {
+			Expect(returnedErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("virtual machine screen", func() {
+	const vmID = int64(12)
+
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken string
+
+		returnedStream io.ReadWriteCloser
+		returnedErr    error
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		sessionToken = setupLoginFlow(server)
+	})
+	JustBeforeEach(func() {
+		// the stream outlives this node, so it cannot be bound to the spec context
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		DeferCleanup(cancel)
+
+		returnedStream, returnedErr = freeboxClient.OpenVirtualMachineScreen(ctx, vmID)
+	})
+	Context("default", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vm/%d/vnc", version, vmID)),
+					verifyAuth(sessionToken),
+					wsHandler(func(ws *websocket.Conn) {
+						Expect(ws.WriteMessage(websocket.BinaryMessage, []byte("RFB 003.008\n"))).To(Succeed())
+
+						received, err := readChunk(ws)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(received)).To(Equal("RFB 003.008\n"))
+
+						_, _, err = ws.ReadMessage()
+						Expect(websocket.IsCloseError(err, websocket.CloseNormalClosure)).To(BeTrue())
+					}),
+				),
+			)
+		})
+		It("should relay the VNC protocol both ways", func() {
+			Expect(returnedErr).To(BeNil())
+
+			buffer := make([]byte, len("RFB 003.008\n"))
+			Expect(io.ReadFull(returnedStream, buffer)).To(Equal(len(buffer)))
+			Expect(string(buffer)).To(Equal("RFB 003.008\n"))
+
+			Expect(returnedStream.Write(buffer)).To(Equal(len(buffer)))
+
+			Expect(returnedStream.Close()).To(Succeed())
+		})
+	})
+	Context("when the virtual machine does not exist", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vm/%d/vnc", version, vmID)),
+					verifyAuth(sessionToken),
+					ghttp.RespondWith(http.StatusNotFound, nil),
+				),
+			)
+		})
+		It("should return the correct error", func() {
+			Expect(returnedErr).To(MatchError(client.ErrVirtualMachineNotFound))
+		})
+	})
+	Context("when server fails to respond", func() {
+		BeforeEach(func() {
+			server.Close()
+		})
+		It("should return an error", func() {
+			Expect(returnedErr).To(HaveOccurred())
+		})
+	})
+})
@@ -2,23 +2,20 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/nikolalohinski/free-go/types"
 )
 
-const (
-	// Errors.
-	ErrVMDiskSizeInvalid = Error("vm disk size is invalid")
-)
-
 // GetVirtualDiskInfo gets a disk info.
 func (c *client) GetVirtualDiskInfo(ctx context.Context, path string) (result types.VirtualDiskInfo, err error) {
 	response, err := c.post(ctx, "vm/disk/info/", &types.GetVirtualDiskPayload{
 		DiskPath: types.Base64Path(path),
 	}, c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == types.DiskErrorNotFound {
+		if errors.Is(err, ErrPathNotFound) {
 			return result, ErrPathNotFound
 		}
 
@@ -58,7 +55,7 @@ func (c *client) CreateVirtualDisk(ctx context.Context, payload types.VirtualDis
 func (c *client) GetVirtualDiskTask(ctx context.Context, identifier int64) (result types.VirtualMachineDiskTask, err error) {
 	response, err := c.get(ctx, fmt.Sprintf("vm/disk/task/%d", identifier), c.withSession(ctx))
 	if err != nil {
-		if response != nil && response.ErrorCode == types.DiskTaskErrorNotFound {
+		if errors.Is(err, ErrTaskNotFound) {
 			return result, ErrTaskNotFound
 		}
 
@@ -104,6 +101,44 @@ func (c *client) DeleteVirtualDiskTask(ctx context.Context, identifier int64) er
 	return nil
 }
 
+// WaitForVirtualDiskTask polls a virtual disk task until it is done and returns it.
+// An error wrapping ErrVirtualDiskTaskFailed is returned if the task ended in error.
+func (c *client) WaitForVirtualDiskTask(ctx context.Context, identifier int64) (types.VirtualMachineDiskTask, error) {
+	for {
+		task, err := c.GetVirtualDiskTask(ctx, identifier)
+		if err != nil {
+			return task, err
+		}
+
+		switch task.State() {
+		case types.DiskTaskStateDone:
+			return task, nil
+		case types.DiskTaskStateError:
+			return task, fmt.Errorf("task %d: %w", identifier, ErrVirtualDiskTaskFailed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return task, fmt.Errorf("stopped waiting for virtual disk task %d: %w", identifier, ctx.Err())
+		case <-time.After(VirtualDiskTaskPollInterval):
+		}
+	}
+}
+
+// waitForVirtualDiskTask waits for a virtual disk task to be done, then deletes it.
+func (c *client) waitForVirtualDiskTask(ctx context.Context, identifier int64) error {
+	_, taskErr := c.WaitForVirtualDiskTask(ctx, identifier)
+	if taskErr != nil && !errors.Is(taskErr, ErrVirtualDiskTaskFailed) {
+		return taskErr
+	}
+
+	if err := c.DeleteVirtualDiskTask(ctx, identifier); err != nil {
+		return errors.Join(taskErr, fmt.Errorf("failed to delete virtual disk task %d: %w", identifier, err))
+	}
+
+	return taskErr
+}
+
 // GetVirtualMachineDiskTask gets a disk task.
 func (c *client) GetVirtualMachineDiskTask(ctx context.Context, identifier int64) (result types.VirtualMachineDiskTask, err error) {
 	response, err := c.get(ctx, fmt.Sprintf("vm/disk/task/%d", identifier), c.withSession(ctx))
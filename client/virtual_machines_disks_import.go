@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// CreateVirtualDiskFromURL downloads a remote image onto the freebox, waits for the download to complete, then
+// starts resizing it when a size is requested. The freebox does not convert images, so the image must already be
+// in a disk format supported by the virtual machines. The resize task is not waited for: its ID is returned
+// alongside the download task ID for the caller to track it.
+func (c *client) CreateVirtualDiskFromURL(ctx context.Context, payload types.VirtualDiskImportPayload) (types.VirtualDiskImport, error) {
+	var result types.VirtualDiskImport
+
+	if payload.Size < 0 {
+		return result, ErrVMDiskSizeInvalid
+	}
+
+	filename := payload.Filename
+	if filename == "" {
+		filename = path.Base(payload.URL)
+	}
+
+	downloadID, err := c.AddDownloadTask(ctx, types.DownloadRequest{
+		DownloadURLs:      []string{payload.URL},
+		DownloadDirectory: payload.Directory,
+		Filename:          filename,
+		Hash:              payload.Hash,
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to download %s: %w", payload.URL, err)
+	}
+
+	result.DownloadTaskID = downloadID
+
+	task, err := c.WaitForDownloadTask(ctx, downloadID, types.WaitForDownloadTaskOptions{})
+	if err != nil {
+		return result, fmt.Errorf("failed to wait for the download of %s: %w", payload.URL, err)
+	}
+
+	// a partial image must never be resized nor used as a disk
+	if !task.IsComplete() {
+		return result, fmt.Errorf("download of %s is %s: %w", payload.URL, task.Status, ErrDownloadIncomplete)
+	}
+
+	result.DiskPath = path.Join(string(task.DownloadDirectory), filename)
+
+	if payload.Size > 0 {
+		result.DiskTaskID, err = c.ResizeVirtualDisk(ctx, types.VirtualDisksResizePayload{
+			DiskPath: types.Base64Path(result.DiskPath),
+			NewSize:  payload.Size,
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to resize disk %s: %w", result.DiskPath, err)
+		}
+	}
+
+	return result, nil
+}
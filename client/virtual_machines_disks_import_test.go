@@ -0,0 +1,144 @@
+package client_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("CreateVirtualDiskFromURL", func() {
+	const (
+		downloadID = int64(31)
+		diskTaskID = int64(7)
+	)
+
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken string
+
+		payload types.VirtualDiskImportPayload
+
+		returnedImport types.VirtualDiskImport
+		returnedErr    error
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		sessionToken = setupLoginFlow(server)
+
+		// the download events are not available, the download task is polled
+		server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/ws/event", version), ghttp.RespondWith(http.StatusNotFound, nil))
+
+		payload = types.VirtualDiskImportPayload{
+			URL:       "https://cloud.debian.org/images/cloud/bookworm/latest/debian-12.qcow2",
+			Directory: "/Freebox/VMs",
+			Filename:  "web.qcow2",
+			Size:      10 * 1024 * 1024 * 1024,
+		}
+
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/downloads/add", version)),
+				verifyAuth(sessionToken),
+				func(w http.ResponseWriter, r *http.Request) {
+					Expect(r.ParseForm()).To(Succeed())
+					Expect(r.PostForm).To(Equal(url.Values{
+						"download_url": {payload.URL},
+						"download_dir": {"L0ZyZWVib3gvVk1z"},
+						"filename":     {"web.qcow2"},
+					}))
+				},
+				ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": { "id": %d } }`, downloadID)),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d", version, downloadID)),
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+					"success": true,
+					"result": { "id": %d, "status": "done", "name": "web.qcow2", "download_dir": "L0ZyZWVib3gvVk1z" }
+				}`, downloadID)),
+			),
+		)
+	})
+	JustBeforeEach(func(ctx SpecContext) {
+		returnedImport, returnedErr = freeboxClient.CreateVirtualDiskFromURL(ctx, payload)
+	})
+	Context("default", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/vm/disk/resize/", version)),
+					verifyAuth(sessionToken),
+					ghttp.VerifyJSON(`{
+						"disk_path": "L0ZyZWVib3gvVk1zL3dlYi5xY293Mg==",
+						"size": 10737418240,
+						"shrink_allow": false
+					}`),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": { "id": %d } }`, diskTaskID)),
+				),
+			)
+		})
+		It("should download the image and start resizing it", func() {
+			Expect(returnedErr).To(BeNil())
+			Expect(returnedImport).To(Equal(types.VirtualDiskImport{
+				DownloadTaskID: downloadID,
+				DiskTaskID:     diskTaskID,
+				DiskPath:       "/Freebox/VMs/web.qcow2",
+			}))
+			Expect(server.ReceivedRequests()).To(HaveLen(6))
+		})
+	})
+	Context("when no size is requested", func() {
+		BeforeEach(func() {
+			payload.Size = 0
+		})
+		It("should only download the image", func() {
+			Expect(returnedErr).To(BeNil())
+			Expect(returnedImport).To(Equal(types.VirtualDiskImport{
+				DownloadTaskID: downloadID,
+				DiskPath:       "/Freebox/VMs/web.qcow2",
+			}))
+			Expect(server.ReceivedRequests()).To(HaveLen(5))
+		})
+	})
+	Context("when the download fails", func() {
+		BeforeEach(func() {
+			server.SetHandler(3, ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d", version, downloadID)),
+				ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+					"success": true,
+					"result": { "id": %d, "status": "error", "error": "internal" }
+				}`, downloadID)),
+			))
+		})
+		It("should return the download task ID along with the error", func() {
+			Expect(returnedErr).NotTo(BeNil())
+			Expect(returnedImport.DownloadTaskID).To(Equal(downloadID))
+			Expect(returnedImport.DiskTaskID).To(BeZero())
+		})
+	})
+	Context("when the size is negative", func() {
+		BeforeEach(func() {
+			payload.Size = -1
+			server.Reset()
+		})
+		It("should return the correct error", func() {
+			Expect(returnedErr).To(MatchError(client.ErrVMDiskSizeInvalid))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+})
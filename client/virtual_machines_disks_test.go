@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	//
 	"github.com/nikolalohinski/free-go/client"
@@ -337,4 +338,68 @@ var _ = Describe("virtual machines disks", func() {
 			})
 		})
 	})
+	Context("waiting for a virtual disk task", func() {
+		const identifier int64 = 42
+		returnedTask := new(types.VirtualMachineDiskTask)
+		BeforeEach(func() {
+			interval := client.VirtualDiskTaskPollInterval
+			client.VirtualDiskTaskPollInterval = time.Millisecond
+			DeferCleanup(func() {
+				client.VirtualDiskTaskPollInterval = interval
+			})
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vm/disk/task/%d", version, identifier)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "id": 42, "type": "resize", "done": false } }`),
+				),
+			)
+		})
+		JustBeforeEach(func(ctx SpecContext) {
+			*returnedTask, *returnedErr = freeboxClient.WaitForVirtualDiskTask(ctx, identifier)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vm/disk/task/%d", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "id": 42, "type": "resize", "done": true, "error": false } }`),
+					),
+				)
+			})
+			It("should poll the task until it is done", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedTask.Type).To(Equal(types.DiskTaskTypeResize))
+				Expect(returnedTask.State()).To(Equal(types.DiskTaskStateDone))
+				Expect(server.ReceivedRequests()).To(HaveLen(4))
+			})
+		})
+		Context("when the task ends in error", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vm/disk/task/%d", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "id": 42, "type": "resize", "done": true, "error": true } }`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(MatchError(client.ErrVirtualDiskTaskFailed))
+				Expect(returnedTask.State()).To(Equal(types.DiskTaskStateError))
+			})
+		})
+		Context("when the task is not found", func() {
+			BeforeEach(func() {
+				server.SetHandler(2, ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vm/disk/task/%d", version, identifier)),
+					ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "task_notfound" }`),
+				))
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(MatchError(client.ErrTaskNotFound))
+			})
+		})
+	})
 })
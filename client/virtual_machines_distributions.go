@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// distributionsCache holds the last list of virtual machine distributions returned by the freebox, which hardly ever
+// changes.
+type distributionsCache struct {
+	mutex     sync.Mutex
+	list      []types.VirtualMachineDistribution
+	fetchedAt time.Time // zero until the list is fetched
+}
+
+// GetCachedVirtualMachineDistributions returns the distributions like GetVirtualMachineDistributions, but only asks
+// the freebox once every VirtualMachineDistributionsCacheTTL. Concurrent calls share the same request.
+func (c *client) GetCachedVirtualMachineDistributions(ctx context.Context) ([]types.VirtualMachineDistribution, error) {
+	c.distributions.mutex.Lock()
+	defer c.distributions.mutex.Unlock()
+
+	if !c.distributions.fetchedAt.IsZero() && time.Since(c.distributions.fetchedAt) < VirtualMachineDistributionsCacheTTL {
+		return slices.Clone(c.distributions.list), nil
+	}
+
+	return c.refreshDistributions(ctx)
+}
+
+// RefreshVirtualMachineDistributions asks the freebox for the distributions and stores them for the next calls to
+// GetCachedVirtualMachineDistributions, whatever the age of the cached ones.
+func (c *client) RefreshVirtualMachineDistributions(ctx context.Context) ([]types.VirtualMachineDistribution, error) {
+	c.distributions.mutex.Lock()
+	defer c.distributions.mutex.Unlock()
+
+	return c.refreshDistributions(ctx)
+}
+
+// refreshDistributions fetches the distributions into the cache, whose lock must be held. The cache is left untouched
+// on failure.
+func (c *client) refreshDistributions(ctx context.Context) ([]types.VirtualMachineDistribution, error) {
+	list, err := c.GetVirtualMachineDistributions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.distributions.list = list
+	c.distributions.fetchedAt = time.Now()
+
+	return slices.Clone(list), nil
+}
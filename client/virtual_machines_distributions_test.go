@@ -0,0 +1,118 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("cached virtual machine distributions", func() {
+	var (
+		freeboxClient client.Client
+
+		server *ghttp.Server
+
+		returnedDistributions = new([]types.VirtualMachineDistribution)
+		returnedErr           = new(error)
+	)
+	respondWithDistributions := func(names ...string) http.HandlerFunc {
+		distributions := []types.VirtualMachineDistribution{}
+		for _, name := range names {
+			distributions = append(distributions, types.VirtualMachineDistribution{OS: types.DebianOS, Name: name})
+		}
+
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vm/distros/", version)),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+				"success": true,
+				"result":  distributions,
+			}),
+		)
+	}
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		setupLoginFlow(server)
+		server.AppendHandlers(respondWithDistributions("Debian 12 (Bookworm)"))
+
+		*returnedDistributions, *returnedErr = freeboxClient.GetCachedVirtualMachineDistributions(context.Background())
+		Expect(*returnedErr).To(BeNil())
+	})
+	Context("when the distributions are fetched again", func() {
+		JustBeforeEach(func() {
+			*returnedDistributions, *returnedErr = freeboxClient.GetCachedVirtualMachineDistributions(context.Background())
+		})
+		Context("default", func() {
+			It("should return the cached distributions without asking the freebox", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedDistributions).To(HaveLen(1))
+				Expect(server.ReceivedRequests()).To(HaveLen(3))
+			})
+		})
+		Context("when the returned list was modified", func() {
+			BeforeEach(func() {
+				(*returnedDistributions)[0].Name = "modified"
+			})
+			It("should return the cached distributions untouched", func() {
+				Expect((*returnedDistributions)[0].Name).To(Equal("Debian 12 (Bookworm)"))
+			})
+		})
+		Context("when the cache has expired", func() {
+			BeforeEach(func() {
+				ttl := client.VirtualMachineDistributionsCacheTTL
+				client.VirtualMachineDistributionsCacheTTL = 0
+				DeferCleanup(func() { client.VirtualMachineDistributionsCacheTTL = ttl })
+
+				server.AppendHandlers(respondWithDistributions("Debian 12 (Bookworm)", "Debian 13 (Trixie)"))
+			})
+			It("should ask the freebox again", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedDistributions).To(HaveLen(2))
+				Expect(server.ReceivedRequests()).To(HaveLen(4))
+			})
+		})
+	})
+	Context("when the distributions are refreshed", func() {
+		JustBeforeEach(func() {
+			*returnedDistributions, *returnedErr = freeboxClient.RefreshVirtualMachineDistributions(context.Background())
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(respondWithDistributions("Debian 12 (Bookworm)", "Debian 13 (Trixie)"))
+			})
+			It("should ask the freebox and cache the new distributions", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedDistributions).To(HaveLen(2))
+
+				cached, err := freeboxClient.GetCachedVirtualMachineDistributions(context.Background())
+				Expect(err).To(BeNil())
+				Expect(cached).To(Equal(*returnedDistributions))
+				Expect(server.ReceivedRequests()).To(HaveLen(4))
+			})
+		})
+		Context("when the freebox fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "internal_error" }`))
+			})
+			It("should return the error and keep the cached distributions", func() {
+				Expect(*returnedErr).To(MatchError(&client.APIError{Code: "internal_error"}))
+
+				cached, err := freeboxClient.GetCachedVirtualMachineDistributions(context.Background())
+				Expect(err).To(BeNil())
+				Expect(cached).To(HaveLen(1))
+			})
+		})
+	})
+})
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// WatchVirtualMachine sends the status of a virtual machine on the returned channel each time it changes.
+// The channel is closed when the context is canceled, or after an error is sent. In both cases the underlying
+// websocket is only released once the context is canceled.
+func (c *client) WatchVirtualMachine(ctx context.Context, identifier int64) (<-chan types.VirtualMachineStateChanged, error) {
+	events, err := c.ListenEvents(ctx, []types.EventDescription{{
+		Source: types.EventSourceVM,
+		Name:   types.EventStateChanged,
+	}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen to virtual machine events: %w", err)
+	}
+
+	channel := make(chan types.VirtualMachineStateChanged, 1)
+
+	go func() {
+		// drain the events until the listener closes its channel so that its goroutine can exit
+		defer func() {
+			for range events {
+			}
+		}()
+
+		defer close(channel)
+
+		send := func(change types.VirtualMachineStateChanged) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case channel <- change:
+				return true
+			}
+		}
+
+		for event := range events {
+			if event.Error != nil {
+				send(types.VirtualMachineStateChanged{Error: event.Error})
+
+				return
+			}
+
+			change, err := event.Notification.DecodeVirtualMachineStateChanged()
+			if err != nil {
+				send(types.VirtualMachineStateChanged{Error: err})
+
+				return
+			}
+
+			if change.ID == identifier && !send(change) {
+				return
+			}
+		}
+	}()
+
+	return channel, nil
+}
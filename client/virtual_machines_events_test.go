@@ -0,0 +1,111 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("WatchVirtualMachine", func() {
+	const vmID = int64(1)
+
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken string
+
+		ctx    context.Context
+		cancel context.CancelFunc
+
+		notifications []string
+
+		returnedChannel <-chan types.VirtualMachineStateChanged
+		returnedErr     error
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		sessionToken = setupLoginFlow(server)
+
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/ws/event", version)),
+			verifyAuth(sessionToken),
+			wsHandler(func(ws *websocket.Conn) {
+				var register map[string]interface{}
+				Expect(readJSON(ws, &register)).To(Succeed())
+				Expect(register).To(HaveKeyWithValue("events", ConsistOf("vm_state_changed")))
+
+				Expect(writeJSON(ws, map[string]interface{}{"action": "register", "success": true})).To(Succeed())
+
+				for _, notification := range notifications {
+					Expect(ws.WriteMessage(websocket.TextMessage, []byte(notification))).To(Succeed())
+				}
+
+				_, _, err := ws.ReadMessage()
+				Expect(websocket.IsCloseError(err, websocket.CloseNormalClosure)).To(BeTrue())
+			}),
+		))
+	})
+	JustBeforeEach(func() {
+		returnedChannel, returnedErr = freeboxClient.WatchVirtualMachine(ctx, vmID)
+	})
+	Context("default", func() {
+		BeforeEach(func() {
+			notifications = []string{
+				`{ "action": "notification", "success": true, "source": "vm", "event": "state_changed", "result": { "id": 2, "status": "starting" } }`,
+				`{ "action": "notification", "success": true, "source": "vm", "event": "state_changed", "result": { "id": 1, "status": "stopping" } }`,
+				`{ "action": "notification", "success": true, "source": "vm", "event": "state_changed", "result": { "id": 1, "status": "stopped" } }`,
+			}
+		})
+		It("should send the status changes of the virtual machine", func() {
+			Expect(returnedErr).To(BeNil())
+			Eventually(returnedChannel).Should(Receive(Equal(types.VirtualMachineStateChanged{ID: vmID, Status: types.StoppingStatus})))
+			Eventually(returnedChannel).Should(Receive(Equal(types.VirtualMachineStateChanged{ID: vmID, Status: types.StoppedStatus})))
+
+			cancel()
+			Eventually(returnedChannel).Should(BeClosed())
+		})
+	})
+	Context("when the server returns an unexpected payload", func() {
+		BeforeEach(func() {
+			notifications = []string{
+				`{ "action": "notification", "success": true, "source": "vm", "event": "state_changed", "result": [] }`,
+			}
+		})
+		It("should send an error then close the channel", func() {
+			Expect(returnedErr).To(BeNil())
+
+			var change types.VirtualMachineStateChanged
+			Eventually(returnedChannel).Should(Receive(&change))
+			Expect(change.Error).To(HaveOccurred())
+			Eventually(returnedChannel).Should(BeClosed())
+
+			cancel()
+		})
+	})
+	Context("when server fails to respond", func() {
+		BeforeEach(func() {
+			server.Close()
+		})
+		It("should return an error", func() {
+			Expect(returnedErr).To(HaveOccurred())
+		})
+	})
+})
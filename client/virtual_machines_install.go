@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// InstallVirtualMachine downloads the image of a distribution onto the freebox, checking its hash, grows it to
+// the requested disk size, then creates a virtual machine booting on it and optionally starts it. Unless set in spec,
+// the disk type is guessed from the extension of the image, defaulting to qcow2.
+func (c *client) InstallVirtualMachine(ctx context.Context, distribution types.VirtualMachineDistribution, spec types.VirtualMachineInstallSpec) (types.VirtualMachine, error) {
+	disk, err := c.CreateVirtualDiskFromURL(ctx, types.VirtualDiskImportPayload{
+		URL:       distribution.URL,
+		Hash:      distribution.Hash,
+		Directory: spec.Directory,
+		Filename:  spec.DiskName,
+		Size:      spec.DiskSize,
+	})
+	if err != nil {
+		return types.VirtualMachine{}, fmt.Errorf("failed to create disk from %s image: %w", distribution.Name, err)
+	}
+
+	if disk.DiskTaskID != 0 {
+		if err := c.waitForVirtualDiskTask(ctx, disk.DiskTaskID); err != nil {
+			return types.VirtualMachine{}, fmt.Errorf("failed to resize disk %s: %w", disk.DiskPath, err)
+		}
+	}
+
+	payload := spec.VirtualMachinePayload
+	payload.DiskPath = types.Base64Path(disk.DiskPath)
+
+	if payload.DiskType == "" {
+		diskType, ok := types.DiskTypeFromPath(disk.DiskPath)
+		if !ok {
+			// the distributions published by the freebox are qcow2 images
+			diskType = types.QCow2Disk
+		}
+
+		payload.DiskType = diskType
+	}
+
+	if payload.OS == "" {
+		payload.OS = distribution.OS
+	}
+
+	machine, err := c.CreateVirtualMachine(ctx, payload)
+	if err != nil {
+		return machine, fmt.Errorf("failed to create virtual machine: %w", err)
+	}
+
+	if spec.Start {
+		if err := c.StartVirtualMachine(ctx, machine.ID); err != nil {
+			return machine, fmt.Errorf("failed to start virtual machine %d: %w", machine.ID, err)
+		}
+	}
+
+	return machine, nil
+}
@@ -0,0 +1,268 @@
+package client_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("InstallVirtualMachine", func() {
+	const (
+		downloadID = int64(31)
+		diskTaskID = int64(7)
+		vmID       = int64(3)
+	)
+
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken string
+
+		distribution types.VirtualMachineDistribution
+		spec         types.VirtualMachineInstallSpec
+		filename     string
+
+		returnedMachine types.VirtualMachine
+		returnedErr     error
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		sessionToken = setupLoginFlow(server)
+
+		// the download events are not available, the download task is polled
+		server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/ws/event", version), ghttp.RespondWith(http.StatusNotFound, nil))
+
+		distribution = types.VirtualMachineDistribution{
+			Name: "Debian 12 (Bookworm)",
+			OS:   types.DebianOS,
+			URL:  "https://cloud.debian.org/images/cloud/bookworm/latest/debian-12.qcow2",
+			Hash: "https://cloud.debian.org/images/cloud/bookworm/latest/SHA512SUMS",
+		}
+		spec = types.VirtualMachineInstallSpec{
+			VirtualMachinePayload: types.VirtualMachinePayload{
+				Name:   "debian",
+				Memory: 2048 * types.Megabyte,
+				VCPUs:  1,
+			},
+			Directory: "/Freebox/VMs",
+			DiskSize:  10 * types.Gigabyte,
+			Start:     true,
+		}
+		filename = "debian-12.qcow2"
+
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/downloads/add", version)),
+				verifyAuth(sessionToken),
+				func(w http.ResponseWriter, r *http.Request) {
+					Expect(r.ParseForm()).To(Succeed())
+					Expect(r.PostForm).To(Equal(url.Values{
+						"download_url": {distribution.URL},
+						"download_dir": {"L0ZyZWVib3gvVk1z"},
+						"filename":     {filename},
+						"hash":         {distribution.Hash},
+					}))
+				},
+				ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": { "id": %d } }`, downloadID)),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d", version, downloadID)),
+				verifyAuth(sessionToken),
+				ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+					"success": true,
+					"result": { "id": %d, "status": "done", "name": "debian-12.qcow2", "download_dir": "L0ZyZWVib3gvVk1z" }
+				}`, downloadID)),
+			),
+		)
+	})
+	JustBeforeEach(func(ctx SpecContext) {
+		returnedMachine, returnedErr = freeboxClient.InstallVirtualMachine(ctx, distribution, spec)
+	})
+	Context("default", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/vm/disk/resize/", version)),
+					verifyAuth(sessionToken),
+					ghttp.VerifyJSON(`{
+						"disk_path": "L0ZyZWVib3gvVk1zL2RlYmlhbi0xMi5xY293Mg==",
+						"size": 10737418240,
+						"shrink_allow": false
+					}`),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": { "id": %d } }`, diskTaskID)),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vm/disk/task/%d", version, diskTaskID)),
+					verifyAuth(sessionToken),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": { "id": %d, "type": "resize", "done": true, "error": false } }`, diskTaskID)),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/vm/disk/task/%d", version, diskTaskID)),
+					verifyAuth(sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/vm/", version)),
+					verifyAuth(sessionToken),
+					ghttp.VerifyJSON(`{
+						"name": "debian",
+						"disk_path": "L0ZyZWVib3gvVk1zL2RlYmlhbi0xMi5xY293Mg==",
+						"disk_type": "qcow2",
+						"memory": 2048,
+						"os": "debian",
+						"vcpus": 1
+					}`),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+						"success": true,
+						"result": {
+							"id": %d,
+							"name": "debian",
+							"disk_path": "L0ZyZWVib3gvVk1zL2RlYmlhbi0xMi5xY293Mg==",
+							"disk_type": "qcow2",
+							"memory": 2048,
+							"os": "debian",
+							"vcpus": 1,
+							"status": "stopped",
+							"bind_usb_ports": ""
+						}
+					}`, vmID)),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/vm/%d/start", version, vmID)),
+					verifyAuth(sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+				),
+			)
+		})
+		It("should download the image, resize it, then create and start the virtual machine", func() {
+			Expect(returnedErr).To(BeNil())
+			Expect(returnedMachine.ID).To(Equal(vmID))
+			Expect(returnedMachine.DiskPath).To(BeEquivalentTo("/Freebox/VMs/debian-12.qcow2"))
+			Expect(server.ReceivedRequests()).To(HaveLen(10))
+		})
+	})
+	Context("when the disk type is not given", func() {
+		createWithDiskType := func(diskPath, diskType string) {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/vm/", version)),
+					verifyAuth(sessionToken),
+					ghttp.VerifyJSON(fmt.Sprintf(`{
+						"name": "debian",
+						"disk_path": %q,
+						"disk_type": %q,
+						"memory": 2048,
+						"os": "debian",
+						"vcpus": 1
+					}`, diskPath, diskType)),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": { "id": %d, "bind_usb_ports": "" } }`, vmID)),
+				),
+			)
+		}
+		BeforeEach(func() {
+			spec.DiskSize = 0
+			spec.Start = false
+		})
+		Context("and the image is a raw image", func() {
+			BeforeEach(func() {
+				spec.DiskName = "debian-12.raw"
+				filename = spec.DiskName
+
+				createWithDiskType("L0ZyZWVib3gvVk1zL2RlYmlhbi0xMi5yYXc=", "raw")
+			})
+			It("should create a virtual machine booting on a raw disk", func() {
+				Expect(returnedErr).To(BeNil())
+				Expect(returnedMachine.ID).To(Equal(vmID))
+			})
+		})
+		Context("and the extension of the image does not tell its type", func() {
+			BeforeEach(func() {
+				spec.DiskName = "debian-12.img"
+				filename = spec.DiskName
+
+				createWithDiskType("L0ZyZWVib3gvVk1zL2RlYmlhbi0xMi5pbWc=", "qcow2")
+			})
+			It("should default to a qcow2 disk", func() {
+				Expect(returnedErr).To(BeNil())
+				Expect(returnedMachine.ID).To(Equal(vmID))
+			})
+		})
+	})
+	Context("when the disk type is given", func() {
+		BeforeEach(func() {
+			spec.DiskSize = 0
+			spec.Start = false
+			spec.DiskType = types.RawDisk
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/vm/", version)),
+					verifyAuth(sessionToken),
+					ghttp.VerifyJSON(`{
+						"name": "debian",
+						"disk_path": "L0ZyZWVib3gvVk1zL2RlYmlhbi0xMi5xY293Mg==",
+						"disk_type": "raw",
+						"memory": 2048,
+						"os": "debian",
+						"vcpus": 1
+					}`),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": { "id": %d, "bind_usb_ports": "" } }`, vmID)),
+				),
+			)
+		})
+		It("should keep it", func() {
+			Expect(returnedErr).To(BeNil())
+			Expect(returnedMachine.ID).To(Equal(vmID))
+		})
+	})
+	Context("when the disk resize fails", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/vm/disk/resize/", version)),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": { "id": %d } }`, diskTaskID)),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vm/disk/task/%d", version, diskTaskID)),
+					ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{ "success": true, "result": { "id": %d, "type": "resize", "done": true, "error": true } }`, diskTaskID)),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/vm/disk/task/%d", version, diskTaskID)),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+				),
+			)
+		})
+		It("should return the correct error without creating the virtual machine", func() {
+			Expect(returnedErr).To(MatchError(client.ErrVirtualDiskTaskFailed))
+			Expect(server.ReceivedRequests()).To(HaveLen(8))
+		})
+	})
+	Context("when the image download fails", func() {
+		BeforeEach(func() {
+			server.SetHandler(3, ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/downloads/%d", version, downloadID)),
+				ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+					"success": true,
+					"result": { "id": %d, "status": "error", "error": "hash_download" }
+				}`, downloadID)),
+			))
+		})
+		It("should return the correct error", func() {
+			Expect(returnedErr).To(MatchError(client.ErrDownloadHashFailed))
+		})
+	})
+})
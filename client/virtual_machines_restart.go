@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// RestartVirtualMachine sends a powerbutton signal to a virtual machine and waits for it to shut down,
+// killing it if it is still running after the timeout, then starts it again. A timeout lower or equal to zero
+// waits for VirtualMachineShutdownTimeout.
+func (c *client) RestartVirtualMachine(ctx context.Context, identifier int64, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = VirtualMachineShutdownTimeout
+	}
+
+	machine, err := c.GetVirtualMachine(ctx, identifier)
+	if err != nil {
+		return err
+	}
+
+	if machine.Status != types.StoppedStatus {
+		if err := c.StopVirtualMachine(ctx, identifier); err != nil {
+			return err
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		if err := c.waitForVirtualMachineStatus(shutdownCtx, identifier, types.StoppedStatus); err != nil {
+			if !errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+				return err
+			}
+
+			if err := c.KillVirtualMachine(ctx, identifier); err != nil {
+				return fmt.Errorf("failed to kill virtual machine %d after %s: %w", identifier, timeout, err)
+			}
+
+			if err := c.waitForVirtualMachineStatus(ctx, identifier, types.StoppedStatus); err != nil {
+				return err
+			}
+		}
+	}
+
+	return c.StartVirtualMachine(ctx, identifier)
+}
+
+// waitForVirtualMachineStatus polls a virtual machine until it reaches the given status.
+func (c *client) waitForVirtualMachineStatus(ctx context.Context, identifier int64, status types.MachineStatus) error {
+	for {
+		machine, err := c.GetVirtualMachine(ctx, identifier)
+		if err != nil {
+			return err
+		}
+
+		if machine.Status == status {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for virtual machine %d to be %s: %w", identifier, status, ctx.Err())
+		case <-time.After(VirtualMachinePollInterval):
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package client_test
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+)
+
+var _ = Describe("RestartVirtualMachine", func() {
+	const vmID = int64(1234)
+
+	var (
+		freeboxClient client.Client
+
+		server       *ghttp.Server
+		sessionToken string
+
+		timeout time.Duration
+
+		lock              sync.Mutex
+		status            string
+		ignorePowerButton bool
+		calls             []string
+
+		returnedErr error
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		freeboxClient = Must(client.New(server.Addr(), version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		sessionToken = setupLoginFlow(server)
+
+		pollInterval := client.VirtualMachinePollInterval
+		client.VirtualMachinePollInterval = 10 * time.Millisecond
+		DeferCleanup(func() { client.VirtualMachinePollInterval = pollInterval })
+
+		timeout = time.Second
+		status = "running"
+		ignorePowerButton = false
+		calls = nil
+
+		server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/vm/%d", version, vmID), ghttp.CombineHandlers(
+			verifyAuth(sessionToken),
+			func(w http.ResponseWriter, r *http.Request) {
+				lock.Lock()
+				defer lock.Unlock()
+
+				ghttp.RespondWith(http.StatusOK, fmt.Sprintf(`{
+					"success": true,
+					"result": { "id": %d, "status": %q, "bind_usb_ports": "" }
+				}`, vmID, status))(w, r)
+			},
+		))
+		for action, next := range map[string]string{"powerbutton": "stopped", "stop": "stopped", "start": "starting"} {
+			action, next := action, next
+			server.RouteToHandler(http.MethodPost, fmt.Sprintf("/api/%s/vm/%d/%s", version, vmID, action), ghttp.CombineHandlers(
+				verifyAuth(sessionToken),
+				func(w http.ResponseWriter, r *http.Request) {
+					lock.Lock()
+					defer lock.Unlock()
+
+					calls = append(calls, action)
+					if action != "powerbutton" || !ignorePowerButton {
+						status = next
+					}
+				},
+				ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+			))
+		}
+	})
+	JustBeforeEach(func(ctx SpecContext) {
+		returnedErr = freeboxClient.RestartVirtualMachine(ctx, vmID, timeout)
+	})
+	Context("default", func() {
+		It("should gracefully stop the virtual machine then start it", func() {
+			Expect(returnedErr).To(BeNil())
+			Expect(calls).To(Equal([]string{"powerbutton", "start"}))
+		})
+	})
+	Context("when the virtual machine ignores the powerbutton signal", func() {
+		BeforeEach(func() {
+			timeout = 50 * time.Millisecond
+			ignorePowerButton = true
+		})
+		It("should kill the virtual machine after the timeout then start it", func() {
+			Expect(returnedErr).To(BeNil())
+			Expect(calls).To(Equal([]string{"powerbutton", "stop", "start"}))
+		})
+	})
+	Context("when no timeout is given", func() {
+		BeforeEach(func() {
+			timeout = 0
+		})
+		It("should wait for the default timeout instead of killing the virtual machine", func() {
+			Expect(returnedErr).To(BeNil())
+			Expect(calls).To(Equal([]string{"powerbutton", "start"}))
+		})
+		Context("when the virtual machine ignores the powerbutton signal", func() {
+			BeforeEach(func() {
+				ignorePowerButton = true
+
+				shutdownTimeout := client.VirtualMachineShutdownTimeout
+				client.VirtualMachineShutdownTimeout = 50 * time.Millisecond
+				DeferCleanup(func() { client.VirtualMachineShutdownTimeout = shutdownTimeout })
+			})
+			It("should kill the virtual machine after the default timeout", func() {
+				Expect(returnedErr).To(BeNil())
+				Expect(calls).To(Equal([]string{"powerbutton", "stop", "start"}))
+			})
+		})
+	})
+	Context("when the virtual machine is already stopped", func() {
+		BeforeEach(func() {
+			status = "stopped"
+		})
+		It("should only start the virtual machine", func() {
+			Expect(returnedErr).To(BeNil())
+			Expect(calls).To(Equal([]string{"start"}))
+		})
+	})
+	Context("when the virtual machine does not exist", func() {
+		BeforeEach(func() {
+			server.RouteToHandler(http.MethodGet, fmt.Sprintf("/api/%s/vm/%d", version, vmID), ghttp.RespondWith(http.StatusOK, `{
+				"success": false,
+				"error_code": "no_such_vm"
+			}`))
+		})
+		It("should return the correct error", func() {
+			Expect(returnedErr).To(MatchError(client.ErrVirtualMachineNotFound))
+			Expect(calls).To(BeEmpty())
+		})
+	})
+})
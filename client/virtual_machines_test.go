@@ -2,6 +2,7 @@ package client_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -87,7 +88,7 @@ var _ = Describe("virtual machines", func() {
 						"usb-external-type-c",
 					},
 					UsedCPUs:    0,
-					TotalMemory: 1024,
+					TotalMemory: 1024 * types.Megabyte,
 					TotalCPUs:   2,
 				},
 				))
@@ -230,19 +231,19 @@ var _ = Describe("virtual machines", func() {
 				Expect(*returnedErr).To(BeNil())
 				Expect((*returnedMachines)).To(Equal([]types.VirtualMachine{{
 					ID:     0,
-					Mac:    "f6:69:9c:d9:4f:3d",
+					Mac:    Must(types.ParseMACAddress("f6:69:9c:d9:4f:3d")),
 					Status: types.StoppedStatus,
 					VirtualMachinePayload: types.VirtualMachinePayload{
 						Name:              "testing",
 						DiskPath:          "/Freebox/disk-path",
 						DiskType:          types.QCow2Disk,
 						CDPath:            "/Freebox/path/to/image",
-						Memory:            300,
+						Memory:            300 * types.Megabyte,
 						OS:                types.DebianOS,
 						VCPUs:             1,
-						EnableScreen:      false,
+						EnableScreen:      types.NewOptional(false),
 						BindUSBPorts:      []string{},
-						EnableCloudInit:   true,
+						EnableCloudInit:   types.NewOptional(true),
 						CloudInitUserData: "\n#cloud-config\n\n\nsystem_info:\n  default_user:\n    name: freemind\n",
 						CloudHostName:     "testing",
 					},
@@ -274,6 +275,124 @@ var _ = Describe("virtual machines", func() {
 			})
 		})
 	})
+	Context("validating a virtual machine payload", func() {
+		payload := new(types.VirtualMachinePayload)
+		BeforeEach(func() {
+			*payload = types.VirtualMachinePayload{
+				Name:         "testing",
+				Memory:       512 * types.Megabyte,
+				VCPUs:        1,
+				OS:           types.DebianOS,
+				DiskType:     types.QCow2Disk,
+				BindUSBPorts: types.BindUSBPorts{"usb-external-type-a"},
+			}
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vm/info/", version)),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": true,
+						"result": {
+							"used_memory": 256,
+							"usb_ports": [
+								"usb-external-type-a",
+								"usb-external-type-c"
+							],
+							"used_cpus": 1,
+							"total_memory": 1024,
+							"total_cpus": 2
+						}
+					}`),
+				),
+			)
+		})
+		JustBeforeEach(func() {
+			*returnedErr = freeboxClient.ValidateVirtualMachinePayload(context.Background(), *payload)
+		})
+		Context("default", func() {
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the payload exceeds the remaining capacity", func() {
+			BeforeEach(func() {
+				payload.Memory = 1024 * types.Megabyte
+				payload.VCPUs = 2
+				payload.BindUSBPorts = types.BindUSBPorts{"usb-internal"}
+			})
+			It("should return an error for every invalid field", func() {
+				Expect(*returnedErr).To(MatchError(types.ErrInvalidVirtualMachinePayload))
+				Expect(*returnedErr).To(MatchError(ContainSubstring("memory: exceeds the 768.0 MiB remaining on the freebox")))
+				Expect(*returnedErr).To(MatchError(ContainSubstring("vcpus: exceeds the 1 remaining on the freebox")))
+				Expect(*returnedErr).To(MatchError(ContainSubstring(`bind_usb_ports: unknown USB port "usb-internal"`)))
+
+				var payloadErr *types.VirtualMachinePayloadError
+				Expect(errors.As(*returnedErr, &payloadErr)).To(BeTrue())
+				Expect(payloadErr.Field).To(Equal("memory"))
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("validating a virtual machine update", func() {
+		payload := new(types.VirtualMachinePayload)
+		BeforeEach(func() {
+			*payload = types.VirtualMachinePayload{
+				Memory: 768 * types.Megabyte,
+				VCPUs:  1,
+			}
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vm/info/", version)),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": true,
+						"result": {
+							"used_memory": 768,
+							"usb_ports": [],
+							"used_cpus": 2,
+							"total_memory": 1024,
+							"total_cpus": 2
+						}
+					}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vm/1234", version)),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": true,
+						"result": {
+							"id": 1234,
+							"name": "testing",
+							"vcpus": 1,
+							"memory": 512,
+							"status": "running",
+							"bind_usb_ports": ""
+						}
+					}`),
+				),
+			)
+		})
+		JustBeforeEach(func() {
+			*returnedErr = freeboxClient.ValidateVirtualMachineUpdate(context.Background(), 1234, *payload)
+		})
+		Context("default", func() {
+			It("should count the resources of the machine as available", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the payload exceeds the capacity left to the machine", func() {
+			BeforeEach(func() {
+				payload.Memory = 1024 * types.Megabyte
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).To(MatchError(ContainSubstring("memory: exceeds the 768.0 MiB remaining on the freebox")))
+			})
+		})
+	})
 	Context("creating a virtual machine", func() {
 		var (
 			payload         = new(types.VirtualMachinePayload)
@@ -284,14 +403,14 @@ var _ = Describe("virtual machines", func() {
 				CloudInitUserData: "\n#cloud-config\n\n\nsystem_info:\n  default_user:\n    name: freemind\n",
 				CDPath:            "/Freebox/path/to/image",
 				OS:                types.DebianOS,
-				EnableCloudInit:   true,
+				EnableCloudInit:   types.NewOptional(true),
 				DiskPath:          "/Freebox/disk-path",
 				VCPUs:             1,
-				Memory:            300,
+				Memory:            300 * types.Megabyte,
 				Name:              "testing",
 				CloudHostName:     "testing",
 				BindUSBPorts:      []string{},
-				EnableScreen:      false,
+				EnableScreen:      types.NewOptional(false),
 				DiskType:          types.QCow2Disk,
 			}
 		})
@@ -313,6 +432,7 @@ var _ = Describe("virtual machines", func() {
 							"memory": 300,
 							"name": "testing",
 							"cloudinit_hostname": "testing",
+							"enable_screen": false,
 							"disk_type": "qcow2"
 						}`),
 						ghttp.RespondWith(http.StatusOK, `{
@@ -342,19 +462,19 @@ var _ = Describe("virtual machines", func() {
 				Expect(*returnedErr).To(BeNil())
 				Expect((*returnedMachine)).To(Equal(types.VirtualMachine{
 					ID:     0,
-					Mac:    "f6:69:9c:d9:4f:3d",
+					Mac:    Must(types.ParseMACAddress("f6:69:9c:d9:4f:3d")),
 					Status: types.StoppedStatus,
 					VirtualMachinePayload: types.VirtualMachinePayload{
 						Name:              "testing",
 						DiskPath:          "/Freebox/disk-path",
 						DiskType:          types.QCow2Disk,
 						CDPath:            "/Freebox/path/to/image",
-						Memory:            300,
+						Memory:            300 * types.Megabyte,
 						OS:                types.DebianOS,
 						VCPUs:             1,
-						EnableScreen:      false,
+						EnableScreen:      types.NewOptional(false),
 						BindUSBPorts:      []string{},
-						EnableCloudInit:   true,
+						EnableCloudInit:   types.NewOptional(true),
 						CloudInitUserData: "\n#cloud-config\n\n\nsystem_info:\n  default_user:\n    name: freemind\n",
 						CloudHostName:     "testing",
 					},
@@ -432,19 +552,19 @@ var _ = Describe("virtual machines", func() {
 				Expect(*returnedErr).To(BeNil())
 				Expect((*returnedMachine)).To(Equal(types.VirtualMachine{
 					ID:     1234,
-					Mac:    "f6:69:9c:d9:4f:3d",
+					Mac:    Must(types.ParseMACAddress("f6:69:9c:d9:4f:3d")),
 					Status: types.StoppedStatus,
 					VirtualMachinePayload: types.VirtualMachinePayload{
 						Name:              "testing",
 						DiskPath:          "/Freebox/disk-path",
 						DiskType:          types.QCow2Disk,
 						CDPath:            "/Freebox/path/to/image",
-						Memory:            300,
+						Memory:            300 * types.Megabyte,
 						OS:                types.DebianOS,
 						VCPUs:             1,
-						EnableScreen:      false,
+						EnableScreen:      types.NewOptional(false),
 						BindUSBPorts:      []string{},
-						EnableCloudInit:   true,
+						EnableCloudInit:   types.NewOptional(true),
 						CloudInitUserData: "\n#cloud-config\n\n\nsystem_info:\n  default_user:\n    name: freemind\n",
 						CloudHostName:     "testing",
 					},
@@ -545,19 +665,19 @@ var _ = Describe("virtual machines", func() {
 				Expect(*returnedErr).To(BeNil())
 				Expect((*returnedMachine)).To(Equal(types.VirtualMachine{
 					ID:     0,
-					Mac:    "f6:69:9c:d9:4f:3d",
+					Mac:    Must(types.ParseMACAddress("f6:69:9c:d9:4f:3d")),
 					Status: types.StoppedStatus,
 					VirtualMachinePayload: types.VirtualMachinePayload{
 						Name:              "testing",
 						DiskPath:          "/Freebox/disk-path",
 						DiskType:          types.QCow2Disk,
 						CDPath:            "/Freebox/path/to/image",
-						Memory:            300,
+						Memory:            300 * types.Megabyte,
 						OS:                types.DebianOS,
 						VCPUs:             1,
-						EnableScreen:      false,
+						EnableScreen:      types.NewOptional(false),
 						BindUSBPorts:      []string{},
-						EnableCloudInit:   true,
+						EnableCloudInit:   types.NewOptional(true),
 						CloudInitUserData: "\n#cloud-config\n\n\nsystem_info:\n  default_user:\n    name: freemind\n",
 						CloudHostName:     "testing",
 					},
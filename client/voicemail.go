@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// ListVoicemails returns the messages left on the voicemail.
+func (c *client) ListVoicemails(ctx context.Context) (result []types.VoicemailEntry, err error) {
+	response, err := c.get(ctx, "call/voicemail/", c.withSession(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET call/voicemail/ endpoint: %w", err)
+	}
+
+	if response.Result == nil {
+		return
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to get voicemails from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetVoicemail returns a message left on the voicemail.
+func (c *client) GetVoicemail(ctx context.Context, identifier string) (result types.VoicemailEntry, err error) {
+	response, err := c.get(ctx, "call/voicemail/"+identifier, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrVoicemailNotFound
+		}
+
+		return result, fmt.Errorf("failed to GET call/voicemail/%s endpoint: %w", identifier, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a voicemail from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdateVoicemail updates a message left on the voicemail, typically to mark it as read.
+func (c *client) UpdateVoicemail(ctx context.Context, identifier string, payload types.VoicemailEntryUpdate) (result types.VoicemailEntry, err error) {
+	response, err := c.put(ctx, "call/voicemail/"+identifier, payload, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return result, ErrVoicemailNotFound
+		}
+
+		return result, fmt.Errorf("failed to PUT call/voicemail/%s endpoint: %w", identifier, err)
+	}
+
+	if err = c.fromGenericResponse(response, &result); err != nil {
+		return result, fmt.Errorf("failed to get a voicemail from generic response: %w", err)
+	}
+
+	return result, nil
+}
+
+// DeleteVoicemail deletes a message left on the voicemail.
+func (c *client) DeleteVoicemail(ctx context.Context, identifier string) error {
+	_, err := c.delete(ctx, "call/voicemail/"+identifier, c.withSession(ctx))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrVoicemailNotFound
+		}
+
+		return fmt.Errorf("failed to DELETE call/voicemail/%s endpoint: %w", identifier, err)
+	}
+
+	return nil
+}
+
+// GetVoicemailAudio streams the audio recording of a message left on the voicemail.
+// The caller is responsible for consuming the returned content.
+func (c *client) GetVoicemailAudio(ctx context.Context, identifier string) (result types.File, err error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/call/voicemail/%s/audio_file", c.base, identifier), nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to forge new request: %w", err)
+	}
+
+	return c.download(request, c.withSession(ctx))
+}
@@ -0,0 +1,278 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("voicemail", func() {
+	const identifier = "1_1711656593"
+
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	Context("listing voicemails", func() {
+		returnedVoicemails := new([]types.VoicemailEntry)
+		JustBeforeEach(func() {
+			*returnedVoicemails, *returnedErr = freeboxClient.ListVoicemails(context.Background())
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/voicemail/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								{
+									"id": "1_1711656593",
+									"country_code": "33",
+									"phone_number": "0102030405",
+									"duration": 17,
+									"date": 1711656593,
+									"read": false
+								}
+							]
+						}`),
+					),
+				)
+			})
+			It("should return the correct voicemails", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedVoicemails).To(Equal([]types.VoicemailEntry{
+					{
+						ID:          identifier,
+						CountryCode: "33",
+						PhoneNumber: "0102030405",
+						Duration:    17,
+						Date:        types.Timestamp{Time: time.Unix(1711656593, 0).UTC()},
+					},
+				}))
+			})
+		})
+		Context("when there are no voicemails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/voicemail/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should return an empty list", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedVoicemails).To(BeEmpty())
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("getting a voicemail", func() {
+		returnedVoicemail := new(types.VoicemailEntry)
+		JustBeforeEach(func() {
+			*returnedVoicemail, *returnedErr = freeboxClient.GetVoicemail(context.Background(), identifier)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/voicemail/%s", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"id": "1_1711656593",
+								"country_code": "33",
+								"phone_number": "0102030405",
+								"duration": 17,
+								"date": 1711656593,
+								"read": true
+							}
+						}`),
+					),
+				)
+			})
+			It("should return the correct voicemail", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedVoicemail).To(Equal(types.VoicemailEntry{
+					ID:          identifier,
+					CountryCode: "33",
+					PhoneNumber: "0102030405",
+					Duration:    17,
+					Date:        types.Timestamp{Time: time.Unix(1711656593, 0).UTC()},
+					Read:        true,
+				}))
+			})
+		})
+		Context("when the voicemail is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/voicemail/%s", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "noent"
+						}`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrVoicemailNotFound))
+			})
+		})
+	})
+	Context("updating a voicemail", func() {
+		returnedVoicemail := new(types.VoicemailEntry)
+		JustBeforeEach(func() {
+			*returnedVoicemail, *returnedErr = freeboxClient.UpdateVoicemail(context.Background(), identifier, types.VoicemailEntryUpdate{Read: true})
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, fmt.Sprintf("/api/%s/call/voicemail/%s", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.VerifyJSON(`{ "read": true }`),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": {
+								"id": "1_1711656593",
+								"read": true
+							}
+						}`),
+					),
+				)
+			})
+			It("should mark the voicemail as read", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedVoicemail.ID).To(Equal(identifier))
+				Expect(returnedVoicemail.Read).To(BeTrue())
+			})
+		})
+		Context("when server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("deleting a voicemail", func() {
+		JustBeforeEach(func() {
+			*returnedErr = freeboxClient.DeleteVoicemail(context.Background(), identifier)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/call/voicemail/%s", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true
+						}`),
+					),
+				)
+			})
+			It("should not return an error", func() {
+				Expect(*returnedErr).To(BeNil())
+			})
+		})
+		Context("when the voicemail is not found", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodDelete, fmt.Sprintf("/api/%s/call/voicemail/%s", version, identifier)),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": false,
+							"error_code": "noent"
+						}`),
+					),
+				)
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(Equal(client.ErrVoicemailNotFound))
+			})
+		})
+	})
+	Context("getting the audio of a voicemail", func() {
+		returnedFile := new(types.File)
+		JustBeforeEach(func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			DeferCleanup(cancel)
+
+			*returnedFile, *returnedErr = freeboxClient.GetVoicemailAudio(ctx, identifier)
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/voicemail/%s/audio_file", version, identifier)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `the-audio`, http.Header{
+							"Content-Type": []string{"audio/wav"},
+						}),
+					),
+				)
+			})
+			It("should stream the audio content", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(returnedFile.ContentType).To(Equal("audio/wav"))
+				Expect(io.ReadAll(returnedFile.Content)).To(BeEquivalentTo([]byte("the-audio")))
+			})
+		})
+		Context("when the server fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/call/voicemail/%s/audio_file", version, identifier)),
+						ghttp.RespondWith(http.StatusNotFound, `not found`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+const vpnServerWireGuard = "wireguard"
+
+// vpnConnection is a client connected to one of the VPN servers of the box, as listed by the vpn/connection/ endpoint.
+type vpnConnection struct {
+	VPN              string          `json:"vpn"`
+	User             string          `json:"user"`
+	AuthTimestamp    types.Timestamp `json:"auth_time"`
+	SourceIP         string          `json:"src_ip"`
+	SourcePort       uint16          `json:"src_port"`
+	LocalIP          string          `json:"local_ip"`
+	ReceivedBytes    types.ByteSize  `json:"rx_bytes"`
+	TransmittedBytes types.ByteSize  `json:"tx_bytes"`
+}
+
+// ListWireGuardPeerStats returns the statistics of the peers connected to the WireGuard VPN server. The API has no
+// endpoint dedicated to the WireGuard peers, so they are read from the connections of every VPN server of the box.
+func (c *client) ListWireGuardPeerStats(ctx context.Context) ([]types.WireGuardPeerStats, error) {
+	response, err := c.get(ctx, "vpn/connection/", c.withSession(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to GET vpn/connection/ endpoint: %w", err)
+	}
+
+	result := []types.WireGuardPeerStats{}
+
+	if response.Result == nil {
+		return result, nil
+	}
+
+	connections := []vpnConnection{}
+	if err = c.fromGenericResponse(response, &connections); err != nil {
+		return nil, fmt.Errorf("failed to get vpn connections from generic response: %w", err)
+	}
+
+	for _, connection := range connections {
+		if connection.VPN != vpnServerWireGuard {
+			continue
+		}
+
+		stats, err := wireGuardPeerStats(connection)
+		if err != nil {
+			return nil, fmt.Errorf("invalid connection of WireGuard peer %q: %w", connection.User, err)
+		}
+
+		result = append(result, stats)
+	}
+
+	return result, nil
+}
+
+func wireGuardPeerStats(connection vpnConnection) (types.WireGuardPeerStats, error) {
+	stats := types.WireGuardPeerStats{
+		User:             connection.User,
+		ConnectedSince:   connection.AuthTimestamp.Time,
+		ReceivedBytes:    connection.ReceivedBytes,
+		TransmittedBytes: connection.TransmittedBytes,
+	}
+
+	if connection.SourceIP != "" {
+		sourceIP, err := netip.ParseAddr(connection.SourceIP)
+		if err != nil {
+			return stats, fmt.Errorf("failed to parse source address: %w", err)
+		}
+
+		stats.Endpoint = netip.AddrPortFrom(sourceIP, connection.SourcePort)
+	}
+
+	if connection.LocalIP != "" {
+		localIP, err := netip.ParseAddr(connection.LocalIP)
+		if err != nil {
+			return stats, fmt.Errorf("failed to parse local address: %w", err)
+		}
+
+		stats.LocalIP = localIP
+	}
+
+	return stats, nil
+}
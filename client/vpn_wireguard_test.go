@@ -0,0 +1,135 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("wireguard vpn server", func() {
+	var (
+		freeboxClient client.Client
+
+		server   *ghttp.Server
+		endpoint = new(string)
+
+		sessionToken = new(string)
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		*endpoint = server.Addr()
+
+		freeboxClient = Must(client.New(*endpoint, version)).
+			WithAppID(appID).
+			WithPrivateToken(privateToken)
+
+		*sessionToken = setupLoginFlow(server)
+	})
+	Context("listing the peer statistics", func() {
+		returnedStats := new([]types.WireGuardPeerStats)
+		JustBeforeEach(func() {
+			*returnedStats, *returnedErr = freeboxClient.ListWireGuardPeerStats(context.Background())
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vpn/connection/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [
+								{
+									"rx_bytes": 2048,
+									"tx_bytes": 4096,
+									"user": "laptop",
+									"vpn": "wireguard",
+									"src_ip": "203.0.113.7",
+									"src_port": 51820,
+									"local_ip": "192.168.27.65",
+									"auth_time": 1711656593
+								},
+								{
+									"rx_bytes": 10,
+									"tx_bytes": 20,
+									"user": "phone",
+									"vpn": "openvpn_routed",
+									"src_ip": "198.51.100.2",
+									"src_port": 1194,
+									"local_ip": "192.168.27.66",
+									"auth_time": 1711656000
+								}
+							]
+						}`),
+					),
+				)
+			})
+			It("should only return the WireGuard peers", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedStats).To(Equal([]types.WireGuardPeerStats{
+					{
+						User:             "laptop",
+						Endpoint:         netip.MustParseAddrPort("203.0.113.7:51820"),
+						LocalIP:          netip.MustParseAddr("192.168.27.65"),
+						ConnectedSince:   time.Unix(1711656593, 0).UTC(),
+						ReceivedBytes:    2048,
+						TransmittedBytes: 4096,
+					},
+				}))
+			})
+		})
+		Context("when no peer is connected", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vpn/connection/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": true }`),
+					),
+				)
+			})
+			It("should return an empty list", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedStats).To(BeEmpty())
+			})
+		})
+		Context("when the address of a peer is malformed", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vpn/connection/", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{
+							"success": true,
+							"result": [{ "user": "laptop", "vpn": "wireguard", "src_ip": "foo" }]
+						}`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+		Context("when the server fails to respond", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// dialWebSocket opens an authenticated websocket connection to the given API path.
+func (c *client) dialWebSocket(ctx context.Context, path string) (*websocket.Conn, *http.Response, error) {
+	header := http.Header{}
+	if err := c.withSession(ctx)(&http.Request{
+		Header: header,
+	}); err != nil {
+		return nil, nil, fmt.Errorf("failed to get a session: %w", err)
+	}
+
+	url := *c.base
+	url.Scheme = "ws"
+
+	if strings.ToLower(c.base.Scheme) == "https" {
+		url.Scheme = "wss"
+	}
+
+	url.Path = url.Path + "/" + path
+
+	ws, dialResponse, err := websocket.DefaultDialer.DialContext(ctx, url.String(), header)
+	if err != nil {
+		if dialResponse != nil {
+			return nil, dialResponse, fmt.Errorf("dialing websocket returned a status %s: %w", dialResponse.Status, err)
+		}
+
+		return nil, nil, fmt.Errorf("failed to dial websocket: %w", err)
+	}
+
+	return ws, dialResponse, nil
+}
+
+// websocketStream exposes a websocket connection as a byte stream: every message received is read in
+// sequence, and every write is sent as a single binary message.
+type websocketStream struct {
+	conn *websocket.Conn
+
+	readLock sync.Mutex
+	reader   io.Reader
+
+	writeLock sync.Mutex
+
+	closeOnce sync.Once
+	closeErr  error
+	cancel    context.CancelFunc
+}
+
+var _ io.ReadWriteCloser = (*websocketStream)(nil)
+
+// newWebSocketStream wraps the connection, which is closed once the context is done.
+func newWebSocketStream(ctx context.Context, conn *websocket.Conn) *websocketStream {
+	ctx, cancel := context.WithCancel(ctx)
+
+	go func(ctx context.Context) {
+		<-ctx.Done()
+
+		_ = conn.Close()
+	}(ctx)
+
+	return &websocketStream{
+		conn:   conn,
+		cancel: cancel,
+	}
+}
+
+func (s *websocketStream) Read(data []byte) (int, error) {
+	s.readLock.Lock()
+	defer s.readLock.Unlock()
+
+	for {
+		if s.reader == nil {
+			_, reader, err := s.conn.NextReader()
+			if err != nil {
+				if isCloseError(err) {
+					return 0, io.EOF
+				}
+
+				return 0, fmt.Errorf("failed to read message: %w", err)
+			}
+
+			s.reader = reader
+		}
+
+		n, err := s.reader.Read(data)
+		if errors.Is(err, io.EOF) {
+			s.reader = nil
+
+			if n == 0 {
+				continue
+			}
+
+			return n, nil
+		}
+
+		return n, err //nolint:wrapcheck
+	}
+}
+
+func (s *websocketStream) Write(data []byte) (int, error) {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	if err := s.conn.WriteMessage(websocket.BinaryMessage, data); err != nil {
+		return 0, fmt.Errorf("failed to write message: %w", err)
+	}
+
+	return len(data), nil
+}
+
+// Close gracefully closes the websocket connection. Subsequent calls return the same result.
+func (s *websocketStream) Close() error {
+	s.closeOnce.Do(func() {
+		defer s.cancel()
+
+		s.writeLock.Lock()
+		err := s.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		s.writeLock.Unlock()
+
+		if err != nil && !errors.Is(err, net.ErrClosed) && !errors.Is(err, websocket.ErrCloseSent) {
+			s.closeErr = fmt.Errorf("failed to send close message: %w", err)
+		}
+
+		if err := s.conn.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+			s.closeErr = errors.Join(s.closeErr, fmt.Errorf("failed to close websocket: %w", err))
+		}
+	})
+
+	return s.closeErr
+}
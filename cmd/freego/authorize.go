@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+func authorize(ctx context.Context, freebox client.Client, args []string, stdout, stderr io.Writer) error {
+	device, err := os.Hostname()
+	if err != nil {
+		device = "unknown"
+	}
+
+	request := types.AuthorizationRequest{Version: "1.0.0"}
+
+	flags := flag.NewFlagSet("authorize", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&request.Name, "name", "freego", "name of the application displayed on the freebox")
+	flags.StringVar(&request.Device, "device", device, "name of the device running the application")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	if err := expectArguments(flags.Args(), 0); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stderr, "Approve the application on the screen of the freebox...")
+
+	token, err := freebox.Authorize(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	fmt.Fprintln(stdout, token)
+
+	return nil
+}
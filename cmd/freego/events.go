@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+// events lists the events which can be watched, by "source:name".
+var events = map[string]types.EventDescription{
+	"vm:state_changed":            {Source: types.EventSourceVM, Name: types.EventStateChanged},
+	"vm:disk_task_done":           {Source: types.EventSourceVMDisk, Name: types.EventDiskTaskDone},
+	"lan_host:l3addr_reachable":   {Source: types.EventSourceLANHost, Name: types.EventHostL3AddrReachable},
+	"lan_host:l3addr_unreachable": {Source: types.EventSourceLANHost, Name: types.EventHostL3AddrUnreachable},
+	"home:sensor_triggered":       {Source: types.EventSourceHome, Name: types.EventHomeSensorTriggered},
+	"home:alarm_state_changed":    {Source: types.EventSourceHome, Name: types.EventHomeAlarmStateChanged},
+}
+
+func listEvents(_ context.Context, _ client.Client, args []string, stdout, _ io.Writer) error {
+	if err := expectArguments(args, 0); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(events))
+	for name := range events {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintln(stdout, name)
+	}
+
+	return nil
+}
+
+// watchEvents prints the notifications of the events as JSON, one per line, until the context is canceled.
+func watchEvents(ctx context.Context, freebox client.Client, args []string, stdout, _ io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: expected at least one event", errUsage)
+	}
+
+	descriptions := make([]types.EventDescription, 0, len(args))
+
+	for _, name := range args {
+		description, ok := events[name]
+		if !ok {
+			return fmt.Errorf("%w: unknown event %q, see freego events sources", errUsage, name)
+		}
+
+		descriptions = append(descriptions, description)
+	}
+
+	channel, err := freebox.ListenEvents(ctx, descriptions)
+	if err != nil {
+		return fmt.Errorf("failed to listen to events: %w", err)
+	}
+
+	encoder := json.NewEncoder(stdout)
+
+	for event := range channel {
+		if event.Error != nil {
+			return fmt.Errorf("stopped listening to events: %w", event.Error)
+		}
+
+		if err := encoder.Encode(event.Notification); err != nil {
+			return fmt.Errorf("failed to print notification: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+func listFiles(ctx context.Context, freebox client.Client, args []string, stdout, _ io.Writer) error {
+	if err := expectArguments(args, 1); err != nil {
+		return err
+	}
+
+	files, err := freebox.ListFiles(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to list files of %s: %w", args[0], err)
+	}
+
+	writer := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "TYPE\tSIZE\tMODIFIED\tNAME")
+
+	for _, file := range files {
+		if file.Name == "." || file.Name == ".." {
+			continue
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n",
+			file.Type,
+			types.ByteSize(file.SizeBytes),
+			file.Modification.Format("2006-01-02 15:04"),
+			file.Name,
+		)
+	}
+
+	return writer.Flush() //nolint:wrapcheck
+}
+
+func uploadFile(ctx context.Context, freebox client.Client, args []string, stdout, _ io.Writer) error {
+	if err := expectArguments(args, 2); err != nil {
+		return err
+	}
+
+	path, err := freebox.UploadFileFromPath(ctx, args[0], args[1])
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", args[0], err)
+	}
+
+	fmt.Fprintln(stdout, path)
+
+	return nil
+}
+
+func downloadFile(ctx context.Context, freebox client.Client, args []string, stdout, _ io.Writer) (err error) {
+	if err := expectArguments(args, 2); err != nil {
+		return err
+	}
+
+	file, err := os.Create(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", args[1], err)
+	}
+
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	written, err := freebox.DownloadFile(ctx, args[0], file)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", args[0], err)
+	}
+
+	fmt.Fprintf(stdout, "%s downloaded to %s\n", types.ByteSize(written), args[1])
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/nikolalohinski/free-go/client"
+)
+
+func listPortForwardingRules(ctx context.Context, freebox client.Client, args []string, stdout, _ io.Writer) error {
+	if err := expectArguments(args, 0); err != nil {
+		return err
+	}
+
+	rules, err := freebox.ListPortForwardingRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list port forwarding rules: %w", err)
+	}
+
+	writer := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tENABLED\tPROTOCOL\tWAN PORTS\tLAN IP\tLAN PORT\tCOMMENT")
+
+	for _, rule := range rules {
+		fmt.Fprintf(writer, "%d\t%t\t%s\t%d-%d\t%s\t%d\t%s\n",
+			rule.ID,
+			rule.Enabled.Value(),
+			rule.IPProtocol,
+			rule.WanPortStart,
+			rule.WanPortEnd,
+			rule.LanIP.Value(),
+			rule.LanPort,
+			rule.Comment,
+		)
+	}
+
+	return writer.Flush() //nolint:wrapcheck
+}
@@ -0,0 +1,119 @@
+// Command freego is a small command line interface to the Freebox API built on the free-go client.
+//
+// It reads the connection settings from the environment as described by client.NewFromEnv: FREEBOX_ENDPOINT,
+// FREEBOX_VERSION, FREEBOX_APP_ID, FREEBOX_TOKEN and FREEBOX_CONFIG. The private token is printed by freego authorize.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/nikolalohinski/free-go/client"
+)
+
+var errUsage = errors.New("invalid usage")
+
+// command is a command of the CLI, such as "vm list".
+type command struct {
+	arguments   string // Synopsis of the arguments of the command
+	description string
+	run         func(ctx context.Context, freebox client.Client, args []string, stdout, stderr io.Writer) error
+}
+
+var commands = map[string]command{
+	"authorize":      {"[-name NAME] [-device DEVICE]", "request a private token for FREEBOX_APP_ID, to approve on the freebox", authorize},
+	"vm list":        {"", "list the virtual machines", listVirtualMachines},
+	"vm start":       {"ID", "start a virtual machine", startVirtualMachine},
+	"vm stop":        {"ID", "stop a virtual machine", stopVirtualMachine},
+	"fw list":        {"", "list the port forwarding rules", listPortForwardingRules},
+	"fs ls":          {"PATH", "list the files of a directory", listFiles},
+	"fs upload":      {"LOCAL_PATH REMOTE_DIRECTORY", "upload a file", uploadFile},
+	"fs download":    {"REMOTE_PATH LOCAL_PATH", "download a file", downloadFile},
+	"events watch":   {"SOURCE:EVENT...", "print the notifications of events until interrupted", watchEvents},
+	"events sources": {"", "list the events which can be watched", listEvents},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "freego: %s\n", err)
+
+		if errors.Is(err, errUsage) {
+			fmt.Fprint(os.Stderr, usage())
+			os.Exit(2)
+		}
+
+		os.Exit(1)
+	}
+}
+
+// run executes the command named by the first arguments with the rest of them. The result of the command is written
+// to stdout, and the messages meant for the user, such as instructions, to stderr.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	name, cmd, ok := findCommand(args)
+	if !ok {
+		return fmt.Errorf("%w: unknown command %q", errUsage, strings.Join(args, " "))
+	}
+
+	freebox, err := client.NewFromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	if err := cmd.run(ctx, freebox, args[len(strings.Fields(name)):], stdout, stderr); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	return nil
+}
+
+// findCommand looks up the command named by the first one or two arguments.
+func findCommand(args []string) (string, command, bool) {
+	for _, length := range []int{2, 1} {
+		if len(args) < length {
+			continue
+		}
+
+		name := strings.Join(args[:length], " ")
+		if cmd, ok := commands[name]; ok {
+			return name, cmd, true
+		}
+	}
+
+	return "", command{}, false
+}
+
+func usage() string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	builder := new(strings.Builder)
+	builder.WriteString("usage: freego COMMAND [ARGUMENTS]\n\ncommands:\n")
+
+	for _, name := range names {
+		fmt.Fprintf(builder, "  %-15s %-30s %s\n", name, commands[name].arguments, commands[name].description)
+	}
+
+	return builder.String()
+}
+
+// expectArguments checks the number of positional arguments of a command.
+func expectArguments(args []string, count int) error {
+	if len(args) != count {
+		return fmt.Errorf("%w: expected %d arguments, got %d", errUsage, count, len(args))
+	}
+
+	return nil
+}
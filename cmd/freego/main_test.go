@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/nikolalohinski/free-go/client"
+)
+
+var _ = Describe("freego", func() {
+	var (
+		server *ghttp.Server
+
+		args []string
+
+		sessionToken = new(string)
+
+		stdout      = new(strings.Builder)
+		stderr      = new(strings.Builder)
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		GinkgoT().Setenv("FREEBOX_ENDPOINT", server.Addr())
+		GinkgoT().Setenv("FREEBOX_VERSION", version)
+		GinkgoT().Setenv("FREEBOX_APP_ID", appID)
+		GinkgoT().Setenv("FREEBOX_TOKEN", privateToken)
+
+		stdout.Reset()
+		stderr.Reset()
+	})
+	JustBeforeEach(func() {
+		*returnedErr = run(context.Background(), args, stdout, stderr)
+	})
+	Context("with an unknown command", func() {
+		BeforeEach(func() {
+			args = []string{"vm", "destroy"}
+		})
+		It("should return a usage error", func() {
+			Expect(*returnedErr).To(MatchError(errUsage))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+	Context("authorize", func() {
+		BeforeEach(func() {
+			args = []string{"authorize", "-device", "laptop"}
+
+			delay := client.AuthorizeRetryDelay
+			client.AuthorizeRetryDelay = time.Millisecond
+			DeferCleanup(func() { client.AuthorizeRetryDelay = delay })
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/login/authorize", version)),
+					ghttp.VerifyJSON(fmt.Sprintf(`{ "app_id": %q, "app_name": "freego", "app_version": "1.0.0", "device_name": "laptop" }`, appID)),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "app_token": "token", "track_id": 1 } }`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/login/authorize/1", version)),
+					ghttp.RespondWith(http.StatusOK, `{ "success": true, "result": { "status": "granted" } }`),
+				),
+			)
+		})
+		It("should print the token to stdout and the instructions to stderr", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(stdout.String()).To(Equal("token\n"))
+			Expect(stderr.String()).To(ContainSubstring("Approve the application"))
+		})
+	})
+	Context("vm list", func() {
+		BeforeEach(func() {
+			args = []string{"vm", "list"}
+
+			*sessionToken = setupLoginFlow(server)
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/vm/", version)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": true,
+						"result": [
+							{ "id": 0, "name": "debian", "status": "running", "vcpus": 2, "memory": 2048 },
+							{ "id": 1, "name": "ubuntu", "status": "stopped", "vcpus": 1, "memory": 512 }
+						]
+					}`),
+				),
+			)
+		})
+		It("should print the virtual machines", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(stdout.String()).To(Equal("" +
+				"ID  NAME    STATUS   VCPUS  MEMORY\n" +
+				"0   debian  running  2      2.0 GiB\n" +
+				"1   ubuntu  stopped  1      512.0 MiB\n",
+			))
+		})
+	})
+	Context("vm start", func() {
+		Context("with an invalid identifier", func() {
+			BeforeEach(func() {
+				args = []string{"vm", "start", "debian"}
+			})
+			It("should return a usage error", func() {
+				Expect(*returnedErr).To(MatchError(errUsage))
+			})
+		})
+		Context("when the virtual machine does not exist", func() {
+			BeforeEach(func() {
+				args = []string{"vm", "start", "42"}
+
+				*sessionToken = setupLoginFlow(server)
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPost, fmt.Sprintf("/api/%s/vm/42/start", version)),
+						verifyAuth(*sessionToken),
+						ghttp.RespondWith(http.StatusOK, `{ "success": false, "error_code": "no_such_vm" }`),
+					),
+				)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).To(MatchError(ContainSubstring("vm start: failed to start virtual machine 42")))
+			})
+		})
+	})
+	Context("fw list", func() {
+		BeforeEach(func() {
+			args = []string{"fw", "list"}
+
+			*sessionToken = setupLoginFlow(server)
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, fmt.Sprintf("/api/%s/fw/redir/", version)),
+					verifyAuth(*sessionToken),
+					ghttp.RespondWith(http.StatusOK, `{
+						"success": true,
+						"result": [
+							{
+								"id": 1,
+								"enabled": true,
+								"ip_proto": "tcp",
+								"wan_port_start": 8080,
+								"wan_port_end": 8080,
+								"lan_ip": "192.168.1.10",
+								"lan_port": 80,
+								"comment": "web"
+							}
+						]
+					}`),
+				),
+			)
+		})
+		It("should print the rules", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(stdout.String()).To(Equal("" +
+				"ID  ENABLED  PROTOCOL  WAN PORTS  LAN IP        LAN PORT  COMMENT\n" +
+				"1   true     tcp       8080-8080  192.168.1.10  80        web\n",
+			))
+		})
+	})
+	Context("events watch", func() {
+		Context("with an unknown event", func() {
+			BeforeEach(func() {
+				args = []string{"events", "watch", "vm:exploded"}
+			})
+			It("should return a usage error", func() {
+				Expect(*returnedErr).To(MatchError(errUsage))
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+	Context("events sources", func() {
+		BeforeEach(func() {
+			args = []string{"events", "sources"}
+		})
+		It("should print the events which can be watched", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(strings.Fields(stdout.String())).To(ContainElements("vm:state_changed", "lan_host:l3addr_reachable"))
+		})
+	})
+})
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/nikolalohinski/free-go/client"
+)
+
+func listVirtualMachines(ctx context.Context, freebox client.Client, args []string, stdout, _ io.Writer) error {
+	if err := expectArguments(args, 0); err != nil {
+		return err
+	}
+
+	machines, err := freebox.ListVirtualMachines(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list virtual machines: %w", err)
+	}
+
+	writer := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tNAME\tSTATUS\tVCPUS\tMEMORY")
+
+	for _, machine := range machines {
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%d\t%s\n", machine.ID, machine.Name, machine.Status, machine.VCPUs, machine.Memory)
+	}
+
+	return writer.Flush() //nolint:wrapcheck
+}
+
+func startVirtualMachine(ctx context.Context, freebox client.Client, args []string, _, _ io.Writer) error {
+	identifier, err := parseIdentifier(args)
+	if err != nil {
+		return err
+	}
+
+	if err := freebox.StartVirtualMachine(ctx, identifier); err != nil {
+		return fmt.Errorf("failed to start virtual machine %d: %w", identifier, err)
+	}
+
+	return nil
+}
+
+func stopVirtualMachine(ctx context.Context, freebox client.Client, args []string, _, _ io.Writer) error {
+	identifier, err := parseIdentifier(args)
+	if err != nil {
+		return err
+	}
+
+	if err := freebox.StopVirtualMachine(ctx, identifier); err != nil {
+		return fmt.Errorf("failed to stop virtual machine %d: %w", identifier, err)
+	}
+
+	return nil
+}
+
+// parseIdentifier parses the only argument of a command as the identifier of a resource.
+func parseIdentifier(args []string) (int64, error) {
+	if err := expectArguments(args, 1); err != nil {
+		return 0, err
+	}
+
+	identifier, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid identifier %q", errUsage, args[0])
+	}
+
+	return identifier, nil
+}
@@ -5,6 +5,7 @@ package integration_test
 import (
 	"context"
 	"fmt"
+	"net/netip"
 
 	"github.com/nikolalohinski/free-go/client"
 	"github.com/nikolalohinski/free-go/types"
@@ -29,14 +30,14 @@ var _ = Describe("port forwarding scenarios", func() {
 	Context("full lifecycle of a port forwarding rule", func() {
 		It("should not return an error nor unexpected responses", func() {
 			// create
-			enabled := true
+			lanIP, sourceIP := netip.MustParseAddr("192.168.1.128"), netip.MustParseAddr("0.0.0.0")
 			payload := types.PortForwardingRulePayload{
-				Enabled:      &enabled,
+				Enabled:      types.NewOptional(true),
 				IPProtocol:   types.TCP,
 				WanPortStart: 12345,
 				WanPortEnd:   12345,
-				LanIP:        "192.168.1.128",
-				SourceIP:     "0.0.0.0",
+				LanIP:        types.NewOptional(lanIP),
+				SourceIP:     types.NewOptional(sourceIP),
 				LanPort:      8080,
 				Comment:      "free-go integration tests",
 			}
@@ -55,12 +56,12 @@ var _ = Describe("port forwarding scenarios", func() {
 
 			// update
 			updatedRule, err := freeboxClient.UpdatePortForwardingRule(ctx, readRule.ID, types.PortForwardingRulePayload{
-				Enabled: new(bool),
+				Enabled: types.NewOptional(false),
 			})
 			Expect(err).To(BeNil())
 			Expect(updatedRule).To(MatchFields(IgnoreExtras, Fields{
 				"PortForwardingRulePayload": MatchFields(IgnoreExtras, Fields{
-					"Enabled": PointTo(BeFalse()),
+					"Enabled": Equal(types.NewOptional(false)),
 				}),
 			}))
 
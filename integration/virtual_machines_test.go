@@ -66,7 +66,7 @@ var _ = Describe("virtual machines", Ordered, func() {
 				Name:     fmt.Sprintf("free-go.integration.tests.%s", uuid.New().String())[:30],
 				DiskPath: types.Base64Path(diskImagePath),
 				DiskType: types.QCow2Disk,
-				Memory:   512,
+				Memory:   512 * types.Megabyte,
 				OS:       types.DebianOS,
 				VCPUs:    1,
 			})
@@ -91,7 +91,7 @@ var _ = Describe("virtual machines", Ordered, func() {
 				Expect(string(event.Notification.Event)).To(Equal("state_changed"))
 				Expect(event.Notification.Result).To(MatchJSON(`{
 					"id": ` + strconv.Itoa(int(virtualMachine.ID)) + `,
-					"status": "` + types.RunningStatus + `"
+					"status": "` + types.RunningStatus.String() + `"
 				}`))
 				isRunning = true
 			}()
@@ -111,7 +111,7 @@ var _ = Describe("virtual machines", Ordered, func() {
 				Expect(string(event.Notification.Event)).To(Equal("state_changed"))
 				Expect(event.Notification.Result).To(MatchJSON(`{
 					"id": ` + strconv.Itoa(int(virtualMachine.ID)) + `,
-					"status": "` + types.StoppedStatus + `"
+					"status": "` + types.StoppedStatus.String() + `"
 				}`))
 				isRunning = false
 			}()
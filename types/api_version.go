@@ -1,14 +1,149 @@
 package types
 
+import (
+	"strings"
+)
+
+// BoxVendor is the internet service provider which shipped the box, as both Free and Iliad run the same API.
+type BoxVendor string
+
+const (
+	BoxVendorFree  BoxVendor = "freebox"  // Freebox, in France
+	BoxVendorIliad BoxVendor = "iliadbox" // Iliadbox, in Italy
+)
+
+// apiDomainSuffixes are the suffixes of the domains of the boxes for remote access, by vendor.
+var apiDomainSuffixes = map[BoxVendor]string{
+	BoxVendorFree:  ".fbxos.fr",
+	BoxVendorIliad: ".ibxos.it",
+}
+
+// BoxModel is the family of hardware of a box, regardless of its revision.
+type BoxModel string
+
+const (
+	BoxModelUnknown    BoxModel = "unknown"
+	BoxModelRevolution BoxModel = "revolution" // Freebox Revolution, also known as the Freebox v6
+	BoxModelMini       BoxModel = "mini"       // Freebox Mini 4K
+	BoxModelOne        BoxModel = "one"        // Freebox One
+	BoxModelDelta      BoxModel = "delta"      // Freebox Delta
+	BoxModelPop        BoxModel = "pop"        // Freebox Pop
+	BoxModelUltra      BoxModel = "ultra"      // Freebox Ultra
+)
+
+// BoxCapabilities tells which features the hardware of a box supports.
+type BoxCapabilities struct {
+	VirtualMachines bool // The box can run virtual machines
+	HomeAutomation  bool // The box can pair home automation nodes, such as alarms and cameras
+	DSL             bool // The box can connect to the internet through a DSL line
+}
+
+// boxModelHardwares are the hardware prefixes of the box_model field of the API version, by model. The revision and
+// the variant follow the prefix, such as fbxgw7-r1/full.
+var boxModelHardwares = map[string]BoxModel{
+	"fbxgw":  BoxModelRevolution,
+	"fbxgw7": BoxModelDelta,
+	"fbxgw8": BoxModelPop,
+	"fbxgw9": BoxModelUltra,
+}
+
+// revolutionVariants are the models sharing the hardware prefix of the Revolution, by variant, such as fbxgw-r2/one.
+var revolutionVariants = map[string]BoxModel{
+	"full": BoxModelRevolution,
+	"mini": BoxModelMini,
+	"one":  BoxModelOne,
+}
+
+// boxModelCapabilities are the capabilities of the models, for the boxes whose API version has no model_info.
+var boxModelCapabilities = map[BoxModel]BoxCapabilities{
+	BoxModelRevolution: {DSL: true},
+	BoxModelMini:       {DSL: true},
+	BoxModelOne:        {DSL: true},
+	BoxModelDelta:      {VirtualMachines: true, HomeAutomation: true, DSL: true},
+	BoxModelPop:        {DSL: true},
+	BoxModelUltra:      {VirtualMachines: true},
+}
+
+func (m BoxModel) String() string {
+	return string(m)
+}
+
+// Capabilities tells which features the model supports. An unknown model supports none.
+func (m BoxModel) Capabilities() BoxCapabilities {
+	return boxModelCapabilities[m]
+}
+
+// BoxModelInfo describes the hardware of the box, as reported by the recent firmwares.
+type BoxModelInfo struct {
+	Name              string `json:"name"`
+	PrettyName        string `json:"pretty_name"`
+	NetOperator       string `json:"net_operator"`
+	HasVM             bool   `json:"has_vm"`
+	HasHomeAutomation bool   `json:"has_home_automation"`
+	HasDSL            bool   `json:"has_dsl"`
+}
+
 type APIVersion struct {
-	UID            string `json:"uid"`
-	DeviceName     string `json:"device_name"`
-	DeviceType     string `json:"device_type"`
-	APIVersion     string `json:"api_version"`
-	APIDomain      string `json:"api_domain"`
-	APIBaseURL     string `json:"api_base_url"`
-	BoxModelName   string `json:"box_model_name"`
-	BoxModel       string `json:"box_model"`
-	HTTPSPort      int    `json:"https_port"`
-	HTTPSAvailable bool   `json:"https_available"`
+	UID            string        `json:"uid"`
+	DeviceName     string        `json:"device_name"`
+	DeviceType     string        `json:"device_type"`
+	APIVersion     string        `json:"api_version"`
+	APIDomain      string        `json:"api_domain"`
+	APIBaseURL     string        `json:"api_base_url"`
+	BoxModelName   string        `json:"box_model_name"`
+	BoxModel       string        `json:"box_model"`
+	HTTPSPort      int           `json:"https_port"`
+	HTTPSAvailable bool          `json:"https_available"`
+	ModelInfo      *BoxModelInfo `json:"model_info,omitempty"` // Nil with the firmwares which do not report it
+}
+
+// Vendor tells which internet service provider shipped the box, from the suffix of its API domain. It defaults to
+// BoxVendorFree when the domain is unknown. It is informative only: the defaults of the client, such as its endpoint,
+// follow the vendor it was configured with.
+func (v APIVersion) Vendor() BoxVendor {
+	for vendor, suffix := range apiDomainSuffixes {
+		if strings.HasSuffix(strings.ToLower(v.APIDomain), suffix) {
+			return vendor
+		}
+	}
+
+	return BoxVendorFree
+}
+
+// Model tells the family of hardware of the box, from its box_model field such as fbxgw7-r1/full. The Mini and the One
+// share the hardware prefix of the Revolution, so their model is told by the variant, such as fbxgw-r1/mini. It
+// defaults to BoxModelUnknown when the hardware is unknown.
+func (v APIVersion) Model() BoxModel {
+	hardware, variant, _ := strings.Cut(strings.ToLower(v.BoxModel), "/")
+	hardware, _, _ = strings.Cut(hardware, "-")
+
+	model, ok := boxModelHardwares[hardware]
+	if !ok {
+		return BoxModelUnknown
+	}
+
+	if variantModel, ok := revolutionVariants[variant]; ok && model == BoxModelRevolution {
+		return variantModel
+	}
+
+	return model
+}
+
+// Capabilities tells which features the hardware of the box supports. They are read from the model_info reported by
+// the box, and guessed from its model when the firmware is too old to report it.
+func (v APIVersion) Capabilities() BoxCapabilities {
+	if v.ModelInfo == nil {
+		return v.Model().Capabilities()
+	}
+
+	return BoxCapabilities{
+		VirtualMachines: v.ModelInfo.HasVM,
+		HomeAutomation:  v.ModelInfo.HasHomeAutomation,
+		DSL:             v.ModelInfo.HasDSL,
+	}
+}
+
+// SupportsVirtualMachines tells whether the hardware of the box can run virtual machines.
+func (v APIVersion) SupportsVirtualMachines() bool {
+	return v.Capabilities().VirtualMachines
 }
@@ -0,0 +1,73 @@
+package types_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("api version", func() {
+	Context("detecting the vendor", func() {
+		for domain, expected := range map[string]types.BoxVendor{
+			"abcdef12.fbxos.fr": types.BoxVendorFree,
+			"abcdef12.ibxos.it": types.BoxVendorIliad,
+			"ABCDEF12.IBXOS.IT": types.BoxVendorIliad,
+			"":                  types.BoxVendorFree,
+		} {
+			domain, expected := domain, expected
+			It("should detect "+string(expected)+" from '"+domain+"'", func() {
+				Expect(types.APIVersion{APIDomain: domain}.Vendor()).To(Equal(expected))
+			})
+		}
+	})
+	Context("detecting the model", func() {
+		for boxModel, expected := range map[string]types.BoxModel{
+			"fbxgw-r1/full":  types.BoxModelRevolution,
+			"fbxgw-r2/full":  types.BoxModelRevolution,
+			"fbxgw-r1/mini":  types.BoxModelMini,
+			"fbxgw-r1/one":   types.BoxModelOne,
+			"fbxgw-r2/one":   types.BoxModelOne,
+			"fbxgw-r2/mini":  types.BoxModelMini,
+			"fbxgw7-r1/full": types.BoxModelDelta,
+			"FBXGW7-R1/FULL": types.BoxModelDelta,
+			"fbxgw8-r1/full": types.BoxModelPop,
+			"fbxgw9-r1/full": types.BoxModelUltra,
+			"unit/test":      types.BoxModelUnknown,
+			"":               types.BoxModelUnknown,
+		} {
+			boxModel, expected := boxModel, expected
+			It("should detect "+expected.String()+" from '"+boxModel+"'", func() {
+				Expect(types.APIVersion{BoxModel: boxModel}.Model()).To(Equal(expected))
+			})
+		}
+	})
+	Context("checking the capabilities", func() {
+		It("should support virtual machines on a delta", func() {
+			version := types.APIVersion{BoxModel: "fbxgw7-r1/full"}
+			Expect(version.SupportsVirtualMachines()).To(BeTrue())
+			Expect(version.Capabilities()).To(Equal(types.BoxCapabilities{
+				VirtualMachines: true,
+				HomeAutomation:  true,
+				DSL:             true,
+			}))
+		})
+		It("should not support virtual machines on a pop", func() {
+			Expect(types.APIVersion{BoxModel: "fbxgw8-r1/full"}.SupportsVirtualMachines()).To(BeFalse())
+		})
+		It("should not support anything on an unknown model", func() {
+			Expect(types.APIVersion{BoxModel: "unit/test"}.Capabilities()).To(BeZero())
+		})
+		It("should prefer the model info reported by the box", func() {
+			version := types.APIVersion{
+				BoxModel: "fbxgw8-r1/full",
+				ModelInfo: &types.BoxModelInfo{
+					HasVM:  true,
+					HasDSL: false,
+				},
+			}
+			Expect(version.SupportsVirtualMachines()).To(BeTrue())
+			Expect(version.Capabilities()).To(Equal(types.BoxCapabilities{VirtualMachines: true}))
+		})
+	})
+})
@@ -0,0 +1,48 @@
+package types
+
+import "time"
+
+type callType string
+
+const (
+	CallTypeMissed   callType = "missed"   // Call received but not answered
+	CallTypeAccepted callType = "accepted" // Call received and answered
+	CallTypeOutgoing callType = "outgoing" // Call emitted
+)
+
+type CallEntry struct {
+	ID        int64         `json:"id"`         // Call id
+	Type      callType      `json:"type"`       // Call type
+	Datetime  Timestamp     `json:"datetime"`   // Call date and time
+	Number    string        `json:"number"`     // Calling or called number
+	Name      string        `json:"name"`       // Calling or called name
+	Duration  Seconds       `json:"duration"`   // Call duration in seconds
+	New       bool          `json:"new"`        // Call entry has not been read yet
+	ContactID FlexibleInt64 `json:"contact_id"` // If the number matches an entry in the contact database, the id of the matching contact
+	LineID    FlexibleInt64 `json:"line_id"`    // Id of the telephony line the call went through
+}
+
+type CallEntryUpdate struct {
+	New bool `json:"new"` // Set to false to mark the call entry as read
+}
+
+// CallLogOptions selects a page of the call log within a time window. The zero value selects every call.
+type CallLogOptions struct {
+	Limit  int64     // Maximum number of calls to return, 0 for no limit
+	Offset int64     // Number of calls of the time window to skip, most recent first
+	Since  time.Time // Only return the calls made at or after this time, if set
+	Until  time.Time // Only return the calls made before this time, if set
+}
+
+// Match reports whether the call was made within the time window of the options.
+func (o CallLogOptions) Match(call CallEntry) bool {
+	if !o.Since.IsZero() && call.Datetime.Before(o.Since) {
+		return false
+	}
+
+	if !o.Until.IsZero() && !call.Datetime.Before(o.Until) {
+		return false
+	}
+
+	return true
+}
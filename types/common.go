@@ -2,9 +2,12 @@ package types
 
 import (
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -17,6 +20,10 @@ func (t Timestamp) MarshalJSON() ([]byte, error) {
 }
 
 func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
 	epoch, err := strconv.ParseInt(string(data), 10, 64)
 	if err != nil {
 		return fmt.Errorf("failed to parse int: %w", err)
@@ -29,6 +36,40 @@ func (t *Timestamp) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// FlexibleInt64 is an integer the freebox sends either as a JSON number or as a JSON string depending on the firmware,
+// such as 42 or "42". It is always sent back as a number. An empty string or null is decoded as 0.
+type FlexibleInt64 int64
+
+func (f FlexibleInt64) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(f), 10)), nil
+}
+
+func (f *FlexibleInt64) UnmarshalJSON(data []byte) error {
+	raw := string(data)
+	if raw == "null" {
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(raw); err == nil {
+		raw = strings.TrimSpace(unquoted)
+	}
+
+	if raw == "" {
+		*f = 0
+
+		return nil
+	}
+
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse int: %w", err)
+	}
+
+	*f = FlexibleInt64(value)
+
+	return nil
+}
+
 type Base64Path string
 
 func (c Base64Path) MarshalJSON() ([]byte, error) {
@@ -50,3 +91,110 @@ func (c *Base64Path) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// MACAddress is a hardware address exchanged as its lower case colon separated form, such as 00:11:22:33:44:55.
+type MACAddress net.HardwareAddr
+
+// ParseMACAddress parses and normalizes a hardware address, in any of the common formats such as 7E:EC:37:CD:5B:6A,
+// 7e-ec-37-cd-5b-6a, 7eec.37cd.5b6a or 7EEC37CD5B6A.
+func ParseMACAddress(raw string) (MACAddress, error) {
+	raw = strings.TrimSpace(raw)
+
+	// the bare hexadecimal format is not supported by net.ParseMAC
+	if len(raw) == 12 {
+		if address, err := hex.DecodeString(raw); err == nil {
+			return MACAddress(address), nil
+		}
+	}
+
+	address, err := net.ParseMAC(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mac address: %w", err)
+	}
+
+	return MACAddress(address), nil
+}
+
+func (m MACAddress) String() string {
+	return net.HardwareAddr(m).String()
+}
+
+func (m MACAddress) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *MACAddress) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*m = nil
+
+		return nil
+	}
+
+	address, err := ParseMACAddress(string(data))
+	if err != nil {
+		return err
+	}
+
+	*m = address
+
+	return nil
+}
+
+// Optional is a field of an update payload which is only sent when set, so that zero values such as false can be sent
+// while unset fields are left untouched by the freebox. The zero value is unset.
+//
+// It is backed by a slice so that unset values are skipped by the omitempty option of encoding/json.
+type Optional[T any] []T
+
+// NewOptional returns a set optional holding value.
+func NewOptional[T any](value T) Optional[T] {
+	return Optional[T]{value}
+}
+
+// IsSet reports whether the optional holds a value.
+func (o Optional[T]) IsSet() bool {
+	return len(o) > 0
+}
+
+// Get returns the value of the optional and whether it is set.
+func (o Optional[T]) Get() (T, bool) {
+	if !o.IsSet() {
+		var zero T
+
+		return zero, false
+	}
+
+	return o[0], true
+}
+
+// Value returns the value of the optional, or the zero value of T when unset.
+func (o Optional[T]) Value() T {
+	value, _ := o.Get()
+
+	return value
+}
+
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.IsSet() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(o[0]) //nolint:wrapcheck
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = nil
+
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to unmarshal optional value: %w", err)
+	}
+
+	*o = NewOptional(value)
+
+	return nil
+}
@@ -2,6 +2,7 @@ package types_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/nikolalohinski/free-go/types"
@@ -51,6 +52,15 @@ var _ = Describe("common", func() {
 					}))
 				})
 			})
+			Context("when the timestamp is null", func() {
+				BeforeEach(func() {
+					payload = []byte(`null`)
+				})
+				It("should leave the timestamp empty", func() {
+					Expect(*returnedErr).To(BeNil())
+					Expect(timestamp.IsZero()).To(BeTrue())
+				})
+			})
 			Context("when the timestamp is not an epoch integer", func() {
 				BeforeEach(func() {
 					payload = []byte(`"foobar"`)
@@ -61,4 +71,231 @@ var _ = Describe("common", func() {
 			})
 		})
 	})
+	Context("json marshal/unmarshal of flexible integers", func() {
+		Context("when marshaling", func() {
+			var bytes []byte
+			JustBeforeEach(func() {
+				bytes, *returnedErr = json.Marshal(types.FlexibleInt64(42))
+			})
+			It("should return a number", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(string(bytes)).To(Equal("42"))
+			})
+		})
+		Context("when unmarshaling", func() {
+			var (
+				payload []byte
+
+				value *types.FlexibleInt64
+			)
+
+			BeforeEach(func() {
+				value = new(types.FlexibleInt64)
+			})
+			JustBeforeEach(func() {
+				*returnedErr = json.Unmarshal(payload, value)
+			})
+
+			for description, raw := range map[string]string{
+				"a number":        `42`,
+				"a string":        `"42"`,
+				"a padded string": `" 42 "`,
+			} {
+				raw := raw
+				Context("when the value is "+description, func() {
+					BeforeEach(func() {
+						payload = []byte(raw)
+					})
+					It("should return the integer", func() {
+						Expect(*returnedErr).To(BeNil())
+						Expect(*value).To(Equal(types.FlexibleInt64(42)))
+					})
+				})
+			}
+			for description, raw := range map[string]string{
+				"null":            `null`,
+				"an empty string": `""`,
+			} {
+				raw := raw
+				Context("when the value is "+description, func() {
+					BeforeEach(func() {
+						payload = []byte(raw)
+					})
+					It("should return zero", func() {
+						Expect(*returnedErr).To(BeNil())
+						Expect(*value).To(BeZero())
+					})
+				})
+			}
+			Context("when the value is not an integer", func() {
+				BeforeEach(func() {
+					payload = []byte(`"foobar"`)
+				})
+				It("should return an error", func() {
+					Expect(*returnedErr).ToNot(BeNil())
+				})
+			})
+			Context("when the value is a decimal number", func() {
+				BeforeEach(func() {
+					payload = []byte(`4.2`)
+				})
+				It("should return an error", func() {
+					Expect(*returnedErr).ToNot(BeNil())
+				})
+			})
+		})
+	})
+	Context("json marshal/unmarshal of mac addresses", func() {
+		Context("when marshaling", func() {
+			var bytes []byte
+			JustBeforeEach(func() {
+				bytes, *returnedErr = json.Marshal(Must(types.ParseMACAddress("7E:EC:37:CD:5B:6A")).(types.MACAddress))
+			})
+			It("should return the normalized address", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(string(bytes)).To(Equal(`"7e:ec:37:cd:5b:6a"`))
+			})
+		})
+		Context("when unmarshaling", func() {
+			var (
+				payload []byte
+
+				address *types.MACAddress
+			)
+			BeforeEach(func() {
+				address = new(types.MACAddress)
+			})
+			JustBeforeEach(func() {
+				*returnedErr = json.Unmarshal(payload, address)
+			})
+			Context("when the address is valid", func() {
+				BeforeEach(func() {
+					payload = []byte(`"7E-EC-37-CD-5B-6A"`)
+				})
+				It("should return the normalized address", func() {
+					Expect(*returnedErr).To(BeNil())
+					Expect(address.String()).To(Equal("7e:ec:37:cd:5b:6a"))
+				})
+			})
+			Context("when the address is empty", func() {
+				BeforeEach(func() {
+					payload = []byte(`""`)
+				})
+				It("should leave the address empty", func() {
+					Expect(*returnedErr).To(BeNil())
+					Expect(*address).To(BeEmpty())
+				})
+			})
+			Context("when the address is invalid", func() {
+				BeforeEach(func() {
+					payload = []byte(`"foobar"`)
+				})
+				It("should return an error", func() {
+					Expect(*returnedErr).ToNot(BeNil())
+				})
+			})
+		})
+	})
+	Context("parsing mac addresses", func() {
+		var address types.MACAddress
+		for _, raw := range []string{
+			"7e:ec:37:cd:5b:6a",
+			"7E:EC:37:CD:5B:6A",
+			"7e-ec-37-cd-5b-6a",
+			"7eec.37cd.5b6a",
+			"7EEC37CD5B6A",
+			" 7e:ec:37:cd:5b:6a ",
+		} {
+			raw := raw
+			Context(fmt.Sprintf("when the address is %q", raw), func() {
+				JustBeforeEach(func() {
+					address, *returnedErr = types.ParseMACAddress(raw)
+				})
+				It("should return the normalized address", func() {
+					Expect(*returnedErr).To(BeNil())
+					Expect(address.String()).To(Equal("7e:ec:37:cd:5b:6a"))
+				})
+			})
+		}
+		Context("when the address is not hexadecimal", func() {
+			JustBeforeEach(func() {
+				address, *returnedErr = types.ParseMACAddress("7EEC37CD5B6Z")
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("json marshal/unmarshal of optional values", func() {
+		type payload struct {
+			Enabled types.Optional[bool] `json:"enabled,omitempty"`
+		}
+		Context("when marshaling", func() {
+			var (
+				value payload
+				bytes []byte
+			)
+			JustBeforeEach(func() {
+				bytes, *returnedErr = json.Marshal(value)
+			})
+			Context("when the value is not set", func() {
+				BeforeEach(func() {
+					value = payload{}
+				})
+				It("should omit the field", func() {
+					Expect(*returnedErr).To(BeNil())
+					Expect(string(bytes)).To(Equal(`{}`))
+				})
+			})
+			Context("when the value is set to its zero value", func() {
+				BeforeEach(func() {
+					value = payload{Enabled: types.NewOptional(false)}
+				})
+				It("should send the field", func() {
+					Expect(*returnedErr).To(BeNil())
+					Expect(string(bytes)).To(Equal(`{"enabled":false}`))
+				})
+			})
+		})
+		Context("when unmarshaling", func() {
+			var (
+				data  []byte
+				value *payload
+			)
+			BeforeEach(func() {
+				value = new(payload)
+			})
+			JustBeforeEach(func() {
+				*returnedErr = json.Unmarshal(data, value)
+			})
+			Context("when the field is present", func() {
+				BeforeEach(func() {
+					data = []byte(`{"enabled":false}`)
+				})
+				It("should set the value", func() {
+					Expect(*returnedErr).To(BeNil())
+					enabled, set := value.Enabled.Get()
+					Expect(set).To(BeTrue())
+					Expect(enabled).To(BeFalse())
+				})
+			})
+			Context("when the field is null", func() {
+				BeforeEach(func() {
+					data = []byte(`{"enabled":null}`)
+				})
+				It("should leave the value unset", func() {
+					Expect(*returnedErr).To(BeNil())
+					Expect(value.Enabled.IsSet()).To(BeFalse())
+				})
+			})
+			Context("when the field has an unexpected type", func() {
+				BeforeEach(func() {
+					data = []byte(`{"enabled":"foobar"}`)
+				})
+				It("should return an error", func() {
+					Expect(*returnedErr).ToNot(BeNil())
+				})
+			})
+		})
+	})
 })
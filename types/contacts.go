@@ -0,0 +1,133 @@
+package types
+
+import (
+	"net/url"
+	"strconv"
+)
+
+type contactNumberType string
+
+const (
+	ContactNumberTypeFixed  contactNumberType = "fixed"  // Landline number
+	ContactNumberTypeMobile contactNumberType = "mobile" // Mobile number
+	ContactNumberTypeWork   contactNumberType = "work"   // Work number
+	ContactNumberTypeFax    contactNumberType = "fax"    // Fax number
+	ContactNumberTypeOther  contactNumberType = "other"  // Other number
+)
+
+type contactEmailType string
+
+const (
+	ContactEmailTypeHome  contactEmailType = "home"  // Personal email address
+	ContactEmailTypeWork  contactEmailType = "work"  // Work email address
+	ContactEmailTypeOther contactEmailType = "other" // Other email address
+)
+
+type contactAddressType string
+
+const (
+	ContactAddressTypeHome  contactAddressType = "home"  // Home address
+	ContactAddressTypeWork  contactAddressType = "work"  // Work address
+	ContactAddressTypeOther contactAddressType = "other" // Other address
+)
+
+type contactURLType string
+
+const (
+	ContactURLTypeProfile contactURLType = "profile" // Profile page
+	ContactURLTypeBlog    contactURLType = "blog"    // Blog
+	ContactURLTypeSite    contactURLType = "site"    // Personal or company website
+	ContactURLTypeOther   contactURLType = "other"   // Other URL
+)
+
+type ContactNumberPayload struct {
+	ContactID int64             `json:"contact_id"`           // Id of the contact the number belongs to
+	Type      contactNumberType `json:"type,omitempty"`       // Number type
+	Number    string            `json:"number,omitempty"`     // Phone number
+	IsDefault bool              `json:"is_default,omitempty"` // Number is the default number of the contact
+}
+
+type ContactNumber struct {
+	ContactNumberPayload
+	ID    int64 `json:"id"`     // Number id
+	IsOwn bool  `json:"is_own"` // Number is one of the freebox owner numbers
+}
+
+type ContactEmailPayload struct {
+	ContactID int64            `json:"contact_id"`      // Id of the contact the email belongs to
+	Type      contactEmailType `json:"type,omitempty"`  // Email type
+	Email     string           `json:"email,omitempty"` // Email address
+}
+
+type ContactEmail struct {
+	ContactEmailPayload
+	ID int64 `json:"id"` // Email id
+}
+
+type ContactAddressPayload struct {
+	ContactID int64              `json:"contact_id"`        // Id of the contact the address belongs to
+	Type      contactAddressType `json:"type,omitempty"`    // Address type
+	Number    string             `json:"number,omitempty"`  // Street number
+	Street    string             `json:"street,omitempty"`  // Street name
+	Street2   string             `json:"street2,omitempty"` // Address complement
+	City      string             `json:"city,omitempty"`    // City
+	Zipcode   string             `json:"zipcode,omitempty"` // Zip code
+	Country   string             `json:"country,omitempty"` // Country
+}
+
+type ContactAddress struct {
+	ContactAddressPayload
+	ID int64 `json:"id"` // Address id
+}
+
+type ContactURLPayload struct {
+	ContactID int64          `json:"contact_id"`     // Id of the contact the URL belongs to
+	Type      contactURLType `json:"type,omitempty"` // URL type
+	URL       string         `json:"url,omitempty"`  // URL
+}
+
+type ContactURL struct {
+	ContactURLPayload
+	ID int64 `json:"id"` // URL id
+}
+
+type Contact struct {
+	ID          int64            `json:"id"`           // Contact id
+	DisplayName string           `json:"display_name"` // Name displayed for the contact
+	FirstName   string           `json:"first_name"`   // First name
+	LastName    string           `json:"last_name"`    // Last name
+	Company     string           `json:"company"`      // Company
+	PhotoURL    string           `json:"photo_url"`    // URL of the photo of the contact
+	LastUpdate  Timestamp        `json:"last_update"`  // Date of the last update of the contact
+	Birthday    string           `json:"birthday"`     // Birthday
+	Notes       string           `json:"notes"`        // Notes
+	Numbers     []ContactNumber  `json:"numbers"`      // Phone numbers of the contact
+	Emails      []ContactEmail   `json:"emails"`       // Email addresses of the contact
+	Addresses   []ContactAddress `json:"addresses"`    // Postal addresses of the contact
+	URLs        []ContactURL     `json:"urls"`         // URLs of the contact
+}
+
+// ContactListOptions selects a page of the contacts on the freebox side, instead of returning every contact. The zero
+// value selects every contact.
+type ContactListOptions struct {
+	Limit   int64 // Maximum number of contacts to return, 0 for no limit
+	Offset  int64 // Number of contacts to skip
+	GroupID int64 // Only return the contacts of this group, 0 for every group
+}
+
+// Query returns the query parameters of the options, leaving out the unset ones.
+func (o ContactListOptions) Query() url.Values {
+	query := url.Values{}
+
+	for name, value := range map[string]int64{
+		"limit":    o.Limit,
+		"offset":   o.Offset,
+		"group_id": o.GroupID,
+	} {
+		if value > 0 {
+			query.Set(name, strconv.FormatInt(value, 10))
+		}
+	}
+
+	return query
+}
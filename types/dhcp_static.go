@@ -1,17 +1,26 @@
 package types
 
+import "net/netip"
+
 type DHCPStaticLeaseInfo struct {
 	ID       string           `json:"id"`       // DHCP static lease object id
-	Mac      string           `json:"mac"`      // Host mac address`
+	Mac      MACAddress       `json:"mac"`      // Host mac address`
 	Comment  string           `json:"comment"`  // an optional comment
 	Hostname string           `json:"hostname"` // hostname matching the mac address
-	IP       string           `json:"ip"`       // IPv4 to assign to the host
+	IP       netip.Addr       `json:"ip"`       // IPv4 to assign to the host
 	Host     LanInterfaceHost `json:"host"`     // LAN host information from LAN browser (refer to LanHost documentation)
 }
 
 type DHCPStaticLeasePayload struct {
-	Mac      string `json:"mac,omitempty"`      // Host mac address`
-	Comment  string `json:"comment,omitempty"`  // an optional comment
-	Hostname string `json:"hostname,omitempty"` // hostname matching the mac address
-	IP       string `json:"ip,omitempty"`       // IPv4 to assign to the host
+	Mac      MACAddress  `json:"mac,omitempty"`      // Host mac address`
+	Comment  string      `json:"comment,omitempty"`  // an optional comment
+	Hostname string      `json:"hostname,omitempty"` // hostname matching the mac address
+	IP       *netip.Addr `json:"ip,omitempty"`       // IPv4 to assign to the host
+}
+
+// DHCPStaticLeaseChanges reports the static leases changed by a reconciliation, by mac address.
+type DHCPStaticLeaseChanges struct {
+	Created []MACAddress `json:"created"`
+	Updated []MACAddress `json:"updated"`
+	Deleted []MACAddress `json:"deleted"`
 }
@@ -1,57 +1,103 @@
 package types
 
-type downloadTaskType string
+import (
+	"io"
+	"math"
+	"slices"
+	"time"
+)
+
+type DownloadTaskType string
 
 const (
-	DownloadTaskTypeBitTorrent downloadTaskType = "bt"   // bittorrent download
-	DownloadTaskTypeNewsGroup  downloadTaskType = "nzb"  // newsgroup download
-	DownloadTaskTypeHTTP       downloadTaskType = "http" // HTTP download
-	DownloadTaskTypeFTP        downloadTaskType = "ftp"  // FTP download
+	DownloadTaskTypeBitTorrent DownloadTaskType = "bt"   // bittorrent download
+	DownloadTaskTypeNewsGroup  DownloadTaskType = "nzb"  // newsgroup download
+	DownloadTaskTypeHTTP       DownloadTaskType = "http" // HTTP download
+	DownloadTaskTypeFTP        DownloadTaskType = "ftp"  // FTP download
 )
 
-type downloadTaskStatus string
+type DownloadTaskStatus string
 
 const (
-	DownloadTaskStatusStopped     = "stopped"    //	task is stopped, can be resumed by setting the status to downloading
-	DownloadTaskStatusQueued      = "queued"     //	task will start when a new download slot is available the queue position is stored in queue_pos attribute
-	DownloadTaskStatusStarting    = "starting"   //	task is preparing to start download
-	DownloadTaskStatusStopping    = "stopping"   //	task is gracefully stopping
-	DownloadTaskStatusError       = "error"      //	there was a problem with the download, you can get an error code in the error field
-	DownloadTaskStatusDone        = "done"       //	the download is over. For bt you can resume seeding setting the status to seeding if the ratio is not reached yet
-	DownloadTaskStatusChecking    = "checking"   //	(only valid for nzb) download is over, the downloaded files are being checked using par2
-	DownloadTaskStatusRepairing   = "repairing"  //	(only valid for nzb) download is over, the downloaded files are being repaired using par2
-	DownloadTaskStatusExtracting  = "extracting" //	only valid for nzb) download is over, the downloaded files are being extracted
-	DownloadTaskStatusSeeding     = "seeding"    //	(only valid for bt) download is over, the content is Change to being shared to other users. The task will automatically stop once the seed ratio has been reached
-	DownloadTaskStatusRetry       = "retry"      //	You can set a task status to ‘retry’ to restart the download task.
-	DownloadTaskStatusDownloading = "downloading"
+	DownloadTaskStatusStopped     DownloadTaskStatus = "stopped"     //	task is stopped, can be resumed by setting the status to downloading
+	DownloadTaskStatusQueued      DownloadTaskStatus = "queued"      //	task will start when a new download slot is available the queue position is stored in queue_pos attribute
+	DownloadTaskStatusStarting    DownloadTaskStatus = "starting"    //	task is preparing to start download
+	DownloadTaskStatusStopping    DownloadTaskStatus = "stopping"    //	task is gracefully stopping
+	DownloadTaskStatusError       DownloadTaskStatus = "error"       //	there was a problem with the download, you can get an error code in the error field
+	DownloadTaskStatusDone        DownloadTaskStatus = "done"        //	the download is over. For bt you can resume seeding setting the status to seeding if the ratio is not reached yet
+	DownloadTaskStatusChecking    DownloadTaskStatus = "checking"    //	(only valid for nzb) download is over, the downloaded files are being checked using par2
+	DownloadTaskStatusRepairing   DownloadTaskStatus = "repairing"   //	(only valid for nzb) download is over, the downloaded files are being repaired using par2
+	DownloadTaskStatusExtracting  DownloadTaskStatus = "extracting"  //	only valid for nzb) download is over, the downloaded files are being extracted
+	DownloadTaskStatusSeeding     DownloadTaskStatus = "seeding"     //	(only valid for bt) download is over, the content is Change to being shared to other users. The task will automatically stop once the seed ratio has been reached
+	DownloadTaskStatusRetry       DownloadTaskStatus = "retry"       //	You can set a task status to ‘retry’ to restart the download task.
+	DownloadTaskStatusDownloading DownloadTaskStatus = "downloading" //	task is downloading
 )
 
-type downloadTaskError string
+var downloadTaskStatuses = []DownloadTaskStatus{
+	DownloadTaskStatusStopped, DownloadTaskStatusQueued, DownloadTaskStatusStarting, DownloadTaskStatusStopping,
+	DownloadTaskStatusError, DownloadTaskStatusDone, DownloadTaskStatusChecking, DownloadTaskStatusRepairing,
+	DownloadTaskStatusExtracting, DownloadTaskStatusSeeding, DownloadTaskStatusRetry, DownloadTaskStatusDownloading,
+}
+
+func (s DownloadTaskStatus) String() string {
+	return string(s)
+}
+
+// IsValid reports whether the status is a known status of a download task.
+func (s DownloadTaskStatus) IsValid() bool {
+	return slices.Contains(downloadTaskStatuses, s)
+}
+
+type DownloadTaskErrorCode string
 
 const (
-	DownloadTaskErrorNotFound          downloadTaskError = "task_not_found"       // No task was found with the given id
-	DownloadTaskErrorInvalidOperation  downloadTaskError = "invalid_operation"    // Attempt to perform an invalid operation
-	DownloadTaskErrorInvalidFile       downloadTaskError = "invalid_file"         // Error with the download file (invalid format ?)
-	DownloadTaskErrorInvalidURL        downloadTaskError = "invalid_url"          // URL is invalid
-	DownloadTaskErrorNotImplemented    downloadTaskError = "not_implemented"      // Method not implemented
-	DownloadTaskErrorOutOfMemory       downloadTaskError = "out_of_memory"        // No more memory available to perform the requested action
-	DownloadTaskErrorInvalidTaskType   downloadTaskError = "invalid_task_type"    // The task type is invalid
-	DownloadTaskErrorHibernating       downloadTaskError = "hibernating"          // The downloader is hibernating
-	DownloadTaskErrorNeedBTStoppedDone downloadTaskError = "need_bt_stopped_done" // This action is only valid for Bittorrent task in stopped or done state
-	DownloadTaskErrorBTTrackerNotFound downloadTaskError = "bt_tracker_not_found" // Attempt to access an invalid tracker object
-	DownloadTaskErrorTooManyTasks      downloadTaskError = "too_many_tasks"       // Too many tasks
-	DownloadTaskErrorInvalidAddress    downloadTaskError = "invalid_address"      // Invalid peer address
-	DownloadTaskErrorPortConflict      downloadTaskError = "port_conflict"        // Port conflict when setting config
-	DownloadTaskErrorInvalidPriority   downloadTaskError = "invalid_priority"     // Invalid priority
-	DownloadTaskErrorInternalError     downloadTaskError = "internal_error"       // Internal error
-	DownloadTaskErrorCtxFileError      downloadTaskError = "ctx_file_error"       // Failed to initialize task context file (need to check disk)
-	DownloadTaskErrorExists            downloadTaskError = "exists"               // Same task already exists
-	DownloadTaskErrorPortOutsideRange  downloadTaskError = "port_outside_range"   // Incoming port is not available for this customer (see ConnectionStatus ipv4_port_range)
+	DownloadTaskErrorNotFound          DownloadTaskErrorCode = "task_not_found"       // No task was found with the given id
+	DownloadTaskErrorInvalidOperation  DownloadTaskErrorCode = "invalid_operation"    // Attempt to perform an invalid operation
+	DownloadTaskErrorInvalidFile       DownloadTaskErrorCode = "invalid_file"         // Error with the download file (invalid format ?)
+	DownloadTaskErrorInvalidURL        DownloadTaskErrorCode = "invalid_url"          // URL is invalid
+	DownloadTaskErrorNotImplemented    DownloadTaskErrorCode = "not_implemented"      // Method not implemented
+	DownloadTaskErrorOutOfMemory       DownloadTaskErrorCode = "out_of_memory"        // No more memory available to perform the requested action
+	DownloadTaskErrorInvalidTaskType   DownloadTaskErrorCode = "invalid_task_type"    // The task type is invalid
+	DownloadTaskErrorHibernating       DownloadTaskErrorCode = "hibernating"          // The downloader is hibernating
+	DownloadTaskErrorNeedBTStoppedDone DownloadTaskErrorCode = "need_bt_stopped_done" // This action is only valid for Bittorrent task in stopped or done state
+	DownloadTaskErrorBTTrackerNotFound DownloadTaskErrorCode = "bt_tracker_not_found" // Attempt to access an invalid tracker object
+	DownloadTaskErrorTooManyTasks      DownloadTaskErrorCode = "too_many_tasks"       // Too many tasks
+	DownloadTaskErrorInvalidAddress    DownloadTaskErrorCode = "invalid_address"      // Invalid peer address
+	DownloadTaskErrorPortConflict      DownloadTaskErrorCode = "port_conflict"        // Port conflict when setting config
+	DownloadTaskErrorInvalidPriority   DownloadTaskErrorCode = "invalid_priority"     // Invalid priority
+	DownloadTaskErrorInternalError     DownloadTaskErrorCode = "internal_error"       // Internal error
+	DownloadTaskErrorCtxFileError      DownloadTaskErrorCode = "ctx_file_error"       // Failed to initialize task context file (need to check disk)
+	DownloadTaskErrorExists            DownloadTaskErrorCode = "exists"               // Same task already exists
+	DownloadTaskErrorPortOutsideRange  DownloadTaskErrorCode = "port_outside_range"   // Incoming port is not available for this customer (see ConnectionStatus ipv4_port_range)
 
 	// Undocumented and reverse engineered error codes.
-	DownloadTaskErrorNone             downloadTaskError = "none"          // No error
-	DownloadTaskErrorHashDownloadDisk downloadTaskError = "hash_download" // Error downloading the hash file
-	DownloadTaskError4XX              downloadTaskError = "http_4xx"      // Error 4xx
+	DownloadTaskErrorNone             DownloadTaskErrorCode = "none"          // No error
+	DownloadTaskErrorHashDownloadDisk DownloadTaskErrorCode = "hash_download" // Error downloading the hash file
+	DownloadTaskError4XX              DownloadTaskErrorCode = "http_4xx"      // Error 4xx
+
+	// Values of the error field of a download task.
+	DownloadTaskErrorInternal           DownloadTaskErrorCode = "internal"                  // Internal error
+	DownloadTaskErrorDiskFull           DownloadTaskErrorCode = "disk_full"                 // The disk is full
+	DownloadTaskErrorUnknown            DownloadTaskErrorCode = "unknown"                   // Unknown error
+	DownloadTaskErrorParseError         DownloadTaskErrorCode = "parse_error"               // Parse error
+	DownloadTaskErrorUnknownHost        DownloadTaskErrorCode = "unknown_host"              // Unknown host
+	DownloadTaskErrorTimeout            DownloadTaskErrorCode = "timeout"                   // Timeout
+	DownloadTaskErrorBadAuthentication  DownloadTaskErrorCode = "bad_authentication"        // Invalid credentials
+	DownloadTaskErrorConnectionRefused  DownloadTaskErrorCode = "connection_refused"        // Remote host refused connection
+	DownloadTaskErrorBTTrackerError     DownloadTaskErrorCode = "bt_tracker_error"          // Unable to announce on tracker
+	DownloadTaskErrorBTMissingFiles     DownloadTaskErrorCode = "bt_missing_files"          // Missing torrent files
+	DownloadTaskErrorBTFileError        DownloadTaskErrorCode = "bt_file_error"             // Error accessing torrent files
+	DownloadTaskErrorMissingCtxFile     DownloadTaskErrorCode = "missing_ctx_file"          // Error accessing task context file
+	DownloadTaskErrorNZBNoGroup         DownloadTaskErrorCode = "nzb_no_group"              // Cannot find the requested group on server
+	DownloadTaskErrorNZBNotFound        DownloadTaskErrorCode = "nzb_not_found"             // Article not found on the server
+	DownloadTaskErrorNZBInvalidCRC      DownloadTaskErrorCode = "nzb_invalid_crc"           // Invalid article CRC
+	DownloadTaskErrorNZBInvalidSize     DownloadTaskErrorCode = "nzb_invalid_size"          // Invalid article size
+	DownloadTaskErrorNZBInvalidFilename DownloadTaskErrorCode = "nzb_invalid_filename"      // Invalid filename
+	DownloadTaskErrorNZBOpenFailed      DownloadTaskErrorCode = "nzb_open_failed"           // Error opening
+	DownloadTaskErrorNZBServerError     DownloadTaskErrorCode = "nzb_server_error"          // Server returned an error
+	DownloadTaskErrorNZBMissingSegments DownloadTaskErrorCode = "nzb_missing_segments"      // Not enough segments to repair the files
+	DownloadTaskErrorNZBDecodeError     DownloadTaskErrorCode = "nzb_decode_error"          // Error decoding the article
+	DownloadTaskErrorNZBAuthFailed      DownloadTaskErrorCode = "nzb_authentication_failed" // Invalid nzb credentials
 )
 
 type downloadTaskIOPriority string
@@ -64,28 +110,43 @@ const (
 
 type DownloadTask struct {
 	ID                 int64                  `json:"id"`
-	Type               downloadTaskType       `json:"type"`
+	Type               DownloadTaskType       `json:"type"`
 	Name               string                 `json:"name"`
-	Status             downloadTaskStatus     `json:"status"`
+	Status             DownloadTaskStatus     `json:"status"`
 	IOPriority         downloadTaskIOPriority `json:"io_priority"`
-	SizeBytes          int64                  `json:"size"`             // Download size (in Bytes)
+	SizeBytes          ByteSize               `json:"size"`             // Download size (in Bytes)
 	QueuePosition      int64                  `json:"queue_pos"`        // position in download queue (0 if not queued)
-	TransmittedBytes   int64                  `json:"tx_bytes"`         // transmitted bytes (including protocol overhead)
-	ReceivedBytes      int64                  `json:"rx_bytes"`         // received bytes (including protocol overhead)
-	TransmitRate       int64                  `json:"tx_rate"`          // current transmit rate (in byte/s)
-	ReceiveRate        int64                  `json:"rx_rate"`          // current receive rate (in byte/s)
+	TransmittedBytes   ByteSize               `json:"tx_bytes"`         // transmitted bytes (including protocol overhead)
+	ReceivedBytes      ByteSize               `json:"rx_bytes"`         // received bytes (including protocol overhead)
+	TransmitRate       BitRate                `json:"tx_rate"`          // current transmit rate
+	ReceiveRate        BitRate                `json:"rx_rate"`          // current receive rate
 	TransmitPercentage int                    `json:"tx_pct"`           // transmit percentage (without protocol overhead). To improve precision the value as been scaled by 100 so that a tx_pct of 123 means 1.23%
 	ReceivedPercentage int                    `json:"rx_pct"`           // received percentage (without protocol overhead). To improve precision the value as been scaled by 100 so that a tx_pct of 123 means 1.23%
-	Error              downloadTaskError      `json:"error"`            // an error code
+	Error              DownloadTaskErrorCode  `json:"error"`            // an error code
 	CreatedTimestamp   Timestamp              `json:"created_ts"`       // timestamp of the download creation time
-	ETASeconds         int64                  `json:"eta"`              // estimated remaining download time (in seconds)
+	ETASeconds         Seconds                `json:"eta"`              // estimated remaining download time (in seconds)
 	DownloadDirectory  Base64Path             `json:"download_dir"`     // directory where the file(s) will be saved (base64 encoded)
 	StopRatio          int64                  `json:"stop_ratio"`       // Only relevant for bittorrent tasks. Once the transmit ration has been reached the task will stop seeding. The ratio is scaled by 100 to improve resolution. A stop_ratio of 150 means that the task will stop seeding once tx_bytes = 1.5 * rx_bytes.
 	ArchivePassword    string                 `json:"archive_password"` // (only relevant for nzb) password for extracting downloaded archives
 	InfoHash           string                 `json:"info_hash"`        // (only relevant for bt) torrent info_hash encoded in hexa
-	PieceLength        int64                  `json:"piece_length"`     // (only relevant for bt) torrent piece length in bytes
+	PieceLength        ByteSize               `json:"piece_length"`     // (only relevant for bt) torrent piece length in bytes
 }
 
+// IsComplete reports whether the download of the task is over: the task is done, or it is seeding, which the freebox
+// only does once the download is over. A stopped or downloading task is not complete.
+//
+// The received bytes are not checked, as a torrent may seed without receiving every byte, for instance when its data
+// was already on disk or when some of its files are not downloaded.
+func (t DownloadTask) IsComplete() bool {
+	return t.Status == DownloadTaskStatusDone || t.Status == DownloadTaskStatusSeeding
+}
+
+const (
+	EventSourceDownload eventSource = "download"
+
+	EventDownloadTaskChanged eventName = "task_changed"
+)
+
 type DownloadRequest struct {
 	DownloadURLs      []string          // The URL(s)
 	DownloadDirectory string            // The download destination directory (optional: will use the configuration download_dir by default)
@@ -98,7 +159,113 @@ type DownloadRequest struct {
 	Cookies           map[string]string // The http cookies (to be able to pass session cookies along with url). This is the content of the HTTP Cookie header, for example: cookie1=value1; cookie2=value2
 }
 
+type DownloadFileRequest struct {
+	File              io.Reader // The content of the .torrent or .nzb file
+	Filename          string    // The name of the file sent to the freebox (optional: defaults to download.torrent)
+	DownloadDirectory string    // The download destination directory (optional: will use the configuration download_dir by default)
+	ArchivePassword   string    // The password required to extract downloaded content (only relevant for nzb)
+}
+
+type DownloadTasksOperation string
+
+const (
+	DownloadTasksOperationPause      DownloadTasksOperation = "pause"       // stop every active task
+	DownloadTasksOperationResume     DownloadTasksOperation = "resume"      // resume every stopped task
+	DownloadTasksOperationDeleteDone DownloadTasksOperation = "delete_done" // delete every finished task, keeping the downloaded files
+	DownloadTasksOperationEraseDone  DownloadTasksOperation = "erase_done"  // delete every finished task along with the downloaded files
+)
+
+type WaitForDownloadTaskOptions struct {
+	PollInterval time.Duration           // Delay between two checks of the task (optional: defaults to client.DownloadTaskPollInterval)
+	Progress     func(task DownloadTask) // Called with the task each time it is checked (optional)
+}
+
 type DownloadTaskUpdate struct {
-	Status     downloadTaskStatus     `json:"status,omitempty"`      // The new status
-	IOPriority downloadTaskIOPriority `json:"io_priority,omitempty"` // The new IO priority
+	Status        DownloadTaskStatus     `json:"status,omitempty"`      // The new status
+	IOPriority    downloadTaskIOPriority `json:"io_priority,omitempty"` // The new IO priority
+	StopRatio     *int64                 `json:"stop_ratio,omitempty"`  // The new seeding stop ratio, scaled by 100 (only relevant for bt). Use NewDownloadStopRatio to build it from a plain ratio.
+	QueuePosition *int64                 `json:"queue_pos,omitempty"`   // The new position in the download queue (only relevant for queued tasks)
+}
+
+// DownloadTaskUpdateResult reports the outcome of one of the updates of UpdateDownloadTasks.
+type DownloadTaskUpdateResult struct {
+	ID    int64 // Identifier of the task
+	Error error // Reason of the failure, nil when the task was updated
+}
+
+// NewDownloadStopRatio converts a plain seeding ratio, such as 1.5, into the scaled value expected by DownloadTaskUpdate.
+func NewDownloadStopRatio(ratio float64) *int64 {
+	scaled := int64(math.Round(ratio * 100)) //nolint:gomnd
+
+	return &scaled
+}
+
+type downloadThrottlingMode string
+
+const (
+	DownloadThrottlingModeNormal    downloadThrottlingMode = "normal"    // Use the normal rate limits
+	DownloadThrottlingModeSlow      downloadThrottlingMode = "slow"      // Use the slow rate limits
+	DownloadThrottlingModeHibernate downloadThrottlingMode = "hibernate" // Downloads are stopped
+	DownloadThrottlingModeSchedule  downloadThrottlingMode = "schedule"  // The mode is changed according to the schedule
+)
+
+type DownloadRates struct {
+	TransmitRate BitRate `json:"tx_rate"` // transmit rate
+	ReceiveRate  BitRate `json:"rx_rate"` // receive rate
+}
+
+type DownloadStats struct {
+	TasksCount            int64                  `json:"nb_tasks"`                // total number of tasks
+	TasksActiveCount      int64                  `json:"nb_tasks_active"`         // number of tasks being downloaded, checked, repaired or extracted
+	TasksStoppedCount     int64                  `json:"nb_tasks_stopped"`        // number of tasks in stopped state
+	TasksQueuedCount      int64                  `json:"nb_tasks_queued"`         // number of tasks in queued state
+	TasksStoppingCount    int64                  `json:"nb_tasks_stopping"`       // number of tasks in stopping state
+	TasksDownloadingCount int64                  `json:"nb_tasks_downloading"`    // number of tasks in downloading state
+	TasksSeedingCount     int64                  `json:"nb_tasks_seeding"`        // number of tasks in seeding state
+	TasksCheckingCount    int64                  `json:"nb_tasks_checking"`       // number of tasks in checking state
+	TasksRepairingCount   int64                  `json:"nb_tasks_repairing"`      // number of tasks in repairing state
+	TasksExtractingCount  int64                  `json:"nb_tasks_extracting"`     // number of tasks in extracting state
+	TasksDoneCount        int64                  `json:"nb_tasks_done"`           // number of tasks in done state
+	TasksErrorCount       int64                  `json:"nb_tasks_error"`          // number of tasks in error state
+	RSSCount              int64                  `json:"nb_rss"`                  // number of RSS feeds
+	RSSItemsUnreadCount   int64                  `json:"nb_rss_items_unread"`     // number of unread RSS items
+	PeersCount            int64                  `json:"nb_peers"`                // number of connected bittorrent peers
+	TransmitRate          BitRate                `json:"tx_rate"`                 // current global transmit rate
+	ReceiveRate           BitRate                `json:"rx_rate"`                 // current global receive rate
+	ThrottlingMode        downloadThrottlingMode `json:"throttling_mode"`         // current throttling mode
+	ThrottlingIsScheduled bool                   `json:"throttling_is_scheduled"` // whether the throttling mode is set by the schedule
+	ThrottlingRate        DownloadRates          `json:"throttling_rate"`         // current rate limits
+}
+
+type downloadFileStatus string
+
+const (
+	DownloadFileStatusQueued      downloadFileStatus = "queued"      // the file is waiting to be downloaded
+	DownloadFileStatusError       downloadFileStatus = "error"       // there was a problem with the file, see the error field
+	DownloadFileStatusDone        downloadFileStatus = "done"        // the file is fully downloaded
+	DownloadFileStatusDownloading downloadFileStatus = "downloading" // the file is being downloaded
+)
+
+type DownloadFilePriority string
+
+const (
+	DownloadFilePriorityNoDownload DownloadFilePriority = "no_dl"  // the file will not be downloaded
+	DownloadFilePriorityLow        DownloadFilePriority = "low"    // the file will be downloaded with a low priority
+	DownloadFilePriorityNormal     DownloadFilePriority = "normal" // the file will be downloaded with a normal priority
+	DownloadFilePriorityHigh       DownloadFilePriority = "high"   // the file will be downloaded with a high priority
+)
+
+type DownloadFile struct {
+	ID            string                `json:"id"`        // file identifier, unique within a download task
+	TaskID        int64                 `json:"task_id"`   // identifier of the download task the file belongs to
+	Path          Base64Path            `json:"path"`      // path of the file on the disk
+	FilePath      string                `json:"filepath"`  // path of the file, relative to the download directory
+	Name          string                `json:"name"`      // name of the file
+	MimeType      string                `json:"mimetype"`  // mime type of the file
+	SizeBytes     ByteSize              `json:"size"`      // file size (in Bytes)
+	ReceivedBytes ByteSize              `json:"rx"`        // received bytes
+	Status        downloadFileStatus    `json:"status"`    // status of the file
+	Priority      DownloadFilePriority  `json:"priority"`  // download priority of the file
+	Error         DownloadTaskErrorCode `json:"error"`     // an error code
+	LongName      string                `json:"long_name"` // full name of the file, including its path within the task
 }
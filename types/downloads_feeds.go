@@ -0,0 +1,66 @@
+package types
+
+type downloadFeedStatus string
+
+const (
+	DownloadFeedStatusReady    downloadFeedStatus = "ready"    // the feed is up to date
+	DownloadFeedStatusFetching downloadFeedStatus = "fetching" // the feed is being fetched
+	DownloadFeedStatusError    downloadFeedStatus = "error"    // the last fetch failed
+)
+
+type DownloadFeed struct {
+	ID             int64              `json:"id"`            // feed identifier
+	Status         downloadFeedStatus `json:"status"`        // status of the feed
+	URL            string             `json:"url"`           // URL of the feed
+	Title          string             `json:"title"`         // title of the feed
+	Description    string             `json:"desc"`          // description of the feed
+	ImageURL       string             `json:"image_url"`     // URL of the feed image
+	ImageLink      string             `json:"image_link"`    // link of the feed image
+	FetchTimestamp Timestamp          `json:"fetch_ts"`      // timestamp of the last fetch
+	PublishDate    Timestamp          `json:"pub_date"`      // publication date of the feed
+	ItemsCount     int64              `json:"nb_items"`      // number of items in the feed
+	UnreadCount    int64              `json:"nb_unread"`     // number of unread items in the feed
+	AutoDownload   bool               `json:"auto_download"` // whether new items are automatically downloaded
+}
+
+type DownloadFeedUpdate struct {
+	AutoDownload bool `json:"auto_download"` // whether new items should be automatically downloaded
+}
+
+type DownloadFeedItem struct {
+	ID              int64     `json:"id"`               // item identifier
+	FeedID          int64     `json:"feed_id"`          // identifier of the feed the item belongs to
+	Title           string    `json:"title"`            // title of the item
+	Description     string    `json:"desc"`             // description of the item
+	Author          string    `json:"author"`           // author of the item
+	Link            string    `json:"link"`             // link of the item
+	PublishDate     Timestamp `json:"pub_date"`         // publication date of the item
+	EnclosureURL    string    `json:"enclosure_url"`    // URL of the item content
+	EnclosureType   string    `json:"enclosure_type"`   // mime type of the item content
+	EnclosureLength int64     `json:"enclosure_length"` // size of the item content (in Bytes)
+	IsRead          bool      `json:"is_read"`          // whether the item has been marked as read
+	IsDownloaded    bool      `json:"is_downloaded"`    // whether the item has been downloaded
+}
+
+type DownloadFeedItemUpdate struct {
+	IsRead bool `json:"is_read"` // whether the item should be marked as read
+}
+
+// DownloadFeedItemFilter selects the items of an RSS feed on the client side. The unset fields match every item.
+type DownloadFeedItemFilter struct {
+	Unread        bool // The item has not been marked as read
+	NotDownloaded bool // The item has not been downloaded
+}
+
+// Match reports whether the item is selected by the filter.
+func (f DownloadFeedItemFilter) Match(item DownloadFeedItem) bool {
+	if f.Unread && item.IsRead {
+		return false
+	}
+
+	if f.NotDownloaded && item.IsDownloaded {
+		return false
+	}
+
+	return true
+}
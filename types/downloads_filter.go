@@ -0,0 +1,42 @@
+package types
+
+import (
+	"fmt"
+	"path"
+	"slices"
+)
+
+// DownloadTaskFilter selects download tasks on the client side. The empty fields match every task.
+type DownloadTaskFilter struct {
+	Statuses    []DownloadTaskStatus // The task has one of these statuses
+	Types       []DownloadTaskType   // The task has one of these types
+	NamePattern string               // The name of the task matches this pattern, with the syntax of path.Match such as *.iso
+}
+
+// Validate checks the name pattern of the filter is well formed.
+func (f DownloadTaskFilter) Validate() error {
+	if _, err := path.Match(f.NamePattern, ""); err != nil {
+		return fmt.Errorf("invalid name pattern %q: %w", f.NamePattern, err)
+	}
+
+	return nil
+}
+
+// Match reports whether the task is selected by the filter. A malformed name pattern matches no task.
+func (f DownloadTaskFilter) Match(task DownloadTask) bool {
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, task.Status) {
+		return false
+	}
+
+	if len(f.Types) > 0 && !slices.Contains(f.Types, task.Type) {
+		return false
+	}
+
+	if f.NamePattern != "" {
+		matched, err := path.Match(f.NamePattern, task.Name)
+
+		return err == nil && matched
+	}
+
+	return true
+}
@@ -0,0 +1,33 @@
+package types
+
+type downloadPeerOrigin string
+
+const (
+	DownloadPeerOriginTracker  downloadPeerOrigin = "tracker"  // the peer was returned by a tracker
+	DownloadPeerOriginIncoming downloadPeerOrigin = "incoming" // the peer connected to the Freebox
+	DownloadPeerOriginDHT      downloadPeerOrigin = "dht"      // the peer was found using DHT
+	DownloadPeerOriginPEX      downloadPeerOrigin = "pex"      // the peer was found using peer exchange
+	DownloadPeerOriginUser     downloadPeerOrigin = "user"     // the peer was added manually
+)
+
+type downloadPeerProtocol string
+
+const (
+	DownloadPeerProtocolTCP downloadPeerProtocol = "tcp" // the peer is connected using TCP
+	DownloadPeerProtocolUTP downloadPeerProtocol = "utp" // the peer is connected using uTP
+)
+
+type DownloadPeer struct {
+	Host             string               `json:"host"`     // IP address of the peer
+	Port             int                  `json:"port"`     // port of the peer
+	State            string               `json:"state"`    // state of the connection with the peer
+	Origin           downloadPeerOrigin   `json:"origin"`   // how the peer was found
+	Protocol         downloadPeerProtocol `json:"protocol"` // protocol used to connect to the peer
+	Client           string               `json:"client"`   // bittorrent client used by the peer
+	Country          string               `json:"country"`  // country code of the peer
+	TransmittedBytes ByteSize             `json:"tx"`       // bytes sent to the peer
+	ReceivedBytes    ByteSize             `json:"rx"`       // bytes received from the peer
+	TransmitRate     BitRate              `json:"tx_rate"`  // current transmit rate to the peer
+	ReceiveRate      BitRate              `json:"rx_rate"`  // current receive rate from the peer
+	Progress         float64              `json:"progress"` // download progress of the peer, between 0 and 1
+}
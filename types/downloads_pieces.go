@@ -0,0 +1,45 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type DownloadPieceState byte
+
+const (
+	DownloadPieceStateComplete    DownloadPieceState = 'X' // the piece is downloaded and verified
+	DownloadPieceStateDownloading DownloadPieceState = '/' // the piece is being downloaded
+	DownloadPieceStateMissing     DownloadPieceState = '.' // the piece has not been downloaded yet
+)
+
+// DownloadPieces holds the state of each piece of a bittorrent download task, in order.
+type DownloadPieces []DownloadPieceState
+
+// Count returns the number of pieces in the given state.
+func (p DownloadPieces) Count(state DownloadPieceState) int {
+	count := 0
+
+	for _, piece := range p {
+		if piece == state {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (p DownloadPieces) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(p)) //nolint:wrapcheck
+}
+
+func (p *DownloadPieces) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to unmarshal pieces: %w", err)
+	}
+
+	*p = DownloadPieces(raw)
+
+	return nil
+}
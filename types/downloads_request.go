@@ -0,0 +1,184 @@
+package types
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var ErrInvalidDownloadRequest = errors.New("invalid download request")
+
+// DownloadRequestBuilder builds a DownloadRequest and validates it on Build.
+type DownloadRequestBuilder struct {
+	request DownloadRequest
+}
+
+// NewDownloadRequest starts building a request downloading the given URLs or magnet links.
+func NewDownloadRequest(downloadURLs ...string) *DownloadRequestBuilder {
+	return &DownloadRequestBuilder{
+		request: DownloadRequest{
+			DownloadURLs: downloadURLs,
+		},
+	}
+}
+
+// WithDownloadDirectory sets the destination directory, as a plain path.
+func (b *DownloadRequestBuilder) WithDownloadDirectory(directory string) *DownloadRequestBuilder {
+	b.request.DownloadDirectory = directory
+
+	return b
+}
+
+// WithFilename overrides the name of the downloaded file.
+func (b *DownloadRequestBuilder) WithFilename(filename string) *DownloadRequestBuilder {
+	b.request.Filename = filename
+
+	return b
+}
+
+// WithHash sets the hash the downloaded file is verified against.
+func (b *DownloadRequestBuilder) WithHash(hash string) *DownloadRequestBuilder {
+	b.request.Hash = hash
+
+	return b
+}
+
+// WithRecursive makes the download recursive.
+func (b *DownloadRequestBuilder) WithRecursive() *DownloadRequestBuilder {
+	b.request.Recursive = true
+
+	return b
+}
+
+// WithCredentials sets the credentials used to authenticate against the remote server.
+func (b *DownloadRequestBuilder) WithCredentials(username, password string) *DownloadRequestBuilder {
+	b.request.Username = username
+	b.request.Password = password
+
+	return b
+}
+
+// WithArchivePassword sets the password used to extract the downloaded archives.
+func (b *DownloadRequestBuilder) WithArchivePassword(password string) *DownloadRequestBuilder {
+	b.request.ArchivePassword = password
+
+	return b
+}
+
+// WithCookie adds a cookie sent along with the download requests.
+func (b *DownloadRequestBuilder) WithCookie(name, value string) *DownloadRequestBuilder {
+	if b.request.Cookies == nil {
+		b.request.Cookies = map[string]string{}
+	}
+
+	b.request.Cookies[name] = value
+
+	return b
+}
+
+// Build validates and returns the request.
+func (b *DownloadRequestBuilder) Build() (DownloadRequest, error) {
+	if err := b.request.Validate(); err != nil {
+		return DownloadRequest{}, err
+	}
+
+	return b.request, nil
+}
+
+// Validate checks the request can be sent to the freebox.
+func (r DownloadRequest) Validate() error {
+	errs := []error{}
+
+	if len(r.DownloadURLs) == 0 {
+		errs = append(errs, fmt.Errorf("%w: at least one download URL is required", ErrInvalidDownloadRequest))
+	}
+
+	for _, downloadURL := range r.DownloadURLs {
+		if err := validateDownloadURL(downloadURL); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %q: %w", ErrInvalidDownloadRequest, downloadURL, err))
+		}
+	}
+
+	if r.Filename != "" {
+		if len(r.DownloadURLs) > 1 || r.Recursive {
+			errs = append(errs, fmt.Errorf("%w: filename is only valid with one non-recursive download URL", ErrInvalidDownloadRequest))
+		}
+
+		if strings.Contains(r.Filename, "/") {
+			errs = append(errs, fmt.Errorf("%w: filename %q must not contain a slash", ErrInvalidDownloadRequest, r.Filename))
+		}
+	}
+
+	if r.Hash != "" {
+		if len(r.DownloadURLs) > 1 || r.Recursive {
+			errs = append(errs, fmt.Errorf("%w: hash is only valid with one non-recursive download URL", ErrInvalidDownloadRequest))
+		}
+
+		if err := validateDownloadHash(r.Hash); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidDownloadRequest, err))
+		}
+	}
+
+	if r.Password != "" && r.Username == "" {
+		errs = append(errs, fmt.Errorf("%w: a password requires a username", ErrInvalidDownloadRequest))
+	}
+
+	for name, value := range r.Cookies {
+		if name == "" || strings.ContainsAny(name, "=; ") || strings.Contains(value, ";") {
+			errs = append(errs, fmt.Errorf("%w: invalid cookie %q", ErrInvalidDownloadRequest, name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateDownloadURL(downloadURL string) error {
+	if strings.ContainsAny(downloadURL, "\r\n") {
+		return errors.New("URL must not contain line breaks")
+	}
+
+	parsed, err := url.Parse(downloadURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	switch strings.ToLower(parsed.Scheme) {
+	case "http", "https", "ftp", "ftps":
+		if parsed.Host == "" {
+			return errors.New("URL has no host")
+		}
+
+		return nil
+	case "magnet":
+		for _, topic := range parsed.Query()["xt"] {
+			if strings.HasPrefix(topic, "urn:btih:") || strings.HasPrefix(topic, "urn:btmh:") {
+				return nil
+			}
+		}
+
+		return errors.New("magnet link has no bittorrent info hash")
+	default:
+		return fmt.Errorf("unsupported scheme %q", parsed.Scheme)
+	}
+}
+
+func validateDownloadHash(hash string) error {
+	for prefix, length := range map[string]int{"sha256:": 32, "sha512:": 64} {
+		if digest, ok := strings.CutPrefix(hash, prefix); ok {
+			decoded, err := hex.DecodeString(digest)
+			if err != nil || len(decoded) != length {
+				return fmt.Errorf("hash %q is not a valid %s digest", hash, strings.TrimSuffix(prefix, ":"))
+			}
+
+			return nil
+		}
+	}
+
+	if err := validateDownloadURL(hash); err != nil {
+		return fmt.Errorf("hash must be sha256:<hex>, sha512:<hex> or the URL of a checksum file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package types_test
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/nikolalohinski/free-go/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("download request builder", func() {
+	var (
+		builder *types.DownloadRequestBuilder
+
+		returnedRequest = new(types.DownloadRequest)
+		returnedErr     = new(error)
+	)
+	JustBeforeEach(func() {
+		*returnedRequest, *returnedErr = builder.Build()
+	})
+	Context("when every field is set", func() {
+		BeforeEach(func() {
+			builder = types.NewDownloadRequest("https://example.org/file.iso").
+				WithDownloadDirectory("/Freebox/ISOs").
+				WithFilename("renamed.iso").
+				WithHash("sha256:"+strings.Repeat("ab", 32)).
+				WithCredentials("user", "password").
+				WithArchivePassword("secret").
+				WithCookie("session", "token")
+		})
+		It("should return the correct request", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*returnedRequest).To(Equal(types.DownloadRequest{
+				DownloadURLs:      []string{"https://example.org/file.iso"},
+				DownloadDirectory: "/Freebox/ISOs",
+				Filename:          "renamed.iso",
+				Hash:              "sha256:" + strings.Repeat("ab", 32),
+				Username:          "user",
+				Password:          "password",
+				ArchivePassword:   "secret",
+				Cookies:           map[string]string{"session": "token"},
+			}))
+		})
+	})
+	Context("when several URLs and a magnet link are given", func() {
+		BeforeEach(func() {
+			builder = types.NewDownloadRequest(
+				"ftp://example.org/file",
+				"magnet:?xt=urn:btih:c9e15763f722f23e98a29decdfae341b98d53056&dn=file",
+			).WithRecursive()
+		})
+		It("should return the correct request", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(returnedRequest.DownloadURLs).To(HaveLen(2))
+			Expect(returnedRequest.Recursive).To(BeTrue())
+		})
+	})
+	Context("when no URL is given", func() {
+		BeforeEach(func() {
+			builder = types.NewDownloadRequest()
+		})
+		It("should return an error", func() {
+			Expect(errors.Is(*returnedErr, types.ErrInvalidDownloadRequest)).To(BeTrue())
+		})
+	})
+	Context("when the URL scheme is not supported", func() {
+		BeforeEach(func() {
+			builder = types.NewDownloadRequest("file:///etc/passwd")
+		})
+		It("should return an error", func() {
+			Expect(errors.Is(*returnedErr, types.ErrInvalidDownloadRequest)).To(BeTrue())
+		})
+	})
+	Context("when a URL contains a line break", func() {
+		BeforeEach(func() {
+			builder = types.NewDownloadRequest("https://example.org/a\nhttps://example.org/b")
+		})
+		It("should return an error", func() {
+			Expect(errors.Is(*returnedErr, types.ErrInvalidDownloadRequest)).To(BeTrue())
+		})
+	})
+	Context("when a magnet link has no info hash", func() {
+		BeforeEach(func() {
+			builder = types.NewDownloadRequest("magnet:?dn=file")
+		})
+		It("should return an error", func() {
+			Expect(errors.Is(*returnedErr, types.ErrInvalidDownloadRequest)).To(BeTrue())
+		})
+	})
+	Context("when a filename is set with several URLs", func() {
+		BeforeEach(func() {
+			builder = types.NewDownloadRequest("https://example.org/a", "https://example.org/b").
+				WithFilename("file")
+		})
+		It("should return an error", func() {
+			Expect(errors.Is(*returnedErr, types.ErrInvalidDownloadRequest)).To(BeTrue())
+		})
+	})
+	Context("when the hash is malformed", func() {
+		BeforeEach(func() {
+			builder = types.NewDownloadRequest("https://example.org/file").
+				WithHash("sha256:nothex")
+		})
+		It("should return an error", func() {
+			Expect(errors.Is(*returnedErr, types.ErrInvalidDownloadRequest)).To(BeTrue())
+		})
+	})
+	Context("when the hash is the URL of a checksum file", func() {
+		BeforeEach(func() {
+			builder = types.NewDownloadRequest("https://example.org/file").
+				WithHash("https://example.org/SHA256SUMS")
+		})
+		It("should not return an error", func() {
+			Expect(*returnedErr).To(BeNil())
+		})
+	})
+	Context("when a password is set without username", func() {
+		BeforeEach(func() {
+			builder = types.NewDownloadRequest("https://example.org/file").
+				WithCredentials("", "password")
+		})
+		It("should return an error", func() {
+			Expect(errors.Is(*returnedErr, types.ErrInvalidDownloadRequest)).To(BeTrue())
+		})
+	})
+	Context("when a cookie is malformed", func() {
+		BeforeEach(func() {
+			builder = types.NewDownloadRequest("https://example.org/file").
+				WithCookie("session", "a;b")
+		})
+		It("should return an error", func() {
+			Expect(errors.Is(*returnedErr, types.ErrInvalidDownloadRequest)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,37 @@
+package types_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("downloads", func() {
+	Context("task statuses", func() {
+		for _, status := range []types.DownloadTaskStatus{
+			types.DownloadTaskStatusStopped, types.DownloadTaskStatusQueued, types.DownloadTaskStatusStarting,
+			types.DownloadTaskStatusStopping, types.DownloadTaskStatusError, types.DownloadTaskStatusDone,
+			types.DownloadTaskStatusChecking, types.DownloadTaskStatusRepairing, types.DownloadTaskStatusExtracting,
+			types.DownloadTaskStatusSeeding, types.DownloadTaskStatusRetry, types.DownloadTaskStatusDownloading,
+		} {
+			status := status
+			It(fmt.Sprintf("should round trip the %s status through json", status), func() {
+				Expect(status.IsValid()).To(BeTrue())
+
+				bytes, err := json.Marshal(types.DownloadTask{Status: status})
+				Expect(err).To(BeNil())
+
+				var decoded types.DownloadTask
+				Expect(json.Unmarshal(bytes, &decoded)).To(Succeed())
+				Expect(decoded.Status).To(Equal(status))
+				Expect(decoded.Status.String()).To(Equal(string(status)))
+			})
+		}
+		It("should not validate unknown statuses", func() {
+			Expect(types.DownloadTaskStatus("foobar").IsValid()).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,21 @@
+package types
+
+type downloadTrackerStatus string
+
+const (
+	DownloadTrackerStatusUnknown  downloadTrackerStatus = "unknown"  // the tracker has not been contacted yet
+	DownloadTrackerStatusWorking  downloadTrackerStatus = "working"  // the last announce succeeded
+	DownloadTrackerStatusFailed   downloadTrackerStatus = "failed"   // the last announce failed
+	DownloadTrackerStatusDisabled downloadTrackerStatus = "disabled" // the tracker is not used
+)
+
+type DownloadTracker struct {
+	Announce           string                `json:"announce"`     // announce URL of the tracker
+	IsBackup           bool                  `json:"is_backup"`    // whether the tracker is only used when the others fail
+	Status             downloadTrackerStatus `json:"status"`       // status of the tracker
+	IntervalSeconds    Seconds               `json:"interval"`     // announce interval requested by the tracker (in seconds)
+	MinIntervalSeconds Seconds               `json:"min_interval"` // minimum announce interval requested by the tracker (in seconds)
+	ReannounceSeconds  Seconds               `json:"reannounce"`   // time until the next announce (in seconds)
+	SeedersCount       int64                 `json:"nseeders"`     // number of seeders reported by the tracker
+	LeechersCount      int64                 `json:"nleechers"`    // number of leechers reported by the tracker
+}
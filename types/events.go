@@ -1,6 +1,13 @@
 package types
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrUnexpectedEvent = errors.New("unexpected event")
 
 type EventDescription struct {
 	Source eventSource
@@ -9,7 +16,9 @@ type EventDescription struct {
 
 type Event struct {
 	Notification EventNotification
-	Error        error
+	ReceivedAt   time.Time // Time the notification was received, zero for the terminal error event
+	Error        error     // Terminal error, only set on the last event sent before the channel is closed
+	Dropped      int64     // Number of notifications dropped since the previous event because the channel was full
 }
 
 type EventNotification struct {
@@ -24,3 +33,16 @@ type (
 	eventSource string
 	eventName   string
 )
+
+// decode unmarshals the result of the notification into result after checking that it is a source_name event.
+func (n EventNotification) decode(source eventSource, name eventName, result interface{}) error {
+	if n.Source != source || n.Event != name {
+		return fmt.Errorf("%w: %s_%s is not a %s_%s event", ErrUnexpectedEvent, n.Source, n.Event, source, name)
+	}
+
+	if err := json.Unmarshal(n.Result, result); err != nil {
+		return fmt.Errorf("failed to unmarshal %s_%s event: %w", n.Source, n.Event, err)
+	}
+
+	return nil
+}
@@ -1,5 +1,10 @@
 package types
 
+import (
+	"io"
+	"time"
+)
+
 type uploadActionForce string
 
 const (
@@ -91,6 +96,12 @@ const (
 	UploadTaskStatusCancelled  UploadTaskStatus = "cancelled"   // Upload cancelled by user
 )
 
+const (
+	EventSourceUpload eventSource = "upload"
+
+	EventUploadTaskChanged eventName = "task_changed"
+)
+
 type UploadTask struct {
 	ID         int64            `json:"id"`          // Upload id
 	Size       int64            `json:"size"`        // Upload file size in bytes
@@ -101,3 +112,41 @@ type UploadTask struct {
 	UploadName string           `json:"upload_name"` // Name of the file uploaded
 	Dirname    string           `json:"dirname"`     // Upload destination directory
 }
+
+// UploadTaskProgress is a snapshot of the progress of an upload task, as seen by the freebox.
+type UploadTaskProgress struct {
+	ID            int64
+	Status        UploadTaskStatus
+	UploadedBytes int64
+	TotalBytes    int64
+	Error         error // Set when watching the task failed, no more progress is sent afterwards
+}
+
+// UploadProgress reports the progress of an upload started with FileUploadStart.
+type UploadProgress struct {
+	SentBytes  int64         // Bytes sent and acknowledged so far
+	TotalBytes int64         // Size of the uploaded file
+	Rate       float64       // Instantaneous rate in bytes per second, measured over the last write
+	ETA        time.Duration // Estimated time left, based on the instantaneous rate, 0 when unknown
+}
+
+// UploadSpec describes a file uploaded by UploadFiles.
+type UploadSpec struct {
+	Reader   io.Reader         // Content of the file
+	Size     int64             // Size of the content in bytes
+	Dirname  string            // Remote destination directory
+	Filename string            // Remote file name
+	Force    uploadActionForce // Select the way conflicts are handled
+}
+
+// UploadResult reports the outcome of one of the uploads of UploadFiles.
+type UploadResult struct {
+	Path  string // Remote path of the file
+	Error error  // Reason of the failure, nil when the file was uploaded
+}
+
+type UploadDirectoryOptions struct {
+	Concurrency int                                                // Number of files uploaded in parallel (defaults to 1)
+	Force       uploadActionForce                                  // Select the way conflicts are handled for each file
+	Progress    func(remotePath string, progress TransferProgress) // Optional per-file progress callback, called concurrently when Concurrency > 1
+}
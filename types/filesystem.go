@@ -2,6 +2,8 @@ package types
 
 import (
 	"io"
+	"slices"
+	"time"
 )
 
 type fileType string
@@ -16,7 +18,7 @@ type FileInfo struct {
 	Index        int64      `json:"index"`
 	Link         bool       `json:"link"`
 	Parent       Base64Path `json:"parent"`
-	Modification uint64     `json:"modification"`
+	Modification Timestamp  `json:"modification"`
 	Hidden       bool       `json:"hidden"`
 	MimeType     string     `json:"mimetype"`
 	Name         string     `json:"name"`
@@ -24,31 +26,59 @@ type FileInfo struct {
 	SizeBytes    uint64     `json:"size"`
 }
 
-type fileTaskType string
+type FileTaskType string
 
 const (
-	FileTaskTypeConcatenate fileTaskType = "cat"     // Concatenate multiple files
-	FileTaskTypeCopy        fileTaskType = "cp"      // Copy files
-	FileTaskTypeMove        fileTaskType = "mv"      // Move files
-	FileTaskTypeRemove      fileTaskType = "rm"      // Remove files
-	FileTaskTypeArchive     fileTaskType = "archive" // Creates an archive
-	FileTaskTypeExtract     fileTaskType = "extract" // Extract an archive
-	FileTaskTypeRepair      fileTaskType = "repair"  // Check and repair files
+	FileTaskTypeConcatenate FileTaskType = "cat"     // Concatenate multiple files
+	FileTaskTypeCopy        FileTaskType = "cp"      // Copy files
+	FileTaskTypeMove        FileTaskType = "mv"      // Move files
+	FileTaskTypeRemove      FileTaskType = "rm"      // Remove files
+	FileTaskTypeArchive     FileTaskType = "archive" // Creates an archive
+	FileTaskTypeExtract     FileTaskType = "extract" // Extract an archive
+	FileTaskTypeRepair      FileTaskType = "repair"  // Check and repair files
 
 	// Undocumented and reverse engineered task types.
-	FileTaskTypeHash fileTaskType = "hash" // Hash a file
+	FileTaskTypeHash      FileTaskType = "hash" // Hash a file
+	FileTaskTypeDiskUsage FileTaskType = "du"   // Compute the disk usage of a directory tree
 )
 
-type fileTaskState string
+var fileTaskTypes = []FileTaskType{
+	FileTaskTypeConcatenate, FileTaskTypeCopy, FileTaskTypeMove, FileTaskTypeRemove, FileTaskTypeArchive,
+	FileTaskTypeExtract, FileTaskTypeRepair, FileTaskTypeHash, FileTaskTypeDiskUsage,
+}
+
+func (t FileTaskType) String() string {
+	return string(t)
+}
+
+// IsValid reports whether the type is a known type of filesystem task.
+func (t FileTaskType) IsValid() bool {
+	return slices.Contains(fileTaskTypes, t)
+}
+
+type FileTaskState string
 
 const (
-	FileTaskStateQueued  fileTaskState = "queued"  // Queued (only one task is active at a given time)
-	FileTaskStateRunning fileTaskState = "running" // Running
-	FileTaskStatePaused  fileTaskState = "paused"  // Paused (user suspended)
-	FileTaskStateDone    fileTaskState = "done"    // Done
-	FileTaskStateFailed  fileTaskState = "failed"  // Failed (see error)
+	FileTaskStateQueued  FileTaskState = "queued"  // Queued (only one task is active at a given time)
+	FileTaskStateRunning FileTaskState = "running" // Running
+	FileTaskStatePaused  FileTaskState = "paused"  // Paused (user suspended)
+	FileTaskStateDone    FileTaskState = "done"    // Done
+	FileTaskStateFailed  FileTaskState = "failed"  // Failed (see error)
 )
 
+var fileTaskStates = []FileTaskState{
+	FileTaskStateQueued, FileTaskStateRunning, FileTaskStatePaused, FileTaskStateDone, FileTaskStateFailed,
+}
+
+func (s FileTaskState) String() string {
+	return string(s)
+}
+
+// IsValid reports whether the state is a known state of a filesystem task.
+func (s FileTaskState) IsValid() bool {
+	return slices.Contains(fileTaskStates, s)
+}
+
 type fileTaskError string
 
 const (
@@ -87,28 +117,54 @@ const (
 
 type FileSystemTask struct {
 	ID                            int64         `json:"id"`
-	Type                          fileTaskType  `json:"type"`
-	State                         fileTaskState `json:"state"`
+	Type                          FileTaskType  `json:"type"`
+	State                         FileTaskState `json:"state"`
 	Error                         fileTaskError `json:"error"`
 	CurrentBytesDone              int64         `json:"curr_bytes_done"`
 	TotalBytes                    int64         `json:"total_bytes"`
 	NumberFilesDone               int64         `json:"nfiles_done"`
-	StartedTimestamp              int64         `json:"started_ts"`
-	DurationSeconds               int64         `json:"duration"`
-	DoneTimestamp                 int64         `json:"done_ts"`
+	StartedTimestamp              Timestamp     `json:"started_ts"`
+	DurationSeconds               Seconds       `json:"duration"`
+	DoneTimestamp                 Timestamp     `json:"done_ts"`
 	CurrentBytes                  int64         `json:"curr_bytes"`
 	To                            string        `json:"to"`
 	NumberFiles                   int64         `json:"nfiles"`
-	CreatedTimestamp              int64         `json:"created_ts"`
+	CreatedTimestamp              Timestamp     `json:"created_ts"`
 	TotalBytesDone                int64         `json:"total_bytes_done"`
 	From                          string        `json:"from"`
 	ProcessingRate                int64         `json:"rate"`
-	EstimatedTimeRemainingSeconds int64         `json:"eta"`
+	EstimatedTimeRemainingSeconds Seconds       `json:"eta"`
 	ProgressPercent               int           `json:"progress"`
 	Sources                       []string      `json:"src"`
 	Destination                   string        `json:"dst"`
 }
 
+// FileSystemTaskProgress is a snapshot of a filesystem task progress.
+type FileSystemTaskProgress struct {
+	ID                     int64
+	State                  FileTaskState
+	TaskError              fileTaskError // Error reported by the task itself (see State)
+	ProgressPercent        int
+	ProcessingRate         int64         // Processing rate in bytes per second
+	EstimatedTimeRemaining time.Duration // Estimated time before the task completes
+	Error                  error         // Set when watching the task failed, no more progress is sent afterwards
+}
+
+type DirectoryChangeType string
+
+const (
+	DirectoryChangeCreated  DirectoryChangeType = "created"
+	DirectoryChangeModified DirectoryChangeType = "modified"
+	DirectoryChangeDeleted  DirectoryChangeType = "deleted"
+)
+
+// DirectoryChange is a change of an entry of a watched directory.
+type DirectoryChange struct {
+	Type  DirectoryChangeType
+	File  FileInfo // Information of the entry, as it was before its removal for deleted entries
+	Error error    // Set when watching the directory failed, no more change is sent afterwards
+}
+
 type HashType string
 
 const (
@@ -128,13 +184,33 @@ type HashResult struct {
 }
 
 type File struct {
-	ContentType string
-	FileName    string
-	Content     io.Reader
+	ContentType   string
+	FileName      string
+	ContentLength int64         // Length of the returned content in bytes, -1 when unknown
+	LastModified  time.Time     // Time of the last modification of the file, zero when unknown
+	AcceptRanges  bool          // Whether the server supports partial downloads of this file
+	Content       io.ReadCloser // Content streamed from the freebox, to close once done with it (see Close)
 }
 
+// Close closes the content of the file, which releases the connection to the freebox. It must be called once done
+// with the content, even when it was not read until the end.
+func (f File) Close() error {
+	if f.Content == nil {
+		return nil
+	}
+
+	return f.Content.Close() //nolint:wrapcheck
+}
+
+type ArchiveFormat string
+
+const (
+	ArchiveFormatZip ArchiveFormat = "zip" // Zip archive
+	ArchiveFormatTar ArchiveFormat = "tar" // Uncompressed tar archive
+)
+
 type FileSytemTaskUpdate struct {
-	State fileTaskState `json:"state"`
+	State FileTaskState `json:"state"`
 }
 
 type FileMoveMode string
@@ -146,6 +222,16 @@ const (
 	FileMoveModeRecent    FileMoveMode = "recent"    // Only overwrite if newer than destination file
 )
 
+// IsValid reports whether the mode is supported by the Freebox.
+func (m FileMoveMode) IsValid() bool {
+	switch m {
+	case FileMoveModeOverwrite, FileMoveModeSkip, FileMoveModeBoth, FileMoveModeRecent:
+		return true
+	default:
+		return false
+	}
+}
+
 type FileCopyMode string
 
 const (
@@ -155,6 +241,16 @@ const (
 	FileCopyModeRecent    FileCopyMode = "recent"    // Only overwrite if newer than destination file
 )
 
+// IsValid reports whether the mode is supported by the Freebox.
+func (m FileCopyMode) IsValid() bool {
+	switch m {
+	case FileCopyModeOverwrite, FileCopyModeSkip, FileCopyModeBoth, FileCopyModeRecent:
+		return true
+	default:
+		return false
+	}
+}
+
 type ExtractFilePayload struct {
 	Src           Base64Path `json:"src"`
 	Dst           Base64Path `json:"dst"`
@@ -0,0 +1,50 @@
+package types_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("filesystem", func() {
+	Context("task states", func() {
+		for _, state := range []types.FileTaskState{
+			types.FileTaskStateQueued, types.FileTaskStateRunning, types.FileTaskStatePaused,
+			types.FileTaskStateDone, types.FileTaskStateFailed,
+		} {
+			state := state
+			It(fmt.Sprintf("should round trip the %s state through json", state), func() {
+				Expect(state.IsValid()).To(BeTrue())
+
+				var decoded types.FileSystemTask
+				Expect(json.Unmarshal([]byte(fmt.Sprintf(`{"state": %q}`, state.String())), &decoded)).To(Succeed())
+				Expect(decoded.State).To(Equal(state))
+			})
+		}
+		It("should not validate unknown states", func() {
+			Expect(types.FileTaskState("foobar").IsValid()).To(BeFalse())
+		})
+	})
+	Context("task types", func() {
+		for _, taskType := range []types.FileTaskType{
+			types.FileTaskTypeConcatenate, types.FileTaskTypeCopy, types.FileTaskTypeMove, types.FileTaskTypeRemove,
+			types.FileTaskTypeArchive, types.FileTaskTypeExtract, types.FileTaskTypeRepair, types.FileTaskTypeHash,
+			types.FileTaskTypeDiskUsage,
+		} {
+			taskType := taskType
+			It(fmt.Sprintf("should round trip the %s type through json", taskType), func() {
+				Expect(taskType.IsValid()).To(BeTrue())
+
+				var decoded types.FileSystemTask
+				Expect(json.Unmarshal([]byte(fmt.Sprintf(`{"type": %q}`, taskType.String())), &decoded)).To(Succeed())
+				Expect(decoded.Type).To(Equal(taskType))
+			})
+		}
+		It("should not validate unknown types", func() {
+			Expect(types.FileTaskType("foobar").IsValid()).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"net/netip"
+	"strconv"
+)
+
+// ForwardingSource tells what created a forwarding.
+type ForwardingSource string
+
+const (
+	ForwardingSourcePortForwarding ForwardingSource = "port_forwarding" // rule set in the port forwarding settings
+	ForwardingSourceUPnP           ForwardingSource = "upnp"            // redirection requested by a LAN host through UPnP IGD
+)
+
+func (s ForwardingSource) String() string {
+	return string(s)
+}
+
+// UPnPRedirection is a port redirection requested by a LAN host through UPnP IGD.
+type UPnPRedirection struct {
+	ID           string     `json:"id"`
+	Enabled      bool       `json:"enabled"`
+	SourceIP     netip.Addr `json:"ext_src_ip"` // only the traffic from this address is redirected, any when unspecified
+	ExternalPort int64      `json:"ext_port"`
+	InternalIP   netip.Addr `json:"int_ip"`
+	InternalPort int64      `json:"int_port"`
+	Protocol     IPProtocol `json:"proto"`
+	Description  string     `json:"desc"`
+}
+
+// Forwarding exposes ports of a LAN host on the WAN, whatever created it, so that the exposure of the LAN can be
+// reviewed at once.
+type Forwarding struct {
+	Source       ForwardingSource
+	ID           string // identifier of the forwarding within its source
+	Enabled      bool
+	Protocol     IPProtocol
+	WanPortStart int64
+	WanPortEnd   int64
+	LanIP        netip.Addr
+	LanPort      int64
+	SourceIP     netip.Addr // only the traffic from this address is forwarded, any when invalid or unspecified
+	Description  string
+}
+
+// Forwarding normalizes the port forwarding rule.
+func (r PortForwardingRule) Forwarding() Forwarding {
+	enabled, _ := r.Enabled.Get()
+	lanIP, _ := r.LanIP.Get()
+	sourceIP, _ := r.SourceIP.Get()
+
+	return Forwarding{
+		Source:       ForwardingSourcePortForwarding,
+		ID:           strconv.FormatInt(r.ID, 10),
+		Enabled:      enabled,
+		Protocol:     r.IPProtocol,
+		WanPortStart: r.WanPortStart,
+		WanPortEnd:   r.WanPortEnd,
+		LanIP:        lanIP,
+		LanPort:      r.LanPort,
+		SourceIP:     sourceIP,
+		Description:  r.Comment,
+	}
+}
+
+// Forwarding normalizes the UPnP redirection.
+func (r UPnPRedirection) Forwarding() Forwarding {
+	return Forwarding{
+		Source:       ForwardingSourceUPnP,
+		ID:           r.ID,
+		Enabled:      r.Enabled,
+		Protocol:     r.Protocol,
+		WanPortStart: r.ExternalPort,
+		WanPortEnd:   r.ExternalPort,
+		LanIP:        r.InternalIP,
+		LanPort:      r.InternalPort,
+		SourceIP:     r.SourceIP,
+		Description:  r.Description,
+	}
+}
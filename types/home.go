@@ -0,0 +1,98 @@
+package types
+
+const (
+	EventSourceHome eventSource = "home"
+
+	EventHomeSensorTriggered   eventName = "sensor_triggered"
+	EventHomeAlarmStateChanged eventName = "alarm_state_changed"
+)
+
+type homeNodeStatus string
+
+const (
+	HomeNodeStatusActive      homeNodeStatus = "active"      // Node is working
+	HomeNodeStatusUnreachable homeNodeStatus = "unreachable" // Node does not answer anymore
+	HomeNodeStatusDisabled    homeNodeStatus = "disabled"    // Node has been disabled
+	HomeNodeStatusError       homeNodeStatus = "error"       // Node is in error
+	HomeNodeStatusUnknown     homeNodeStatus = "unknown"     // Node status is not known yet
+)
+
+type homeEndpointType string
+
+const (
+	HomeEndpointTypeSignal homeEndpointType = "signal" // Endpoint reporting a value, such as a temperature or a position
+	HomeEndpointTypeSlot   homeEndpointType = "slot"   // Endpoint accepting a value, such as a command
+)
+
+type homeEndpointValueType string
+
+const (
+	HomeEndpointValueTypeBool   homeEndpointValueType = "bool"
+	HomeEndpointValueTypeInt    homeEndpointValueType = "int"
+	HomeEndpointValueTypeFloat  homeEndpointValueType = "float"
+	HomeEndpointValueTypeString homeEndpointValueType = "string"
+	HomeEndpointValueTypeVoid   homeEndpointValueType = "void" // Endpoint does not carry any value, such as the "stop" command of a shutter
+)
+
+type HomeNode struct {
+	ID            FlexibleInt64          `json:"id"`             // Node id
+	Adapter       FlexibleInt64          `json:"adapter"`        // Id of the adapter the node is attached to
+	Category      string                 `json:"category"`       // Category of the node, such as shutter, pir or alarm
+	Name          string                 `json:"name"`           // Technical name of the node
+	Label         string                 `json:"label"`          // Name of the node displayed to the user
+	Status        homeNodeStatus         `json:"status"`         // Node status
+	Group         HomeNodeGroup          `json:"group"`          // Group of the node, such as a room
+	Type          HomeNodeType           `json:"type"`           // Type of the node
+	Props         map[string]interface{} `json:"props"`          // Properties specific to the node type
+	ShowEndpoints []HomeEndpoint         `json:"show_endpoints"` // Endpoints of the node
+}
+
+type HomeNodeGroup struct {
+	Label string `json:"label"` // Name of the group
+}
+
+type HomeNodeType struct {
+	Name     string `json:"name"`     // Name of the type
+	Label    string `json:"label"`    // Name of the type displayed to the user
+	Icon     string `json:"icon"`     // Icon of the type
+	Inherit  string `json:"inherit"`  // Name of the parent type
+	Abstract bool   `json:"abstract"` // Type can not be instantiated
+	Generic  bool   `json:"generic"`  // Type is generic
+}
+
+type HomeEndpoint struct {
+	ID         FlexibleInt64         `json:"id"`         // Endpoint id
+	Type       homeEndpointType      `json:"ep_type"`    // Whether the endpoint reports or accepts a value
+	Name       string                `json:"name"`       // Technical name of the endpoint
+	Label      string                `json:"label"`      // Name of the endpoint displayed to the user
+	Category   string                `json:"category"`   // Category of the endpoint
+	Visibility string                `json:"visibility"` // Whether the endpoint should be displayed to the user
+	Access     string                `json:"access"`     // Access to the endpoint: r, w or rw
+	ValueType  homeEndpointValueType `json:"value_type"` // Type of the value of the endpoint
+	Value      interface{}           `json:"value"`      // Last known value of the endpoint, its Go type depends on ValueType
+}
+
+type HomeEndpointValue struct {
+	Value     interface{}           `json:"value"`      // Value of the endpoint, its Go type depends on ValueType
+	ValueType homeEndpointValueType `json:"value_type"` // Type of the value
+	Refresh   int64                 `json:"refresh"`    // Refresh interval of the value in milliseconds
+}
+
+// HomeSensorTriggered is the result of a home sensor_triggered event notification, such as a motion detector or a door
+// opening sensor detecting an activity.
+type HomeSensorTriggered struct {
+	NodeID     int64       `json:"node_id"`  // Id of the sensor node
+	EndpointID int64       `json:"ep_id"`    // Id of the signal endpoint which changed
+	Category   string      `json:"category"` // Category of the sensor, such as pir or dws
+	Label      string      `json:"label"`    // Name of the sensor displayed to the user
+	Value      interface{} `json:"value"`    // New value of the signal endpoint
+}
+
+// DecodeHomeSensorTriggered decodes the result of a home sensor_triggered notification.
+func (n EventNotification) DecodeHomeSensorTriggered() (HomeSensorTriggered, error) {
+	var result HomeSensorTriggered
+
+	err := n.decode(EventSourceHome, EventHomeSensorTriggered, &result)
+
+	return result, err
+}
@@ -0,0 +1,51 @@
+package types
+
+type AlarmState string
+
+const (
+	AlarmStateIdle              AlarmState = "idle"               // Alarm is disarmed
+	AlarmStateFullArming        AlarmState = "alarm1_arming"      // Alarm is waiting for the activation delay before being fully armed
+	AlarmStateFullArmed         AlarmState = "alarm1_armed"       // Alarm is fully armed
+	AlarmStatePartialArming     AlarmState = "alarm2_arming"      // Alarm is waiting for the activation delay before being partially armed
+	AlarmStatePartialArmed      AlarmState = "alarm2_armed"       // Alarm is partially armed, such as at night
+	AlarmStateFullAlertTimer    AlarmState = "alarm1_alert_timer" // Intrusion detected while fully armed, waiting for the trigger delay
+	AlarmStatePartialAlertTimer AlarmState = "alarm2_alert_timer" // Intrusion detected while partially armed, waiting for the trigger delay
+	AlarmStateAlert             AlarmState = "alert"              // Siren is ringing
+)
+
+type AlarmMode string
+
+const (
+	AlarmModeFull    AlarmMode = "alarm1" // Every sensor is watched
+	AlarmModePartial AlarmMode = "alarm2" // Only the sensors of the secondary zone are watched
+)
+
+type AlarmSettings struct {
+	ActivationDelay int64 `json:"timeout1"` // Delay in seconds before the alarm is armed
+	AlertDuration   int64 `json:"timeout2"` // Duration in seconds of the siren
+	TriggerDelay    int64 `json:"timeout3"` // Delay in seconds between an intrusion and the siren
+	Volume          int64 `json:"volume"`   // Volume of the siren, from 0 to 100
+}
+
+type AlarmSettingsPayload struct {
+	ActivationDelay *int64 `json:"timeout1,omitempty"` // Delay in seconds before the alarm is armed
+	AlertDuration   *int64 `json:"timeout2,omitempty"` // Duration in seconds of the siren
+	TriggerDelay    *int64 `json:"timeout3,omitempty"` // Delay in seconds between an intrusion and the siren
+	Volume          *int64 `json:"volume,omitempty"`   // Volume of the siren, from 0 to 100
+}
+
+// HomeAlarmStateChanged is the result of a home alarm_state_changed event notification.
+type HomeAlarmStateChanged struct {
+	NodeID int64      `json:"node_id"` // Id of the alarm node
+	State  AlarmState `json:"state"`   // New state of the alarm
+	Error  error      `json:"-"`       // Set when watching the alarm failed, the other fields are then empty
+}
+
+// DecodeHomeAlarmStateChanged decodes the result of a home alarm_state_changed notification.
+func (n EventNotification) DecodeHomeAlarmStateChanged() (HomeAlarmStateChanged, error) {
+	var result HomeAlarmStateChanged
+
+	err := n.decode(EventSourceHome, EventHomeAlarmStateChanged, &result)
+
+	return result, err
+}
@@ -0,0 +1,49 @@
+package types
+
+type homePairingStatus string
+
+const (
+	HomePairingStatusRunning homePairingStatus = "running" // Pairing is waiting for the device or for an answer to the current page
+	HomePairingStatusSuccess homePairingStatus = "success" // Device has been paired
+	HomePairingStatusFailed  homePairingStatus = "failed"  // Pairing failed, it has to be started again
+	HomePairingStatusStopped homePairingStatus = "stopped" // Pairing is not running
+)
+
+type homePairingFieldType string
+
+const (
+	HomePairingFieldTypeText   homePairingFieldType = "text"   // Information displayed to the user
+	HomePairingFieldTypeInput  homePairingFieldType = "input"  // Value to be filled by the user, such as a PIN code
+	HomePairingFieldTypeButton homePairingFieldType = "button" // Button to be pressed on the device before going to the next page
+	HomePairingFieldTypeChoice homePairingFieldType = "choice" // Value to be picked by the user among Choices
+)
+
+// HomePairingStep is a page of the interactive pairing of a home automation device.
+type HomePairingStep struct {
+	Status    homePairingStatus  `json:"status"`     // Status of the pairing
+	Page      int64              `json:"page"`       // Index of the current page, to be given back when answering it
+	PageCount int64              `json:"page_count"` // Number of pages of the pairing
+	Title     string             `json:"title"`      // Title of the page
+	Fields    []HomePairingField `json:"fields"`     // Prompts of the page
+	NodeID    int64              `json:"node_id"`    // Id of the paired node once the pairing succeeded
+}
+
+type HomePairingField struct {
+	ID       string               `json:"id"`                // Field id, to be given back when answering the page
+	Type     homePairingFieldType `json:"type"`              // Type of the field
+	Label    string               `json:"label"`             // Prompt displayed to the user
+	Value    interface{}          `json:"value,omitempty"`   // Default value of the field
+	Choices  []string             `json:"choices,omitempty"` // Accepted values of a choice field
+	Optional bool                 `json:"optional"`          // Field can be left empty
+}
+
+// HomePairingAnswer is the answer to a page of a pairing.
+type HomePairingAnswer struct {
+	Page   int64                    `json:"page"`   // Index of the answered page
+	Fields []HomePairingFieldAnswer `json:"fields"` // Values of the fields of the page
+}
+
+type HomePairingFieldAnswer struct {
+	ID    string      `json:"id"`    // Field id
+	Value interface{} `json:"value"` // Value given by the user
+}
@@ -0,0 +1,88 @@
+package types_test
+
+import (
+	"encoding/json"
+
+	"github.com/nikolalohinski/free-go/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("home", func() {
+	returnedErr := new(error)
+	Context("decoding an alarm_state_changed event notification", func() {
+		var (
+			notification types.EventNotification
+
+			returnedChange = new(types.HomeAlarmStateChanged)
+		)
+		BeforeEach(func() {
+			notification = types.EventNotification{
+				Action:  "notification",
+				Success: true,
+				Source:  types.EventSourceHome,
+				Event:   types.EventHomeAlarmStateChanged,
+				Result:  json.RawMessage(`{"node_id": 7, "state": "alert"}`),
+			}
+		})
+		JustBeforeEach(func() {
+			*returnedChange, *returnedErr = notification.DecodeHomeAlarmStateChanged()
+		})
+		Context("default", func() {
+			It("should return the correct state change", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedChange).To(Equal(types.HomeAlarmStateChanged{
+					NodeID: 7,
+					State:  types.AlarmStateAlert,
+				}))
+			})
+		})
+		Context("when the notification is another event", func() {
+			BeforeEach(func() {
+				notification.Event = types.EventHomeSensorTriggered
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(MatchError(types.ErrUnexpectedEvent))
+			})
+		})
+	})
+	Context("decoding a sensor_triggered event notification", func() {
+		var (
+			notification types.EventNotification
+
+			returnedTrigger = new(types.HomeSensorTriggered)
+		)
+		BeforeEach(func() {
+			notification = types.EventNotification{
+				Action:  "notification",
+				Success: true,
+				Source:  types.EventSourceHome,
+				Event:   types.EventHomeSensorTriggered,
+				Result:  json.RawMessage(`{"node_id": 9, "ep_id": 2, "category": "pir", "label": "Détecteur entrée", "value": true}`),
+			}
+		})
+		JustBeforeEach(func() {
+			*returnedTrigger, *returnedErr = notification.DecodeHomeSensorTriggered()
+		})
+		Context("default", func() {
+			It("should return the correct trigger", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedTrigger).To(Equal(types.HomeSensorTriggered{
+					NodeID:     9,
+					EndpointID: 2,
+					Category:   "pir",
+					Label:      "Détecteur entrée",
+					Value:      true,
+				}))
+			})
+		})
+		Context("when the result is not an object", func() {
+			BeforeEach(func() {
+				notification.Result = json.RawMessage(`[]`)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+})
@@ -1,5 +1,7 @@
 package types
 
+import "net/netip"
+
 type LanInfo struct {
 	Name      string `json:"name"`
 	HostCount int    `json:"host_count"`
@@ -56,6 +58,73 @@ type LanInterfaceHost struct {
 	L2Ident           L2Ident          `json:"l2ident"`
 	Names             []HostName       `json:"names"`
 	L3Connectivities  []L3Connectivity `json:"l3connectivities"`
+	AccessPoint       *HostAccessPoint `json:"access_point,omitempty"` // Equipment the host is connected through, when known
+}
+
+// MACAddress returns the mac address of the host, when it is identified by one.
+func (h LanInterfaceHost) MACAddress() (MACAddress, bool) {
+	if h.L2Ident.Type != MacAddress {
+		return nil, false
+	}
+
+	address, err := ParseMACAddress(h.L2Ident.ID)
+	if err != nil {
+		return nil, false
+	}
+
+	return address, true
+}
+
+// Vendor returns the vendor name reported by the freebox, or the one of the mac address of the host in the database
+// when the freebox does not know it, such as the one returned by DefaultOUIDatabase.
+func (h LanInterfaceHost) Vendor(database OUIDatabase) string {
+	if h.VendorName != "" {
+		return h.VendorName
+	}
+
+	address, ok := h.MACAddress()
+	if !ok {
+		return ""
+	}
+
+	vendor, _ := database.Lookup(address)
+
+	return vendor
+}
+
+type hostConnectivityType string
+
+const (
+	HostConnectivityEthernet hostConnectivityType = "ethernet"
+	HostConnectivityWifi     hostConnectivityType = "wifi"
+)
+
+// HostAccessPoint is the equipment a host is connected through, such as the box itself or a wifi repeater.
+type HostAccessPoint struct {
+	MAC                 MACAddress               `json:"mac"`                            // Mac address of the equipment
+	Type                string                   `json:"type"`                           // Type of the equipment, such as gateway or repeater
+	UID                 string                   `json:"uid"`                            // Identifier of the equipment
+	ConnectivityType    hostConnectivityType     `json:"connectivity_type"`              // Link between the host and the equipment
+	ReceivedBytes       ByteSize                 `json:"rx_bytes"`                       // Bytes received from the host
+	TransmittedBytes    ByteSize                 `json:"tx_bytes"`                       // Bytes transmitted to the host
+	ReceiveRate         BitRate                  `json:"rx_rate"`                        // Current rate of the traffic received from the host
+	TransmitRate        BitRate                  `json:"tx_rate"`                        // Current rate of the traffic transmitted to the host
+	EthernetInformation *HostEthernetInformation `json:"ethernet_information,omitempty"` // Set when connected through ethernet
+	WifiInformation     *HostWifiInformation     `json:"wifi_information,omitempty"`     // Set when connected through wifi
+}
+
+type HostEthernetInformation struct {
+	Duplex string `json:"duplex"` // Duplex mode of the link, such as full
+	Speed  int64  `json:"speed"`  // Speed of the link, in Mbit/s
+	Link   string `json:"link"`   // State of the link, such as up
+}
+
+type HostWifiInformation struct {
+	Band      string `json:"band"`        // Band of the connection, such as 5g
+	SSID      string `json:"ssid"`        // Name of the wifi network
+	Signal    int64  `json:"signal"`      // Strength of the signal, in dBm
+	PhyRxRate int64  `json:"phy_rx_rate"` // Physical receive rate, as reported by the wifi driver
+	PhyTxRate int64  `json:"phy_tx_rate"` // Physical transmit rate, as reported by the wifi driver
 }
 
 type HostName struct {
@@ -88,10 +157,10 @@ const (
 )
 
 type L3Connectivity struct {
-	Address           string    `json:"addr"`
-	Active            bool      `json:"active"`
-	Reachable         bool      `json:"reachable"`
-	LastActivity      Timestamp `json:"last_activity"`
-	LastTimeReachable Timestamp `json:"last_time_reachable"`
-	Type              af        `json:"af"`
+	Address           netip.Addr `json:"addr"`
+	Active            bool       `json:"active"`
+	Reachable         bool       `json:"reachable"`
+	LastActivity      Timestamp  `json:"last_activity"`
+	LastTimeReachable Timestamp  `json:"last_time_reachable"`
+	Type              af         `json:"af"`
 }
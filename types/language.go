@@ -0,0 +1,18 @@
+package types
+
+import "slices"
+
+// LanguageSettings is the language of the Freebox OS interface.
+type LanguageSettings struct {
+	Language  string   `json:"lang"`      // Current language, as an ISO 639-2 code such as fra or eng
+	Available []string `json:"avalaible"` // Languages supported by the freebox, the field being misspelled by the API
+}
+
+// Supports reports whether the freebox can switch to the given language.
+func (s LanguageSettings) Supports(language string) bool {
+	return slices.Contains(s.Available, language)
+}
+
+type LanguageSettingsPayload struct {
+	Language string `json:"lang"` // ISO 639-2 code of the language to switch to, one of LanguageSettings.Available
+}
@@ -0,0 +1,38 @@
+package types
+
+type NetworkControlMode string
+
+const (
+	NetworkControlModeAllowed NetworkControlMode = "allowed" // Internet access is allowed
+	NetworkControlModeDenied  NetworkControlMode = "denied"  // Internet access is denied
+	NetworkControlModeWebOnly NetworkControlMode = "webonly" // Only web browsing is allowed
+)
+
+type networkControlRuleMode string
+
+const (
+	NetworkControlRuleModeAllowed  networkControlRuleMode = "allowed"  // Internet access is always allowed
+	NetworkControlRuleModeDenied   networkControlRuleMode = "denied"   // Internet access is always denied
+	NetworkControlRuleModeFiltered networkControlRuleMode = "filtered" // Internet access follows the planning of the profile
+)
+
+type NetworkControlPayload struct {
+	RuleMode      networkControlRuleMode `json:"rule_mode,omitempty"`      // Mode of the profile outside of overrides
+	Override      *bool                  `json:"override,omitempty"`       // A temporary mode overrides the rule mode
+	OverrideMode  NetworkControlMode     `json:"override_mode,omitempty"`  // Mode applied while overriding
+	OverrideUntil *Timestamp             `json:"override_until,omitempty"` // End of the override
+}
+
+type NetworkControl struct {
+	NetworkControlPayload
+	ProfileID   int64              `json:"profile_id"`   // Id of the controlled profile
+	CurrentMode NetworkControlMode `json:"current_mode"` // Mode currently applied to the devices of the profile
+	NextChange  Timestamp          `json:"next_change"`  // Next time the current mode changes according to the planning
+	MACs        []MACAddress       `json:"macs"`         // MAC addresses of the devices of the profile
+}
+
+// NetworkControlPlanning is the weekly schedule of a profile, applied when its rule mode is filtered.
+type NetworkControlPlanning struct {
+	Resolution int64                `json:"resolution"` // Number of slots per day, such as 48 for half hours
+	Mapping    []NetworkControlMode `json:"mapping"`    // Mode of each slot of the week, starting on monday at midnight
+}
@@ -0,0 +1,82 @@
+package types
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+)
+
+// OUI is an organizationally unique identifier, the first three bytes of the mac addresses assigned by a vendor.
+type OUI [3]byte
+
+// OUIDatabase maps organizationally unique identifiers to the name of their vendor, to identify hosts offline.
+type OUIDatabase map[OUI]string
+
+// defaultOUIDatabase holds the vendors of a few devices commonly found on the LAN of a box, such as the freebox
+// equipments and virtual machines.
+var defaultOUIDatabase = OUIDatabase{
+	{0x00, 0x07, 0xcb}: "Freebox SAS",
+	{0x00, 0x24, 0xd4}: "Freebox SAS",
+	{0x14, 0x0c, 0x76}: "Freebox SAS",
+	{0x68, 0xa3, 0x78}: "Freebox SAS",
+	{0x70, 0xfc, 0x8f}: "Freebox SAS",
+	{0x8c, 0x97, 0xea}: "Freebox SAS",
+	{0xf4, 0xca, 0xe5}: "Freebox SAS",
+	{0xb8, 0x27, 0xeb}: "Raspberry Pi Foundation",
+	{0xdc, 0xa6, 0x32}: "Raspberry Pi Trading Ltd",
+	{0xe4, 0x5f, 0x01}: "Raspberry Pi Trading Ltd",
+	{0xd8, 0x3a, 0xdd}: "Raspberry Pi Trading Ltd",
+	{0x00, 0x50, 0x56}: "VMware, Inc.",
+	{0x00, 0x0c, 0x29}: "VMware, Inc.",
+	{0x00, 0x05, 0x69}: "VMware, Inc.",
+	{0x08, 0x00, 0x27}: "PCS Systemtechnik GmbH",
+	{0x00, 0x16, 0x3e}: "Xensource, Inc.",
+}
+
+// DefaultOUIDatabase returns a copy of the vendors of a few devices commonly found on the LAN of a box, such as the
+// freebox equipments and virtual machines. ParseOUIDatabase loads the full registry.
+func DefaultOUIDatabase() OUIDatabase {
+	return maps.Clone(defaultOUIDatabase)
+}
+
+// ParseOUIDatabase reads the registry published by the IEEE as oui.txt, where each vendor is listed on a line such as
+// "00-07-CB   (hex)		FREEBOX SAS". The other lines are ignored.
+func ParseOUIDatabase(reader io.Reader) (OUIDatabase, error) {
+	database := OUIDatabase{}
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		prefix, vendor, found := strings.Cut(scanner.Text(), "(hex)")
+		if !found {
+			continue
+		}
+
+		bytes, err := hex.DecodeString(strings.ReplaceAll(strings.TrimSpace(prefix), "-", ""))
+		if err != nil || len(bytes) != len(OUI{}) {
+			return nil, fmt.Errorf("invalid organizationally unique identifier %q", strings.TrimSpace(prefix))
+		}
+
+		database[OUI(bytes)] = strings.TrimSpace(vendor)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read OUI database: %w", err)
+	}
+
+	return database, nil
+}
+
+// Lookup returns the vendor of the mac address. Locally administered addresses, such as the random addresses of
+// smartphones, have no vendor.
+func (d OUIDatabase) Lookup(mac MACAddress) (string, bool) {
+	if len(mac) < len(OUI{}) || mac[0]&0x02 != 0 {
+		return "", false
+	}
+
+	vendor, ok := d[OUI(mac[:3])]
+
+	return vendor, ok
+}
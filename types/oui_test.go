@@ -0,0 +1,106 @@
+package types_test
+
+import (
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("oui database", func() {
+	Context("parsing the IEEE registry", func() {
+		var (
+			registry string
+
+			returnedDatabase types.OUIDatabase
+			returnedErr      error
+		)
+		JustBeforeEach(func() {
+			returnedDatabase, returnedErr = types.ParseOUIDatabase(strings.NewReader(registry))
+		})
+		Context("default", func() {
+			BeforeEach(func() {
+				registry = "OUI/MA-L\t\t\tOrganization\ncompany_id\t\t\tOrganization\n\t\t\t\tAddress\n\n" +
+					"00-07-CB   (hex)\t\tFREEBOX SAS\n0007CB     (base 16)\t\tFREEBOX SAS\n\t\t\t\t8 rue de la ville l'Eveque\n\n" +
+					"B8-27-EB   (hex)\t\tRaspberry Pi Foundation\nB827EB     (base 16)\t\tRaspberry Pi Foundation\n"
+			})
+			It("should return the vendors", func() {
+				Expect(returnedErr).To(BeNil())
+				Expect(returnedDatabase).To(Equal(types.OUIDatabase{
+					{0x00, 0x07, 0xcb}: "FREEBOX SAS",
+					{0xb8, 0x27, 0xeb}: "Raspberry Pi Foundation",
+				}))
+			})
+		})
+		Context("when an identifier is malformed", func() {
+			BeforeEach(func() {
+				registry = "00-07   (hex)\t\tFREEBOX SAS\n"
+			})
+			It("should return an error", func() {
+				Expect(returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("looking up a vendor", func() {
+		It("should find the vendor of a registered address", func() {
+			vendor, ok := types.DefaultOUIDatabase().Lookup(Must(types.ParseMACAddress("8c:97:ea:12:34:56")).(types.MACAddress))
+			Expect(ok).To(BeTrue())
+			Expect(vendor).To(Equal("Freebox SAS"))
+		})
+		It("should not find the vendor of a locally administered address", func() {
+			_, ok := types.OUIDatabase{{0x02, 0x07, 0xcb}: "foo"}.Lookup(Must(types.ParseMACAddress("02:07:cb:12:34:56")).(types.MACAddress))
+			Expect(ok).To(BeFalse())
+		})
+		It("should not find the vendor of an unknown address", func() {
+			_, ok := types.DefaultOUIDatabase().Lookup(Must(types.ParseMACAddress("00:11:22:33:44:55")).(types.MACAddress))
+			Expect(ok).To(BeFalse())
+		})
+	})
+	Context("identifying a lan host", func() {
+		var host types.LanInterfaceHost
+		BeforeEach(func() {
+			Expect(json.Unmarshal([]byte(`{
+				"id": "ether-b8:27:eb:01:02:03",
+				"vendor_name": "",
+				"interface": "pub",
+				"l2ident": {"id": "b8:27:eb:01:02:03", "type": "mac_address"},
+				"access_point": {
+					"mac": "8c:97:ea:00:00:01",
+					"type": "gateway",
+					"uid": "0",
+					"connectivity_type": "wifi",
+					"rx_bytes": 2048,
+					"tx_bytes": 4096,
+					"rx_rate": 125,
+					"tx_rate": 250,
+					"wifi_information": {"band": "5g", "ssid": "home", "signal": -52, "phy_rx_rate": 866, "phy_tx_rate": 780}
+				}
+			}`), &host)).To(Succeed())
+		})
+		It("should return its mac address", func() {
+			address, ok := host.MACAddress()
+			Expect(ok).To(BeTrue())
+			Expect(address.String()).To(Equal("b8:27:eb:01:02:03"))
+		})
+		It("should look its vendor up when the freebox does not know it", func() {
+			Expect(host.Vendor(types.DefaultOUIDatabase())).To(Equal("Raspberry Pi Foundation"))
+		})
+		It("should prefer the vendor known by the freebox", func() {
+			host.VendorName = "Raspberry Pi"
+			Expect(host.Vendor(types.DefaultOUIDatabase())).To(Equal("Raspberry Pi"))
+		})
+		It("should decode its access point", func() {
+			Expect(host.AccessPoint).ToNot(BeNil())
+			Expect(host.AccessPoint.MAC.String()).To(Equal("8c:97:ea:00:00:01"))
+			Expect(host.AccessPoint.ConnectivityType).To(Equal(types.HostConnectivityWifi))
+			Expect(host.AccessPoint.ReceiveRate).To(Equal(1000 * types.BitPerSecond))
+			Expect(host.AccessPoint.WifiInformation).To(Equal(&types.HostWifiInformation{
+				Band: "5g", SSID: "home", Signal: -52, PhyRxRate: 866, PhyTxRate: 780,
+			}))
+			Expect(host.AccessPoint.EthernetInformation).To(BeNil())
+		})
+	})
+})
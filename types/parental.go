@@ -0,0 +1,27 @@
+package types
+
+type parentalFilterMode string
+
+const (
+	ParentalFilterModeAllowed  parentalFilterMode = "allowed"  // Internet access is allowed
+	ParentalFilterModeDenied   parentalFilterMode = "denied"   // Internet access is denied
+	ParentalFilterModeWebOnly  parentalFilterMode = "webonly"  // Only web browsing is allowed
+	ParentalFilterModePlanning parentalFilterMode = "planning" // Internet access follows the planning of the filter
+)
+
+type ParentalConfig struct {
+	DefaultFilterMode parentalFilterMode `json:"default_filter_mode,omitempty"` // Mode applied to the devices without filter
+}
+
+type ParentalFilterPayload struct {
+	Description string             `json:"desc,omitempty"`   // Description of the filter, such as the name of the child
+	MACs        []MACAddress       `json:"macs,omitempty"`   // MAC addresses of the filtered devices
+	Mode        parentalFilterMode `json:"mode,omitempty"`   // Mode of the filter
+	Forced      *bool              `json:"forced,omitempty"` // Mode is forced regardless of the planning
+}
+
+type ParentalFilter struct {
+	ParentalFilterPayload
+	ID          int64              `json:"id"`           // Filter id
+	CurrentMode parentalFilterMode `json:"current_mode"` // Mode currently applied, resolved from the planning if needed
+}
@@ -0,0 +1,43 @@
+package types
+
+type phoneType string
+
+const (
+	PhoneTypeFXS  phoneType = "fxs"  // Analog phone plugged into the freebox
+	PhoneTypeDECT phoneType = "dect" // Cordless handset paired with the DECT base of the freebox
+)
+
+// PhoneStatus is the status of a phone line. A line reporting a hardware defect, or a phone stuck off hook, usually
+// means the landline is not usable.
+type PhoneStatus struct {
+	ID             int64     `json:"id"`              // Phone id
+	Type           phoneType `json:"type"`            // Type of phone
+	Vendor         string    `json:"vendor"`          // Phone vendor, when known
+	IsRinging      bool      `json:"is_ringing"`      // Phone is ringing
+	OnHook         bool      `json:"on_hook"`         // Phone is on hook
+	HardwareDefect bool      `json:"hardware_defect"` // Phone has a hardware defect
+	GainRX         int64     `json:"gain_rx"`         // Receiving gain
+	GainTX         int64     `json:"gain_tx"`         // Emitting gain
+}
+
+type PhoneConfigPayload struct {
+	Network          *bool  `json:"network,omitempty"`           // Network based telephony is enabled
+	DECTEnabled      *bool  `json:"dect_enabled,omitempty"`      // DECT base is enabled
+	DECTRegistration *bool  `json:"dect_registration,omitempty"` // DECT base accepts new handsets to be paired
+	DECTEcoMode      *bool  `json:"dect_eco_mode,omitempty"`     // DECT base emits at a lower power
+	DECTNemoMode     *bool  `json:"dect_nemo_mode,omitempty"`    // DECT base stops emitting while handsets are idle
+	DECTRingOnOff    *bool  `json:"dect_ring_on_off,omitempty"`  // DECT base rings on incoming calls
+	DECTPin          string `json:"dect_pin,omitempty"`          // PIN code required to pair a handset
+	DECTRingPattern  int64  `json:"dect_ring_pattern,omitempty"` // Ring pattern of the DECT base
+}
+
+type PhoneConfig struct {
+	Network          bool   `json:"network"`
+	DECTEnabled      bool   `json:"dect_enabled"`
+	DECTRegistration bool   `json:"dect_registration"`
+	DECTEcoMode      bool   `json:"dect_eco_mode"`
+	DECTNemoMode     bool   `json:"dect_nemo_mode"`
+	DECTRingOnOff    bool   `json:"dect_ring_on_off"`
+	DECTPin          string `json:"dect_pin"`
+	DECTRingPattern  int64  `json:"dect_ring_pattern"`
+}
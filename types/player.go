@@ -0,0 +1,69 @@
+package types
+
+type playerPowerState string
+
+const (
+	PlayerPowerStateRunning playerPowerState = "running" // Player is on
+	PlayerPowerStateStandby playerPowerState = "standby" // Player is in standby
+)
+
+type Player struct {
+	ID           int64  `json:"id"`            // Player id
+	DeviceName   string `json:"device_name"`   // Name of the player
+	DeviceModel  string `json:"device_model"`  // Model of the player
+	UID          string `json:"uid"`           // Unique identifier of the player
+	STBType      string `json:"stb_type"`      // Type of set-top box
+	APIVersion   string `json:"api_version"`   // Version of the API exposed by the player, such as 6.2
+	APIAvailable bool   `json:"api_available"` // Player exposes an API
+	Reachable    bool   `json:"reachable"`     // Player can be reached by the freebox
+}
+
+type PlayerStatus struct {
+	PowerState    playerPowerState    `json:"power_state"`    // Power state of the player
+	ForegroundApp PlayerForegroundApp `json:"foreground_app"` // Application displayed by the player
+}
+
+type PlayerForegroundApp struct {
+	PackageID int64            `json:"package_id"` // Id of the application
+	Package   string           `json:"package"`    // Package name of the application, such as fr.freebox.tv
+	CurlURL   string           `json:"curl_url"`   // URL opening the application
+	Context   PlayerAppContext `json:"context"`    // What the application is playing
+}
+
+type PlayerAppContext struct {
+	Channel *PlayerChannel `json:"channel,omitempty"` // Channel being watched, if any
+	Show    *PlayerShow    `json:"show,omitempty"`    // Show being watched, if any
+}
+
+type PlayerChannel struct {
+	Name   string `json:"channelName"`   // Name of the channel
+	Number int64  `json:"channelNumber"` // Number of the channel
+	UUID   string `json:"channelUuid"`   // UUID of the channel
+}
+
+type PlayerShow struct {
+	Title    string `json:"title"`    // Title of the show
+	Subtitle string `json:"subtitle"` // Subtitle of the show, such as the episode name
+}
+
+type PlayerKey string
+
+const (
+	PlayerKeyPlay      PlayerKey = "play"       // Resume the media
+	PlayerKeyPause     PlayerKey = "pause"      // Pause the media
+	PlayerKeyPlayPause PlayerKey = "play_pause" // Toggle between play and pause
+	PlayerKeyStop      PlayerKey = "stop"       // Stop the media
+	PlayerKeyNext      PlayerKey = "next"       // Go to the next media or channel
+	PlayerKeyPrevious  PlayerKey = "prev"       // Go to the previous media or channel
+	PlayerKeyRecord    PlayerKey = "record"     // Record the media
+)
+
+type PlayerVolumePayload struct {
+	Volume *int64 `json:"volume,omitempty"` // Volume, from 0 to 100
+	Mute   *bool  `json:"mute,omitempty"`   // Sound is muted
+}
+
+type PlayerVolume struct {
+	Volume int64 `json:"volume"` // Volume, from 0 to 100
+	Mute   bool  `json:"mute"`   // Sound is muted
+}
@@ -1,21 +1,57 @@
 package types
 
-type ipProtocol = string
+import (
+	"net/netip"
+	"slices"
+)
+
+// IPProtocol is the protocol of the traffic forwarded by a port forwarding rule.
+type IPProtocol string
 
 const (
-	TCP ipProtocol = "tcp"
-	UDP ipProtocol = "udp"
+	TCP IPProtocol = "tcp"
+	UDP IPProtocol = "udp"
+
+	// TCPAndUDP is not understood by the freebox, but stands for a pair of rules forwarding the same ports in TCP and
+	// in UDP. See IPProtocol.Expand and PortForwardingRuleBuilder.BuildAll.
+	TCPAndUDP IPProtocol = "tcp+udp"
 )
 
+var ipProtocols = []IPProtocol{TCP, UDP}
+
+// IPProtocols returns every protocol which can be forwarded by a port forwarding rule.
+func IPProtocols() []IPProtocol {
+	return slices.Clone(ipProtocols)
+}
+
+func (p IPProtocol) String() string {
+	return string(p)
+}
+
+// IsValid reports whether the protocol can be sent to the freebox.
+func (p IPProtocol) IsValid() bool {
+	return slices.Contains(ipProtocols, p)
+}
+
+// Expand returns the protocols of the rules to create for the protocol, which are TCP and UDP for TCPAndUDP and the
+// protocol itself otherwise.
+func (p IPProtocol) Expand() []IPProtocol {
+	if p == TCPAndUDP {
+		return []IPProtocol{TCP, UDP}
+	}
+
+	return []IPProtocol{p}
+}
+
 type PortForwardingRulePayload struct {
-	Enabled      *bool      `json:"enabled,omitempty"`
-	IPProtocol   ipProtocol `json:"ip_proto,omitempty"`
-	WanPortStart int64      `json:"wan_port_start,omitempty"`
-	WanPortEnd   int64      `json:"wan_port_end,omitempty"`
-	LanIP        string     `json:"lan_ip,omitempty"`
-	LanPort      int64      `json:"lan_port,omitempty"`
-	SourceIP     string     `json:"src_ip,omitempty"`
-	Comment      string     `json:"comment,omitempty"`
+	Enabled      Optional[bool]       `json:"enabled,omitempty"`
+	IPProtocol   IPProtocol           `json:"ip_proto,omitempty"`
+	WanPortStart int64                `json:"wan_port_start,omitempty"`
+	WanPortEnd   int64                `json:"wan_port_end,omitempty"`
+	LanIP        Optional[netip.Addr] `json:"lan_ip,omitempty"`
+	LanPort      int64                `json:"lan_port,omitempty"`
+	SourceIP     Optional[netip.Addr] `json:"src_ip,omitempty"`
+	Comment      string               `json:"comment,omitempty"`
 }
 
 type PortForwardingRule struct {
@@ -0,0 +1,152 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"unicode/utf8"
+)
+
+var ErrInvalidPortForwardingRule = errors.New("invalid port forwarding rule")
+
+const (
+	// PortForwardingCommentMaxLength is the maximum number of characters of the comment of a port forwarding rule.
+	PortForwardingCommentMaxLength = 255
+
+	minPort = 1
+	maxPort = 65535
+)
+
+// PortForwardingRuleBuilder builds a PortForwardingRulePayload and validates it on Build.
+type PortForwardingRuleBuilder struct {
+	payload PortForwardingRulePayload
+}
+
+// NewPortForwardingRule starts building an enabled rule forwarding the same port of the WAN to the given LAN host, in
+// TCP and from any source address, until told otherwise.
+func NewPortForwardingRule(lanIP netip.Addr, lanPort int64) *PortForwardingRuleBuilder {
+	return &PortForwardingRuleBuilder{
+		payload: PortForwardingRulePayload{
+			Enabled:      NewOptional(true),
+			IPProtocol:   TCP,
+			WanPortStart: lanPort,
+			WanPortEnd:   lanPort,
+			LanIP:        NewOptional(lanIP),
+			LanPort:      lanPort,
+			SourceIP:     NewOptional(netip.IPv4Unspecified()),
+		},
+	}
+}
+
+// WithProtocol sets the IP protocol of the forwarded traffic.
+func (b *PortForwardingRuleBuilder) WithProtocol(protocol IPProtocol) *PortForwardingRuleBuilder {
+	b.payload.IPProtocol = protocol
+
+	return b
+}
+
+// WithWanPort forwards a single port of the WAN.
+func (b *PortForwardingRuleBuilder) WithWanPort(port int64) *PortForwardingRuleBuilder {
+	return b.WithWanPortRange(port, port)
+}
+
+// WithWanPortRange forwards a range of ports of the WAN, both ends included, starting at the port of the LAN host.
+func (b *PortForwardingRuleBuilder) WithWanPortRange(start, end int64) *PortForwardingRuleBuilder {
+	b.payload.WanPortStart = start
+	b.payload.WanPortEnd = end
+
+	return b
+}
+
+// WithSourceIP only forwards the traffic coming from the given address.
+func (b *PortForwardingRuleBuilder) WithSourceIP(sourceIP netip.Addr) *PortForwardingRuleBuilder {
+	b.payload.SourceIP = NewOptional(sourceIP)
+
+	return b
+}
+
+// WithComment sets the comment of the rule.
+func (b *PortForwardingRuleBuilder) WithComment(comment string) *PortForwardingRuleBuilder {
+	b.payload.Comment = comment
+
+	return b
+}
+
+// Disabled creates the rule without enabling it.
+func (b *PortForwardingRuleBuilder) Disabled() *PortForwardingRuleBuilder {
+	b.payload.Enabled = NewOptional(false)
+
+	return b
+}
+
+// Build validates and returns the payload.
+func (b *PortForwardingRuleBuilder) Build() (PortForwardingRulePayload, error) {
+	if err := b.payload.Validate(); err != nil {
+		return PortForwardingRulePayload{}, err
+	}
+
+	return b.payload, nil
+}
+
+// BuildAll validates and returns one payload per protocol of the rule, such as a pair of payloads forwarding the same
+// ports in TCP and in UDP when the protocol is TCPAndUDP.
+func (b *PortForwardingRuleBuilder) BuildAll() ([]PortForwardingRulePayload, error) {
+	payloads := []PortForwardingRulePayload{}
+
+	for _, protocol := range b.payload.IPProtocol.Expand() {
+		payload := b.payload
+		payload.IPProtocol = protocol
+
+		if err := payload.Validate(); err != nil {
+			return nil, err
+		}
+
+		payloads = append(payloads, payload)
+	}
+
+	return payloads, nil
+}
+
+// Validate checks the fields set in the payload can be sent to the freebox. Unset fields are not checked, so that a
+// payload only updating some fields of a rule is valid.
+func (p PortForwardingRulePayload) Validate() error {
+	errs := []error{}
+
+	switch {
+	case p.IPProtocol == TCPAndUDP:
+		errs = append(errs, fmt.Errorf("%w: protocol %q must be expanded into one rule per protocol", ErrInvalidPortForwardingRule, p.IPProtocol))
+	case p.IPProtocol != "" && !p.IPProtocol.IsValid():
+		errs = append(errs, fmt.Errorf("%w: unsupported protocol %q", ErrInvalidPortForwardingRule, p.IPProtocol))
+	}
+
+	for _, port := range []struct {
+		name  string
+		value int64
+	}{
+		{"wan_port_start", p.WanPortStart},
+		{"wan_port_end", p.WanPortEnd},
+		{"lan_port", p.LanPort},
+	} {
+		if port.value != 0 && (port.value < minPort || port.value > maxPort) {
+			errs = append(errs, fmt.Errorf("%w: %s %d is not between %d and %d", ErrInvalidPortForwardingRule, port.name, port.value, minPort, maxPort))
+		}
+	}
+
+	if p.WanPortStart != 0 && p.WanPortEnd != 0 && p.WanPortStart > p.WanPortEnd {
+		errs = append(errs, fmt.Errorf("%w: wan_port_start %d is after wan_port_end %d", ErrInvalidPortForwardingRule, p.WanPortStart, p.WanPortEnd))
+	}
+
+	if lanIP, ok := p.LanIP.Get(); ok && (!lanIP.Is4() || lanIP.IsUnspecified()) {
+		errs = append(errs, fmt.Errorf("%w: lan_ip %q is not the IPv4 address of a host", ErrInvalidPortForwardingRule, lanIP))
+	}
+
+	if sourceIP, ok := p.SourceIP.Get(); ok && !sourceIP.Is4() {
+		errs = append(errs, fmt.Errorf("%w: src_ip %q is not an IPv4 address", ErrInvalidPortForwardingRule, sourceIP))
+	}
+
+	if length := utf8.RuneCountInString(p.Comment); length > PortForwardingCommentMaxLength {
+		errs = append(errs, fmt.Errorf("%w: comment is %d characters long, at most %d are allowed", ErrInvalidPortForwardingRule, length, PortForwardingCommentMaxLength))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,156 @@
+package types_test
+
+import (
+	"errors"
+	"net/netip"
+	"strings"
+
+	"github.com/nikolalohinski/free-go/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("port forwarding rule builder", func() {
+	var (
+		builder *types.PortForwardingRuleBuilder
+
+		returnedPayload = new(types.PortForwardingRulePayload)
+		returnedErr     = new(error)
+	)
+	JustBeforeEach(func() {
+		*returnedPayload, *returnedErr = builder.Build()
+	})
+	Context("when only the LAN host is given", func() {
+		BeforeEach(func() {
+			builder = types.NewPortForwardingRule(netip.MustParseAddr("192.168.1.10"), 443)
+		})
+		It("should fill the defaults", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*returnedPayload).To(Equal(types.PortForwardingRulePayload{
+				Enabled:      types.NewOptional(true),
+				IPProtocol:   types.TCP,
+				WanPortStart: 443,
+				WanPortEnd:   443,
+				LanIP:        types.NewOptional(netip.MustParseAddr("192.168.1.10")),
+				LanPort:      443,
+				SourceIP:     types.NewOptional(netip.MustParseAddr("0.0.0.0")),
+			}))
+		})
+	})
+	Context("when every field is set", func() {
+		BeforeEach(func() {
+			builder = types.NewPortForwardingRule(netip.MustParseAddr("192.168.1.10"), 27015).
+				WithProtocol(types.UDP).
+				WithWanPortRange(27015, 27030).
+				WithSourceIP(netip.MustParseAddr("203.0.113.7")).
+				WithComment("game server").
+				Disabled()
+		})
+		It("should return the correct payload", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*returnedPayload).To(Equal(types.PortForwardingRulePayload{
+				Enabled:      types.NewOptional(false),
+				IPProtocol:   types.UDP,
+				WanPortStart: 27015,
+				WanPortEnd:   27030,
+				LanIP:        types.NewOptional(netip.MustParseAddr("192.168.1.10")),
+				LanPort:      27015,
+				SourceIP:     types.NewOptional(netip.MustParseAddr("203.0.113.7")),
+				Comment:      "game server",
+			}))
+		})
+	})
+	Context("when a single WAN port is given", func() {
+		BeforeEach(func() {
+			builder = types.NewPortForwardingRule(netip.MustParseAddr("192.168.1.10"), 22).WithWanPort(2222)
+		})
+		It("should forward it to the LAN port", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(returnedPayload.WanPortStart).To(Equal(int64(2222)))
+			Expect(returnedPayload.WanPortEnd).To(Equal(int64(2222)))
+			Expect(returnedPayload.LanPort).To(Equal(int64(22)))
+		})
+	})
+	Context("when the protocol is not supported", func() {
+		BeforeEach(func() {
+			builder = types.NewPortForwardingRule(netip.MustParseAddr("192.168.1.10"), 443).WithProtocol("icmp")
+		})
+		It("should return an error", func() {
+			Expect(errors.Is(*returnedErr, types.ErrInvalidPortForwardingRule)).To(BeTrue())
+		})
+	})
+	Context("when the rule forwards both tcp and udp", func() {
+		BeforeEach(func() {
+			builder = types.NewPortForwardingRule(netip.MustParseAddr("192.168.1.10"), 53).WithProtocol(types.TCPAndUDP)
+		})
+		It("should ask for one rule per protocol", func() {
+			Expect(errors.Is(*returnedErr, types.ErrInvalidPortForwardingRule)).To(BeTrue())
+		})
+		It("should build a pair of rules", func() {
+			payloads, err := builder.BuildAll()
+			Expect(err).To(BeNil())
+			Expect(payloads).To(HaveLen(2))
+			Expect(payloads[0].IPProtocol).To(Equal(types.TCP))
+			Expect(payloads[1].IPProtocol).To(Equal(types.UDP))
+			Expect(payloads[0].WanPortStart).To(Equal(payloads[1].WanPortStart))
+			Expect(payloads[0].LanIP).To(Equal(payloads[1].LanIP))
+		})
+	})
+	Context("when building every rule of an invalid rule", func() {
+		BeforeEach(func() {
+			builder = types.NewPortForwardingRule(netip.MustParseAddr("192.168.1.10"), 53).
+				WithProtocol(types.TCPAndUDP).
+				WithWanPortRange(60, 50)
+		})
+		It("should return an error", func() {
+			payloads, err := builder.BuildAll()
+			Expect(errors.Is(err, types.ErrInvalidPortForwardingRule)).To(BeTrue())
+			Expect(payloads).To(BeNil())
+		})
+	})
+	Context("when the WAN port range is reversed", func() {
+		BeforeEach(func() {
+			builder = types.NewPortForwardingRule(netip.MustParseAddr("192.168.1.10"), 443).WithWanPortRange(8443, 8080)
+		})
+		It("should return an error", func() {
+			Expect(errors.Is(*returnedErr, types.ErrInvalidPortForwardingRule)).To(BeTrue())
+			Expect((*returnedErr).Error()).To(ContainSubstring("wan_port_start 8443 is after wan_port_end 8080"))
+		})
+	})
+	Context("when a port is out of range", func() {
+		BeforeEach(func() {
+			builder = types.NewPortForwardingRule(netip.MustParseAddr("192.168.1.10"), 70000)
+		})
+		It("should return an error", func() {
+			Expect(errors.Is(*returnedErr, types.ErrInvalidPortForwardingRule)).To(BeTrue())
+		})
+	})
+	for _, lanIP := range []netip.Addr{{}, netip.MustParseAddr("0.0.0.0"), netip.MustParseAddr("fe80::1")} {
+		lanIP := lanIP
+		Context("when the LAN address is "+lanIP.String(), func() {
+			BeforeEach(func() {
+				builder = types.NewPortForwardingRule(lanIP, 443)
+			})
+			It("should return an error", func() {
+				Expect(errors.Is(*returnedErr, types.ErrInvalidPortForwardingRule)).To(BeTrue())
+			})
+		})
+	}
+	Context("when the comment is too long", func() {
+		BeforeEach(func() {
+			builder = types.NewPortForwardingRule(netip.MustParseAddr("192.168.1.10"), 443).
+				WithComment(strings.Repeat("é", types.PortForwardingCommentMaxLength+1))
+		})
+		It("should return an error", func() {
+			Expect(errors.Is(*returnedErr, types.ErrInvalidPortForwardingRule)).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("port forwarding rule payload", func() {
+	Context("when it only updates some fields", func() {
+		It("should be valid", func() {
+			Expect(types.PortForwardingRulePayload{Comment: "updated"}.Validate()).To(Succeed())
+		})
+	})
+})
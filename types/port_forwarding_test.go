@@ -0,0 +1,42 @@
+package types_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nikolalohinski/free-go/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("port forwarding", func() {
+	Context("ip protocols", func() {
+		It("should list every protocol", func() {
+			Expect(types.IPProtocols()).To(ConsistOf(types.TCP, types.UDP))
+		})
+		for _, protocol := range types.IPProtocols() {
+			protocol := protocol
+			It(fmt.Sprintf("should round trip the %s protocol through json", protocol), func() {
+				Expect(protocol.IsValid()).To(BeTrue())
+
+				bytes, err := json.Marshal(types.PortForwardingRulePayload{IPProtocol: protocol})
+				Expect(err).To(BeNil())
+				Expect(string(bytes)).To(Equal(fmt.Sprintf(`{"ip_proto":%q}`, protocol.String())))
+
+				var decoded types.PortForwardingRule
+				Expect(json.Unmarshal([]byte(fmt.Sprintf(`{"ip_proto": %q}`, protocol.String())), &decoded)).To(Succeed())
+				Expect(decoded.IPProtocol).To(Equal(protocol))
+			})
+			It(fmt.Sprintf("should expand the %s protocol to itself", protocol), func() {
+				Expect(protocol.Expand()).To(Equal([]types.IPProtocol{protocol}))
+			})
+		}
+		It("should expand both protocols into tcp and udp", func() {
+			Expect(types.TCPAndUDP.Expand()).To(Equal([]types.IPProtocol{types.TCP, types.UDP}))
+		})
+		It("should not validate unknown protocols", func() {
+			Expect(types.IPProtocol("icmp").IsValid()).To(BeFalse())
+			Expect(types.TCPAndUDP.IsValid()).To(BeFalse())
+		})
+	})
+})
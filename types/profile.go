@@ -0,0 +1,11 @@
+package types
+
+type ProfilePayload struct {
+	Name string `json:"name,omitempty"` // Name of the profile, such as the name of a family member
+	Icon string `json:"icon,omitempty"` // Path of the icon of the profile
+}
+
+type Profile struct {
+	ProfilePayload
+	ID int64 `json:"id"` // Profile id
+}
@@ -0,0 +1,49 @@
+package types
+
+type pvrRecordState string
+
+const (
+	PVRRecordStateWaitingStartTime pvrRecordState = "waiting_start_time" // Waiting for the start of the recording
+	PVRRecordStateStarting         pvrRecordState = "starting"           // Recording is starting
+	PVRRecordStateRunning          pvrRecordState = "running"            // Recording is in progress
+	PVRRecordStateRunningError     pvrRecordState = "running_error"      // Recording is in progress but encountered an error
+	PVRRecordStateFinished         pvrRecordState = "finished"           // Recording is done
+	PVRRecordStateFailed           pvrRecordState = "failed"             // Recording failed (see error)
+)
+
+type pvrRecordError string
+
+const (
+	PVRRecordErrorNone            pvrRecordError = "none"             // No error
+	PVRRecordErrorResourceMissing pvrRecordError = "resource_missing" // A resource required for the recording was not available
+	PVRRecordErrorTunerFailed     pvrRecordError = "tuner_failed"     // Tuner failed
+	PVRRecordErrorDiskFull        pvrRecordError = "disk_full"        // Not enough space on the recordings partition
+	PVRRecordErrorDiskMissing     pvrRecordError = "disk_missing"     // Recordings partition not found
+	PVRRecordErrorInternal        pvrRecordError = "internal"         // Internal error
+)
+
+type PVRFinishedRecordPayload struct {
+	Name    string `json:"name,omitempty"`    // Record name
+	Subname string `json:"subname,omitempty"` // Record episode name
+}
+
+type PVRFinishedRecord struct {
+	PVRFinishedRecordPayload
+	ID             int64          `json:"id"`              // Record id
+	Start          Timestamp      `json:"start"`           // Record start date and time
+	End            Timestamp      `json:"end"`             // Record end date and time
+	ChannelNumber  int64          `json:"channel_number"`  // Number of the recorded channel
+	ChannelName    string         `json:"channel_name"`    // Name of the recorded channel
+	ChannelUUID    string         `json:"channel_uuid"`    // UUID of the recorded channel
+	ChannelType    string         `json:"channel_type"`    // Type of the recorded channel
+	ChannelQuality string         `json:"channel_quality"` // Quality of the recorded channel
+	ChannelSource  string         `json:"channel_source"`  // Source of the recorded channel
+	BroadcastType  string         `json:"broadcast_type"`  // Broadcast type of the recorded channel
+	Media          string         `json:"media"`           // Name of the disk the record is stored on
+	Path           Base64Path     `json:"path"`            // Directory of the record file
+	Filename       string         `json:"filename"`        // Name of the record file
+	ByteSize       int64          `json:"byte_size"`       // Size of the record file in bytes
+	Secure         bool           `json:"secure"`          // Record is protected by the parental code
+	State          pvrRecordState `json:"state"`           // Record state
+	Error          pvrRecordError `json:"error"`           // Record error
+}
@@ -0,0 +1,17 @@
+package types
+
+import "time"
+
+type ShareLink struct {
+	Token   string     `json:"token"`   // The link token, used to identify the link
+	Path    Base64Path `json:"path"`    // The shared path
+	Name    string     `json:"name"`    // The shared file name
+	Expire  Timestamp  `json:"expire"`  // Link expiration date (epoch 0 means the link never expires)
+	FullURL string     `json:"fullurl"` // The public URL of the link
+}
+
+type ShareLinkRequest struct {
+	Path    string    // The path to share
+	Expire  time.Time // The link expiration date (optional: the link never expires when left empty)
+	FullURL string    // Override the public URL of the link (optional: computed by the server by default)
+}
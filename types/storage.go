@@ -0,0 +1,25 @@
+package types
+
+type StoragePartitionState string
+
+const (
+	StoragePartitionStateError      StoragePartitionState = "error"      // the partition could not be mounted
+	StoragePartitionStateMounted    StoragePartitionState = "mounted"    // the partition is mounted and usable
+	StoragePartitionStateUmounted   StoragePartitionState = "umounted"   // the partition is not mounted
+	StoragePartitionStateMounting   StoragePartitionState = "mounting"   // the partition is being mounted
+	StoragePartitionStateUmounting  StoragePartitionState = "umounting"  // the partition is being unmounted
+	StoragePartitionStateChecking   StoragePartitionState = "checking"   // the file system of the partition is being checked
+	StoragePartitionStateFormatting StoragePartitionState = "formatting" // the partition is being formatted
+)
+
+type StoragePartition struct {
+	ID         int64                 `json:"id"`          // partition identifier
+	DiskID     int64                 `json:"disk_id"`     // identifier of the disk holding the partition
+	State      StoragePartitionState `json:"state"`       // state of the partition
+	FSType     string                `json:"fstype"`      // file system of the partition, such as ext4
+	Label      string                `json:"label"`       // label of the partition
+	Path       Base64Path            `json:"path"`        // path of the partition in the filesystem API (base64 encoded)
+	TotalBytes ByteSize              `json:"total_bytes"` // size of the partition
+	UsedBytes  ByteSize              `json:"used_bytes"`  // space used on the partition
+	FreeBytes  ByteSize              `json:"free_bytes"`  // space left on the partition
+}
@@ -0,0 +1,14 @@
+package types
+
+// TransferProgress reports the progress of a file transfer.
+type TransferProgress struct {
+	TransferredBytes int64 // Bytes transferred so far
+	TotalBytes       int64 // Total bytes to transfer, -1 when unknown
+}
+
+type DownloadDirectoryOptions struct {
+	Include     []string                                           // Optional path.Match patterns, relative to the downloaded directory, a file must match to be downloaded
+	Exclude     []string                                           // Optional path.Match patterns, relative to the downloaded directory, of files and directories to skip
+	Concurrency int                                                // Number of files downloaded in parallel (defaults to 1)
+	Progress    func(remotePath string, progress TransferProgress) // Optional per-file progress callback, called concurrently when Concurrency > 1
+}
@@ -0,0 +1,192 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrMemorySizeTooSmall = errors.New("memory size is smaller than a megabyte")
+
+// ByteSize is an amount of data, in bytes.
+type ByteSize int64
+
+// Units of ByteSize and MemorySize. They are untyped so that expressions such as 512 * Megabyte fit both.
+const (
+	Byte     = 1
+	Kilobyte = 1024 * Byte
+	Megabyte = 1024 * Kilobyte
+	Gigabyte = 1024 * Megabyte
+	Terabyte = 1024 * Gigabyte
+)
+
+// Bytes returns the size as a number of bytes.
+func (s ByteSize) Bytes() int64 {
+	return int64(s)
+}
+
+// Kilobytes returns the size as a number of kilobytes (1024 bytes).
+func (s ByteSize) Kilobytes() float64 {
+	return float64(s) / float64(Kilobyte)
+}
+
+// Megabytes returns the size as a number of megabytes (1024 kilobytes).
+func (s ByteSize) Megabytes() float64 {
+	return float64(s) / float64(Megabyte)
+}
+
+// Gigabytes returns the size as a number of gigabytes (1024 megabytes).
+func (s ByteSize) Gigabytes() float64 {
+	return float64(s) / float64(Gigabyte)
+}
+
+// String formats the size with the largest binary unit it holds at least one of, such as 1.5 GiB.
+func (s ByteSize) String() string {
+	units := []struct {
+		size   ByteSize
+		symbol string
+	}{
+		{Terabyte, "TiB"},
+		{Gigabyte, "GiB"},
+		{Megabyte, "MiB"},
+		{Kilobyte, "KiB"},
+	}
+
+	for _, unit := range units {
+		if s >= unit.size || s <= -unit.size {
+			return fmt.Sprintf("%.1f %s", float64(s)/float64(unit.size), unit.symbol)
+		}
+	}
+
+	return fmt.Sprintf("%d B", int64(s))
+}
+
+// MemorySize is an amount of memory, in bytes. It is exchanged with the freebox as a whole number of megabytes, so a
+// size such as 2048 is 2 KiB and not 2 GiB: use the units, as in 2 * Gigabyte.
+type MemorySize ByteSize
+
+// Bytes returns the size as a number of bytes.
+func (s MemorySize) Bytes() int64 {
+	return int64(s)
+}
+
+// Megabytes returns the size as a number of megabytes (1024 kilobytes).
+func (s MemorySize) Megabytes() float64 {
+	return ByteSize(s).Megabytes()
+}
+
+// Gigabytes returns the size as a number of gigabytes (1024 megabytes).
+func (s MemorySize) Gigabytes() float64 {
+	return ByteSize(s).Gigabytes()
+}
+
+func (s MemorySize) String() string {
+	return ByteSize(s).String()
+}
+
+// MarshalJSON sends the size as a number of megabytes, rounded down. A size which is not zero but smaller than a
+// megabyte is rejected with ErrMemorySizeTooSmall, rather than silently sent as zero.
+func (s MemorySize) MarshalJSON() ([]byte, error) {
+	if s != 0 && s > -Megabyte && s < Megabyte {
+		return nil, fmt.Errorf("%d bytes: %w", int64(s), ErrMemorySizeTooSmall)
+	}
+
+	return json.Marshal(int64(s) / int64(Megabyte)) //nolint:wrapcheck
+}
+
+func (s *MemorySize) UnmarshalJSON(data []byte) error {
+	var megabytes int64
+	if err := json.Unmarshal(data, &megabytes); err != nil {
+		return fmt.Errorf("failed to unmarshal memory size: %w", err)
+	}
+
+	*s = MemorySize(megabytes * Megabyte)
+
+	return nil
+}
+
+// BitRate is a data transfer rate, in bits per second. It is exchanged with the freebox in bytes per second.
+type BitRate int64
+
+const (
+	BitPerSecond     BitRate = 1
+	BytePerSecond            = 8 * BitPerSecond
+	KilobitPerSecond         = 1000 * BitPerSecond
+	MegabitPerSecond         = 1000 * KilobitPerSecond
+	GigabitPerSecond         = 1000 * MegabitPerSecond
+)
+
+// BitsPerSecond returns the rate as a number of bits per second.
+func (r BitRate) BitsPerSecond() int64 {
+	return int64(r)
+}
+
+// BytesPerSecond returns the rate as a number of bytes per second.
+func (r BitRate) BytesPerSecond() int64 {
+	return int64(r / BytePerSecond)
+}
+
+// Kilobits returns the rate as a number of kilobits (1000 bits) per second.
+func (r BitRate) Kilobits() float64 {
+	return float64(r) / float64(KilobitPerSecond)
+}
+
+// Megabits returns the rate as a number of megabits (1000 kilobits) per second.
+func (r BitRate) Megabits() float64 {
+	return float64(r) / float64(MegabitPerSecond)
+}
+
+// String formats the rate with the largest decimal unit it holds at least one of, such as 12.5 Mbit/s.
+func (r BitRate) String() string {
+	units := []struct {
+		rate   BitRate
+		symbol string
+	}{
+		{GigabitPerSecond, "Gbit/s"},
+		{MegabitPerSecond, "Mbit/s"},
+		{KilobitPerSecond, "kbit/s"},
+	}
+
+	for _, unit := range units {
+		if r >= unit.rate || r <= -unit.rate {
+			return fmt.Sprintf("%.1f %s", float64(r)/float64(unit.rate), unit.symbol)
+		}
+	}
+
+	return fmt.Sprintf("%d bit/s", int64(r))
+}
+
+func (r BitRate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.BytesPerSecond()) //nolint:wrapcheck
+}
+
+func (r *BitRate) UnmarshalJSON(data []byte) error {
+	var bytesPerSecond int64
+	if err := json.Unmarshal(data, &bytesPerSecond); err != nil {
+		return fmt.Errorf("failed to unmarshal bit rate: %w", err)
+	}
+
+	*r = BitRate(bytesPerSecond) * BytePerSecond
+
+	return nil
+}
+
+// Seconds is a duration reported by the freebox as a whole number of seconds, such as the estimated time left of a
+// task. The raw number is kept as is, Duration converts it.
+type Seconds int64
+
+// Seconds returns the raw number of seconds.
+func (s Seconds) Seconds() int64 {
+	return int64(s)
+}
+
+// Duration returns the number of seconds as a time.Duration.
+func (s Seconds) Duration() time.Duration {
+	return time.Duration(s) * time.Second
+}
+
+// String formats the duration such as 1h2m3s.
+func (s Seconds) String() string {
+	return s.Duration().String()
+}
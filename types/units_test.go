@@ -0,0 +1,165 @@
+package types_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/nikolalohinski/free-go/types"
+)
+
+var _ = Describe("units", func() {
+	returnedErr := new(error)
+	Context("byte sizes", func() {
+		It("should convert to larger units", func() {
+			size := types.ByteSize(1536 * types.Megabyte)
+			Expect(size.Bytes()).To(Equal(int64(1610612736)))
+			Expect(size.Megabytes()).To(Equal(1536.0))
+			Expect(size.Gigabytes()).To(Equal(1.5))
+		})
+		for size, expected := range map[types.ByteSize]string{
+			0:                     "0 B",
+			512:                   "512 B",
+			1536:                  "1.5 KiB",
+			10 * types.Megabyte:   "10.0 MiB",
+			1536 * types.Megabyte: "1.5 GiB",
+			2 * types.Terabyte:    "2.0 TiB",
+		} {
+			size, expected := size, expected
+			It("should format "+expected, func() {
+				Expect(size.String()).To(Equal(expected))
+			})
+		}
+	})
+	Context("json marshal/unmarshal of memory sizes", func() {
+		Context("when marshaling", func() {
+			var (
+				bytes []byte
+				size  types.MemorySize
+			)
+			BeforeEach(func() {
+				size = 512 * types.Megabyte
+			})
+			JustBeforeEach(func() {
+				bytes, *returnedErr = json.Marshal(size)
+			})
+			It("should return the size in megabytes", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(string(bytes)).To(Equal("512"))
+			})
+			Context("when the size is zero", func() {
+				BeforeEach(func() {
+					size = 0
+				})
+				It("should return zero", func() {
+					Expect(*returnedErr).To(BeNil())
+					Expect(string(bytes)).To(Equal("0"))
+				})
+			})
+			Context("when the size is smaller than a megabyte", func() {
+				BeforeEach(func() {
+					size = 2048
+				})
+				It("should return the correct error", func() {
+					Expect(errors.Is(*returnedErr, types.ErrMemorySizeTooSmall)).To(BeTrue())
+				})
+			})
+		})
+		Context("when unmarshaling", func() {
+			var (
+				payload []byte
+
+				size *types.MemorySize
+			)
+			BeforeEach(func() {
+				size = new(types.MemorySize)
+			})
+			JustBeforeEach(func() {
+				*returnedErr = json.Unmarshal(payload, size)
+			})
+			Context("when the size is an integer", func() {
+				BeforeEach(func() {
+					payload = []byte(`2048`)
+				})
+				It("should return the size in bytes", func() {
+					Expect(*returnedErr).To(BeNil())
+					Expect(*size).To(Equal(types.MemorySize(2 * types.Gigabyte)))
+					Expect(size.String()).To(Equal("2.0 GiB"))
+				})
+			})
+			Context("when the size is not an integer", func() {
+				BeforeEach(func() {
+					payload = []byte(`"foobar"`)
+				})
+				It("should return an error", func() {
+					Expect(*returnedErr).ToNot(BeNil())
+				})
+			})
+		})
+	})
+	Context("json marshal/unmarshal of bit rates", func() {
+		Context("when marshaling", func() {
+			var bytes []byte
+			JustBeforeEach(func() {
+				bytes, *returnedErr = json.Marshal(8 * types.MegabitPerSecond)
+			})
+			It("should return the rate in bytes per second", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(string(bytes)).To(Equal("1000000"))
+			})
+		})
+		Context("when unmarshaling", func() {
+			var (
+				payload []byte
+
+				rate *types.BitRate
+			)
+			BeforeEach(func() {
+				rate = new(types.BitRate)
+			})
+			JustBeforeEach(func() {
+				*returnedErr = json.Unmarshal(payload, rate)
+			})
+			Context("when the rate is an integer", func() {
+				BeforeEach(func() {
+					payload = []byte(`1562500`)
+				})
+				It("should return the rate in bits per second", func() {
+					Expect(*returnedErr).To(BeNil())
+					Expect(rate.BitsPerSecond()).To(Equal(int64(12500000)))
+					Expect(rate.Megabits()).To(Equal(12.5))
+					Expect(rate.String()).To(Equal("12.5 Mbit/s"))
+				})
+			})
+			Context("when the rate is not an integer", func() {
+				BeforeEach(func() {
+					payload = []byte(`"foobar"`)
+				})
+				It("should return an error", func() {
+					Expect(*returnedErr).ToNot(BeNil())
+				})
+			})
+		})
+	})
+	Context("seconds", func() {
+		It("should convert to a duration", func() {
+			seconds := types.Seconds(3723)
+			Expect(seconds.Seconds()).To(Equal(int64(3723)))
+			Expect(seconds.Duration()).To(Equal(time.Hour + 2*time.Minute + 3*time.Second))
+			Expect(seconds.String()).To(Equal("1h2m3s"))
+		})
+		It("should keep the raw value through json", func() {
+			var task types.DownloadTask
+			Expect(json.Unmarshal([]byte(`{"eta": 90}`), &task)).To(Succeed())
+			Expect(task.ETASeconds).To(Equal(types.Seconds(90)))
+			Expect(task.ETASeconds.Duration()).To(Equal(90 * time.Second))
+
+			bytes, err := json.Marshal(types.CallEntry{Duration: 42})
+			Expect(err).To(BeNil())
+			Expect(string(bytes)).To(ContainSubstring(`"duration":42`))
+		})
+	})
+})
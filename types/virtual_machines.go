@@ -3,22 +3,25 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"path"
+	"slices"
+	"strings"
 )
 
 type VirtualMachinesInfo struct {
-	USBUsed     bool     `json:"usb_used"`
-	SATAUsed    bool     `json:"sata_used"`
-	SATAPorts   []string `json:"sata_ports"`
-	UsedMemory  int64    `json:"used_memory"`
-	USBPorts    []string `json:"usb_ports"`
-	UsedCPUs    int64    `json:"used_cpus"`
-	TotalMemory int64    `json:"total_memory"`
-	TotalCPUs   int64    `json:"total_cpus"`
+	USBUsed     bool       `json:"usb_used"`
+	SATAUsed    bool       `json:"sata_used"`
+	SATAPorts   []string   `json:"sata_ports"`
+	UsedMemory  MemorySize `json:"used_memory"`
+	USBPorts    []string   `json:"usb_ports"`
+	UsedCPUs    int64      `json:"used_cpus"`
+	TotalMemory MemorySize `json:"total_memory"`
+	TotalCPUs   int64      `json:"total_cpus"`
 }
 
 type VirtualMachineDistribution struct {
 	Hash string `json:"hash"`
-	OS   string `json:"os"`
+	OS   OS     `json:"os"`
 	URL  string `json:"url"`
 	Name string `json:"name"`
 }
@@ -29,56 +32,137 @@ const (
 	EventStateChanged eventName = "state_changed"
 )
 
-type diskType = string
+// VirtualMachineStateChanged is the result of a vm state_changed event notification.
+type VirtualMachineStateChanged struct {
+	ID     int64         `json:"id"`
+	Status MachineStatus `json:"status"`
+	Error  error         `json:"-"` // Set when watching the virtual machine failed, the other fields are then empty
+}
+
+// DecodeVirtualMachineStateChanged decodes the result of a vm state_changed notification.
+func (n EventNotification) DecodeVirtualMachineStateChanged() (VirtualMachineStateChanged, error) {
+	var result VirtualMachineStateChanged
+
+	err := n.decode(EventSourceVM, EventStateChanged, &result)
+
+	return result, err
+}
+
+type DiskType string
 
 const (
-	RawDisk   diskType = "raw"   // Raw disk data.
-	QCow2Disk diskType = "qcow2" // Qcow2 image type. Usually qcow version 3. Note: not all features are supported. In particular, reference to other images is disabled.
+	RawDisk   DiskType = "raw"   // Raw disk data.
+	QCow2Disk DiskType = "qcow2" // Qcow2 image type. Usually qcow version 3. Note: not all features are supported. In particular, reference to other images is disabled.
 )
 
-type os = string
+var diskTypes = []DiskType{RawDisk, QCow2Disk}
+
+// DiskTypes returns every disk type supported by the freebox.
+func DiskTypes() []DiskType {
+	return slices.Clone(diskTypes)
+}
+
+func (t DiskType) String() string {
+	return string(t)
+}
+
+// IsValid reports whether the disk type is supported by the freebox.
+func (t DiskType) IsValid() bool {
+	return slices.Contains(diskTypes, t)
+}
+
+// DiskTypeFromPath guesses the type of a disk image from its extension, such as qcow2 for debian-12.qcow2 or raw for
+// disk.raw. The second value is false when the extension does not tell the type, as with .img images which are either.
+func DiskTypeFromPath(name string) (DiskType, bool) {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".qcow2", ".qcow":
+		return QCow2Disk, true
+	case ".raw":
+		return RawDisk, true
+	default:
+		return "", false
+	}
+}
+
+type OS string
 
 const (
-	UnknownOS    os = "unknown"
-	FedoraOS     os = "fedora"
-	DebianOS     os = "debian"
-	UbuntuOS     os = "ubuntu"
-	FreebsdOS    os = "freebsd"
-	OpensuseOS   os = "opensuse"
-	CentosOS     os = "centos"
-	JeedomOS     os = "jeedom"
-	HomebridgeOS os = "homebridge"
+	UnknownOS    OS = "unknown"
+	FedoraOS     OS = "fedora"
+	DebianOS     OS = "debian"
+	UbuntuOS     OS = "ubuntu"
+	FreebsdOS    OS = "freebsd"
+	OpensuseOS   OS = "opensuse"
+	CentosOS     OS = "centos"
+	JeedomOS     OS = "jeedom"
+	HomebridgeOS OS = "homebridge"
 )
 
-type machineStatus = string
+var oses = []OS{UnknownOS, FedoraOS, DebianOS, UbuntuOS, FreebsdOS, OpensuseOS, CentosOS, JeedomOS, HomebridgeOS}
+
+func (o OS) String() string {
+	return string(o)
+}
+
+// IsValid reports whether the OS is known by the freebox.
+func (o OS) IsValid() bool {
+	return slices.Contains(oses, o)
+}
+
+type MachineStatus string
 
 const (
-	StoppedStatus  machineStatus = "stopped"
-	RunningStatus  machineStatus = "running"
-	StartingStatus machineStatus = "starting"
-	StoppingStatus machineStatus = "stopping"
+	StoppedStatus  MachineStatus = "stopped"
+	RunningStatus  MachineStatus = "running"
+	StartingStatus MachineStatus = "starting"
+	StoppingStatus MachineStatus = "stopping"
 )
 
+var machineStatuses = []MachineStatus{StoppedStatus, RunningStatus, StartingStatus, StoppingStatus}
+
+// MachineStatuses returns every known status of a virtual machine.
+func MachineStatuses() []MachineStatus {
+	return slices.Clone(machineStatuses)
+}
+
+func (s MachineStatus) String() string {
+	return string(s)
+}
+
+// IsValid reports whether the status is a known status of a virtual machine.
+func (s MachineStatus) IsValid() bool {
+	return slices.Contains(machineStatuses, s)
+}
+
 type VirtualMachinePayload struct {
-	Name              string       `json:"name,omitempty"`
-	DiskPath          Base64Path   `json:"disk_path,omitempty"` // Base64 encoded
-	DiskType          diskType     `json:"disk_type,omitempty"`
-	CDPath            Base64Path   `json:"cd_path,omitempty"` // Base64 encoded
-	Memory            int64        `json:"memory,omitempty"`
-	OS                os           `json:"os,omitempty"`
-	VCPUs             int64        `json:"vcpus,omitempty"`
-	EnableScreen      bool         `json:"enable_screen,omitempty"`
-	BindUSBPorts      BindUSBPorts `json:"bind_usb_ports,omitempty"` // Empty string returned if no binds defined
-	EnableCloudInit   bool         `json:"enable_cloudinit,omitempty"`
-	CloudInitUserData string       `json:"cloudinit_userdata,omitempty"`
-	CloudHostName     string       `json:"cloudinit_hostname,omitempty"`
+	Name              string         `json:"name,omitempty"`
+	DiskPath          Base64Path     `json:"disk_path,omitempty"` // Base64 encoded
+	DiskType          DiskType       `json:"disk_type,omitempty"`
+	CDPath            Base64Path     `json:"cd_path,omitempty"` // Base64 encoded
+	Memory            MemorySize     `json:"memory,omitempty"`  // In bytes, such as 2 * Gigabyte, and sent as megabytes
+	OS                OS             `json:"os,omitempty"`
+	VCPUs             int64          `json:"vcpus,omitempty"`
+	EnableScreen      Optional[bool] `json:"enable_screen,omitempty"`
+	BindUSBPorts      BindUSBPorts   `json:"bind_usb_ports,omitempty"` // Empty string returned if no binds defined
+	EnableCloudInit   Optional[bool] `json:"enable_cloudinit,omitempty"`
+	CloudInitUserData string         `json:"cloudinit_userdata,omitempty"`
+	CloudHostName     string         `json:"cloudinit_hostname,omitempty"`
 }
 
 type VirtualMachine struct {
 	VirtualMachinePayload
 	ID     int64         `json:"id"`
-	Mac    string        `json:"mac"`
-	Status machineStatus `json:"status"`
+	Mac    MACAddress    `json:"mac"`
+	Status MachineStatus `json:"status"`
+}
+
+// VirtualMachineInstallSpec describes the virtual machine created by InstallVirtualMachine.
+type VirtualMachineInstallSpec struct {
+	VirtualMachinePayload          // Settings of the virtual machine, the disk fields are filled with the downloaded image
+	Directory             string   // Directory where the distribution image is downloaded (optional: will use the configuration download_dir by default)
+	DiskName              string   // Name of the disk image (optional: defaults to the name of the distribution image)
+	DiskSize              ByteSize // Size in bytes the disk image is grown to (optional: the image is kept as is when 0)
+	Start                 bool     // Start the virtual machine once created
 }
 
 type BindUSBPorts []string
@@ -0,0 +1,137 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	CloudInitUserDataMaxSize = 32767 // Maximum size in bytes of the user data accepted by the freebox
+	cloudConfigHeader        = "#cloud-config\n"
+)
+
+var ErrInvalidCloudInitUserData = errors.New("invalid cloud-init user data")
+
+// CloudInitUser describes a user created by cloud-init on first boot.
+type CloudInitUser struct {
+	Name              string   `yaml:"name"`
+	Groups            []string `yaml:"groups,omitempty"`              // Supplementary groups of the user
+	Shell             string   `yaml:"shell,omitempty"`               // Login shell, e.g. /bin/bash
+	Sudo              string   `yaml:"sudo,omitempty"`                // Sudo rule, e.g. ALL=(ALL) NOPASSWD:ALL
+	SSHAuthorizedKeys []string `yaml:"ssh_authorized_keys,omitempty"` // Public keys allowed to log in as the user
+	HashedPassword    string   `yaml:"hashed_passwd,omitempty"`       // Password hash, as generated by mkpasswd
+	LockPassword      *bool    `yaml:"lock_passwd,omitempty"`         // Disable password login (cloud-init defaults to true)
+}
+
+type cloudConfig struct {
+	Users             []interface{} `yaml:"users,omitempty"`
+	SSHAuthorizedKeys []string      `yaml:"ssh_authorized_keys,omitempty"`
+	PackageUpdate     bool          `yaml:"package_update,omitempty"`
+	PackageUpgrade    bool          `yaml:"package_upgrade,omitempty"`
+	Packages          []string      `yaml:"packages,omitempty"`
+	RunCommands       []interface{} `yaml:"runcmd,omitempty"`
+}
+
+// CloudInitUserDataBuilder builds the cloud-config user data of a virtual machine, to be used as
+// VirtualMachinePayload.CloudInitUserData.
+type CloudInitUserDataBuilder struct {
+	config cloudConfig
+	errs   []error
+}
+
+// NewCloudInitUserData starts building an empty cloud-config.
+func NewCloudInitUserData() *CloudInitUserDataBuilder {
+	return &CloudInitUserDataBuilder{}
+}
+
+// WithDefaultUser keeps the default user of the distribution along with the users added with WithUser.
+func (b *CloudInitUserDataBuilder) WithDefaultUser() *CloudInitUserDataBuilder {
+	b.config.Users = append(b.config.Users, "default")
+
+	return b
+}
+
+// WithUser adds a user created on first boot.
+func (b *CloudInitUserDataBuilder) WithUser(user CloudInitUser) *CloudInitUserDataBuilder {
+	if user.Name == "" {
+		b.errs = append(b.errs, fmt.Errorf("%w: user name is required", ErrInvalidCloudInitUserData))
+	}
+
+	for _, key := range user.SSHAuthorizedKeys {
+		b.validateSSHKey(key)
+	}
+
+	b.config.Users = append(b.config.Users, user)
+
+	return b
+}
+
+// WithSSHAuthorizedKeys adds public keys allowed to log in as the default user.
+func (b *CloudInitUserDataBuilder) WithSSHAuthorizedKeys(keys ...string) *CloudInitUserDataBuilder {
+	for _, key := range keys {
+		b.validateSSHKey(key)
+	}
+
+	b.config.SSHAuthorizedKeys = append(b.config.SSHAuthorizedKeys, keys...)
+
+	return b
+}
+
+// WithPackages adds packages installed on first boot.
+func (b *CloudInitUserDataBuilder) WithPackages(packages ...string) *CloudInitUserDataBuilder {
+	b.config.Packages = append(b.config.Packages, packages...)
+
+	return b
+}
+
+// WithPackageUpgrade updates the package index and upgrades the installed packages on first boot.
+func (b *CloudInitUserDataBuilder) WithPackageUpgrade() *CloudInitUserDataBuilder {
+	b.config.PackageUpdate = true
+	b.config.PackageUpgrade = true
+
+	return b
+}
+
+// WithRunCommand adds a command run on first boot. A single argument is run by the shell,
+// several arguments are run as is, without any shell interpretation.
+func (b *CloudInitUserDataBuilder) WithRunCommand(command ...string) *CloudInitUserDataBuilder {
+	switch len(command) {
+	case 0:
+		b.errs = append(b.errs, fmt.Errorf("%w: run command must not be empty", ErrInvalidCloudInitUserData))
+	case 1:
+		b.config.RunCommands = append(b.config.RunCommands, command[0])
+	default:
+		b.config.RunCommands = append(b.config.RunCommands, command)
+	}
+
+	return b
+}
+
+// Build serializes the cloud-config and checks it fits in the freebox limit.
+func (b *CloudInitUserDataBuilder) Build() (string, error) {
+	if len(b.errs) > 0 {
+		return "", errors.Join(b.errs...)
+	}
+
+	content, err := yaml.Marshal(&b.config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal cloud-config: %w", err)
+	}
+
+	userData := cloudConfigHeader + string(content)
+
+	if len(userData) > CloudInitUserDataMaxSize {
+		return "", fmt.Errorf("%w: %d bytes exceed the limit of %d bytes", ErrInvalidCloudInitUserData, len(userData), CloudInitUserDataMaxSize)
+	}
+
+	return userData, nil
+}
+
+func (b *CloudInitUserDataBuilder) validateSSHKey(key string) {
+	if strings.TrimSpace(key) == "" || strings.ContainsAny(key, "\r\n") {
+		b.errs = append(b.errs, fmt.Errorf("%w: invalid SSH public key %q", ErrInvalidCloudInitUserData, key))
+	}
+}
@@ -0,0 +1,114 @@
+package types_test
+
+import (
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/nikolalohinski/free-go/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("cloud-init user data builder", func() {
+	var (
+		builder *types.CloudInitUserDataBuilder
+
+		returnedUserData = new(string)
+		returnedErr      = new(error)
+	)
+	JustBeforeEach(func() {
+		*returnedUserData, *returnedErr = builder.Build()
+	})
+	Context("when every field is set", func() {
+		BeforeEach(func() {
+			lock := false
+			builder = types.NewCloudInitUserData().
+				WithDefaultUser().
+				WithUser(types.CloudInitUser{
+					Name:              "freemind",
+					Groups:            []string{"sudo", "docker"},
+					Shell:             "/bin/bash",
+					Sudo:              "ALL=(ALL) NOPASSWD:ALL",
+					SSHAuthorizedKeys: []string{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAI freemind@laptop"},
+					LockPassword:      &lock,
+				}).
+				WithSSHAuthorizedKeys("ssh-rsa AAAAB3NzaC1yc2E admin@laptop").
+				WithPackageUpgrade().
+				WithPackages("curl", "htop").
+				WithRunCommand("echo 'hello: world' > /etc/motd").
+				WithRunCommand("systemctl", "enable", "--now", "docker")
+		})
+		It("should return the correct user data", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*returnedUserData).To(Equal(heredoc.Doc(`
+				#cloud-config
+				users:
+				    - default
+				    - name: freemind
+				      groups:
+				        - sudo
+				        - docker
+				      shell: /bin/bash
+				      sudo: ALL=(ALL) NOPASSWD:ALL
+				      ssh_authorized_keys:
+				        - ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAI freemind@laptop
+				      lock_passwd: false
+				ssh_authorized_keys:
+				    - ssh-rsa AAAAB3NzaC1yc2E admin@laptop
+				package_update: true
+				package_upgrade: true
+				packages:
+				    - curl
+				    - htop
+				runcmd:
+				    - 'echo ''hello: world'' > /etc/motd'
+				    - - systemctl
+				      - enable
+				      - --now
+				      - docker
+			`)))
+		})
+	})
+	Context("when nothing is set", func() {
+		BeforeEach(func() {
+			builder = types.NewCloudInitUserData()
+		})
+		It("should return an empty cloud-config", func() {
+			Expect(*returnedErr).To(BeNil())
+			Expect(*returnedUserData).To(Equal("#cloud-config\n{}\n"))
+		})
+	})
+	Context("when a user has no name", func() {
+		BeforeEach(func() {
+			builder = types.NewCloudInitUserData().WithUser(types.CloudInitUser{Shell: "/bin/sh"})
+		})
+		It("should return the correct error", func() {
+			Expect(*returnedErr).To(MatchError(types.ErrInvalidCloudInitUserData))
+		})
+	})
+	Context("when an SSH key spans several lines", func() {
+		BeforeEach(func() {
+			builder = types.NewCloudInitUserData().WithSSHAuthorizedKeys("ssh-rsa AAAA\nssh-rsa BBBB")
+		})
+		It("should return the correct error", func() {
+			Expect(*returnedErr).To(MatchError(types.ErrInvalidCloudInitUserData))
+		})
+	})
+	Context("when a run command is empty", func() {
+		BeforeEach(func() {
+			builder = types.NewCloudInitUserData().WithRunCommand()
+		})
+		It("should return the correct error", func() {
+			Expect(*returnedErr).To(MatchError(types.ErrInvalidCloudInitUserData))
+		})
+	})
+	Context("when the user data exceeds the freebox limit", func() {
+		BeforeEach(func() {
+			builder = types.NewCloudInitUserData().WithRunCommand("echo " + strings.Repeat("a", types.CloudInitUserDataMaxSize))
+		})
+		It("should return the correct error", func() {
+			Expect(*returnedErr).To(MatchError(types.ErrInvalidCloudInitUserData))
+			Expect(*returnedUserData).To(BeEmpty())
+		})
+	})
+})
@@ -3,8 +3,17 @@ package types
 type virtualMachineDiskTaskType string
 
 const (
-	DiskTaskTypeCreate virtualMachineDiskTaskType = "create"
-	DiskTaskTypeResize virtualMachineDiskTaskType = "resize"
+	DiskTaskTypeCreate  virtualMachineDiskTaskType = "create"
+	DiskTaskTypeResize  virtualMachineDiskTaskType = "resize"
+	DiskTaskTypeConvert virtualMachineDiskTaskType = "convert"
+)
+
+type virtualMachineDiskTaskState string
+
+const (
+	DiskTaskStateRunning virtualMachineDiskTaskState = "running" // Being processed, or waiting to be as the freebox does not tell queued tasks apart
+	DiskTaskStateDone    virtualMachineDiskTaskState = "done"    // Done successfully
+	DiskTaskStateError   virtualMachineDiskTaskState = "error"   // Done in error
 )
 
 const (
@@ -31,20 +40,20 @@ const (
 )
 
 type VirtualDiskInfo struct {
-	Type        diskType `json:"type"`
-	ActualSize  int64    `json:"actual_size"`  // Space used by virtual image on disk. This is how much filesystem space is consumed on the box.
-	VirtualSize int64    `json:"virtual_size"` // Size of virtual disk. This is the size the disk will appear inside the VM.
+	Type        DiskType `json:"type"`
+	ActualSize  ByteSize `json:"actual_size"`  // Space used by virtual image on disk. This is how much filesystem space is consumed on the box.
+	VirtualSize ByteSize `json:"virtual_size"` // Size of virtual disk. This is the size the disk will appear inside the VM.
 }
 
 type VirtualDisksCreatePayload struct {
 	DiskPath Base64Path `json:"disk_path"` // Base64 encoded
-	Size     int64      `json:"size"`      // Size of virtual disk in bytes
-	DiskType diskType   `json:"disk_type"`
+	Size     ByteSize   `json:"size"`      // Size of virtual disk in bytes
+	DiskType DiskType   `json:"disk_type"`
 }
 
 type VirtualDisksResizePayload struct {
 	DiskPath    Base64Path `json:"disk_path"`    // Base64 encoded
-	NewSize     int64      `json:"size"`         // New size of virtual disk in bytes
+	NewSize     ByteSize   `json:"size"`         // New size of virtual disk in bytes
 	ShrinkAllow bool       `json:"shrink_allow"` // Whether shrinking the disk is allowed. Setting to true means this operation can be destructive.
 }
 
@@ -55,6 +64,34 @@ type VirtualMachineDiskTask struct {
 	Error bool                       `json:"error"`
 }
 
+// State returns the state of the task derived from its done and error flags.
+func (t VirtualMachineDiskTask) State() virtualMachineDiskTaskState {
+	switch {
+	case t.Done && t.Error:
+		return DiskTaskStateError
+	case t.Done:
+		return DiskTaskStateDone
+	default:
+		return DiskTaskStateRunning
+	}
+}
+
 type GetVirtualDiskPayload struct {
 	DiskPath Base64Path `json:"disk_path"` // Base64 encoded
 }
+
+// VirtualDiskImportPayload describes a remote image downloaded onto the freebox to be used as a virtual disk.
+type VirtualDiskImportPayload struct {
+	URL       string   // URL of the image, such as the URL of a VirtualMachineDistribution
+	Hash      string   // Hash the image is verified against, such as the hash of a VirtualMachineDistribution (optional)
+	Directory string   // Directory where the image is downloaded (optional: will use the configuration download_dir by default)
+	Filename  string   // Name of the disk image (optional: defaults to the name of the image in the URL)
+	Size      ByteSize // Size in bytes the disk image is resized to once downloaded (optional: the image is kept as is when 0)
+}
+
+// VirtualDiskImport holds the tasks started to create a virtual disk from a remote image.
+type VirtualDiskImport struct {
+	DownloadTaskID int64  // ID of the download task, done once returned
+	DiskTaskID     int64  // ID of the virtual disk resize task, 0 when the image is not resized
+	DiskPath       string // Path of the virtual disk on the freebox
+}
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"slices"
+	"strings"
+)
+
+// FindDistributionByOS returns the first distribution of the operating system in the list.
+func FindDistributionByOS(distributions []VirtualMachineDistribution, os OS) (VirtualMachineDistribution, bool) {
+	return findDistribution(distributions, func(distribution VirtualMachineDistribution) bool {
+		return distribution.OS == os
+	})
+}
+
+// FindDistributionByName returns the distribution of the list with the name, such as Debian 12 (Bookworm), ignoring
+// the case.
+func FindDistributionByName(distributions []VirtualMachineDistribution, name string) (VirtualMachineDistribution, bool) {
+	return findDistribution(distributions, func(distribution VirtualMachineDistribution) bool {
+		return strings.EqualFold(distribution.Name, name)
+	})
+}
+
+func findDistribution(distributions []VirtualMachineDistribution, match func(VirtualMachineDistribution) bool) (VirtualMachineDistribution, bool) {
+	index := slices.IndexFunc(distributions, match)
+	if index < 0 {
+		return VirtualMachineDistribution{}, false
+	}
+
+	return distributions[index], true
+}
@@ -0,0 +1,38 @@
+package types_test
+
+import (
+	"github.com/nikolalohinski/free-go/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("virtual machine distributions", func() {
+	distributions := []types.VirtualMachineDistribution{
+		{OS: types.UbuntuOS, Name: "Ubuntu 22.04 LTS (Jammy)"},
+		{OS: types.DebianOS, Name: "Debian 12 (Bookworm)"},
+		{OS: types.UbuntuOS, Name: "Ubuntu 21.10 (Impish)"},
+	}
+	Context("finding by operating system", func() {
+		It("should return the first distribution of the operating system", func() {
+			distribution, found := types.FindDistributionByOS(distributions, types.UbuntuOS)
+			Expect(found).To(BeTrue())
+			Expect(distribution.Name).To(Equal("Ubuntu 22.04 LTS (Jammy)"))
+		})
+		It("should report a missing operating system", func() {
+			distribution, found := types.FindDistributionByOS(distributions, types.FedoraOS)
+			Expect(found).To(BeFalse())
+			Expect(distribution).To(BeZero())
+		})
+	})
+	Context("finding by name", func() {
+		It("should ignore the case", func() {
+			distribution, found := types.FindDistributionByName(distributions, "debian 12 (bookworm)")
+			Expect(found).To(BeTrue())
+			Expect(distribution.OS).To(Equal(types.DebianOS))
+		})
+		It("should report a missing name", func() {
+			_, found := types.FindDistributionByName(distributions, "Debian 11 (Bullseye)")
+			Expect(found).To(BeFalse())
+		})
+	})
+})
@@ -2,6 +2,7 @@ package types_test
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/nikolalohinski/free-go/types"
 	. "github.com/onsi/ginkgo/v2"
@@ -149,4 +150,115 @@ var _ = Describe("virtual machines", func() {
 			})
 		})
 	})
+	Context("decoding a state_changed event notification", func() {
+		var (
+			notification types.EventNotification
+
+			returnedChange = new(types.VirtualMachineStateChanged)
+		)
+		BeforeEach(func() {
+			notification = types.EventNotification{
+				Action:  "notification",
+				Success: true,
+				Source:  types.EventSourceVM,
+				Event:   types.EventStateChanged,
+				Result:  json.RawMessage(`{"id": 3, "status": "running"}`),
+			}
+		})
+		JustBeforeEach(func() {
+			*returnedChange, *returnedErr = notification.DecodeVirtualMachineStateChanged()
+		})
+		Context("default", func() {
+			It("should return the correct state change", func() {
+				Expect(*returnedErr).To(BeNil())
+				Expect(*returnedChange).To(Equal(types.VirtualMachineStateChanged{
+					ID:     3,
+					Status: types.RunningStatus,
+				}))
+			})
+		})
+		Context("when the notification is another event", func() {
+			BeforeEach(func() {
+				notification.Event = types.EventDiskTaskDone
+			})
+			It("should return the correct error", func() {
+				Expect(*returnedErr).To(MatchError(types.ErrUnexpectedEvent))
+			})
+		})
+		Context("when the result is not an object", func() {
+			BeforeEach(func() {
+				notification.Result = json.RawMessage(`[]`)
+			})
+			It("should return an error", func() {
+				Expect(*returnedErr).ToNot(BeNil())
+			})
+		})
+	})
+	Context("enums", func() {
+		for _, status := range []types.MachineStatus{types.StoppedStatus, types.RunningStatus, types.StartingStatus, types.StoppingStatus} {
+			status := status
+			It(fmt.Sprintf("should round trip the %s status through json", status), func() {
+				Expect(status.IsValid()).To(BeTrue())
+
+				var decoded types.VirtualMachineStateChanged
+				Expect(json.Unmarshal([]byte(fmt.Sprintf(`{"status": %q}`, status.String())), &decoded)).To(Succeed())
+				Expect(decoded.Status).To(Equal(status))
+			})
+		}
+		for _, os := range []types.OS{types.UnknownOS, types.FedoraOS, types.DebianOS, types.UbuntuOS, types.FreebsdOS, types.OpensuseOS, types.CentosOS, types.JeedomOS, types.HomebridgeOS} {
+			os := os
+			It(fmt.Sprintf("should round trip the %s OS through json", os), func() {
+				Expect(os.IsValid()).To(BeTrue())
+
+				bytes, err := json.Marshal(types.VirtualMachinePayload{OS: os})
+				Expect(err).To(BeNil())
+
+				var decoded types.VirtualMachinePayload
+				Expect(json.Unmarshal(bytes, &decoded)).To(Succeed())
+				Expect(decoded.OS).To(Equal(os))
+			})
+		}
+		for _, diskType := range []types.DiskType{types.RawDisk, types.QCow2Disk} {
+			diskType := diskType
+			It(fmt.Sprintf("should round trip the %s disk type through json", diskType), func() {
+				Expect(diskType.IsValid()).To(BeTrue())
+
+				bytes, err := json.Marshal(types.VirtualMachinePayload{DiskType: diskType})
+				Expect(err).To(BeNil())
+
+				var decoded types.VirtualMachinePayload
+				Expect(json.Unmarshal(bytes, &decoded)).To(Succeed())
+				Expect(decoded.DiskType).To(Equal(diskType))
+			})
+		}
+		It("should not validate unknown values", func() {
+			Expect(types.MachineStatus("foobar").IsValid()).To(BeFalse())
+			Expect(types.OS("foobar").IsValid()).To(BeFalse())
+			Expect(types.DiskType("foobar").IsValid()).To(BeFalse())
+		})
+		It("should not let the lists of values be modified", func() {
+			types.DiskTypes()[0] = "foobar"
+			types.MachineStatuses()[0] = "foobar"
+			Expect(types.DiskTypes()).To(Equal([]types.DiskType{types.RawDisk, types.QCow2Disk}))
+			Expect(types.MachineStatuses()).To(ContainElement(types.StoppedStatus))
+		})
+	})
+	Context("guessing the disk type of an image", func() {
+		for name, expected := range map[string]types.DiskType{
+			"debian-12.qcow2": types.QCow2Disk,
+			"legacy.QCOW":     types.QCow2Disk,
+			"disk.raw":        types.RawDisk,
+		} {
+			name, expected := name, expected
+			It("should recognize "+name, func() {
+				diskType, ok := types.DiskTypeFromPath(name)
+				Expect(ok).To(BeTrue())
+				Expect(diskType).To(Equal(expected))
+			})
+		}
+		It("should not guess the type of an ambiguous image", func() {
+			_, ok := types.DiskTypeFromPath("/Freebox/VMs/ubuntu.img")
+			Expect(ok).To(BeFalse())
+		})
+	})
 })
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+)
+
+const virtualMachineNameMaxLength = 30
+
+var ErrInvalidVirtualMachinePayload = errors.New("invalid virtual machine payload")
+
+// VirtualMachinePayloadError reports why a field of a VirtualMachinePayload can not be accepted by the freebox.
+type VirtualMachinePayloadError struct {
+	Field  string // JSON name of the invalid field
+	Reason string
+}
+
+func (e *VirtualMachinePayloadError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", ErrInvalidVirtualMachinePayload, e.Field, e.Reason)
+}
+
+func (e *VirtualMachinePayloadError) Unwrap() error {
+	return ErrInvalidVirtualMachinePayload
+}
+
+// Validate checks the payload of a new virtual machine against the capacity of the hypervisor returned by
+// GetVirtualMachineInfo. Every invalid field is reported as a *VirtualMachinePayloadError.
+func (p VirtualMachinePayload) Validate(info VirtualMachinesInfo) error {
+	errs := []error{}
+
+	if utf8.RuneCountInString(p.Name) > virtualMachineNameMaxLength {
+		errs = append(errs, &VirtualMachinePayloadError{"name", fmt.Sprintf("must be at most %d characters", virtualMachineNameMaxLength)})
+	}
+
+	if p.Memory < 0 {
+		errs = append(errs, &VirtualMachinePayloadError{"memory", "must be positive"})
+	} else if remaining := info.TotalMemory - info.UsedMemory; p.Memory > remaining {
+		errs = append(errs, &VirtualMachinePayloadError{"memory", fmt.Sprintf("exceeds the %s remaining on the freebox", remaining)})
+	}
+
+	if p.VCPUs < 0 {
+		errs = append(errs, &VirtualMachinePayloadError{"vcpus", "must be positive"})
+	} else if remaining := info.TotalCPUs - info.UsedCPUs; p.VCPUs > remaining {
+		errs = append(errs, &VirtualMachinePayloadError{"vcpus", fmt.Sprintf("exceeds the %d remaining on the freebox", remaining)})
+	}
+
+	for _, port := range p.BindUSBPorts {
+		if !slices.Contains(info.USBPorts, port) {
+			errs = append(errs, &VirtualMachinePayloadError{"bind_usb_ports", fmt.Sprintf("unknown USB port %q, expected one of %v", port, info.USBPorts)})
+		}
+	}
+
+	if p.OS != "" && !p.OS.IsValid() {
+		errs = append(errs, &VirtualMachinePayloadError{"os", fmt.Sprintf("unknown OS %q", p.OS)})
+	}
+
+	if p.DiskType != "" && !p.DiskType.IsValid() {
+		errs = append(errs, &VirtualMachinePayloadError{"disk_type", fmt.Sprintf("unknown disk type %q", p.DiskType)})
+	}
+
+	if len(p.CloudInitUserData) > CloudInitUserDataMaxSize {
+		errs = append(errs, &VirtualMachinePayloadError{"cloudinit_userdata", fmt.Sprintf("must be at most %d bytes", CloudInitUserDataMaxSize)})
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateUpdate checks the payload updating the current virtual machine against the capacity of the hypervisor.
+// The memory and CPUs of the machine are counted as used by the hypervisor while it is not stopped, they are given
+// back before the payload is checked so that the machine can keep its own allocation.
+func (p VirtualMachinePayload) ValidateUpdate(info VirtualMachinesInfo, current VirtualMachine) error {
+	if current.Status != StoppedStatus {
+		info.UsedMemory = max(info.UsedMemory-current.Memory, 0)
+		info.UsedCPUs = max(info.UsedCPUs-current.VCPUs, 0)
+	}
+
+	return p.Validate(info)
+}
@@ -0,0 +1,97 @@
+package types_test
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/nikolalohinski/free-go/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("virtual machine payload validation", func() {
+	var (
+		payload types.VirtualMachinePayload
+		info    types.VirtualMachinesInfo
+
+		returnedErr = new(error)
+	)
+	BeforeEach(func() {
+		payload = types.VirtualMachinePayload{
+			Name:     "testing",
+			Memory:   1024 * types.Megabyte,
+			VCPUs:    2,
+			OS:       types.UbuntuOS,
+			DiskType: types.RawDisk,
+		}
+		info = types.VirtualMachinesInfo{
+			USBPorts:    []string{"usb-external-type-a"},
+			TotalMemory: 2 * types.Gigabyte,
+			TotalCPUs:   2,
+		}
+	})
+	JustBeforeEach(func() {
+		*returnedErr = payload.Validate(info)
+	})
+	Context("when the payload fits in the hypervisor", func() {
+		It("should not return an error", func() {
+			Expect(*returnedErr).To(BeNil())
+		})
+	})
+	Context("when the name has as many non-ASCII characters as allowed", func() {
+		BeforeEach(func() {
+			payload.Name = strings.Repeat("é", 30)
+		})
+		It("should not return an error", func() {
+			Expect(*returnedErr).To(BeNil())
+		})
+	})
+	Context("when fields are invalid", func() {
+		BeforeEach(func() {
+			payload.Name = strings.Repeat("a", 31)
+			payload.VCPUs = -1
+			payload.OS = "windows"
+			payload.DiskType = "vmdk"
+			payload.CloudInitUserData = strings.Repeat("a", types.CloudInitUserDataMaxSize+1)
+		})
+		It("should report every invalid field", func() {
+			Expect(*returnedErr).To(MatchError(types.ErrInvalidVirtualMachinePayload))
+
+			fields := []string{}
+			for _, err := range (*returnedErr).(interface{ Unwrap() []error }).Unwrap() {
+				var payloadErr *types.VirtualMachinePayloadError
+				Expect(errors.As(err, &payloadErr)).To(BeTrue())
+				fields = append(fields, payloadErr.Field)
+			}
+			Expect(fields).To(Equal([]string{"name", "vcpus", "os", "disk_type", "cloudinit_userdata"}))
+		})
+	})
+	Context("when updating a running virtual machine", func() {
+		current := new(types.VirtualMachine)
+		BeforeEach(func() {
+			info.UsedMemory = 2 * types.Gigabyte
+			info.UsedCPUs = 2
+			*current = types.VirtualMachine{
+				Status: types.RunningStatus,
+				VirtualMachinePayload: types.VirtualMachinePayload{
+					Memory: 2 * types.Gigabyte,
+					VCPUs:  2,
+				},
+			}
+		})
+		JustBeforeEach(func() {
+			*returnedErr = payload.ValidateUpdate(info, *current)
+		})
+		It("should not count its own allocation as used", func() {
+			Expect(*returnedErr).To(BeNil())
+		})
+		Context("when the machine is stopped", func() {
+			BeforeEach(func() {
+				current.Status = types.StoppedStatus
+			})
+			It("should check the payload against the remaining capacity", func() {
+				Expect(*returnedErr).To(MatchError(types.ErrInvalidVirtualMachinePayload))
+			})
+		})
+	})
+})
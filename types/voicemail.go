@@ -0,0 +1,14 @@
+package types
+
+type VoicemailEntry struct {
+	ID          string    `json:"id"`           // Voicemail id
+	CountryCode string    `json:"country_code"` // Country code of the caller
+	PhoneNumber string    `json:"phone_number"` // Phone number of the caller
+	Duration    Seconds   `json:"duration"`     // Message duration in seconds
+	Date        Timestamp `json:"date"`         // Message date and time
+	Read        bool      `json:"read"`         // Message has been read
+}
+
+type VoicemailEntryUpdate struct {
+	Read bool `json:"read"` // Set to true to mark the message as read
+}
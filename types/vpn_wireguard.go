@@ -0,0 +1,17 @@
+package types
+
+import (
+	"net/netip"
+	"time"
+)
+
+// WireGuardPeerStats reports the activity of a peer connected to the WireGuard VPN server of the box. The API does not
+// expose the handshakes of the peers, so a connected peer can not be told alive or dead from these statistics.
+type WireGuardPeerStats struct {
+	User             string         // Name of the user the peer authenticated as
+	Endpoint         netip.AddrPort // Public address and port the peer connects from
+	LocalIP          netip.Addr     // Address of the peer inside the VPN
+	ConnectedSince   time.Time      // Time the session of the peer was established
+	ReceivedBytes    ByteSize       // Bytes received from the peer
+	TransmittedBytes ByteSize       // Bytes transmitted to the peer
+}